@@ -7,7 +7,10 @@ import (
 	"time"
 
 	"github.com/ava-labs/avalanchego/api"
+	"github.com/ava-labs/avalanchego/network"
+	"github.com/ava-labs/avalanchego/utils/codec"
 	"github.com/ava-labs/avalanchego/utils/rpc"
+	"github.com/ava-labs/avalanchego/vms/rpcchainvm/manifest"
 )
 
 // Client for the Avalanche Platform Info API Endpoint
@@ -70,9 +73,88 @@ func (c *Client) AliasChain(chain, alias string) (bool, error) {
 	return res.Success, err
 }
 
+// FreezeChain ...
+func (c *Client) FreezeChain(chain string) (bool, error) {
+	res := &api.SuccessResponse{}
+	err := c.requester.SendRequest("freezeChain", &FreezeChainArgs{
+		Chain: chain,
+	}, res)
+	return res.Success, err
+}
+
+// UnfreezeChain ...
+func (c *Client) UnfreezeChain(chain string) (bool, error) {
+	res := &api.SuccessResponse{}
+	err := c.requester.SendRequest("unfreezeChain", &FreezeChainArgs{
+		Chain: chain,
+	}, res)
+	return res.Success, err
+}
+
+// GetProcessingTree ...
+func (c *Client) GetProcessingTree(chain string) (*GetProcessingTreeReply, error) {
+	res := &GetProcessingTreeReply{}
+	err := c.requester.SendRequest("getProcessingTree", &GetProcessingTreeArgs{
+		Chain: chain,
+	}, res)
+	return res, err
+}
+
+// GetBlockLifecycles ...
+func (c *Client) GetBlockLifecycles(chain string) (*GetBlockLifecyclesReply, error) {
+	res := &GetBlockLifecyclesReply{}
+	err := c.requester.SendRequest("getBlockLifecycles", &GetBlockLifecyclesArgs{
+		Chain: chain,
+	}, res)
+	return res, err
+}
+
+// SetChaos ...
+func (c *Client) SetChaos(args *ChaosArgs) (bool, error) {
+	res := &api.SuccessResponse{}
+	err := c.requester.SendRequest("setChaos", args, res)
+	return res.Success, err
+}
+
+// GetChaos ...
+func (c *Client) GetChaos() (*ChaosArgs, error) {
+	res := &ChaosArgs{}
+	err := c.requester.SendRequest("getChaos", struct{}{}, res)
+	return res, err
+}
+
 // Stacktrace ...
 func (c *Client) Stacktrace() (bool, error) {
 	res := &api.SuccessResponse{}
 	err := c.requester.SendRequest("stacktrace", struct{}{}, res)
 	return res.Success, err
 }
+
+// GetCodecTypes returns the types registered in each version of the node's
+// codecs, by codec name
+func (c *Client) GetCodecTypes() (map[string]map[uint16][]codec.RegisteredType, error) {
+	res := &GetCodecTypesReply{}
+	err := c.requester.SendRequest("getCodecTypes", struct{}{}, res)
+	return res.Codecs, err
+}
+
+// SetPluginManifest ...
+func (c *Client) SetPluginManifest(signed manifest.Signed) (bool, error) {
+	res := &api.SuccessResponse{}
+	err := c.requester.SendRequest("setPluginManifest", &signed, res)
+	return res.Success, err
+}
+
+// GetPluginManifest ...
+func (c *Client) GetPluginManifest() (*manifest.Manifest, error) {
+	res := &manifest.Manifest{}
+	err := c.requester.SendRequest("getPluginManifest", struct{}{}, res)
+	return res, err
+}
+
+// GetMalformedMessages ...
+func (c *Client) GetMalformedMessages() ([]network.MalformedMessage, error) {
+	res := &GetMalformedMessagesReply{}
+	err := c.requester.SendRequest("getMalformedMessages", struct{}{}, res)
+	return res.Messages, err
+}
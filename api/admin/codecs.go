@@ -0,0 +1,66 @@
+// (c) 2019-2020, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package admin
+
+import (
+	"fmt"
+	"sync"
+
+	"github.com/ava-labs/avalanchego/snow"
+	"github.com/ava-labs/avalanchego/utils/codec"
+)
+
+// codecsVM is implemented by VMs whose codecs can be inspected, such as the
+// AVM
+type codecsVM interface {
+	// Codecs returns the VM's codecs, by name
+	Codecs() map[string]codec.Manager
+}
+
+// codecs keeps track of the codecs whose registered types can be inspected.
+// It's registered with the chain manager, so the codecs of a chain's VM are
+// added when the chain is created or restarted.
+type codecs struct {
+	lock sync.RWMutex
+	// Codec name --> Codec
+	managers map[string]codec.Manager
+}
+
+func newCodecs(managers map[string]codec.Manager) *codecs {
+	c := &codecs{managers: make(map[string]codec.Manager, len(managers))}
+	for name, manager := range managers {
+		c.managers[name] = manager
+	}
+	return c
+}
+
+// RegisterChain implements the chains.Registrant interface. The codecs of the
+// chain's VM are named by the chain's primary alias followed by the codec's
+// name, such as X.avm.
+func (c *codecs) RegisterChain(name string, _ *snow.Context, vm interface{}) {
+	codecVM, ok := vm.(codecsVM)
+	if !ok {
+		return
+	}
+
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	for codecName, manager := range codecVM.Codecs() {
+		c.managers[fmt.Sprintf("%s.%s", name, codecName)] = manager
+	}
+}
+
+// registeredTypes returns the types registered in each version of each codec,
+// by codec name
+func (c *codecs) registeredTypes() map[string]map[uint16][]codec.RegisteredType {
+	c.lock.RLock()
+	defer c.lock.RUnlock()
+
+	types := make(map[string]map[uint16][]codec.RegisteredType, len(c.managers))
+	for name, manager := range c.managers {
+		types[name] = manager.RegisteredTypes()
+	}
+	return types
+}
@@ -0,0 +1,68 @@
+// (c) 2019-2020, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package admin
+
+import (
+	"testing"
+
+	"github.com/ava-labs/avalanchego/snow"
+	"github.com/ava-labs/avalanchego/utils/codec"
+)
+
+type testCodecsVM struct {
+	codecs map[string]codec.Manager
+}
+
+func (vm *testCodecsVM) Codecs() map[string]codec.Manager { return vm.codecs }
+
+type testCodecType struct{}
+
+func newTestCodecManager(t *testing.T, types ...interface{}) codec.Manager {
+	c := codec.NewDefault()
+	for _, typ := range types {
+		if err := c.RegisterType(typ); err != nil {
+			t.Fatal(err)
+		}
+	}
+	manager := codec.NewDefaultManager()
+	if err := manager.RegisterCodec(0, c); err != nil {
+		t.Fatal(err)
+	}
+	return manager
+}
+
+func TestCodecsRegisterChain(t *testing.T) {
+	c := newCodecs(map[string]codec.Manager{
+		"platformvm": newTestCodecManager(t),
+	})
+
+	// VMs that don't expose their codecs aren't added
+	c.RegisterChain("C", snow.DefaultContextTest(), struct{}{})
+	c.RegisterChain("X", snow.DefaultContextTest(), &testCodecsVM{codecs: map[string]codec.Manager{
+		"avm": newTestCodecManager(t, &testCodecType{}),
+	}})
+
+	types := c.registeredTypes()
+	if len(types) != 2 {
+		t.Fatalf("expected 2 codecs but got %v", types)
+	}
+	if _, ok := types["platformvm"]; !ok {
+		t.Fatalf("expected the codecs the service was created with")
+	}
+	avmTypes, ok := types["X.avm"]
+	if !ok {
+		t.Fatalf("expected the codec of the X-Chain's VM but got %v", types)
+	}
+	if len(avmTypes[0]) != 1 {
+		t.Fatalf("expected 1 registered type but got %v", avmTypes[0])
+	}
+
+	// A restarted chain replaces the codecs it was created with
+	c.RegisterChain("X", snow.DefaultContextTest(), &testCodecsVM{codecs: map[string]codec.Manager{
+		"avm": newTestCodecManager(t),
+	}})
+	if avmTypes := c.registeredTypes()["X.avm"]; len(avmTypes[0]) != 0 {
+		t.Fatalf("expected the restarted chain's codec but got %v", avmTypes)
+	}
+}
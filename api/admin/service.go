@@ -7,13 +7,19 @@ import (
 	"errors"
 	"io/ioutil"
 	"net/http"
+	"time"
 
 	"github.com/gorilla/rpc/v2"
 
 	"github.com/ava-labs/avalanchego/api"
 	"github.com/ava-labs/avalanchego/chains"
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/network"
 	"github.com/ava-labs/avalanchego/snow/engine/common"
+	"github.com/ava-labs/avalanchego/snow/networking/sender"
+	"github.com/ava-labs/avalanchego/utils/codec"
 	"github.com/ava-labs/avalanchego/utils/logging"
+	"github.com/ava-labs/avalanchego/vms/rpcchainvm/manifest"
 
 	cjson "github.com/ava-labs/avalanchego/utils/json"
 )
@@ -26,7 +32,11 @@ const (
 )
 
 var (
-	errAliasTooLong = errors.New("alias length is too long")
+	errAliasTooLong  = errors.New("alias length is too long")
+	errChaosDisabled = errors.New("chaos mode is only available on private networks")
+
+	errPluginsNotVerified = errors.New("this node doesn't verify plugins")
+	errNoPluginManifest   = errors.New("no plugin manifest has been set")
 )
 
 // Admin is the API service for node admin management
@@ -35,18 +45,41 @@ type Admin struct {
 	performance  Performance
 	chainManager chains.Manager
 	httpServer   *api.Server
+	networking   network.Network
+	// Codecs whose registered types can be inspected
+	codecs *codecs
+	// Injects faults into consensus messages. Nil if chaos mode is disabled.
+	chaosSender *sender.ChaosSender
+	// Verifies plugin binaries before they're run. Nil if plugins aren't
+	// verified.
+	pluginVerifier *manifest.Verifier
 }
 
 // NewService returns a new admin API service
-func NewService(log logging.Logger, chainManager chains.Manager, httpServer *api.Server) (*common.HTTPHandler, error) {
+func NewService(
+	log logging.Logger,
+	chainManager chains.Manager,
+	httpServer *api.Server,
+	networking network.Network,
+	codecs map[string]codec.Manager,
+	chaosSender *sender.ChaosSender,
+	pluginVerifier *manifest.Verifier,
+) (*common.HTTPHandler, error) {
 	newServer := rpc.NewServer()
-	codec := cjson.NewCodec()
-	newServer.RegisterCodec(codec, "application/json")
-	newServer.RegisterCodec(codec, "application/json;charset=UTF-8")
+	jsonCodec := cjson.NewCodec()
+	newServer.RegisterCodec(jsonCodec, "application/json")
+	newServer.RegisterCodec(jsonCodec, "application/json;charset=UTF-8")
+	// The codecs of chains' VMs are added as the chains are created
+	chainCodecs := newCodecs(codecs)
+	chainManager.AddRegistrant(chainCodecs)
 	if err := newServer.RegisterService(&Admin{
-		log:          log,
-		chainManager: chainManager,
-		httpServer:   httpServer,
+		log:            log,
+		chainManager:   chainManager,
+		httpServer:     httpServer,
+		networking:     networking,
+		codecs:         chainCodecs,
+		chaosSender:    chaosSender,
+		pluginVerifier: pluginVerifier,
 	}, "admin"); err != nil {
 		return nil, err
 	}
@@ -128,6 +161,236 @@ func (service *Admin) AliasChain(_ *http.Request, args *AliasChainArgs, reply *a
 	return service.httpServer.AddAliasesWithReadLock("bc/"+chainID.String(), "bc/"+args.Alias)
 }
 
+// FreezeChainArgs are the arguments for calling FreezeChain and UnfreezeChain
+type FreezeChainArgs struct {
+	Chain string `json:"chain"`
+}
+
+// FreezeChain stops a chain from building blocks and voting in polls, while it
+// continues to serve containers to its peers. The chain stays frozen across
+// node restarts until UnfreezeChain is called.
+func (service *Admin) FreezeChain(_ *http.Request, args *FreezeChainArgs, reply *api.SuccessResponse) error {
+	service.log.Info("Admin: FreezeChain called with Chain: %s", args.Chain)
+
+	return service.setFrozen(args.Chain, true, reply)
+}
+
+// UnfreezeChain resumes block building and voting on a frozen chain
+func (service *Admin) UnfreezeChain(_ *http.Request, args *FreezeChainArgs, reply *api.SuccessResponse) error {
+	service.log.Info("Admin: UnfreezeChain called with Chain: %s", args.Chain)
+
+	return service.setFrozen(args.Chain, false, reply)
+}
+
+func (service *Admin) setFrozen(chain string, frozen bool, reply *api.SuccessResponse) error {
+	chainID, err := service.chainManager.Lookup(chain)
+	if err != nil {
+		return err
+	}
+	if err := service.chainManager.SetFrozen(chainID, frozen); err != nil {
+		return err
+	}
+
+	reply.Success = true
+	return nil
+}
+
+// GetProcessingTreeArgs are the arguments for calling GetProcessingTree
+type GetProcessingTreeArgs struct {
+	Chain string `json:"chain"`
+}
+
+// ProcessingBlock describes a block that is being processed by consensus
+type ProcessingBlock struct {
+	ID       ids.ID `json:"id"`
+	ParentID ids.ID `json:"parentID"`
+	// Omitted if the block doesn't report its height
+	Height      cjson.Uint64 `json:"height,omitempty"`
+	Depth       cjson.Uint32 `json:"depth"`
+	NumChildren cjson.Uint32 `json:"numChildren"`
+	Preferred   bool         `json:"preferred"`
+}
+
+// GetProcessingTreeReply are the results from calling GetProcessingTree
+type GetProcessingTreeReply struct {
+	LastAccepted ids.ID       `json:"lastAccepted"`
+	Preference   ids.ID       `json:"preference"`
+	MaxDepth     cjson.Uint32 `json:"maxDepth"`
+	// Blocks that more than one processing block names as its parent. Empty
+	// iff the chain isn't forked.
+	BranchPoints []ids.ID          `json:"branchPoints"`
+	Blocks       []ProcessingBlock `json:"blocks"`
+}
+
+// GetProcessingTree returns the blocks being processed by a Snowman chain,
+// which shows whether, and where, the chain is forked
+func (service *Admin) GetProcessingTree(_ *http.Request, args *GetProcessingTreeArgs, reply *GetProcessingTreeReply) error {
+	service.log.Info("Admin: GetProcessingTree called with Chain: %s", args.Chain)
+
+	chainID, err := service.chainManager.Lookup(args.Chain)
+	if err != nil {
+		return err
+	}
+	tree, err := service.chainManager.ProcessingTree(chainID)
+	if err != nil {
+		return err
+	}
+
+	reply.LastAccepted = tree.LastAccepted
+	reply.Preference = tree.Preference
+	reply.MaxDepth = cjson.Uint32(tree.MaxDepth)
+	reply.BranchPoints = tree.BranchPoints
+	reply.Blocks = make([]ProcessingBlock, len(tree.Blocks))
+	for i, blk := range tree.Blocks {
+		reply.Blocks[i] = ProcessingBlock{
+			ID:          blk.ID,
+			ParentID:    blk.ParentID,
+			Height:      cjson.Uint64(blk.Height),
+			Depth:       cjson.Uint32(blk.Depth),
+			NumChildren: cjson.Uint32(blk.NumChildren),
+			Preferred:   blk.Preferred,
+		}
+	}
+	return nil
+}
+
+// GetBlockLifecyclesArgs are the arguments for calling GetBlockLifecycles
+type GetBlockLifecyclesArgs struct {
+	Chain string `json:"chain"`
+}
+
+// BlockLifecycle describes when a block reached each stage of being processed
+// by this node. Stages the block skipped are omitted.
+type BlockLifecycle struct {
+	ID ids.ID `json:"id"`
+	// Omitted if the block doesn't report its height
+	Height cjson.Uint64 `json:"height,omitempty"`
+	// For a block built by this node, when the VM was asked to build it
+	Received *time.Time `json:"received,omitempty"`
+	Parsed   *time.Time `json:"parsed,omitempty"`
+	Verified *time.Time `json:"verified,omitempty"`
+	Issued   *time.Time `json:"issued,omitempty"`
+	Accepted *time.Time `json:"accepted,omitempty"`
+	// How long the block spent in each stage, from the previous stage it
+	// reached, like "1.5ms"
+	ParseDuration  string `json:"parseDuration,omitempty"`
+	VerifyDuration string `json:"verifyDuration,omitempty"`
+	IssueDuration  string `json:"issueDuration,omitempty"`
+	AcceptDuration string `json:"acceptDuration,omitempty"`
+	// How long it took to accept the block, from the first stage it reached
+	TotalDuration string `json:"totalDuration,omitempty"`
+}
+
+// GetBlockLifecyclesReply are the results from calling GetBlockLifecycles
+type GetBlockLifecyclesReply struct {
+	// Sorted from the most recently accepted block to the least
+	Blocks []BlockLifecycle `json:"blocks"`
+}
+
+// GetBlockLifecycles returns when each of the most recently accepted blocks of
+// a Snowman chain was received, parsed, verified, issued, and accepted, which
+// shows where the time to finality is spent
+func (service *Admin) GetBlockLifecycles(_ *http.Request, args *GetBlockLifecyclesArgs, reply *GetBlockLifecyclesReply) error {
+	service.log.Info("Admin: GetBlockLifecycles called with Chain: %s", args.Chain)
+
+	chainID, err := service.chainManager.Lookup(args.Chain)
+	if err != nil {
+		return err
+	}
+	lifecycles, err := service.chainManager.BlockLifecycles(chainID)
+	if err != nil {
+		return err
+	}
+
+	reply.Blocks = make([]BlockLifecycle, len(lifecycles))
+	for i, lifecycle := range lifecycles {
+		blk := BlockLifecycle{
+			ID:     lifecycle.ID,
+			Height: cjson.Uint64(lifecycle.Height),
+		}
+		// The time of the last stage the block reached, and of the first
+		var last, first time.Time
+		stage := func(t time.Time, duration *string) *time.Time {
+			if t.IsZero() {
+				return nil
+			}
+			if !last.IsZero() && duration != nil {
+				*duration = t.Sub(last).String()
+			}
+			if first.IsZero() {
+				first = t
+			}
+			last = t
+			return &t
+		}
+		blk.Received = stage(lifecycle.Received, nil)
+		blk.Parsed = stage(lifecycle.Parsed, &blk.ParseDuration)
+		blk.Verified = stage(lifecycle.Verified, &blk.VerifyDuration)
+		blk.Issued = stage(lifecycle.Issued, &blk.IssueDuration)
+		blk.Accepted = stage(lifecycle.Accepted, &blk.AcceptDuration)
+		if blk.Accepted != nil {
+			blk.TotalDuration = last.Sub(first).String()
+		}
+		reply.Blocks[i] = blk
+	}
+	return nil
+}
+
+// ChaosArgs describes the faults injected into the consensus messages this node
+// sends
+type ChaosArgs struct {
+	// Probability that a message is dropped
+	DropRate cjson.Float32 `json:"dropRate"`
+	// Probability that a message that isn't dropped is delivered twice
+	DuplicateRate cjson.Float32 `json:"duplicateRate"`
+	// Each delivery of a message is delayed by a number of milliseconds drawn
+	// uniformly from [MinDelay, MaxDelay]
+	MinDelay cjson.Uint64 `json:"minDelay"`
+	MaxDelay cjson.Uint64 `json:"maxDelay"`
+	// Seeds the faults, so that a run can be reproduced
+	Seed cjson.Uint64 `json:"seed"`
+}
+
+// SetChaos injects faults into the consensus messages this node sends, to test
+// how consensus holds up on a bad network. Setting all of the arguments to 0
+// stops injecting faults. Only available on private networks.
+func (service *Admin) SetChaos(_ *http.Request, args *ChaosArgs, reply *api.SuccessResponse) error {
+	service.log.Info("Admin: SetChaos called with DropRate: %f, DuplicateRate: %f, MinDelay: %d, MaxDelay: %d, Seed: %d",
+		args.DropRate, args.DuplicateRate, args.MinDelay, args.MaxDelay, args.Seed)
+
+	if service.chaosSender == nil {
+		return errChaosDisabled
+	}
+	if err := service.chaosSender.Configure(sender.ChaosConfig{
+		DropRate:      float64(args.DropRate),
+		DuplicateRate: float64(args.DuplicateRate),
+		MinDelay:      time.Duration(args.MinDelay) * time.Millisecond,
+		MaxDelay:      time.Duration(args.MaxDelay) * time.Millisecond,
+		Seed:          int64(args.Seed),
+	}); err != nil {
+		return err
+	}
+	reply.Success = true
+	return nil
+}
+
+// GetChaos returns the faults being injected into the consensus messages this
+// node sends. Only available on private networks.
+func (service *Admin) GetChaos(_ *http.Request, _ *struct{}, reply *ChaosArgs) error {
+	service.log.Info("Admin: GetChaos called")
+
+	if service.chaosSender == nil {
+		return errChaosDisabled
+	}
+	config := service.chaosSender.Config()
+	reply.DropRate = cjson.Float32(config.DropRate)
+	reply.DuplicateRate = cjson.Float32(config.DuplicateRate)
+	reply.MinDelay = cjson.Uint64(config.MinDelay / time.Millisecond)
+	reply.MaxDelay = cjson.Uint64(config.MaxDelay / time.Millisecond)
+	reply.Seed = cjson.Uint64(config.Seed)
+	return nil
+}
+
 // Stacktrace returns the current global stacktrace
 func (service *Admin) Stacktrace(_ *http.Request, _ *struct{}, reply *api.SuccessResponse) error {
 	service.log.Info("Admin: Stacktrace called")
@@ -136,3 +399,67 @@ func (service *Admin) Stacktrace(_ *http.Request, _ *struct{}, reply *api.Succes
 	stacktrace := []byte(logging.Stacktrace{Global: true}.String())
 	return ioutil.WriteFile(stacktraceFile, stacktrace, 0600)
 }
+
+// GetCodecTypesReply are the results from calling GetCodecTypes
+type GetCodecTypesReply struct {
+	// Codec name --> Codec version --> Types registered in that version. The
+	// codecs of a chain's VM, such as the X-Chain's, are named by the chain's
+	// alias followed by the codec's name, such as X.avm.
+	Codecs map[string]map[uint16][]codec.RegisteredType `json:"codecs"`
+}
+
+// GetCodecTypes returns the type IDs registered in each version of the node's
+// codecs
+func (service *Admin) GetCodecTypes(_ *http.Request, _ *struct{}, reply *GetCodecTypesReply) error {
+	service.log.Info("Admin: GetCodecTypes called")
+
+	reply.Codecs = service.codecs.registeredTypes()
+	return nil
+}
+
+// SetPluginManifest replaces the manifest of the plugin binaries this node may
+// run. The manifest must be signed by one of the node's trusted signers and
+// must be newer than the current manifest. Plugins that are already running
+// aren't affected.
+func (service *Admin) SetPluginManifest(_ *http.Request, args *manifest.Signed, reply *api.SuccessResponse) error {
+	service.log.Info("Admin: SetPluginManifest called")
+
+	if service.pluginVerifier == nil {
+		return errPluginsNotVerified
+	}
+	if err := service.pluginVerifier.SetManifest(*args); err != nil {
+		return err
+	}
+	reply.Success = true
+	return nil
+}
+
+// GetPluginManifest returns the manifest of the plugin binaries this node may
+// run
+func (service *Admin) GetPluginManifest(_ *http.Request, _ *struct{}, reply *manifest.Manifest) error {
+	service.log.Info("Admin: GetPluginManifest called")
+
+	if service.pluginVerifier == nil {
+		return errPluginsNotVerified
+	}
+	current, ok := service.pluginVerifier.Manifest()
+	if !ok {
+		return errNoPluginManifest
+	}
+	*reply = current
+	return nil
+}
+
+// GetMalformedMessagesReply are the results from calling GetMalformedMessages
+type GetMalformedMessagesReply struct {
+	Messages []network.MalformedMessage `json:"messages"`
+}
+
+// GetMalformedMessages returns the most recent messages that peers sent this
+// node and that it couldn't parse, oldest first
+func (service *Admin) GetMalformedMessages(_ *http.Request, _ *struct{}, reply *GetMalformedMessagesReply) error {
+	service.log.Info("Admin: GetMalformedMessages called")
+
+	reply.Messages = service.networking.MalformedMessages()
+	return nil
+}
@@ -0,0 +1,43 @@
+// (c) 2019-2020, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package admin
+
+import (
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/ava-labs/avalanchego/network"
+	"github.com/ava-labs/avalanchego/utils/logging"
+)
+
+type malformedNetwork struct {
+	network.Network
+	messages []network.MalformedMessage
+}
+
+func (n *malformedNetwork) MalformedMessages() []network.MalformedMessage { return n.messages }
+
+func TestGetMalformedMessages(t *testing.T) {
+	messages := []network.MalformedMessage{{
+		NodeID:   "NodeID-111111111111111111116DBWJs",
+		IP:       "127.0.0.1:9651",
+		Received: time.Unix(1, 0),
+		Length:   3,
+		Bytes:    "0xffffff",
+		Error:    "unknown opcode",
+	}}
+	service := &Admin{
+		log:        logging.NoLog{},
+		networking: &malformedNetwork{messages: messages},
+	}
+
+	reply := GetMalformedMessagesReply{}
+	if err := service.GetMalformedMessages(nil, nil, &reply); err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(reply.Messages, messages) {
+		t.Fatalf("expected %v but got %v", messages, reply.Messages)
+	}
+}
@@ -1,6 +1,8 @@
 package auth
 
 import (
+	"crypto/rand"
+	"encoding/hex"
 	"errors"
 	"fmt"
 	"io"
@@ -12,6 +14,9 @@ import (
 
 	jwt "github.com/dgrijalva/jwt-go"
 
+	"github.com/ava-labs/avalanchego/database"
+	"github.com/ava-labs/avalanchego/utils/codec"
+	"github.com/ava-labs/avalanchego/utils/hashing"
 	"github.com/ava-labs/avalanchego/utils/password"
 	"github.com/ava-labs/avalanchego/utils/timer"
 )
@@ -22,18 +27,35 @@ const (
 
 	headerKey      = "Authorization"
 	headerValStart = "Bearer "
+
+	// Number of random bytes in a signing key
+	signingKeyLen = 32
+	// Number of bytes of the hash of a signing key used as its ID
+	signingKeyIDLen = 8
+
+	codecVersion = 0
 )
 
 var (
 	// TokenLifespan is how long a token lives before it expires
 	TokenLifespan = time.Hour * 12
 
+	// RefreshTokenLifespan is how long a refresh token lives before it expires
+	RefreshTokenLifespan = time.Hour * 24 * 7
+
+	stateKey = []byte("state")
+
 	// ErrNoToken is returned by GetToken if no token is provided
 	ErrNoToken = errors.New("auth token not provided")
 
 	errWrongPassword      = errors.New("incorrect password")
 	errInvalidTokenFormat = errors.New("token is invalid format")
 	errSamePassword       = errors.New("new password can't be same as old password")
+	errNotRefreshToken    = errors.New("token isn't a refresh token")
+	errRefreshToken       = errors.New("refresh tokens can't be used to access APIs")
+	errTokenRevoked       = errors.New("token was revoked")
+	errUnknownSigningKey  = errors.New("token was signed with an unknown key")
+	errWrongSigningMethod = errors.New("token was signed with an unexpected method")
 )
 
 // Auth handles HTTP API authorization for this node
@@ -41,9 +63,43 @@ type Auth struct {
 	Enabled  bool          // True iff API calls need auth token
 	Password password.Hash // Hash of the password. Can be changed via API call.
 
-	lock    sync.RWMutex // Prevent race condition when accessing password
-	clock   timer.Clock  // Tells the time. Can be faked for testing
-	revoked []string     // List of tokens that have been revoked
+	lock    sync.RWMutex   // Prevent race condition when accessing password
+	clock   timer.Clock    // Tells the time. Can be faked for testing
+	revoked []revokedToken // List of tokens that have been revoked
+
+	// Keys that tokens are signed with. The last key is the one new tokens are
+	// signed with. The others have been rotated out, but the tokens they
+	// signed are accepted until they expire.
+	keys []signingKey
+
+	// Persists [keys] and [revoked]. If nil, they aren't persisted.
+	db    database.Database
+	codec codec.Manager
+}
+
+// signingKey is a key that tokens are signed with
+type signingKey struct {
+	// Identifies the key in the header of the tokens it signs
+	ID     string `serialize:"true"`
+	Secret []byte `serialize:"true"`
+	// Unix time at which this key was rotated out, or 0 if it's in use
+	RetiredAt int64 `serialize:"true"`
+}
+
+// revokedToken is a token that has been revoked
+type revokedToken struct {
+	Token string `serialize:"true"`
+	// Unix time at which the token expires. After this, the token is rejected
+	// regardless of being revoked, so it needn't be remembered.
+	ExpiresAt int64 `serialize:"true"`
+}
+
+// persistedState is what's persisted to the database
+type persistedState struct {
+	// Hash of the password the keys were created under
+	Password password.Hash  `serialize:"true"`
+	Keys     []signingKey   `serialize:"true"`
+	Revoked  []revokedToken `serialize:"true"`
 }
 
 // Custom claim type used for API access token
@@ -54,11 +110,190 @@ type endpointClaims struct {
 	// If endpoints has an element "*", allows access to all API endpoints
 	// In this case, "*" should be the only element of [endpoints]
 	Endpoints []string
+
+	// True iff this is a refresh token. Refresh tokens can only be exchanged
+	// for new tokens; they don't allow access to any endpoint.
+	Refresh bool `json:",omitempty"`
+}
+
+// Initialize loads the signing keys and revoked tokens persisted in [db], and
+// persists them there from now on. [pw] is the current password. If the keys
+// were created under a different password, they're discarded, which makes
+// tokens issued under that password invalid.
+func (auth *Auth) Initialize(db database.Database, pw string) error {
+	c := codec.NewDefault()
+	manager := codec.NewDefaultManager()
+	if err := manager.RegisterCodec(codecVersion, c); err != nil {
+		return err
+	}
+
+	auth.lock.Lock()
+	defer auth.lock.Unlock()
+
+	auth.db = db
+	auth.codec = manager
+
+	stateBytes, err := db.Get(stateKey)
+	if err == database.ErrNotFound {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	state := persistedState{}
+	if _, err := manager.Unmarshal(stateBytes, &state); err != nil {
+		return err
+	}
+	if !state.Password.Check(pw) {
+		// The password changed while the node was offline
+		auth.keys = nil
+		auth.revoked = nil
+		return auth.persist()
+	}
+	auth.keys = state.Keys
+	auth.revoked = state.Revoked
+	auth.prune()
+	return auth.persist()
+}
+
+// persist writes the signing keys and revoked tokens to the database, if
+// there is one.
+// Assumes [auth.lock] is held.
+func (auth *Auth) persist() error {
+	if auth.db == nil {
+		return nil
+	}
+	stateBytes, err := auth.codec.Marshal(codecVersion, &persistedState{
+		Password: auth.Password,
+		Keys:     auth.keys,
+		Revoked:  auth.revoked,
+	})
+	if err != nil {
+		return err
+	}
+	return auth.db.Put(stateKey, stateBytes)
+}
+
+// prune forgets the keys that no unexpired token can be signed with, and the
+// revoked tokens that have expired.
+// Assumes [auth.lock] is held.
+func (auth *Auth) prune() {
+	now := auth.clock.Time()
+	keys := auth.keys[:0]
+	for _, key := range auth.keys {
+		// A retired key may have signed a refresh token just before it was
+		// retired
+		if key.RetiredAt == 0 || time.Unix(key.RetiredAt, 0).Add(RefreshTokenLifespan).After(now) {
+			keys = append(keys, key)
+		}
+	}
+	auth.keys = keys
+
+	revoked := auth.revoked[:0]
+	for _, token := range auth.revoked {
+		if token.ExpiresAt > now.Unix() {
+			revoked = append(revoked, token)
+		}
+	}
+	auth.revoked = revoked
+}
+
+// newSigningKey returns a new, random signing key
+func newSigningKey() (signingKey, error) {
+	secret := make([]byte, signingKeyLen)
+	if _, err := rand.Read(secret); err != nil {
+		return signingKey{}, err
+	}
+	return signingKey{
+		ID:     hex.EncodeToString(hashing.ComputeHash256(secret)[:signingKeyIDLen]),
+		Secret: secret,
+	}, nil
+}
+
+// currentKey returns the key to sign new tokens with. If there isn't one, it's
+// created.
+// Assumes [auth.lock] is held.
+func (auth *Auth) currentKey() (signingKey, error) {
+	if numKeys := len(auth.keys); numKeys > 0 && auth.keys[numKeys-1].RetiredAt == 0 {
+		return auth.keys[numKeys-1], nil
+	}
+	key, err := newSigningKey()
+	if err != nil {
+		return signingKey{}, err
+	}
+	auth.keys = append(auth.keys, key)
+	return key, auth.persist()
+}
+
+// getTokenKey returns the key to use when parsing [token]
+// Assumes [auth.lock] is held.
+func (auth *Auth) getTokenKey(token *jwt.Token) (interface{}, error) {
+	if _, ok := token.Method.(*jwt.SigningMethodHMAC); !ok {
+		return nil, errWrongSigningMethod
+	}
+	keyID, _ := token.Header["kid"].(string)
+	for _, key := range auth.keys {
+		if key.ID == keyID {
+			return key.Secret, nil
+		}
+	}
+	return nil, errUnknownSigningKey
 }
 
-// getTokenKey returns the key to use when making and parsing tokens
-func (auth *Auth) getTokenKey(*jwt.Token) (interface{}, error) {
-	return auth.Password.Password[:], nil
+// parseToken parses [tokenStr] and checks that it's valid, unexpired, and
+// hasn't been revoked
+// Assumes [auth.lock] is held.
+func (auth *Auth) parseToken(tokenStr string) (*endpointClaims, error) {
+	claims := &endpointClaims{}
+	token, err := jwt.ParseWithClaims(tokenStr, claims, auth.getTokenKey)
+	if err != nil {
+		return nil, err
+	}
+	if !token.Valid {
+		return nil, errors.New("token is invalid. Is it expired?")
+	}
+	for _, revokedToken := range auth.revoked {
+		if revokedToken.Token == tokenStr {
+			return nil, errTokenRevoked
+		}
+	}
+	return claims, nil
+}
+
+// signToken returns a new token, signed with the current key, that allows
+// access to [endpoints]. If [refresh], it's a refresh token.
+// Assumes [auth.lock] is held.
+func (auth *Auth) signToken(endpoints []string, refresh bool) (string, error) {
+	key, err := auth.currentKey()
+	if err != nil {
+		return "", err
+	}
+	lifespan := TokenLifespan
+	if refresh {
+		lifespan = RefreshTokenLifespan
+	}
+	claims := endpointClaims{
+		StandardClaims: jwt.StandardClaims{
+			ExpiresAt: auth.clock.Time().Add(lifespan).Unix(),
+		},
+		Endpoints: endpoints,
+		Refresh:   refresh,
+	}
+	token := jwt.NewWithClaims(jwt.SigningMethodHS256, claims)
+	token.Header["kid"] = key.ID
+	return token.SignedString(key.Secret) // Sign the token and return its string repr.
+}
+
+// newTokenPair returns a new token, and a refresh token that can be exchanged
+// for a new pair once the token expires. Both allow access to [endpoints].
+// Assumes [auth.lock] is held.
+func (auth *Auth) newTokenPair(endpoints []string) (string, string, error) {
+	token, err := auth.signToken(endpoints, false)
+	if err != nil {
+		return "", "", err
+	}
+	refreshToken, err := auth.signToken(endpoints, true)
+	return token, refreshToken, err
 }
 
 // getToken gets the JWT token from the request header
@@ -79,38 +314,76 @@ func getToken(r *http.Request) (string, error) {
 // that the API's path ends with an element of [endpoints]
 // If one of the elements of [endpoints] is "*", allows access to all APIs
 func (auth *Auth) newToken(password string, endpoints []string) (string, error) {
-	auth.lock.RLock()
-	defer auth.lock.RUnlock()
+	token, _, err := auth.newTokens(password, endpoints)
+	return token, err
+}
+
+// newTokens is newToken, but also returns a refresh token that can be
+// exchanged for new tokens without the password
+func (auth *Auth) newTokens(password string, endpoints []string) (string, string, error) {
+	auth.lock.Lock()
+	defer auth.lock.Unlock()
 	if !auth.Password.Check(password) {
-		return "", errWrongPassword
+		return "", "", errWrongPassword
 	}
-	canAccessAll := false
 	for _, endpoint := range endpoints {
 		if endpoint == "*" {
-			canAccessAll = true
+			endpoints = []string{"*"}
 			break
 		}
 	}
-	claims := endpointClaims{
-		StandardClaims: jwt.StandardClaims{
-			ExpiresAt: auth.clock.Time().Add(TokenLifespan).Unix(),
-		},
+	return auth.newTokenPair(endpoints)
+}
+
+// Exchanges the refresh token whose string repr. is [refreshTokenStr] for a new
+// token and refresh token, which allow access to the same endpoints.
+// The refresh token is revoked, so it can only be exchanged once.
+func (auth *Auth) refreshToken(refreshTokenStr string) (string, string, error) {
+	auth.lock.Lock()
+	defer auth.lock.Unlock()
+
+	claims, err := auth.parseToken(refreshTokenStr)
+	if err != nil {
+		return "", "", err
 	}
-	if canAccessAll {
-		claims.Endpoints = []string{"*"}
-	} else {
-		claims.Endpoints = endpoints
+	if !claims.Refresh {
+		return "", "", errNotRefreshToken
+	}
+	auth.prune()
+	auth.revoked = append(auth.revoked, revokedToken{
+		Token:     refreshTokenStr,
+		ExpiresAt: claims.ExpiresAt,
+	})
+	if err := auth.persist(); err != nil {
+		return "", "", err
+	}
+	return auth.newTokenPair(claims.Endpoints)
+}
+
+// Rotates the key that new tokens are signed with.
+// Tokens signed with the previous key are accepted until they expire.
+// Returns an error if the wrong password is given
+func (auth *Auth) rotateKey(password string) error {
+	auth.lock.Lock()
+	defer auth.lock.Unlock()
+	if !auth.Password.Check(password) {
+		return errWrongPassword
 	}
-	token := jwt.NewWithClaims(jwt.SigningMethodHS256, claims)
-	return token.SignedString(auth.Password.Password[:]) // Sign the token and return its string repr.
 
+	now := auth.clock.Time().Unix()
+	for i := range auth.keys {
+		if auth.keys[i].RetiredAt == 0 {
+			auth.keys[i].RetiredAt = now
+		}
+	}
+	auth.prune()
+	_, err := auth.currentKey()
+	return err
 }
 
 // Revokes the token whose string repr. is [tokenStr]; it will not be accepted as authorization for future API calls.
 // If the token is invalid, this is a no-op.
 // Only currently valid tokens can be revoked
-// If a token is revoked and then the password is changed, and then changed back to the current password,
-// the token will be un-revoked. Don't re-use passwords before at least TokenLifespan has elapsed.
 // Returns an error if the wrong password is given
 func (auth *Auth) revokeToken(tokenStr string, password string) error {
 	auth.lock.Lock()
@@ -120,23 +393,30 @@ func (auth *Auth) revokeToken(tokenStr string, password string) error {
 	}
 
 	// See if token is well-formed and signature is right
-	token, err := jwt.Parse(tokenStr, auth.getTokenKey)
+	claims := &endpointClaims{}
+	token, err := jwt.ParseWithClaims(tokenStr, claims, auth.getTokenKey)
 	if err != nil {
 		return err
 	}
 
 	// Only need to revoke if the token is valid
-	if token.Valid {
-		auth.revoked = append(auth.revoked, tokenStr)
+	if !token.Valid {
+		return nil
 	}
-	return nil
+	auth.prune()
+	auth.revoked = append(auth.revoked, revokedToken{
+		Token:     tokenStr,
+		ExpiresAt: claims.ExpiresAt,
+	})
+	return auth.persist()
 }
 
 // Change the password required to create and revoke tokens.
 // [oldPassword] is the current password.
 // [newPassword] is the new password. It can't be the empty string and it can't
 //               be unreasonably long.
-// Changing the password makes tokens issued under a previous password invalid.
+// Changing the password makes tokens issued under a previous password invalid,
+// because all of the keys that signed them are discarded.
 func (auth *Auth) changePassword(oldPassword, newPassword string) error {
 	if oldPassword == newPassword {
 		return errSamePassword
@@ -157,8 +437,9 @@ func (auth *Auth) changePassword(oldPassword, newPassword string) error {
 
 	// All the revoked tokens are now invalid; no need to mark specifically as
 	// revoked.
+	auth.keys = nil
 	auth.revoked = nil
-	return nil
+	return auth.persist()
 }
 
 // WrapHandler wraps a handler. Before passing a request to the handler, check that
@@ -195,34 +476,28 @@ func (auth *Auth) WrapHandler(h http.Handler) http.Handler {
 			return
 		}
 
+		// Check that the signature is right, that the token isn't expired, and
+		// that the token wasn't revoked
 		auth.lock.RLock()
-		token, err := jwt.ParseWithClaims(tokenStr, &endpointClaims{}, auth.getTokenKey)
+		claims, err := auth.parseToken(tokenStr)
 		auth.lock.RUnlock()
 
-		if err != nil { // Probably because signature wrong
+		if err != nil {
 			w.WriteHeader(http.StatusUnauthorized)
 			// Error is intentionally dropped here as there is nothing left to
 			// do with it.
 			_, _ = io.WriteString(w, fmt.Sprintf("invalid auth token: %s", err))
 			return
 		}
-		if !token.Valid { // Check that token isn't expired
+		if claims.Refresh {
 			w.WriteHeader(http.StatusUnauthorized)
 			// Error is intentionally dropped here as there is nothing left to
 			// do with it.
-			_, _ = io.WriteString(w, "invalid auth token. Is it expired?")
+			_, _ = io.WriteString(w, errRefreshToken.Error())
 			return
 		}
 
 		// Make sure this token gives access to the requested endpoint
-		claims, ok := token.Claims.(*endpointClaims)
-		if !ok {
-			w.WriteHeader(http.StatusUnauthorized)
-			// Error is intentionally dropped here as there is nothing left to
-			// do with it.
-			_, _ = io.WriteString(w, "expected auth token's claims to be type endpointClaims but is different type")
-			return
-		}
 		canAccess := false // true iff the token authorizes access to the API
 		for _, endpoint := range claims.Endpoints {
 			if endpoint == "*" || strings.HasSuffix(r.URL.Path, endpoint) {
@@ -238,19 +513,6 @@ func (auth *Auth) WrapHandler(h http.Handler) http.Handler {
 			return
 		}
 
-		auth.lock.RLock()
-		for _, revokedToken := range auth.revoked { // Make sure this token wasn't revoked
-			if revokedToken == tokenStr {
-				w.WriteHeader(http.StatusUnauthorized)
-				// Error is intentionally dropped here as there is nothing left
-				// to do with it.
-				_, _ = io.WriteString(w, "the provided auth token was revoked")
-				auth.lock.RUnlock()
-				return
-			}
-		}
-		auth.lock.RUnlock()
-
 		h.ServeHTTP(w, r) // Authorization successful
 	})
 }
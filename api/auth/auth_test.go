@@ -11,6 +11,7 @@ import (
 
 	jwt "github.com/dgrijalva/jwt-go"
 
+	"github.com/ava-labs/avalanchego/database/memdb"
 	"github.com/ava-labs/avalanchego/utils/password"
 )
 
@@ -58,10 +59,10 @@ func TestNewTokenHappyPath(t *testing.T) {
 	}
 
 	// Parse the token
-	token, err := jwt.ParseWithClaims(tokenStr, &endpointClaims{}, func(*jwt.Token) (interface{}, error) {
+	token, err := jwt.ParseWithClaims(tokenStr, &endpointClaims{}, func(token *jwt.Token) (interface{}, error) {
 		auth.lock.RLock()
 		defer auth.lock.RUnlock()
-		return auth.Password.Password[:], nil
+		return auth.getTokenKey(token)
 	})
 	if err != nil {
 		t.Fatalf("couldn't parse new token: %s", err)
@@ -181,7 +182,7 @@ func TestRevokeToken(t *testing.T) {
 
 	if err := auth.revokeToken(tokenStr, testPassword); err != nil {
 		t.Fatal("should have succeeded")
-	} else if len(auth.revoked) != 1 || auth.revoked[0] != tokenStr {
+	} else if len(auth.revoked) != 1 || auth.revoked[0].Token != tokenStr {
 		t.Fatal("revoked token list is incorrect")
 	}
 }
@@ -379,3 +380,167 @@ func TestWrapHandlerAuthDisabled(t *testing.T) {
 		}
 	}
 }
+
+// expectAccess asserts whether [tokenStr] allows access to [endpoint]
+func expectAccess(t *testing.T, auth *Auth, tokenStr, endpoint string, expected bool) {
+	req := httptest.NewRequest(http.MethodPost, fmt.Sprintf("http://127.0.0.1:9650%s", endpoint), strings.NewReader(""))
+	req.Header.Add("Authorization", fmt.Sprintf("Bearer %s", tokenStr))
+	rr := httptest.NewRecorder()
+	auth.WrapHandler(dummyHandler).ServeHTTP(rr, req)
+	if expected && rr.Code != http.StatusOK {
+		t.Fatalf("token should have allowed access to %s", endpoint)
+	} else if !expected && rr.Code != http.StatusUnauthorized {
+		t.Fatalf("token shouldn't have allowed access to %s", endpoint)
+	}
+}
+
+func TestRefreshToken(t *testing.T) {
+	auth := Auth{
+		Enabled:  true,
+		Password: hashedPassword,
+	}
+
+	endpoints := []string{"/ext/info"}
+	tokenStr, refreshTokenStr, err := auth.newTokens(testPassword, endpoints)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// Refresh tokens don't allow access to APIs, and tokens can't be exchanged
+	// for new tokens
+	expectAccess(t, &auth, refreshTokenStr, "/ext/info", false)
+	if _, _, err := auth.refreshToken(tokenStr); err == nil {
+		t.Fatal("should have failed because the token isn't a refresh token")
+	}
+
+	// Make tokens that were issued long enough ago that the token has expired
+	// but the refresh token hasn't
+	auth.clock.Set(time.Now().Add(-TokenLifespan - time.Minute))
+	tokenStr, refreshTokenStr, err = auth.newTokens(testPassword, endpoints)
+	if err != nil {
+		t.Fatal(err)
+	}
+	auth.clock.Sync()
+	expectAccess(t, &auth, tokenStr, "/ext/info", false)
+
+	newTokenStr, newRefreshTokenStr, err := auth.refreshToken(refreshTokenStr)
+	if err != nil {
+		t.Fatal(err)
+	}
+	expectAccess(t, &auth, newTokenStr, "/ext/info", true)
+	expectAccess(t, &auth, newTokenStr, "/ext/bc/X", false)
+
+	// A refresh token can only be exchanged once
+	if _, _, err := auth.refreshToken(refreshTokenStr); err == nil {
+		t.Fatal("should have failed because the refresh token was already used")
+	}
+	if _, _, err := auth.refreshToken(newRefreshTokenStr); err != nil {
+		t.Fatal(err)
+	}
+
+	// Refresh tokens that have expired are forgotten once another refresh
+	// token is exchanged
+	auth.clock.Set(time.Now().Add(RefreshTokenLifespan + time.Minute))
+	_, refreshTokenStr, err = auth.newTokens(testPassword, endpoints)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, _, err := auth.refreshToken(refreshTokenStr); err != nil {
+		t.Fatal(err)
+	}
+	if len(auth.revoked) != 1 {
+		t.Fatalf("expected 1 revoked token but have %d", len(auth.revoked))
+	}
+}
+
+func TestRotateKey(t *testing.T) {
+	auth := Auth{
+		Enabled:  true,
+		Password: hashedPassword,
+	}
+
+	oldTokenStr, oldRefreshTokenStr, err := auth.newTokens(testPassword, []string{"*"})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if err := auth.rotateKey("notThePassword"); err == nil {
+		t.Fatal("should have failed because password is wrong")
+	}
+	if err := auth.rotateKey(testPassword); err != nil {
+		t.Fatal(err)
+	}
+
+	newTokenStr, err := auth.newToken(testPassword, []string{"*"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(auth.keys) != 2 {
+		t.Fatalf("expected 2 signing keys but have %d", len(auth.keys))
+	}
+
+	// Tokens signed with either key are accepted during the rollover
+	expectAccess(t, &auth, oldTokenStr, "/ext/info", true)
+	expectAccess(t, &auth, newTokenStr, "/ext/info", true)
+
+	// Once every token the old key signed has expired, the old key is dropped
+	auth.clock.Set(time.Now().Add(RefreshTokenLifespan + time.Minute))
+	if err := auth.rotateKey(testPassword); err != nil {
+		t.Fatal(err)
+	}
+	if len(auth.keys) != 2 {
+		t.Fatalf("expected 2 signing keys but have %d", len(auth.keys))
+	}
+	if _, _, err := auth.refreshToken(oldRefreshTokenStr); err == nil {
+		t.Fatal("should have failed because the key that signed the refresh token was dropped")
+	}
+}
+
+func TestPersistence(t *testing.T) {
+	db := memdb.New()
+	auth := Auth{
+		Enabled:  true,
+		Password: hashedPassword,
+	}
+	if err := auth.Initialize(db, testPassword); err != nil {
+		t.Fatal(err)
+	}
+
+	tokenStr, err := auth.newToken(testPassword, []string{"*"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	revokedTokenStr, err := auth.newToken(testPassword, []string{"/ext/info"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := auth.revokeToken(revokedTokenStr, testPassword); err != nil {
+		t.Fatal(err)
+	}
+
+	// Tokens survive a restart, and so do revocations
+	restarted := Auth{
+		Enabled:  true,
+		Password: hashedPassword,
+	}
+	if err := restarted.Initialize(db, testPassword); err != nil {
+		t.Fatal(err)
+	}
+	expectAccess(t, &restarted, tokenStr, "/ext/info", true)
+	expectAccess(t, &restarted, revokedTokenStr, "/ext/info", false)
+
+	// Restarting with a different password invalidates the tokens
+	password2 := "fejhkefjhefjhefhje" // #nosec G101
+	hashedPassword2 := password.Hash{}
+	if err := hashedPassword2.Set(password2); err != nil {
+		t.Fatal(err)
+	}
+	restarted = Auth{
+		Enabled:  true,
+		Password: hashedPassword2,
+	}
+	if err := restarted.Initialize(db, password2); err != nil {
+		t.Fatal(err)
+	}
+	expectAccess(t, &restarted, tokenStr, "/ext/info", false)
+}
@@ -18,8 +18,9 @@ const (
 )
 
 var (
-	errNoPassword = errors.New("argument 'password' not given")
-	errNoToken    = errors.New("argument 'token' not given")
+	errNoPassword     = errors.New("argument 'password' not given")
+	errNoToken        = errors.New("argument 'token' not given")
+	errNoRefreshToken = errors.New("argument 'refreshToken' not given")
 )
 
 // Service ...
@@ -65,8 +66,22 @@ type Token struct {
 	Token string `json:"token"` // The new token. Expires in [TokenLifespan].
 }
 
-// NewToken returns a new token
-func (s *Service) NewToken(_ *http.Request, args *NewTokenArgs, reply *Token) error {
+// RefreshToken ...
+type RefreshToken struct {
+	// Can be exchanged for a new token and refresh token via the RefreshToken
+	// API call. Expires in [RefreshTokenLifespan].
+	RefreshToken string `json:"refreshToken"`
+}
+
+// NewTokenReply ...
+type NewTokenReply struct {
+	Token
+	RefreshToken
+}
+
+// NewToken returns a new token, and a refresh token that can be exchanged for
+// a new token when it expires
+func (s *Service) NewToken(_ *http.Request, args *NewTokenArgs, reply *NewTokenReply) error {
 	s.log.Info("Auth: NewToken called")
 	if args.Password.Password == "" {
 		return errNoPassword
@@ -75,11 +90,37 @@ func (s *Service) NewToken(_ *http.Request, args *NewTokenArgs, reply *Token) er
 		return fmt.Errorf("argument 'endpoints' must have between %d and %d elements, but has %d",
 			1, maxEndpoints, l)
 	}
-	token, err := s.newToken(args.Password.Password, args.Endpoints)
-	reply.Token = token
+	token, refreshToken, err := s.newTokens(args.Password.Password, args.Endpoints)
+	reply.Token.Token = token
+	reply.RefreshToken.RefreshToken = refreshToken
+	return err
+}
+
+// RefreshToken exchanges a refresh token for a new token and refresh token,
+// which allow access to the same endpoints. The password isn't needed, and the
+// given refresh token can't be used again.
+func (s *Service) RefreshToken(_ *http.Request, args *RefreshToken, reply *NewTokenReply) error {
+	s.log.Info("Auth: RefreshToken called")
+	if args.RefreshToken == "" {
+		return errNoRefreshToken
+	}
+	token, refreshToken, err := s.refreshToken(args.RefreshToken)
+	reply.Token.Token = token
+	reply.RefreshToken.RefreshToken = refreshToken
 	return err
 }
 
+// RotateKey rotates the key that new tokens are signed with. Tokens signed with
+// the previous key are accepted until they expire.
+func (s *Service) RotateKey(_ *http.Request, args *Password, reply *Success) error {
+	s.log.Info("Auth: RotateKey called")
+	if args.Password == "" {
+		return errNoPassword
+	}
+	reply.Success = true
+	return s.rotateKey(args.Password)
+}
+
 // RevokeTokenArgs ...
 type RevokeTokenArgs struct {
 	Password
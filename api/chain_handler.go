@@ -0,0 +1,32 @@
+// (c) 2019-2020, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package api
+
+import (
+	"net/http"
+	"sync"
+)
+
+// chainHandler serves a route registered by a chain. The underlying handler can
+// be replaced so that a chain that was restarted keeps serving its API from the
+// same route.
+type chainHandler struct {
+	lock    sync.RWMutex
+	handler http.Handler
+}
+
+func (ch *chainHandler) ServeHTTP(writer http.ResponseWriter, request *http.Request) {
+	ch.lock.RLock()
+	handler := ch.handler
+	ch.lock.RUnlock()
+
+	handler.ServeHTTP(writer, request)
+}
+
+func (ch *chainHandler) setHandler(handler http.Handler) {
+	ch.lock.Lock()
+	defer ch.lock.Unlock()
+
+	ch.handler = handler
+}
@@ -0,0 +1,162 @@
+// (c) 2019-2020, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package api
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+
+	"github.com/ava-labs/avalanchego/utils/logging"
+	"github.com/ava-labs/avalanchego/utils/timer"
+)
+
+const (
+	// Min amount of time between logging calls to the same deprecated method
+	deprecationLogFrequency = time.Minute
+
+	// Header set in responses to calls to deprecated methods
+	deprecationHeader = "Deprecation"
+)
+
+// deprecations tracks the calls to deprecated API methods, so operators can
+// tell whether the methods are still used before they're removed
+type deprecations struct {
+	log   logging.Logger
+	clock timer.Clock
+
+	lock sync.Mutex
+	// Lowercased method name --> deprecated method
+	methods map[string]*deprecatedMethod
+
+	// Number of calls to each deprecated method, labeled by method. Nil if
+	// metrics haven't been registered.
+	calls *prometheus.CounterVec
+}
+
+type deprecatedMethod struct {
+	// Method name, like "avm.importAVAX"
+	name string
+	// Explains what to use instead of the method
+	notice string
+	// Last time a call to this method was logged
+	lastLogged time.Time
+	// Number of calls since a call was last logged
+	unlogged uint64
+}
+
+func (d *deprecations) initialize(log logging.Logger) {
+	d.log = log
+	d.methods = make(map[string]*deprecatedMethod)
+}
+
+// registerMetrics exposes the number of calls to each deprecated method
+func (d *deprecations) registerMetrics(namespace string, registerer prometheus.Registerer) error {
+	calls := prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "deprecated_calls",
+		Help:      "Number of calls to each deprecated API method",
+	}, []string{"method"})
+	if err := registerer.Register(calls); err != nil {
+		return fmt.Errorf("failed to register deprecated_calls statistics due to %w", err)
+	}
+
+	d.lock.Lock()
+	defer d.lock.Unlock()
+
+	d.calls = calls
+	for _, method := range d.methods {
+		// Report methods that were never called too
+		d.calls.WithLabelValues(method.name)
+	}
+	return nil
+}
+
+// deprecate marks the JSON RPC method [name] as deprecated. [notice] should
+// explain what to use instead.
+func (d *deprecations) deprecate(name, notice string) {
+	d.lock.Lock()
+	defer d.lock.Unlock()
+
+	d.methods[strings.ToLower(name)] = &deprecatedMethod{
+		name:   name,
+		notice: notice,
+	}
+	if d.calls != nil {
+		d.calls.WithLabelValues(name)
+	}
+}
+
+// wrapHandler returns a handler that tags the responses to calls to deprecated
+// methods and records the calls, before calling [handler]
+func (d *deprecations) wrapHandler(handler http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if method, ok := d.deprecatedMethod(r); ok {
+			d.called(method, r)
+			w.Header().Set(deprecationHeader, "true")
+			w.Header().Set("Warning", fmt.Sprintf("299 - %q", method.name+" is deprecated: "+method.notice))
+		}
+		handler.ServeHTTP(w, r)
+	})
+}
+
+// deprecatedMethod returns the deprecated method [r] calls, if any. The body
+// of [r] is restored so it can be read again.
+func (d *deprecations) deprecatedMethod(r *http.Request) (*deprecatedMethod, bool) {
+	d.lock.Lock()
+	noDeprecations := len(d.methods) == 0
+	d.lock.Unlock()
+	if noDeprecations || r.Method != http.MethodPost || r.Body == nil {
+		return nil, false
+	}
+
+	body, err := ioutil.ReadAll(r.Body)
+	_ = r.Body.Close()
+	r.Body = ioutil.NopCloser(bytes.NewReader(body))
+	if err != nil {
+		// Let the handler report the malformed request
+		return nil, false
+	}
+
+	call := struct {
+		Method string `json:"method"`
+	}{}
+	if err := json.Unmarshal(body, &call); err != nil {
+		return nil, false
+	}
+
+	d.lock.Lock()
+	defer d.lock.Unlock()
+
+	method, ok := d.methods[strings.ToLower(call.Method)]
+	return method, ok
+}
+
+// called records a call to [method], logging it unless a call to [method] was
+// logged recently
+func (d *deprecations) called(method *deprecatedMethod, r *http.Request) {
+	d.lock.Lock()
+	defer d.lock.Unlock()
+
+	if d.calls != nil {
+		d.calls.WithLabelValues(method.name).Inc()
+	}
+
+	now := d.clock.Time()
+	if now.Sub(method.lastLogged) < deprecationLogFrequency {
+		method.unlogged++
+		return
+	}
+	d.log.Warn("deprecated API method %s called by %s with user agent %q (%d other calls since last logged). %s",
+		method.name, r.RemoteAddr, r.UserAgent(), method.unlogged, method.notice)
+	method.lastLogged = now
+	method.unlogged = 0
+}
@@ -0,0 +1,79 @@
+// (c) 2019-2020, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package api
+
+import (
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+
+	"github.com/ava-labs/avalanchego/utils/logging"
+)
+
+func TestDeprecations(t *testing.T) {
+	d := deprecations{}
+	d.initialize(logging.NoLog{})
+	if err := d.registerMetrics("test", prometheus.NewRegistry()); err != nil {
+		t.Fatal(err)
+	}
+	d.deprecate("avm.importAVAX", "use avm.import instead")
+	now := time.Now()
+	d.clock.Set(now)
+
+	body := `{"jsonrpc":"2.0","id":1,"method":"avm.ImportAVAX","params":{}}`
+	handlerBody := ""
+	handler := d.wrapHandler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		bytes, err := ioutil.ReadAll(r.Body)
+		if err != nil {
+			t.Fatal(err)
+		}
+		handlerBody = string(bytes)
+	}))
+
+	w := httptest.NewRecorder()
+	handler.ServeHTTP(w, httptest.NewRequest(http.MethodPost, "/ext/bc/X", strings.NewReader(body)))
+	if handlerBody != body {
+		t.Fatalf("expected the handler to read %q but got %q", body, handlerBody)
+	}
+	if w.Header().Get(deprecationHeader) != "true" {
+		t.Fatal("expected the response to be tagged as deprecated")
+	}
+	if warning := w.Header().Get("Warning"); !strings.Contains(warning, "use avm.import instead") {
+		t.Fatalf("expected the warning to explain what to use instead but got %q", warning)
+	}
+
+	method := d.methods["avm.importavax"]
+	if !method.lastLogged.Equal(now) {
+		t.Fatal("expected the first call to be logged")
+	}
+
+	// Calls shortly after a logged call aren't logged
+	d.clock.Set(now.Add(deprecationLogFrequency / 2))
+	handler.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodPost, "/ext/bc/X", strings.NewReader(body)))
+	if method.unlogged != 1 || !method.lastLogged.Equal(now) {
+		t.Fatalf("expected the second call not to be logged but %d calls are unlogged", method.unlogged)
+	}
+
+	d.clock.Set(now.Add(deprecationLogFrequency))
+	handler.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodPost, "/ext/bc/X", strings.NewReader(body)))
+	if method.unlogged != 0 || !method.lastLogged.Equal(now.Add(deprecationLogFrequency)) {
+		t.Fatal("expected the third call to be logged")
+	}
+
+	// Other methods aren't tagged
+	w = httptest.NewRecorder()
+	body = `{"jsonrpc":"2.0","id":1,"method":"avm.import","params":{}}`
+	handler.ServeHTTP(w, httptest.NewRequest(http.MethodPost, "/ext/bc/X", strings.NewReader(body)))
+	if w.Header().Get(deprecationHeader) != "" {
+		t.Fatal("didn't expect the response to be tagged as deprecated")
+	}
+	if handlerBody != body {
+		t.Fatalf("expected the handler to read %q but got %q", body, handlerBody)
+	}
+}
@@ -6,6 +6,8 @@ package info
 import (
 	"time"
 
+	"github.com/ava-labs/avalanchego/chains"
+	"github.com/ava-labs/avalanchego/genesis"
 	"github.com/ava-labs/avalanchego/network"
 	"github.com/ava-labs/avalanchego/utils/rpc"
 )
@@ -55,10 +57,18 @@ func (c *Client) GetBlockchainID(alias string) (string, error) {
 // Peers ...
 func (c *Client) Peers() ([]network.PeerID, error) {
 	res := &PeersReply{}
-	err := c.requester.SendRequest("peers", struct{}{}, res)
+	err := c.requester.SendRequest("peers", &PeersArgs{}, res)
 	return res.Peers, err
 }
 
+// FilteredPeers returns the peers that match [args] and the number of peers
+// that matched before pagination
+func (c *Client) FilteredPeers(args *PeersArgs) ([]network.PeerID, uint64, error) {
+	res := &PeersReply{}
+	err := c.requester.SendRequest("peers", args, res)
+	return res.Peers, uint64(res.TotalPeers), err
+}
+
 // IsBootstrapped ...
 func (c *Client) IsBootstrapped(chain string) (bool, error) {
 	res := &IsBootstrappedResponse{}
@@ -68,6 +78,20 @@ func (c *Client) IsBootstrapped(chain string) (bool, error) {
 	return res.IsBootstrapped, err
 }
 
+// GetChains ...
+func (c *Client) GetChains() ([]chains.ChainInfo, error) {
+	res := &GetChainsReply{}
+	err := c.requester.SendRequest("getChains", struct{}{}, res)
+	return res.Chains, err
+}
+
+// GetChainDBSizes ...
+func (c *Client) GetChainDBSizes() (*chains.DBSizeReport, error) {
+	res := &chains.DBSizeReport{}
+	err := c.requester.SendRequest("getChainDBSizes", struct{}{}, res)
+	return res, err
+}
+
 // GetTxFee ...
 func (c *Client) GetTxFee() (*GetTxFeeResponse, error) {
 	res := &GetTxFeeResponse{}
@@ -75,9 +99,54 @@ func (c *Client) GetTxFee() (*GetTxFeeResponse, error) {
 	return res, err
 }
 
+// GetNetworkUpgrades ...
+func (c *Client) GetNetworkUpgrades() ([]NetworkUpgrade, error) {
+	res := &GetNetworkUpgradesReply{}
+	err := c.requester.SendRequest("getNetworkUpgrades", struct{}{}, res)
+	return res.Upgrades, err
+}
+
+// GetTotalSupply returns the supply of AVAX less the fees burned on each chain
+func (c *Client) GetTotalSupply() (*GetTotalSupplyReply, error) {
+	res := &GetTotalSupplyReply{}
+	err := c.requester.SendRequest("getTotalSupply", struct{}{}, res)
+	return res, err
+}
+
 // GetNodeIP ...
 func (c *Client) GetNodeIP() (string, error) {
 	res := &GetNodeIPReply{}
 	err := c.requester.SendRequest("getNodeIP", struct{}{}, res)
 	return res.IP, err
 }
+
+// ConvertEncoding ...
+func (c *Client) ConvertEncoding(input, from, to, hrp string) (string, error) {
+	res := &ConvertEncodingReply{}
+	err := c.requester.SendRequest("convertEncoding", &ConvertEncodingArgs{
+		Input: input,
+		From:  from,
+		To:    to,
+		HRP:   hrp,
+	}, res)
+	return res.Output, err
+}
+
+// ValidateEncoding ...
+func (c *Client) ValidateEncoding(input, encoding string) (*ValidateEncodingReply, error) {
+	res := &ValidateEncodingReply{}
+	err := c.requester.SendRequest("validateEncoding", &ValidateEncodingArgs{
+		Input:    input,
+		Encoding: encoding,
+	}, res)
+	return res, err
+}
+
+// CompareGenesis ...
+func (c *Client) CompareGenesis(config genesis.UnparsedConfig) (*CompareGenesisReply, error) {
+	res := &CompareGenesisReply{}
+	err := c.requester.SendRequest("compareGenesis", &CompareGenesisArgs{
+		Genesis: config,
+	}, res)
+	return res, err
+}
@@ -0,0 +1,138 @@
+// (c) 2019-2020, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package info
+
+import (
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/ava-labs/avalanchego/utils/formatting"
+	"github.com/ava-labs/avalanchego/utils/json"
+)
+
+// Encodings supported by ConvertEncoding and ValidateEncoding. CB58 and hex
+// strings end with a 4 byte checksum, and hex strings are prefixed with 0x.
+const (
+	cb58Encoding   = "cb58"
+	hexEncoding    = "hex"
+	bech32Encoding = "bech32"
+)
+
+// decodeString decodes [input], which is encoded with [encoding]. If
+// [encoding] is bech32, the HRP of [input] is also returned. Bech32 strings
+// may be prefixed with a chain alias, like addresses are.
+func decodeString(encoding, input string) ([]byte, string, error) {
+	switch strings.ToLower(encoding) {
+	case cb58Encoding:
+		b, err := formatting.Decode(formatting.CB58, input)
+		return b, "", err
+	case hexEncoding:
+		b, err := formatting.Decode(formatting.Hex, input)
+		return b, "", err
+	case bech32Encoding:
+		hrp, b, err := formatting.ParseBech32(input)
+		if err != nil {
+			var addrErr error
+			if _, hrp, b, addrErr = formatting.ParseAddress(input); addrErr != nil {
+				return nil, "", err
+			}
+		}
+		return b, hrp, nil
+	default:
+		return nil, "", fmt.Errorf("unknown encoding %q. Must be one of %s, %s, or %s", encoding, cb58Encoding, hexEncoding, bech32Encoding)
+	}
+}
+
+// encodeBytes encodes [b] with [encoding]. [hrp] is only used, and must be
+// provided, if [encoding] is bech32.
+func encodeBytes(encoding, hrp string, b []byte) (string, error) {
+	switch strings.ToLower(encoding) {
+	case cb58Encoding:
+		return formatting.Encode(formatting.CB58, b)
+	case hexEncoding:
+		return formatting.Encode(formatting.Hex, b)
+	case bech32Encoding:
+		if hrp == "" {
+			return "", fmt.Errorf("an HRP must be provided to encode with %s", bech32Encoding)
+		}
+		return formatting.FormatBech32(hrp, b)
+	default:
+		return "", fmt.Errorf("unknown encoding %q. Must be one of %s, %s, or %s", encoding, cb58Encoding, hexEncoding, bech32Encoding)
+	}
+}
+
+// ConvertEncodingArgs are the arguments to ConvertEncoding
+type ConvertEncodingArgs struct {
+	// String to convert
+	Input string `json:"input"`
+	// Encoding of [Input]. One of cb58, hex, or bech32.
+	From string `json:"from"`
+	// Encoding to convert [Input] to. One of cb58, hex, or bech32.
+	To string `json:"to"`
+	// HRP of the output, if [To] is bech32. If empty, the HRP of the input is
+	// used.
+	HRP string `json:"hrp"`
+}
+
+// ConvertEncodingReply is the response from ConvertEncoding
+type ConvertEncodingReply struct {
+	Output string `json:"output"`
+}
+
+// ConvertEncoding re-encodes a string from one encoding to another. The
+// checksum of the input is verified.
+func (service *Info) ConvertEncoding(_ *http.Request, args *ConvertEncodingArgs, reply *ConvertEncodingReply) error {
+	service.log.Info("Info: ConvertEncoding called")
+
+	b, inputHRP, err := decodeString(args.From, args.Input)
+	if err != nil {
+		return fmt.Errorf("couldn't decode input: %w", err)
+	}
+	hrp := args.HRP
+	if hrp == "" {
+		hrp = inputHRP
+	}
+	reply.Output, err = encodeBytes(args.To, hrp, b)
+	if err != nil {
+		return fmt.Errorf("couldn't encode output: %w", err)
+	}
+	return nil
+}
+
+// ValidateEncodingArgs are the arguments to ValidateEncoding
+type ValidateEncodingArgs struct {
+	// String to validate
+	Input string `json:"input"`
+	// Encoding of [Input]. One of cb58, hex, or bech32.
+	Encoding string `json:"encoding"`
+}
+
+// ValidateEncodingReply is the response from ValidateEncoding
+type ValidateEncodingReply struct {
+	// True iff [Input] is validly encoded and its checksum is correct
+	Valid bool `json:"valid"`
+	// Why [Input] isn't valid
+	Error string `json:"error,omitempty"`
+	// Number of bytes [Input] decodes to
+	Length json.Uint32 `json:"length"`
+	// HRP of [Input], if it's bech32
+	HRP string `json:"hrp,omitempty"`
+}
+
+// ValidateEncoding reports whether a string is validly encoded. An invalid
+// string is reported in the response rather than as an error.
+func (service *Info) ValidateEncoding(_ *http.Request, args *ValidateEncodingArgs, reply *ValidateEncodingReply) error {
+	service.log.Info("Info: ValidateEncoding called")
+
+	b, hrp, err := decodeString(args.Encoding, args.Input)
+	if err != nil {
+		reply.Error = err.Error()
+		return nil
+	}
+	reply.Valid = true
+	reply.Length = json.Uint32(len(b))
+	reply.HRP = hrp
+	return nil
+}
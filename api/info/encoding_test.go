@@ -0,0 +1,145 @@
+// (c) 2019-2020, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package info
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/ava-labs/avalanchego/utils/formatting"
+	"github.com/ava-labs/avalanchego/utils/logging"
+)
+
+func TestConvertEncoding(t *testing.T) {
+	service := &Info{log: logging.NoLog{}}
+	raw := []byte{0, 1, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11, 12, 13, 14, 15, 16, 17, 18, 19}
+
+	cb58, err := formatting.Encode(formatting.CB58, raw)
+	if err != nil {
+		t.Fatal(err)
+	}
+	hex, err := formatting.Encode(formatting.Hex, raw)
+	if err != nil {
+		t.Fatal(err)
+	}
+	bech32, err := formatting.FormatBech32("avax", raw)
+	if err != nil {
+		t.Fatal(err)
+	}
+	fujiBech32, err := formatting.FormatBech32("fuji", raw)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name     string
+		args     ConvertEncodingArgs
+		expected string
+	}{
+		{
+			name:     "cb58 to hex",
+			args:     ConvertEncodingArgs{Input: cb58, From: "cb58", To: "hex"},
+			expected: hex,
+		},
+		{
+			name:     "hex to cb58",
+			args:     ConvertEncodingArgs{Input: hex, From: "HEX", To: "cb58"},
+			expected: cb58,
+		},
+		{
+			name:     "cb58 to bech32",
+			args:     ConvertEncodingArgs{Input: cb58, From: "cb58", To: "bech32", HRP: "avax"},
+			expected: bech32,
+		},
+		{
+			name:     "bech32 to cb58",
+			args:     ConvertEncodingArgs{Input: bech32, From: "bech32", To: "cb58"},
+			expected: cb58,
+		},
+		{
+			name:     "address to hex",
+			args:     ConvertEncodingArgs{Input: "X-" + bech32, From: "bech32", To: "hex"},
+			expected: hex,
+		},
+		{
+			name:     "bech32 keeps its HRP",
+			args:     ConvertEncodingArgs{Input: bech32, From: "bech32", To: "bech32"},
+			expected: bech32,
+		},
+		{
+			name:     "bech32 changes its HRP",
+			args:     ConvertEncodingArgs{Input: bech32, From: "bech32", To: "bech32", HRP: "fuji"},
+			expected: fujiBech32,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			reply := ConvertEncodingReply{}
+			if err := service.ConvertEncoding(nil, &test.args, &reply); err != nil {
+				t.Fatal(err)
+			}
+			if reply.Output != test.expected {
+				t.Fatalf("expected %s but got %s", test.expected, reply.Output)
+			}
+		})
+	}
+
+	// The HRP of the output must be known
+	reply := ConvertEncodingReply{}
+	if err := service.ConvertEncoding(nil, &ConvertEncodingArgs{Input: cb58, From: "cb58", To: "bech32"}, &reply); err == nil {
+		t.Fatalf("should have errored without an HRP")
+	}
+	if err := service.ConvertEncoding(nil, &ConvertEncodingArgs{Input: cb58, From: "cb58", To: "base64"}, &reply); err == nil {
+		t.Fatalf("should have errored due to an unknown encoding")
+	}
+}
+
+func TestValidateEncoding(t *testing.T) {
+	service := &Info{log: logging.NoLog{}}
+	raw := []byte{1, 2, 3, 4}
+
+	cb58, err := formatting.Encode(formatting.CB58, raw)
+	if err != nil {
+		t.Fatal(err)
+	}
+	reply := ValidateEncodingReply{}
+	if err := service.ValidateEncoding(nil, &ValidateEncodingArgs{Input: cb58, Encoding: "cb58"}, &reply); err != nil {
+		t.Fatal(err)
+	}
+	if !reply.Valid || reply.Error != "" || int(reply.Length) != len(raw) {
+		t.Fatalf("expected %s to be valid, decoding to %d bytes, but got %+v", cb58, len(raw), reply)
+	}
+
+	// Corrupt the checksum
+	hex, err := formatting.Encode(formatting.Hex, raw)
+	if err != nil {
+		t.Fatal(err)
+	}
+	corrupted := hex[:len(hex)-1] + "0"
+	if corrupted == hex {
+		corrupted = hex[:len(hex)-1] + "1"
+	}
+	reply = ValidateEncodingReply{}
+	if err := service.ValidateEncoding(nil, &ValidateEncodingArgs{Input: corrupted, Encoding: "hex"}, &reply); err != nil {
+		t.Fatal(err)
+	}
+	if reply.Valid || reply.Error == "" {
+		t.Fatalf("expected %s to be invalid but got %+v", corrupted, reply)
+	}
+
+	bech32, err := formatting.FormatBech32("local", raw)
+	if err != nil {
+		t.Fatal(err)
+	}
+	reply = ValidateEncodingReply{}
+	if err := service.ValidateEncoding(nil, &ValidateEncodingArgs{Input: bech32, Encoding: "bech32"}, &reply); err != nil {
+		t.Fatal(err)
+	}
+	if !reply.Valid || reply.HRP != "local" {
+		t.Fatalf("expected %s to be valid with HRP local but got %+v", bech32, reply)
+	}
+	if decoded, _, err := decodeString(bech32Encoding, bech32); err != nil || !bytes.Equal(decoded, raw) {
+		t.Fatalf("expected %s to decode to %v but got %v, %v", bech32, raw, decoded, err)
+	}
+}
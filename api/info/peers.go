@@ -0,0 +1,100 @@
+// (c) 2019-2020, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package info
+
+import (
+	"fmt"
+	"sort"
+
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/network"
+	"github.com/ava-labs/avalanchego/snow/validators"
+	"github.com/ava-labs/avalanchego/version"
+)
+
+// Orderings that peers can be sorted by
+const (
+	sortByUptime  = "uptime"  // longest connected first
+	sortByLatency = "latency" // lowest latency first
+	sortByStake   = "stake"   // most stake first
+)
+
+// peerFilter selects, orders, and paginates peers
+type peerFilter struct {
+	// validators used to filter and sort by stake. May be nil if neither is
+	// requested.
+	vdrs           validators.Set
+	validatorsOnly bool
+
+	// nil means no bound
+	minVersion, maxVersion version.Version
+
+	sortBy        string
+	offset, limit int
+}
+
+// apply returns the page of [peers] matching the filter and the number of
+// peers that matched before pagination
+func (f *peerFilter) apply(parser version.Parser, peers []network.PeerID) ([]network.PeerID, int, error) {
+	weights := make(map[string]uint64, len(peers))
+	matched := make([]network.PeerID, 0, len(peers))
+	for _, peer := range peers {
+		if f.vdrs != nil {
+			nodeID, err := ids.NodeIDFromString(peer.ID)
+			if err != nil {
+				return nil, 0, fmt.Errorf("couldn't parse peer ID %q: %w", peer.ID, err)
+			}
+			weight, isValidator := f.vdrs.GetWeight(nodeID.ShortID())
+			if f.validatorsOnly && !isValidator {
+				continue
+			}
+			weights[peer.ID] = weight
+		}
+		if f.minVersion != nil || f.maxVersion != nil {
+			peerVersion, err := parser.Parse(peer.Version)
+			if err != nil {
+				continue
+			}
+			if f.minVersion != nil && (peerVersion.App() != f.minVersion.App() || peerVersion.Before(f.minVersion)) {
+				continue
+			}
+			if f.maxVersion != nil && (peerVersion.App() != f.maxVersion.App() || f.maxVersion.Before(peerVersion)) {
+				continue
+			}
+		}
+		matched = append(matched, peer)
+	}
+
+	switch f.sortBy {
+	case "":
+	case sortByUptime:
+		sort.SliceStable(matched, func(i, j int) bool { return matched[i].ConnectedAt.Before(matched[j].ConnectedAt) })
+	case sortByLatency:
+		// Peers that haven't responded to a ping yet are sorted last
+		sort.SliceStable(matched, func(i, j int) bool {
+			switch {
+			case matched[i].Latency == 0:
+				return false
+			case matched[j].Latency == 0:
+				return true
+			default:
+				return matched[i].Latency < matched[j].Latency
+			}
+		})
+	case sortByStake:
+		sort.SliceStable(matched, func(i, j int) bool { return weights[matched[i].ID] > weights[matched[j].ID] })
+	default:
+		return nil, 0, fmt.Errorf("can't sort peers by %q", f.sortBy)
+	}
+
+	total := len(matched)
+	if f.offset >= total {
+		return []network.PeerID{}, total, nil
+	}
+	matched = matched[f.offset:]
+	if f.limit > 0 && f.limit < len(matched) {
+		matched = matched[:f.limit]
+	}
+	return matched, total, nil
+}
@@ -0,0 +1,134 @@
+// (c) 2019-2020, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package info
+
+import (
+	"testing"
+	"time"
+
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/network"
+	"github.com/ava-labs/avalanchego/snow/validators"
+	"github.com/ava-labs/avalanchego/version"
+)
+
+func TestPeerFilter(t *testing.T) {
+	parser := version.NewDefaultParser()
+	nodeID0 := ids.GenerateTestShortID()
+	nodeID1 := ids.GenerateTestShortID()
+	nodeID2 := ids.GenerateTestShortID()
+
+	vdrs := validators.NewSet()
+	if err := vdrs.AddWeight(nodeID0, 1); err != nil {
+		t.Fatal(err)
+	}
+	if err := vdrs.AddWeight(nodeID1, 2); err != nil {
+		t.Fatal(err)
+	}
+
+	now := time.Now()
+	peers := []network.PeerID{
+		{
+			ID:          ids.NodeID(nodeID0).String(),
+			Version:     "avalanche/1.0.4",
+			ConnectedAt: now,
+			Latency:     20,
+		},
+		{
+			ID:          ids.NodeID(nodeID1).String(),
+			Version:     "avalanche/1.0.5",
+			ConnectedAt: now.Add(-time.Minute),
+		},
+		{
+			ID:          ids.NodeID(nodeID2).String(),
+			Version:     "avalanche/1.0.5",
+			ConnectedAt: now.Add(-time.Hour),
+			Latency:     10,
+		},
+	}
+
+	minVersion, err := parser.Parse("avalanche/1.0.5")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		description string
+		filter      peerFilter
+		expected    []string
+		total       int
+	}{
+		{
+			description: "no filter",
+			filter:      peerFilter{},
+			expected:    []string{peers[0].ID, peers[1].ID, peers[2].ID},
+			total:       3,
+		},
+		{
+			description: "validators by stake",
+			filter:      peerFilter{vdrs: vdrs, validatorsOnly: true, sortBy: sortByStake},
+			expected:    []string{peers[1].ID, peers[0].ID},
+			total:       2,
+		},
+		{
+			description: "min version",
+			filter:      peerFilter{minVersion: minVersion},
+			expected:    []string{peers[1].ID, peers[2].ID},
+			total:       2,
+		},
+		{
+			description: "max version",
+			filter:      peerFilter{maxVersion: minVersion},
+			expected:    []string{peers[0].ID, peers[1].ID, peers[2].ID},
+			total:       3,
+		},
+		{
+			description: "uptime",
+			filter:      peerFilter{sortBy: sortByUptime},
+			expected:    []string{peers[2].ID, peers[1].ID, peers[0].ID},
+			total:       3,
+		},
+		{
+			description: "latency, unknown last",
+			filter:      peerFilter{sortBy: sortByLatency},
+			expected:    []string{peers[2].ID, peers[0].ID, peers[1].ID},
+			total:       3,
+		},
+		{
+			description: "page",
+			filter:      peerFilter{sortBy: sortByUptime, offset: 1, limit: 1},
+			expected:    []string{peers[1].ID},
+			total:       3,
+		},
+		{
+			description: "offset past the end",
+			filter:      peerFilter{offset: 5},
+			expected:    []string{},
+			total:       3,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.description, func(t *testing.T) {
+			result, total, err := test.filter.apply(parser, peers)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if total != test.total {
+				t.Fatalf("expected %d matching peers but got %d", test.total, total)
+			}
+			if len(result) != len(test.expected) {
+				t.Fatalf("expected %d peers but got %d", len(test.expected), len(result))
+			}
+			for i, peer := range result {
+				if peer.ID != test.expected[i] {
+					t.Fatalf("expected peer %d to be %s but was %s", i, test.expected[i], peer.ID)
+				}
+			}
+		})
+	}
+
+	if _, _, err := (&peerFilter{sortBy: "unknown"}).apply(parser, peers); err == nil {
+		t.Fatal("should have failed to sort by an unknown ordering")
+	}
+}
@@ -6,13 +6,17 @@ package info
 import (
 	"fmt"
 	"net/http"
+	"sort"
+	"time"
 
 	"github.com/gorilla/rpc/v2"
 
 	"github.com/ava-labs/avalanchego/chains"
+	"github.com/ava-labs/avalanchego/genesis"
 	"github.com/ava-labs/avalanchego/ids"
 	"github.com/ava-labs/avalanchego/network"
 	"github.com/ava-labs/avalanchego/snow/engine/common"
+	"github.com/ava-labs/avalanchego/snow/validators"
 	"github.com/ava-labs/avalanchego/utils/constants"
 	"github.com/ava-labs/avalanchego/utils/json"
 	"github.com/ava-labs/avalanchego/utils/logging"
@@ -24,21 +28,27 @@ type Info struct {
 	version       version.Version
 	nodeID        ids.ShortID
 	networkID     uint32
+	genesisConfig *genesis.Config
 	log           logging.Logger
 	networking    network.Network
+	validators    validators.Manager
+	parser        version.Parser
 	chainManager  chains.Manager
 	creationTxFee uint64
 	txFee         uint64
+	supply        *supplyTracker
 }
 
 // NewService returns a new admin API service
 func NewService(
 	log logging.Logger,
-	version version.Version,
+	nodeVersion version.Version,
 	nodeID ids.ShortID,
 	networkID uint32,
+	genesisConfig *genesis.Config,
 	chainManager chains.Manager,
 	peers network.Network,
+	vdrs validators.Manager,
 	creationTxFee uint64,
 	txFee uint64,
 ) (*common.HTTPHandler, error) {
@@ -46,15 +56,21 @@ func NewService(
 	codec := json.NewCodec()
 	newServer.RegisterCodec(codec, "application/json")
 	newServer.RegisterCodec(codec, "application/json;charset=UTF-8")
+	supply := newSupplyTracker()
+	chainManager.AddRegistrant(supply)
 	if err := newServer.RegisterService(&Info{
-		version:       version,
+		version:       nodeVersion,
 		nodeID:        nodeID,
 		networkID:     networkID,
+		genesisConfig: genesisConfig,
 		log:           log,
 		chainManager:  chainManager,
 		networking:    peers,
+		validators:    vdrs,
+		parser:        version.NewDefaultParser(),
 		creationTxFee: creationTxFee,
 		txFee:         txFee,
+		supply:        supply,
 	}, "info"); err != nil {
 		return nil, err
 	}
@@ -83,7 +99,7 @@ type GetNodeIDReply struct {
 func (service *Info) GetNodeID(_ *http.Request, _ *struct{}, reply *GetNodeIDReply) error {
 	service.log.Info("Info: GetNodeID called")
 
-	reply.NodeID = service.nodeID.PrefixedString(constants.NodeIDPrefix)
+	reply.NodeID = ids.NodeID(service.nodeID).String()
 	return nil
 }
 
@@ -132,20 +148,72 @@ func (service *Info) GetBlockchainID(_ *http.Request, args *GetBlockchainIDArgs,
 	return err
 }
 
+// PeersArgs are the arguments for calling Peers. The zero value returns every
+// connected peer.
+type PeersArgs struct {
+	// If true, only return peers that validate [SubnetID]
+	ValidatorsOnly bool `json:"validatorsOnly"`
+	// Subnet used to filter and sort by stake. Defaults to the primary network.
+	SubnetID ids.ID `json:"subnetID"`
+	// If non-empty, only return peers running at least this version
+	MinVersion string `json:"minVersion"`
+	// If non-empty, only return peers running at most this version
+	MaxVersion string `json:"maxVersion"`
+	// One of "", "uptime", "latency", or "stake"
+	SortBy string `json:"sortBy"`
+	// Number of matching peers to skip
+	Offset json.Uint32 `json:"offset"`
+	// Maximum number of peers to return. 0 means no limit.
+	Limit json.Uint32 `json:"limit"`
+}
+
 // PeersReply are the results from calling Peers
 type PeersReply struct {
 	// Number of elements in [Peers]
 	NumPeers json.Uint64 `json:"numPeers"`
+	// Number of peers that matched the filters, before pagination
+	TotalPeers json.Uint64 `json:"totalPeers"`
 	// Each element is a peer
 	Peers []network.PeerID `json:"peers"`
 }
 
-// Peers returns the list of current validators
-func (service *Info) Peers(_ *http.Request, _ *struct{}, reply *PeersReply) error {
+// Peers returns the peers this node is connected to that match [args]
+func (service *Info) Peers(_ *http.Request, args *PeersArgs, reply *PeersReply) error {
 	service.log.Info("Info: Peers called")
 
-	reply.Peers = service.networking.Peers()
-	reply.NumPeers = json.Uint64(len(reply.Peers))
+	filter := peerFilter{
+		validatorsOnly: args.ValidatorsOnly,
+		sortBy:         args.SortBy,
+		offset:         int(args.Offset),
+		limit:          int(args.Limit),
+	}
+	if vdrs, ok := service.validators.GetValidators(args.SubnetID); ok {
+		filter.vdrs = vdrs
+	} else if args.ValidatorsOnly || args.SortBy == sortByStake {
+		return fmt.Errorf("no validators of subnet %s are known", args.SubnetID)
+	}
+	if args.MinVersion != "" {
+		minVersion, err := service.parser.Parse(args.MinVersion)
+		if err != nil {
+			return fmt.Errorf("couldn't parse minVersion: %w", err)
+		}
+		filter.minVersion = minVersion
+	}
+	if args.MaxVersion != "" {
+		maxVersion, err := service.parser.Parse(args.MaxVersion)
+		if err != nil {
+			return fmt.Errorf("couldn't parse maxVersion: %w", err)
+		}
+		filter.maxVersion = maxVersion
+	}
+
+	peers, total, err := filter.apply(service.parser, service.networking.Peers())
+	if err != nil {
+		return err
+	}
+	reply.Peers = peers
+	reply.NumPeers = json.Uint64(len(peers))
+	reply.TotalPeers = json.Uint64(total)
 	return nil
 }
 
@@ -177,6 +245,29 @@ func (service *Info) IsBootstrapped(_ *http.Request, args *IsBootstrappedArgs, r
 	return nil
 }
 
+// GetChainsReply are the results from calling GetChains
+type GetChainsReply struct {
+	Chains []chains.ChainInfo `json:"chains"`
+}
+
+// GetChains returns a description of each chain running on this node
+func (service *Info) GetChains(_ *http.Request, _ *struct{}, reply *GetChainsReply) error {
+	service.log.Info("Info: GetChains called")
+
+	reply.Chains = service.chainManager.Chains()
+	return nil
+}
+
+// GetChainDBSizes returns the disk usage of the database of each chain running
+// on this node. Sizes are sampled periodically, so they may be a few minutes
+// old.
+func (service *Info) GetChainDBSizes(_ *http.Request, _ *struct{}, reply *chains.DBSizeReport) error {
+	service.log.Info("Info: GetChainDBSizes called")
+
+	*reply = service.chainManager.DBSizes()
+	return nil
+}
+
 // GetTxFeeResponse ...
 type GetTxFeeResponse struct {
 	CreationTxFee json.Uint64 `json:"creationTxFee"`
@@ -190,6 +281,79 @@ func (service *Info) GetTxFee(_ *http.Request, args *struct{}, reply *GetTxFeeRe
 	return nil
 }
 
+// NetworkUpgrade is the status of a network upgrade
+type NetworkUpgrade struct {
+	Name           string    `json:"name"`
+	ActivationTime time.Time `json:"activationTime"`
+	Activated      bool      `json:"activated"`
+}
+
+// GetNetworkUpgradesReply are the results from calling GetNetworkUpgrades
+type GetNetworkUpgradesReply struct {
+	Upgrades []NetworkUpgrade `json:"upgrades"`
+}
+
+// GetNetworkUpgrades returns when each network upgrade activates on this
+// node's network, and whether it's activated according to this node's clock.
+// Chains that gate an upgrade on their chain time may activate it later.
+func (service *Info) GetNetworkUpgrades(_ *http.Request, _ *struct{}, reply *GetNetworkUpgradesReply) error {
+	service.log.Info("Info: GetNetworkUpgrades called")
+
+	upgrades := version.GetNetworkUpgrades(service.networkID)
+	names := make([]string, 0, len(upgrades))
+	for name := range upgrades {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	now := time.Now()
+	reply.Upgrades = make([]NetworkUpgrade, len(names))
+	for i, name := range names {
+		activationTime, _ := upgrades.ActivationTime(name)
+		reply.Upgrades[i] = NetworkUpgrade{
+			Name:           name,
+			ActivationTime: activationTime,
+			Activated:      upgrades.IsActivated(name, now),
+		}
+	}
+	return nil
+}
+
+// GetTotalSupplyReply are the results from calling GetTotalSupply
+type GetTotalSupplyReply struct {
+	// Upper bound on the supply of AVAX, as reported by the P-Chain. Includes
+	// the genesis supply and the rewards minted or promised to current
+	// stakers.
+	CurrentSupply json.Uint64 `json:"currentSupply"`
+	// Chain alias --> AVAX burned as fees on that chain
+	BurnedFees map[string]json.Uint64 `json:"burnedFees"`
+	// CurrentSupply less the fees burned on every chain
+	TotalSupply json.Uint64 `json:"totalSupply"`
+}
+
+// GetTotalSupply returns the supply of AVAX, accounting for the genesis
+// supply, minted rewards, and the fees burned on each chain of the primary
+// network
+func (service *Info) GetTotalSupply(_ *http.Request, _ *struct{}, reply *GetTotalSupplyReply) error {
+	service.log.Info("Info: GetTotalSupply called")
+
+	currentSupply, burnedFees, err := service.supply.supply()
+	if err != nil {
+		return err
+	}
+	total, err := totalSupply(currentSupply, burnedFees)
+	if err != nil {
+		return fmt.Errorf("couldn't calculate the total supply: %w", err)
+	}
+	reply.CurrentSupply = json.Uint64(currentSupply)
+	reply.BurnedFees = make(map[string]json.Uint64, len(burnedFees))
+	for chain, fees := range burnedFees {
+		reply.BurnedFees[chain] = json.Uint64(fees)
+	}
+	reply.TotalSupply = json.Uint64(total)
+	return nil
+}
+
 // GetNodeIPReply are the results from calling GetNodeVersion
 type GetNodeIPReply struct {
 	IP string `json:"ip"`
@@ -202,3 +366,39 @@ func (service *Info) GetNodeIP(_ *http.Request, _ *struct{}, reply *GetNodeIPRep
 	reply.IP = service.networking.IP().String()
 	return nil
 }
+
+// CompareGenesisArgs are the arguments for calling CompareGenesis
+type CompareGenesisArgs struct {
+	// Genesis config, in the same format as the built-in genesis configs
+	Genesis genesis.UnparsedConfig `json:"genesis"`
+}
+
+// CompareGenesisReply are the results from calling CompareGenesis
+type CompareGenesisReply struct {
+	// True iff the genesis config matches this node's
+	Matches bool `json:"matches"`
+	// Fields that differ. "expected" values are this node's, and "actual"
+	// values are the given genesis config's.
+	Differences []genesis.Difference `json:"differences"`
+}
+
+// CompareGenesis compares a genesis config to the one this node uses for its
+// network, so that a network isn't launched with incompatible genesis configs
+func (service *Info) CompareGenesis(_ *http.Request, args *CompareGenesisArgs, reply *CompareGenesisReply) error {
+	service.log.Info("Info: CompareGenesis called")
+
+	config, err := args.Genesis.Parse()
+	if err != nil {
+		return fmt.Errorf("couldn't parse genesis config: %w", err)
+	}
+	differences, err := genesis.Diff(service.genesisConfig, &config)
+	if err != nil {
+		return err
+	}
+	reply.Matches = len(differences) == 0
+	reply.Differences = differences
+	if reply.Differences == nil {
+		reply.Differences = []genesis.Difference{}
+	}
+	return nil
+}
@@ -0,0 +1,114 @@
+// (c) 2019-2020, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package info
+
+import (
+	"errors"
+	"fmt"
+	"sync"
+
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/snow"
+	"github.com/ava-labs/avalanchego/snow/engine/common"
+	"github.com/ava-labs/avalanchego/utils/constants"
+	"github.com/ava-labs/avalanchego/utils/math"
+)
+
+var (
+	errNoPlatformChain = errors.New("the P-Chain hasn't been created yet")
+)
+
+// currentSupplier is implemented by the P-Chain's VM
+type currentSupplier interface {
+	// Returns an upper bound on the supply of AVAX
+	CurrentSupply() (uint64, error)
+}
+
+// feeChain is a chain that tracks the fees burned on it
+type feeChain struct {
+	name    string
+	ctx     *snow.Context
+	tracker common.FeeTracker
+}
+
+// supplyTracker keeps track of the primary network's chains that the supply of
+// AVAX is calculated from. It's registered with the chain manager, so it's
+// told about each chain when it's created or restarted.
+type supplyTracker struct {
+	lock sync.Mutex
+	// The P-Chain. Nil until the P-Chain is created.
+	platformCtx *snow.Context
+	platform    currentSupplier
+	// Chain ID --> Chain that tracks the fees burned on it
+	chains map[ids.ID]feeChain
+}
+
+func newSupplyTracker() *supplyTracker {
+	return &supplyTracker{chains: make(map[ids.ID]feeChain)}
+}
+
+// RegisterChain implements the chains.Registrant interface
+func (s *supplyTracker) RegisterChain(name string, ctx *snow.Context, vm interface{}) {
+	// Subnets' chains don't burn AVAX
+	if ctx.SubnetID != constants.PrimaryNetworkID {
+		return
+	}
+
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	if supplier, ok := vm.(currentSupplier); ok && ctx.ChainID == constants.PlatformChainID {
+		s.platformCtx = ctx
+		s.platform = supplier
+	}
+	if tracker, ok := vm.(common.FeeTracker); ok {
+		s.chains[ctx.ChainID] = feeChain{
+			name:    name,
+			ctx:     ctx,
+			tracker: tracker,
+		}
+	}
+}
+
+// supply returns an upper bound on the supply of AVAX, and the amount of AVAX
+// burned as fees on each chain, keyed by the chain's primary alias
+func (s *supplyTracker) supply() (uint64, map[string]uint64, error) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	if s.platform == nil {
+		return 0, nil, errNoPlatformChain
+	}
+	s.platformCtx.Lock.Lock()
+	currentSupply, err := s.platform.CurrentSupply()
+	s.platformCtx.Lock.Unlock()
+	if err != nil {
+		return 0, nil, fmt.Errorf("couldn't get the current supply: %w", err)
+	}
+
+	burnedFees := make(map[string]uint64, len(s.chains))
+	for _, chain := range s.chains {
+		chain.ctx.Lock.Lock()
+		fees, err := chain.tracker.BurnedFees()
+		chain.ctx.Lock.Unlock()
+		if err != nil {
+			return 0, nil, fmt.Errorf("couldn't get the fees burned on chain %s: %w", chain.name, err)
+		}
+		burnedFees[chain.name] = fees
+	}
+	return currentSupply, burnedFees, nil
+}
+
+// totalSupply returns [currentSupply] less the sum of [burnedFees]
+func totalSupply(currentSupply uint64, burnedFees map[string]uint64) (uint64, error) {
+	totalBurned := uint64(0)
+	for _, fees := range burnedFees {
+		var err error
+		totalBurned, err = math.Add64(totalBurned, fees)
+		if err != nil {
+			return 0, err
+		}
+	}
+	return math.Sub64(currentSupply, totalBurned)
+}
@@ -0,0 +1,89 @@
+// (c) 2019-2020, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package info
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/snow"
+	"github.com/ava-labs/avalanchego/utils/constants"
+)
+
+type testPlatformVM struct {
+	currentSupply, burnedFees uint64
+}
+
+func (vm *testPlatformVM) CurrentSupply() (uint64, error) { return vm.currentSupply, nil }
+func (vm *testPlatformVM) BurnedFees() (uint64, error)    { return vm.burnedFees, nil }
+
+type testFeeVM struct {
+	burnedFees uint64
+}
+
+func (vm *testFeeVM) BurnedFees() (uint64, error) { return vm.burnedFees, nil }
+
+func newChainContext(subnetID, chainID ids.ID) *snow.Context {
+	ctx := snow.DefaultContextTest()
+	ctx.SubnetID = subnetID
+	ctx.ChainID = chainID
+	return ctx
+}
+
+func TestSupplyTracker(t *testing.T) {
+	s := newSupplyTracker()
+	if _, _, err := s.supply(); !errors.Is(err, errNoPlatformChain) {
+		t.Fatalf("expected %s but got %v", errNoPlatformChain, err)
+	}
+
+	s.RegisterChain("P", newChainContext(constants.PrimaryNetworkID, constants.PlatformChainID), &testPlatformVM{
+		currentSupply: 1000,
+		burnedFees:    10,
+	})
+	xChainID := ids.GenerateTestID()
+	s.RegisterChain("X", newChainContext(constants.PrimaryNetworkID, xChainID), &testFeeVM{burnedFees: 20})
+	s.RegisterChain("C", newChainContext(constants.PrimaryNetworkID, ids.GenerateTestID()), &testFeeVM{burnedFees: 30})
+	// Chains that don't track fees, and the chains of other subnets, aren't
+	// counted
+	s.RegisterChain("timestamp", newChainContext(constants.PrimaryNetworkID, ids.GenerateTestID()), struct{}{})
+	s.RegisterChain("subnet", newChainContext(ids.GenerateTestID(), ids.GenerateTestID()), &testFeeVM{burnedFees: 40})
+
+	currentSupply, burnedFees, err := s.supply()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if currentSupply != 1000 {
+		t.Fatalf("expected current supply 1000 but got %d", currentSupply)
+	}
+	expectedFees := map[string]uint64{"P": 10, "X": 20, "C": 30}
+	if len(burnedFees) != len(expectedFees) {
+		t.Fatalf("expected burned fees %v but got %v", expectedFees, burnedFees)
+	}
+	for chain, fees := range expectedFees {
+		if burnedFees[chain] != fees {
+			t.Fatalf("expected burned fees %v but got %v", expectedFees, burnedFees)
+		}
+	}
+	total, err := totalSupply(currentSupply, burnedFees)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if total != 940 {
+		t.Fatalf("expected total supply 940 but got %d", total)
+	}
+
+	// A restarted chain replaces the instance it was created with
+	s.RegisterChain("X", newChainContext(constants.PrimaryNetworkID, xChainID), &testFeeVM{burnedFees: 25})
+	if _, burnedFees, err = s.supply(); err != nil {
+		t.Fatal(err)
+	}
+	if burnedFees["X"] != 25 {
+		t.Fatalf("expected the restarted chain's burned fees to be reported but got %d", burnedFees["X"])
+	}
+
+	if _, err := totalSupply(10, burnedFees); err == nil {
+		t.Fatal("burning more than the supply should have errored")
+	}
+}
@@ -0,0 +1,55 @@
+// (c) 2019-2020, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package quorum
+
+import (
+	"time"
+
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/vms/avm"
+	"github.com/ava-labs/avalanchego/vms/platformvm"
+)
+
+// AVMTxStatus returns the status of [txID] on the AVM [chain], as reported by
+// at least [threshold] weight of [endpoints]
+func AVMTxStatus(
+	endpoints []Endpoint,
+	threshold uint64,
+	chain string,
+	txID ids.ID,
+	requestTimeout time.Duration,
+) (*Result, error) {
+	return Do(endpoints, threshold, func(uri string) (interface{}, error) {
+		return avm.NewClient(uri, chain, requestTimeout).GetTxStatus(txID)
+	})
+}
+
+// PlatformTxStatus returns the status of [txID] on the P-Chain, as reported by
+// at least [threshold] weight of [endpoints]
+func PlatformTxStatus(
+	endpoints []Endpoint,
+	threshold uint64,
+	txID ids.ID,
+	requestTimeout time.Duration,
+) (*Result, error) {
+	return Do(endpoints, threshold, func(uri string) (interface{}, error) {
+		reply, err := platformvm.NewClient(uri, requestTimeout).GetTxStatus(txID, false)
+		if err != nil {
+			return nil, err
+		}
+		return reply.Status, nil
+	})
+}
+
+// PlatformHeight returns the height of the last accepted P-Chain block, as
+// reported by at least [threshold] weight of [endpoints]
+func PlatformHeight(
+	endpoints []Endpoint,
+	threshold uint64,
+	requestTimeout time.Duration,
+) (*Result, error) {
+	return Do(endpoints, threshold, func(uri string) (interface{}, error) {
+		return platformvm.NewClient(uri, requestTimeout).GetHeight()
+	})
+}
@@ -0,0 +1,126 @@
+// (c) 2019-2020, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+// Package quorum queries the APIs of several independent nodes and only trusts
+// an answer that is reported by enough stake. This lets a wallet backend avoid
+// relying on a single node's view of the network.
+package quorum
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sync"
+
+	safemath "github.com/ava-labs/avalanchego/utils/math"
+)
+
+var (
+	errNoEndpoints = errors.New("no endpoints to query")
+	errNoQuorum    = errors.New("no answer was reported by enough weight")
+)
+
+// Endpoint is a node whose API is queried
+type Endpoint struct {
+	// URI of the node's API, such as http://127.0.0.1:9650
+	URI string
+	// Weight given to this node's answers, such as its stake
+	Weight uint64
+}
+
+// Query fetches a value from the node whose API is at [uri]. Values are
+// compared by their JSON encoding.
+type Query func(uri string) (interface{}, error)
+
+// Answer is the reply of a single endpoint
+type Answer struct {
+	Endpoint
+	Value interface{}
+	Err   error
+}
+
+// Result is the outcome of querying a set of endpoints
+type Result struct {
+	// Value reported by the most weight
+	Value interface{}
+	// Weight that reported [Value]
+	Weight uint64
+	// Weight of all the queried endpoints
+	TotalWeight uint64
+	// Answers of the endpoints that reported a value other than [Value]
+	Divergent []Answer
+	// Answers of the endpoints that failed to respond
+	Failed []Answer
+}
+
+// Do runs [query] against every endpoint in parallel. Returns the value that
+// was reported by the most weight if that weight is at least [threshold]. The
+// result is returned even if there is no quorum so that callers can report the
+// divergence.
+func Do(endpoints []Endpoint, threshold uint64, query Query) (*Result, error) {
+	if len(endpoints) == 0 {
+		return nil, errNoEndpoints
+	}
+
+	answers := make([]Answer, len(endpoints))
+	wg := sync.WaitGroup{}
+	wg.Add(len(endpoints))
+	for i, endpoint := range endpoints {
+		go func(i int, endpoint Endpoint) {
+			defer wg.Done()
+
+			value, err := query(endpoint.URI)
+			answers[i] = Answer{
+				Endpoint: endpoint,
+				Value:    value,
+				Err:      err,
+			}
+		}(i, endpoint)
+	}
+	wg.Wait()
+
+	result := &Result{}
+	weights := make(map[string]uint64)
+	keys := make([]string, len(answers))
+	bestKey := ""
+	for i, answer := range answers {
+		totalWeight, err := safemath.Add64(result.TotalWeight, answer.Weight)
+		if err != nil {
+			return nil, err
+		}
+		result.TotalWeight = totalWeight
+
+		if answer.Err != nil {
+			continue
+		}
+		keyBytes, err := json.Marshal(answer.Value)
+		if err != nil {
+			answers[i].Err = fmt.Errorf("couldn't compare answer: %w", err)
+			continue
+		}
+		key := string(keyBytes)
+		keys[i] = key
+		weights[key] += answer.Weight
+
+		if _, ok := weights[bestKey]; !ok || weights[key] > weights[bestKey] {
+			bestKey = key
+			result.Value = answer.Value
+		}
+	}
+	result.Weight = weights[bestKey]
+
+	for i, answer := range answers {
+		switch {
+		case answer.Err != nil:
+			result.Failed = append(result.Failed, answer)
+		case keys[i] != bestKey:
+			result.Divergent = append(result.Divergent, answer)
+		}
+	}
+
+	if len(weights) == 0 || result.Weight < threshold {
+		return result, fmt.Errorf("%w: best answer has weight %d but %d is required",
+			errNoQuorum, result.Weight, threshold)
+	}
+	return result, nil
+}
@@ -0,0 +1,62 @@
+// (c) 2019-2020, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package quorum
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDoQuorum(t *testing.T) {
+	errUnreachable := errors.New("unreachable")
+	answers := map[string]interface{}{
+		"a": "Accepted",
+		"b": "Accepted",
+		"c": "Processing",
+		"d": errUnreachable,
+	}
+	query := func(uri string) (interface{}, error) {
+		if err, ok := answers[uri].(error); ok {
+			return nil, err
+		}
+		return answers[uri], nil
+	}
+	endpoints := []Endpoint{
+		{URI: "a", Weight: 2},
+		{URI: "b", Weight: 3},
+		{URI: "c", Weight: 4},
+		{URI: "d", Weight: 1},
+	}
+
+	result, err := Do(endpoints, 5, query)
+	assert.NoError(t, err)
+	assert.Equal(t, "Accepted", result.Value)
+	assert.Equal(t, uint64(5), result.Weight)
+	assert.Equal(t, uint64(10), result.TotalWeight)
+	assert.Len(t, result.Divergent, 1)
+	assert.Equal(t, "c", result.Divergent[0].URI)
+	assert.Len(t, result.Failed, 1)
+	assert.Equal(t, errUnreachable, result.Failed[0].Err)
+
+	result, err = Do(endpoints, 6, query)
+	assert.True(t, errors.Is(err, errNoQuorum))
+	assert.Equal(t, "Accepted", result.Value)
+}
+
+func TestDoAllFailed(t *testing.T) {
+	errUnreachable := errors.New("unreachable")
+	result, err := Do([]Endpoint{{URI: "a", Weight: 1}}, 0, func(string) (interface{}, error) {
+		return nil, errUnreachable
+	})
+	assert.True(t, errors.Is(err, errNoQuorum))
+	assert.Nil(t, result.Value)
+	assert.Len(t, result.Failed, 1)
+}
+
+func TestDoNoEndpoints(t *testing.T) {
+	_, err := Do(nil, 0, nil)
+	assert.Equal(t, errNoEndpoints, err)
+}
@@ -14,9 +14,12 @@ import (
 
 	"github.com/gorilla/handlers"
 
+	"github.com/prometheus/client_golang/prometheus"
+
 	"github.com/rs/cors"
 
 	"github.com/ava-labs/avalanchego/api/auth"
+	"github.com/ava-labs/avalanchego/database"
 	"github.com/ava-labs/avalanchego/snow"
 	"github.com/ava-labs/avalanchego/snow/engine/common"
 	"github.com/ava-labs/avalanchego/utils/logging"
@@ -43,6 +46,14 @@ type Server struct {
 	// Handles authorization. Must be non-nil after initialization, even if
 	// token authorization is off.
 	auth *auth.Auth
+
+	// Maps a chain's name to the log its HTTP requests are written to. Logs are
+	// reused if the chain is registered again after being restarted.
+	chainLogsLock sync.Mutex
+	chainLogs     map[string]logging.Logger
+
+	// Tracks calls to deprecated methods
+	deprecations deprecations
 }
 
 // Initialize creates the API server at the provided host and port
@@ -53,11 +64,14 @@ func (s *Server) Initialize(
 	port uint16,
 	authEnabled bool,
 	authPassword string,
+	authDB database.Database,
 ) error {
 	s.log = log
 	s.factory = factory
 	s.listenAddress = fmt.Sprintf("%s:%d", host, port)
 	s.router = newRouter()
+	s.chainLogs = make(map[string]logging.Logger)
+	s.deprecations.initialize(log)
 	s.auth = &auth.Auth{Enabled: authEnabled}
 	if err := s.auth.Password.Set(authPassword); err != nil {
 		return err
@@ -65,6 +79,9 @@ func (s *Server) Initialize(
 	if !authEnabled {
 		return nil
 	}
+	if err := s.auth.Initialize(authDB, authPassword); err != nil {
+		return fmt.Errorf("couldn't load API auth keys: %w", err)
+	}
 
 	// only create auth service if token authorization is required
 	s.log.Info("API authorization is enabled. Auth tokens must be passed in the header of API requests, except requests to the auth service.")
@@ -81,6 +98,7 @@ func (s *Server) Dispatch() error {
 	}
 	s.log.Info("HTTP API server listening on %q", s.listenAddress)
 	handler := cors.Default().Handler(s.router)
+	handler = s.deprecations.wrapHandler(handler)
 	handler = s.auth.WrapHandler(handler)
 	return http.Serve(listener, handler)
 }
@@ -93,10 +111,25 @@ func (s *Server) DispatchTLS(certFile, keyFile string) error {
 	}
 	s.log.Info("HTTPS API server listening on %q", s.listenAddress)
 	handler := cors.Default().Handler(s.router)
+	handler = s.deprecations.wrapHandler(handler)
 	handler = s.auth.WrapHandler(handler)
 	return http.ServeTLS(listener, handler, certFile, keyFile)
 }
 
+// DeprecateMethod marks the API method [method], like "avm.importAVAX", as
+// deprecated. Responses to calls to it are tagged with a deprecation header
+// carrying [notice], which should explain what to use instead, and the calls
+// are logged and counted.
+func (s *Server) DeprecateMethod(method, notice string) {
+	s.deprecations.deprecate(method, notice)
+}
+
+// RegisterDeprecationMetrics exposes the number of calls to each deprecated
+// method
+func (s *Server) RegisterDeprecationMetrics(namespace string, registerer prometheus.Registerer) error {
+	return s.deprecations.registerMetrics(namespace, registerer)
+}
+
 // RegisterChain registers the API endpoints associated with this chain That is,
 // add <route, handler> pairs to server so that http calls can be made to the vm
 func (s *Server) RegisterChain(chainName string, ctx *snow.Context, vmIntf interface{}) {
@@ -105,7 +138,7 @@ func (s *Server) RegisterChain(chainName string, ctx *snow.Context, vmIntf inter
 		return
 	}
 
-	httpLogger, err := s.factory.MakeChain(chainName, "http")
+	httpLogger, err := s.chainLog(chainName)
 	if err != nil {
 		s.log.Error("Failed to create new http logger: %s", err)
 		return
@@ -130,7 +163,25 @@ func (s *Server) RegisterChain(chainName string, ctx *snow.Context, vmIntf inter
 	}
 }
 
-// AddChainRoute registers a route to a chain's handler
+// chainLog returns the log HTTP requests to [chainName] are written to
+func (s *Server) chainLog(chainName string) (logging.Logger, error) {
+	s.chainLogsLock.Lock()
+	defer s.chainLogsLock.Unlock()
+
+	if log, exists := s.chainLogs[chainName]; exists {
+		return log, nil
+	}
+	log, err := s.factory.MakeChain(chainName, "http")
+	if err != nil {
+		return nil, err
+	}
+	s.chainLogs[chainName] = log
+	return log, nil
+}
+
+// AddChainRoute registers a route to a chain's handler. If the route was
+// previously registered by the same chain, the existing route is pointed at
+// [handler].
 func (s *Server) AddChainRoute(handler *common.HTTPHandler, ctx *snow.Context, base, endpoint string, loggingWriter io.Writer) error {
 	url := fmt.Sprintf("%s/%s", baseURL, base)
 	s.log.Info("adding route %s%s", url, endpoint)
@@ -143,7 +194,13 @@ func (s *Server) AddChainRoute(handler *common.HTTPHandler, ctx *snow.Context, b
 	}
 	// Apply middleware to reject calls to the handler before the chain finishes bootstrapping
 	h = rejectMiddleware(h, ctx)
-	return s.router.AddRouter(url, endpoint, h)
+	if existing, err := s.router.GetHandler(url, endpoint); err == nil {
+		if ch, ok := existing.(*chainHandler); ok {
+			ch.setHandler(h)
+			return nil
+		}
+	}
+	return s.router.AddRouter(url, endpoint, &chainHandler{handler: h})
 }
 
 // AddRoute registers a route to a handler.
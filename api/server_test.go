@@ -13,6 +13,8 @@ import (
 	"github.com/gorilla/rpc/v2"
 	"github.com/gorilla/rpc/v2/json2"
 
+	"github.com/ava-labs/avalanchego/database/memdb"
+	"github.com/ava-labs/avalanchego/snow"
 	"github.com/ava-labs/avalanchego/snow/engine/common"
 	"github.com/ava-labs/avalanchego/utils/logging"
 )
@@ -37,6 +39,7 @@ func TestCall(t *testing.T) {
 		8080,
 		false,
 		"",
+		memdb.New(),
 	)
 	if err != nil {
 		t.Fatal(err)
@@ -80,3 +83,57 @@ func TestCall(t *testing.T) {
 		t.Fatalf("Should have been called")
 	}
 }
+
+func TestAddChainRouteReplacesHandler(t *testing.T) {
+	s := Server{}
+	err := s.Initialize(
+		logging.NoLog{},
+		logging.NoFactory{},
+		"localhost",
+		8080,
+		false,
+		"",
+		memdb.New(),
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	ctx := snow.DefaultContextTest()
+	ctx.Bootstrapped()
+
+	firstCalled := false
+	first := &common.HTTPHandler{
+		LockOptions: common.NoLock,
+		Handler:     http.HandlerFunc(func(http.ResponseWriter, *http.Request) { firstCalled = true }),
+	}
+	if err := s.AddChainRoute(first, ctx, "bc/lol", "", logging.NoLog{}); err != nil {
+		t.Fatal(err)
+	}
+
+	secondCalled := false
+	second := &common.HTTPHandler{
+		LockOptions: common.NoLock,
+		Handler:     http.HandlerFunc(func(http.ResponseWriter, *http.Request) { secondCalled = true }),
+	}
+	if err := s.AddChainRoute(second, ctx, "bc/lol", "", logging.NoLog{}); err != nil {
+		t.Fatalf("re-registering a chain's route should replace the handler but errored with: %s", err)
+	}
+
+	handler, err := s.router.GetHandler(baseURL+"/bc/lol", "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	req, err := http.NewRequest("POST", "*", nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	handler.ServeHTTP(httptest.NewRecorder(), req)
+
+	if firstCalled {
+		t.Fatalf("Replaced handler shouldn't have been called")
+	}
+	if !secondCalled {
+		t.Fatalf("Replacement handler should have been called")
+	}
+}
@@ -34,7 +34,9 @@ type Memory struct {
 	db    database.Database
 }
 
-// Initialize the SharedMemory
+// Initialize the SharedMemory. [db] must share its underlying database with
+// the databases of the chains using this memory, as their batches are written
+// atomically with the shared memory's.
 func (m *Memory) Initialize(log logging.Logger, db database.Database) error {
 	c := codec.NewDefault()
 	manager := codec.NewDefaultManager()
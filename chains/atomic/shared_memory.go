@@ -48,6 +48,14 @@ type Element struct {
 }
 
 // SharedMemory ...
+//
+// Put and Remove write to shared memory in the same atomic write as the
+// provided [batches]. A chain that exports or imports while accepting a block
+// must pass the batch containing its acceptance so that, after a crash, the
+// block is accepted iff its shared memory operations were applied. Because
+// every chain writes to the same underlying database, writes are also
+// recovered in the order they were made, so a peer chain can never recover
+// having imported a value whose export was lost.
 type SharedMemory interface {
 	// Adds to the peer chain's side
 	Put(peerChainID ids.ID, elems []*Element, batches ...database.Batch) error
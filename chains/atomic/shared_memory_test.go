@@ -4,11 +4,15 @@
 package atomic
 
 import (
+	"errors"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
 
+	"github.com/ava-labs/avalanchego/database"
 	"github.com/ava-labs/avalanchego/database/memdb"
+	"github.com/ava-labs/avalanchego/database/prefixdb"
+	"github.com/ava-labs/avalanchego/database/versiondb"
 	"github.com/ava-labs/avalanchego/ids"
 	"github.com/ava-labs/avalanchego/utils/logging"
 )
@@ -74,3 +78,65 @@ func TestSharedMemory(t *testing.T) {
 	assert.NoError(t, err)
 	assert.Equal(t, [][]byte{{1}, {5}}, values, "wrong indexed values returned")
 }
+
+type failedReplayBatch struct{ database.Batch }
+
+func (b *failedReplayBatch) Inner() database.Batch { return b }
+
+func (b *failedReplayBatch) Replay(database.KeyValueWriter) error { return errFailedReplay }
+
+var errFailedReplay = errors.New("failed replay")
+
+func TestSharedMemoryPutIsAtomicWithBatch(t *testing.T) {
+	baseDB := memdb.New()
+	m := Memory{}
+	if err := m.Initialize(logging.NoLog{}, prefixdb.New([]byte{0}, baseDB)); err != nil {
+		t.Fatal(err)
+	}
+
+	chainID0 := ids.GenerateTestID()
+	chainID1 := ids.GenerateTestID()
+
+	sm0 := m.NewSharedMemory(chainID0)
+	sm1 := m.NewSharedMemory(chainID1)
+
+	// The exporting chain accepts a block in the same write as the export
+	vmDB := versiondb.New(prefixdb.New([]byte{1}, baseDB))
+	assert.NoError(t, vmDB.Put([]byte("accepted"), []byte{1}))
+	batch, err := vmDB.CommitBatch()
+	assert.NoError(t, err)
+
+	elems := []*Element{{Key: []byte{2}, Value: []byte{3}}}
+	assert.NoError(t, sm0.Put(chainID1, elems, batch))
+
+	accepted, err := prefixdb.New([]byte{1}, baseDB).Has([]byte("accepted"))
+	assert.NoError(t, err)
+	assert.True(t, accepted, "block acceptance should have been written")
+
+	values, err := sm1.Get(chainID0, [][]byte{{2}})
+	assert.NoError(t, err)
+	assert.Equal(t, [][]byte{{3}}, values)
+}
+
+func TestSharedMemoryFailedPutWritesNothing(t *testing.T) {
+	baseDB := memdb.New()
+	m := Memory{}
+	if err := m.Initialize(logging.NoLog{}, prefixdb.New([]byte{0}, baseDB)); err != nil {
+		t.Fatal(err)
+	}
+
+	chainID0 := ids.GenerateTestID()
+	chainID1 := ids.GenerateTestID()
+
+	sm0 := m.NewSharedMemory(chainID0)
+	sm1 := m.NewSharedMemory(chainID1)
+
+	// If the block's acceptance can't be written, the export must not be
+	// visible to the importing chain
+	elems := []*Element{{Key: []byte{2}, Value: []byte{3}}}
+	err := sm0.Put(chainID1, elems, &failedReplayBatch{})
+	assert.Equal(t, errFailedReplay, err)
+
+	_, err = sm1.Get(chainID0, [][]byte{{2}})
+	assert.Error(t, err, "export shouldn't have been written")
+}
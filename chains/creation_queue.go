@@ -0,0 +1,98 @@
+// (c) 2019-2020, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package chains
+
+import (
+	"sync"
+
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/utils/constants"
+)
+
+// creationQueue holds the chains waiting to be created, and creates them one
+// at a time. Chains of the primary network are created before the chains of
+// other subnets. Otherwise, chains are created in the order they were queued.
+type creationQueue struct {
+	// Creates a chain. Called on its own goroutine.
+	create func(ChainParameters)
+
+	lock sync.Mutex
+	// Chains waiting to be created
+	queued []ChainParameters
+	// IDs of the chains that are queued or being created
+	pending ids.Set
+	// True while a chain is being created
+	creating bool
+}
+
+func newCreationQueue(create func(ChainParameters)) *creationQueue {
+	return &creationQueue{create: create}
+}
+
+// push queues the creation of the chain described by [chainParams]. Returns
+// false if the chain is already queued or being created.
+func (q *creationQueue) push(chainParams ChainParameters) bool {
+	q.lock.Lock()
+	defer q.lock.Unlock()
+
+	if q.pending.Contains(chainParams.ID) {
+		return false
+	}
+	q.pending.Add(chainParams.ID)
+	q.queued = append(q.queued, chainParams)
+	q.dispatch()
+	return true
+}
+
+// dispatch starts creating the next queued chain, unless a chain is already
+// being created.
+// Assumes [q.lock] is held.
+func (q *creationQueue) dispatch() {
+	if q.creating {
+		return
+	}
+	chainParams, ok := q.next()
+	if !ok {
+		return
+	}
+	q.creating = true
+	go func() {
+		q.create(chainParams)
+		q.done(chainParams)
+	}()
+}
+
+// next removes and returns the queued chain that should be created next.
+// Returns false if no chain is queued.
+// Assumes [q.lock] is held.
+func (q *creationQueue) next() (ChainParameters, bool) {
+	if len(q.queued) == 0 {
+		return ChainParameters{}, false
+	}
+
+	index := 0
+	for i, chainParams := range q.queued {
+		if chainParams.SubnetID == constants.PrimaryNetworkID {
+			index = i
+			break
+		}
+	}
+
+	chainParams := q.queued[index]
+	copy(q.queued[index:], q.queued[index+1:])
+	q.queued[len(q.queued)-1] = ChainParameters{}
+	q.queued = q.queued[:len(q.queued)-1]
+	return chainParams, true
+}
+
+// done marks that the chain described by [chainParams] is no longer being
+// created, and starts creating the next queued chain
+func (q *creationQueue) done(chainParams ChainParameters) {
+	q.lock.Lock()
+	defer q.lock.Unlock()
+
+	q.pending.Remove(chainParams.ID)
+	q.creating = false
+	q.dispatch()
+}
@@ -0,0 +1,119 @@
+// (c) 2019-2020, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package chains
+
+import (
+	"testing"
+	"time"
+
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/utils/constants"
+)
+
+// blockingCreator records the chains it's asked to create, and doesn't finish
+// creating a chain until it's released
+type blockingCreator struct {
+	started  chan ChainParameters
+	releases map[ids.ID]chan struct{}
+}
+
+func newBlockingCreator(chains ...ChainParameters) *blockingCreator {
+	c := &blockingCreator{
+		started:  make(chan ChainParameters, len(chains)),
+		releases: make(map[ids.ID]chan struct{}),
+	}
+	for _, chainParams := range chains {
+		c.releases[chainParams.ID] = make(chan struct{})
+	}
+	return c
+}
+
+func (c *blockingCreator) create(chainParams ChainParameters) {
+	c.started <- chainParams
+	<-c.releases[chainParams.ID]
+}
+
+func (c *blockingCreator) release(chainParams ChainParameters) {
+	close(c.releases[chainParams.ID])
+}
+
+func (c *blockingCreator) expectStarted(t *testing.T, expected ChainParameters) {
+	select {
+	case chainParams := <-c.started:
+		if chainParams.ID != expected.ID {
+			t.Fatalf("expected chain %s to be created but chain %s was", expected.ID, chainParams.ID)
+		}
+	case <-time.After(time.Second):
+		t.Fatalf("expected chain %s to be created", expected.ID)
+	}
+}
+
+func (c *blockingCreator) expectNoneStarted(t *testing.T) {
+	select {
+	case chainParams := <-c.started:
+		t.Fatalf("chain %s shouldn't have been created yet", chainParams.ID)
+	case <-time.After(50 * time.Millisecond):
+	}
+}
+
+func TestCreationQueueOneAtATime(t *testing.T) {
+	chain0 := ChainParameters{ID: ids.GenerateTestID(), SubnetID: ids.GenerateTestID()}
+	chain1 := ChainParameters{ID: ids.GenerateTestID(), SubnetID: ids.GenerateTestID()}
+
+	creator := newBlockingCreator(chain0, chain1)
+	q := newCreationQueue(creator.create)
+
+	if !q.push(chain0) {
+		t.Fatalf("chain should have been queued")
+	}
+	creator.expectStarted(t, chain0)
+
+	// Chains aren't created while another chain is being created, even if
+	// they're of a different subnet
+	if !q.push(chain1) {
+		t.Fatalf("chain should have been queued")
+	}
+	creator.expectNoneStarted(t)
+
+	// A chain that's already queued isn't queued again
+	if q.push(chain1) {
+		t.Fatalf("chain shouldn't have been queued twice")
+	}
+	// Nor is a chain that's being created
+	if q.push(chain0) {
+		t.Fatalf("chain shouldn't have been queued while it's being created")
+	}
+
+	creator.release(chain0)
+	creator.expectStarted(t, chain1)
+	creator.release(chain1)
+}
+
+func TestCreationQueuePrimaryNetworkFirst(t *testing.T) {
+	subnetChain := ChainParameters{ID: ids.GenerateTestID(), SubnetID: ids.GenerateTestID()}
+	blockingChain := ChainParameters{ID: ids.GenerateTestID(), SubnetID: ids.GenerateTestID()}
+	primaryChain0 := ChainParameters{ID: ids.GenerateTestID(), SubnetID: constants.PrimaryNetworkID}
+	primaryChain1 := ChainParameters{ID: ids.GenerateTestID(), SubnetID: constants.PrimaryNetworkID}
+
+	creator := newBlockingCreator(subnetChain, blockingChain, primaryChain0, primaryChain1)
+	q := newCreationQueue(creator.create)
+
+	q.push(blockingChain)
+	creator.expectStarted(t, blockingChain)
+
+	q.push(subnetChain)
+	q.push(primaryChain0)
+	q.push(primaryChain1)
+	creator.expectNoneStarted(t)
+
+	// The primary network's chains are created before the chain of the subnet,
+	// even though they were queued after it
+	creator.release(blockingChain)
+	creator.expectStarted(t, primaryChain0)
+	creator.release(primaryChain0)
+	creator.expectStarted(t, primaryChain1)
+	creator.release(primaryChain1)
+	creator.expectStarted(t, subnetChain)
+	creator.release(subnetChain)
+}
@@ -0,0 +1,218 @@
+// (c) 2019-2020, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package chains
+
+import (
+	"fmt"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+
+	"github.com/ava-labs/avalanchego/database"
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/utils/json"
+	"github.com/ava-labs/avalanchego/utils/wrappers"
+)
+
+const (
+	// How often the size of each chain's database is sampled
+	dbSizeSampleFrequency = 10 * time.Minute
+
+	// Period over which the growth rate of a chain's database is measured
+	dbSizeGrowthWindow = 24 * time.Hour
+
+	// leveldb property holding the number of tables waiting to be compacted
+	// out of level 0
+	level0TablesProperty = "leveldb.num-files-at-level0"
+)
+
+// ChainDBSize describes the disk usage of a chain's database
+type ChainDBSize struct {
+	ID ids.ID `json:"id"`
+	// Approximate number of bytes used on disk
+	Size json.Uint64 `json:"size"`
+	// Change in size, in bytes per day, over the last day. Negative if the
+	// database shrank. Zero until two samples have been taken.
+	DailyGrowth float64 `json:"dailyGrowth"`
+}
+
+// DBSizeReport describes the disk usage of the chains on this node
+type DBSizeReport struct {
+	Chains []ChainDBSize `json:"chains"`
+	// Number of tables waiting to be compacted in the database shared by all
+	// the chains
+	CompactionBacklog json.Uint64 `json:"compactionBacklog"`
+}
+
+type dbSizeSample struct {
+	time time.Time
+	size uint64
+}
+
+// dbSizeTracker samples the size of a chain's database
+type dbSizeTracker struct {
+	db database.Sizer
+
+	size, dailyGrowth prometheus.Gauge
+
+	lock sync.Mutex
+	// samples taken within the last [dbSizeGrowthWindow], oldest first
+	samples []dbSizeSample
+}
+
+func newDBSizeTracker(db database.Sizer, namespace string, registerer prometheus.Registerer) (*dbSizeTracker, error) {
+	t := &dbSizeTracker{
+		db: db,
+		size: prometheus.NewGauge(prometheus.GaugeOpts{
+			Namespace: namespace,
+			Name:      "db_size",
+			Help:      "Approximate number of bytes used on disk by the chain's database",
+		}),
+		dailyGrowth: prometheus.NewGauge(prometheus.GaugeOpts{
+			Namespace: namespace,
+			Name:      "db_daily_growth",
+			Help:      "Growth of the chain's database over the last day, in bytes per day",
+		}),
+	}
+	errs := wrappers.Errs{}
+	errs.Add(
+		registerer.Register(t.size),
+		registerer.Register(t.dailyGrowth),
+	)
+	return t, errs.Err
+}
+
+// sample records the current size of the database
+func (t *dbSizeTracker) sample(now time.Time) error {
+	size, err := t.db.SizeOf(nil, nil)
+	if err != nil {
+		return err
+	}
+
+	t.lock.Lock()
+	defer t.lock.Unlock()
+
+	t.samples = append(t.samples, dbSizeSample{time: now, size: size})
+	cutoff := now.Add(-dbSizeGrowthWindow)
+	for len(t.samples) > 2 && t.samples[1].time.Before(cutoff) {
+		t.samples = t.samples[1:]
+	}
+
+	t.size.Set(float64(size))
+	t.dailyGrowth.Set(t.growth())
+	return nil
+}
+
+// growth returns the growth rate, in bytes per day, between the oldest and
+// newest samples.
+// assumes [t.lock] is held.
+func (t *dbSizeTracker) growth() float64 {
+	if len(t.samples) < 2 {
+		return 0
+	}
+	oldest := t.samples[0]
+	newest := t.samples[len(t.samples)-1]
+	elapsed := newest.time.Sub(oldest.time)
+	if elapsed <= 0 {
+		return 0
+	}
+	change := float64(newest.size) - float64(oldest.size)
+	return change * float64(dbSizeGrowthWindow) / float64(elapsed)
+}
+
+// report returns the most recently sampled size and growth rate
+func (t *dbSizeTracker) report(chainID ids.ID) ChainDBSize {
+	t.lock.Lock()
+	defer t.lock.Unlock()
+
+	report := ChainDBSize{
+		ID:          chainID,
+		DailyGrowth: t.growth(),
+	}
+	if len(t.samples) > 0 {
+		report.Size = json.Uint64(t.samples[len(t.samples)-1].size)
+	}
+	return report
+}
+
+// trackDBSize starts sampling the size of the database of the chain with ID
+// [chainID]. The tracker of a restarted chain is reused.
+func (m *manager) trackDBSize(chainID ids.ID, db database.Database, namespace string, registerer prometheus.Registerer) error {
+	m.chainsLock.Lock()
+	defer m.chainsLock.Unlock()
+
+	if _, exists := m.dbSizes[chainID]; exists {
+		return nil
+	}
+	sizer, ok := db.(database.Sizer)
+	if !ok {
+		return nil
+	}
+	tracker, err := newDBSizeTracker(sizer, namespace, registerer)
+	if err != nil {
+		return fmt.Errorf("couldn't register database size metrics: %w", err)
+	}
+	if err := tracker.sample(m.clock.Time()); err != nil {
+		return fmt.Errorf("couldn't read database size: %w", err)
+	}
+	m.dbSizes[chainID] = tracker
+	return nil
+}
+
+// sampleDBSizes periodically samples the size of each chain's database until
+// the manager is shut down
+func (m *manager) sampleDBSizes() {
+	ticker := time.NewTicker(dbSizeSampleFrequency)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ticker.C:
+		case <-m.closer:
+			return
+		}
+
+		m.chainsLock.Lock()
+		trackers := make(map[ids.ID]*dbSizeTracker, len(m.dbSizes))
+		for chainID, tracker := range m.dbSizes {
+			trackers[chainID] = tracker
+		}
+		m.chainsLock.Unlock()
+
+		now := m.clock.Time()
+		for chainID, tracker := range trackers {
+			if err := tracker.sample(now); err != nil {
+				m.Log.Debug("couldn't sample the database size of chain %s: %s", chainID, err)
+			}
+		}
+	}
+}
+
+// DBSizes implements the Manager interface
+func (m *manager) DBSizes() DBSizeReport {
+	m.chainsLock.Lock()
+	chainIDs := make([]ids.ID, 0, len(m.dbSizes))
+	for chainID := range m.dbSizes {
+		chainIDs = append(chainIDs, chainID)
+	}
+	ids.SortIDs(chainIDs)
+	trackers := make([]*dbSizeTracker, len(chainIDs))
+	for i, chainID := range chainIDs {
+		trackers[i] = m.dbSizes[chainID]
+	}
+	m.chainsLock.Unlock()
+
+	report := DBSizeReport{Chains: make([]ChainDBSize, len(chainIDs))}
+	for i, tracker := range trackers {
+		report.Chains[i] = tracker.report(chainIDs[i])
+	}
+	if stat, err := m.DB.Stat(level0TablesProperty); err == nil {
+		if backlog, err := strconv.ParseUint(stat, 10, 64); err == nil {
+			report.CompactionBacklog = json.Uint64(backlog)
+		}
+	}
+	return report
+}
@@ -0,0 +1,59 @@
+// (c) 2019-2020, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package chains
+
+import (
+	"testing"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+
+	"github.com/ava-labs/avalanchego/database/memdb"
+	"github.com/ava-labs/avalanchego/ids"
+)
+
+func TestDBSizeTrackerGrowth(t *testing.T) {
+	db := memdb.New()
+	tracker, err := newDBSizeTracker(db, "", prometheus.NewRegistry())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	now := time.Unix(1000000, 0)
+	if err := tracker.sample(now); err != nil {
+		t.Fatal(err)
+	}
+	if report := tracker.report(ids.Empty); report.Size != 0 || report.DailyGrowth != 0 {
+		t.Fatalf("unexpected report of an empty database: %+v", report)
+	}
+
+	// Grow by 100 bytes over 12 hours
+	if err := db.Put(make([]byte, 50), make([]byte, 50)); err != nil {
+		t.Fatal(err)
+	}
+	now = now.Add(12 * time.Hour)
+	if err := tracker.sample(now); err != nil {
+		t.Fatal(err)
+	}
+	report := tracker.report(ids.Empty)
+	if report.Size != 100 {
+		t.Fatalf("expected size 100 but got %d", report.Size)
+	}
+	if report.DailyGrowth != 200 {
+		t.Fatalf("expected growth of 200 bytes per day but got %f", report.DailyGrowth)
+	}
+
+	// Samples older than the growth window are dropped
+	now = now.Add(2 * dbSizeGrowthWindow)
+	if err := tracker.sample(now); err != nil {
+		t.Fatal(err)
+	}
+	now = now.Add(time.Hour)
+	if err := tracker.sample(now); err != nil {
+		t.Fatal(err)
+	}
+	if report := tracker.report(ids.Empty); report.DailyGrowth != 0 {
+		t.Fatalf("expected no growth but got %f", report.DailyGrowth)
+	}
+}
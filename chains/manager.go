@@ -7,6 +7,7 @@ import (
 	"errors"
 	"fmt"
 	"sync"
+	"time"
 
 	"github.com/ava-labs/avalanchego/api"
 	"github.com/ava-labs/avalanchego/api/health"
@@ -30,6 +31,8 @@ import (
 	"github.com/ava-labs/avalanchego/snow/validators"
 	"github.com/ava-labs/avalanchego/utils/constants"
 	"github.com/ava-labs/avalanchego/utils/logging"
+	"github.com/ava-labs/avalanchego/utils/timer"
+	"github.com/ava-labs/avalanchego/version"
 	"github.com/ava-labs/avalanchego/vms"
 
 	avcon "github.com/ava-labs/avalanchego/snow/consensus/avalanche"
@@ -43,6 +46,25 @@ import (
 
 const (
 	defaultChannelSize = 1024
+
+	// Identifies the chains' handlers among the consensus event handlers
+	decidedCacheIdentifier = "decided cache"
+
+	// Min portion of a chain's stake that must be connected for the chain to
+	// be considered stalled rather than unable to make progress
+	stallMinConnectedStake = .8
+)
+
+var (
+	errChainShutdown = errors.New("chain has been shutdown")
+	errChainFrozen   = errors.New("chain has been frozen")
+	errChainStalled  = errors.New("chain has stalled")
+	errUnknownChain  = errors.New("unknown chain")
+
+	errNotSnowmanChain      = errors.New("chain doesn't run Snowman consensus")
+	errChainNotBootstrapped = errors.New("chain hasn't finished bootstrapping")
+
+	frozenChainsPrefix = []byte("frozen chains")
 )
 
 // Manager manages the chains running on this node.
@@ -84,6 +106,26 @@ type Manager interface {
 	// Returns true iff the chain with the given ID exists and is finished bootstrapping
 	IsBootstrapped(ids.ID) bool
 
+	// Returns a description of each running chain
+	Chains() []ChainInfo
+
+	// Returns the most recently sampled disk usage of each chain
+	DBSizes() DBSizeReport
+
+	// Freezes or unfreezes the chain with the given ID. A frozen chain stops
+	// building blocks and voting, but still serves containers to its peers.
+	// The frozen state is persisted, so the chain remains frozen across
+	// restarts of the node.
+	SetFrozen(chainID ids.ID, frozen bool) error
+
+	// Returns a description of the blocks being processed by the Snowman
+	// chain with the given ID
+	ProcessingTree(chainID ids.ID) (smcon.ProcessingTree, error)
+
+	// Returns when each of the most recently accepted blocks of the Snowman
+	// chain with the given ID reached each stage of being processed
+	BlockLifecycles(chainID ids.ID) ([]smeng.BlockLifecycle, error)
+
 	Shutdown()
 }
 
@@ -134,6 +176,43 @@ type ManagerConfig struct {
 	WhitelistedSubnets      ids.Set          // Subnets to validate
 	TimeoutManager          *timeout.Manager // Manages request timeouts when sending messages to other validators
 	HealthService           *health.Health
+
+	// Number of times a non-critical chain that failed is re-created before
+	// it is left shut down. If 0, failed chains are never restarted.
+	//
+	// A chain fails when its engine returns an error or panics while handling
+	// a message, or when creating the chain, including initializing its VM and
+	// engine, panics. Panics on goroutines started by a VM can't be recovered
+	// and still terminate the node, as does any failure of a critical chain.
+	// Panics in a chain's API handlers are recovered by the HTTP server and
+	// don't fail the chain.
+	ChainRestartLimit int
+
+	// A chain is reported as unhealthy if it hasn't accepted anything for this
+	// long although its VM has pending work and enough stake is connected. If
+	// 0, chains are never reported as stalled.
+	ChainStallTimeout time.Duration
+
+	// Maximum number of GetAncestors requests a bootstrapping chain keeps
+	// outstanding at once. If 0, the engine default is used.
+	BootstrapMaxOutstandingRequests int
+
+	// Records how beacons respond to bootstrapping requests. Shared by all
+	// chains.
+	BeaconQuality common.BeaconQuality
+
+	// Maximum number of chains of the same subnet, other than the primary
+	// network, that this node runs. Chains beyond the limit aren't created.
+	// If 0, there is no limit.
+	MaxSubnetChains int
+
+	// Subnet ID --> How the chains of that subnet gossip. Subnets that
+	// aren't in the map use the node's defaults.
+	SubnetGossipConfigs map[ids.ID]SubnetGossipConfig
+
+	// If non-nil, consensus messages are sent through this rather than
+	// directly through [Net], so that faults can be injected into them
+	ChaosSender *sender.ChaosSender
 }
 
 type manager struct {
@@ -147,10 +226,46 @@ type manager struct {
 	unblocked     bool
 	blockedChains []ChainParameters
 
+	// Chains waiting to be created once the manager is unblocked
+	creationQueue *creationQueue
+	// Held while a chain is being built, so that chains are built one at a
+	// time
+	createLock sync.Mutex
+
 	chainsLock sync.Mutex
 	// Key: Chain's ID
 	// Value: The chain
 	chains map[ids.ID]*router.Handler
+	// Key: Chain's ID
+	// Value: Number of times the chain has been restarted
+	restarts map[ids.ID]int
+	// Key: Chain's ID
+	// Value: The chain's log. Reused when the chain is restarted, as creating
+	// a new log would truncate the existing one.
+	logs map[ids.ID]logging.Logger
+	// Key: Chain's ID
+	// Value: The chain's health check. Updated to point to the new handler
+	// when the chain is restarted.
+	healthChecks map[ids.ID]*healthCheckWrapper
+	// Key: Chain's ID
+	// Value: Description of the chain
+	chainInfo map[ids.ID]ChainInfo
+
+	// Key: Subnet's ID
+	// Value: Number of chains of the subnet that have been created or are
+	// being created
+	subnetChains map[ids.ID]int
+
+	// Key: Chain's ID
+	// Value: Samples the size of the chain's database
+	dbSizes map[ids.ID]*dbSizeTracker
+
+	// Contains the IDs of the chains that have been frozen
+	frozenDB database.Database
+
+	clock timer.Clock
+	// Closed when the manager is shut down
+	closer chan struct{}
 }
 
 // New returns a new Manager where:
@@ -161,8 +276,18 @@ func New(config *ManagerConfig) Manager {
 	m := &manager{
 		ManagerConfig: *config,
 		chains:        make(map[ids.ID]*router.Handler),
+		restarts:      make(map[ids.ID]int),
+		logs:          make(map[ids.ID]logging.Logger),
+		healthChecks:  make(map[ids.ID]*healthCheckWrapper),
+		chainInfo:     make(map[ids.ID]ChainInfo),
+		subnetChains:  make(map[ids.ID]int),
+		dbSizes:       make(map[ids.ID]*dbSizeTracker),
+		frozenDB:      prefixdb.New(frozenChainsPrefix, config.DB),
+		closer:        make(chan struct{}),
 	}
+	m.creationQueue = newCreationQueue(m.ForceCreateChain)
 	m.Initialize()
+	go m.sampleDBSizes()
 	return m
 }
 
@@ -174,12 +299,22 @@ func (m *manager) CreateChain(chain ChainParameters) {
 	if !m.unblocked {
 		m.blockedChains = append(m.blockedChains, chain)
 	} else {
-		m.ForceCreateChain(chain)
+		m.queueChain(chain)
+	}
+}
+
+// queueChain queues the creation of the chain described by [chainParams]
+func (m *manager) queueChain(chainParams ChainParameters) {
+	if !m.creationQueue.push(chainParams) {
+		m.Log.Debug("chain %s is already being created", chainParams.ID)
 	}
 }
 
 // Create a chain
 func (m *manager) ForceCreateChain(chainParams ChainParameters) {
+	m.createLock.Lock()
+	defer m.createLock.Unlock()
+
 	if !m.WhitelistedSubnets.Contains(chainParams.SubnetID) {
 		m.Log.Debug("Skipped creating non-whitelisted chain:\n"+
 			"    ID: %s\n"+
@@ -197,6 +332,15 @@ func (m *manager) ForceCreateChain(chainParams ChainParameters) {
 		return
 	}
 
+	if !m.reserveSubnetChain(chainParams.SubnetID) {
+		m.Log.Warn("Skipped creating chain %s because subnet %s already has %d chains",
+			chainParams.ID,
+			chainParams.SubnetID,
+			m.MaxSubnetChains,
+		)
+		return
+	}
+
 	m.Log.Info("creating chain:\n"+
 		"    ID: %s\n"+
 		"    VMID:%s",
@@ -204,8 +348,9 @@ func (m *manager) ForceCreateChain(chainParams ChainParameters) {
 		chainParams.VMAlias,
 	)
 
-	chain, err := m.buildChain(chainParams)
+	chain, err := m.safeBuildChain(chainParams, 0)
 	if err != nil {
+		m.releaseSubnetChain(chainParams.SubnetID)
 		m.Log.Error("Error while creating new chain: %s", err)
 		return
 	}
@@ -221,8 +366,56 @@ func (m *manager) ForceCreateChain(chainParams ChainParameters) {
 	m.notifyRegistrants(chain.Name, chain.Ctx, chain.VM)
 }
 
-// Create a chain
-func (m *manager) buildChain(chainParams ChainParameters) (*chain, error) {
+// reserveSubnetChain counts a chain of [subnetID] towards the subnet's
+// [MaxSubnetChains] limit. Returns false if the subnet has reached the limit.
+func (m *manager) reserveSubnetChain(subnetID ids.ID) bool {
+	m.chainsLock.Lock()
+	defer m.chainsLock.Unlock()
+
+	if m.MaxSubnetChains > 0 && subnetID != constants.PrimaryNetworkID && m.subnetChains[subnetID] >= m.MaxSubnetChains {
+		return false
+	}
+	m.subnetChains[subnetID]++
+	return true
+}
+
+// releaseSubnetChain undoes a call to reserveSubnetChain for a chain that
+// couldn't be created or has stopped running
+func (m *manager) releaseSubnetChain(subnetID ids.ID) {
+	m.chainsLock.Lock()
+	defer m.chainsLock.Unlock()
+
+	if m.subnetChains[subnetID]--; m.subnetChains[subnetID] <= 0 {
+		delete(m.subnetChains, subnetID)
+	}
+}
+
+// removeChain forgets the chain described by [chainParams] once it stopped and
+// won't be restarted
+func (m *manager) removeChain(chainParams ChainParameters) {
+	m.chainsLock.Lock()
+	delete(m.chainInfo, chainParams.ID)
+	m.chainsLock.Unlock()
+
+	m.releaseSubnetChain(chainParams.SubnetID)
+}
+
+// safeBuildChain builds the chain, returning an error rather than panicking if
+// the VM or engine panics while the chain is being created.
+func (m *manager) safeBuildChain(chainParams ChainParameters, restarts int) (c *chain, err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			m.Log.Error("chain %s panicked while being created:\n%s\nFrom:\n%s", chainParams.ID, r, logging.Stacktrace{})
+			c = nil
+			err = fmt.Errorf("chain %s panicked while being created: %v", chainParams.ID, r)
+		}
+	}()
+	return m.buildChain(chainParams, restarts)
+}
+
+// Create a chain. [restarts] is the number of times this chain has previously
+// failed and been re-created.
+func (m *manager) buildChain(chainParams ChainParameters, restarts int) (*chain, error) {
 	vmID, err := m.VMManager.Lookup(chainParams.VMAlias)
 	if err != nil {
 		return nil, fmt.Errorf("error while looking up VM: %w", err)
@@ -233,8 +426,15 @@ func (m *manager) buildChain(chainParams ChainParameters) (*chain, error) {
 		primaryAlias = chainParams.ID.String()
 	}
 
+	// Metrics can't be unregistered, so the metrics of a restarted chain are
+	// registered under a new namespace.
+	metricsAlias := primaryAlias
+	if restarts > 0 {
+		metricsAlias = fmt.Sprintf("%s_restart%d", primaryAlias, restarts)
+	}
+
 	// Create the log and context of the chain
-	chainLog, err := m.LogFactory.MakeChain(primaryAlias, "")
+	chainLog, err := m.chainLog(chainParams.ID, primaryAlias)
 	if err != nil {
 		return nil, fmt.Errorf("error while creating chain's log %w", err)
 	}
@@ -253,7 +453,8 @@ func (m *manager) buildChain(chainParams ChainParameters) (*chain, error) {
 		SharedMemory:        m.AtomicMemory.NewSharedMemory(chainParams.ID),
 		BCLookup:            m,
 		SNLookup:            m,
-		Namespace:           fmt.Sprintf("%s_%s_vm", constants.PlatformName, primaryAlias),
+		NetworkUpgrades:     version.GetNetworkUpgrades(m.NetworkID),
+		Namespace:           fmt.Sprintf("%s_%s_vm", constants.PlatformName, metricsAlias),
 		Metrics:             m.ConsensusParams.Metrics,
 	}
 
@@ -296,7 +497,7 @@ func (m *manager) buildChain(chainParams ChainParameters) (*chain, error) {
 	}
 
 	consensusParams := m.ConsensusParams
-	consensusParams.Namespace = fmt.Sprintf("%s_%s", constants.PlatformName, primaryAlias)
+	consensusParams.Namespace = fmt.Sprintf("%s_%s", constants.PlatformName, metricsAlias)
 
 	// The validators of this blockchain
 	var vdrs validators.Set // Validators validating this blockchain
@@ -317,9 +518,18 @@ func (m *manager) buildChain(chainParams ChainParameters) (*chain, error) {
 
 	bootstrapWeight := beacons.Weight()
 
+	info := ChainInfo{
+		ID:         chainParams.ID,
+		SubnetID:   chainParams.SubnetID,
+		VMID:       vmID,
+		Interfaces: vmInterfaces(vm),
+		Restarts:   restarts,
+	}
+
 	var chain *chain
 	switch vm := vm.(type) {
 	case vertex.DAGVM:
+		info.Engine = AvalancheEngine
 		chain, err = m.createAvalancheChain(
 			ctx,
 			chainParams.GenesisData,
@@ -334,6 +544,7 @@ func (m *manager) buildChain(chainParams ChainParameters) (*chain, error) {
 			return nil, fmt.Errorf("error while creating new avalanche vm %w", err)
 		}
 	case block.ChainVM:
+		info.Engine = SnowmanEngine
 		chain, err = m.createSnowmanChain(
 			ctx,
 			chainParams.GenesisData,
@@ -356,29 +567,170 @@ func (m *manager) buildChain(chainParams ChainParameters) (*chain, error) {
 		return nil, err
 	}
 
+	if err := m.registerHealthCheck(chain); err != nil {
+		return nil, err
+	}
+
+	chainDB := prefixdb.New(chainParams.ID[:], m.DB)
+	if err := m.trackDBSize(chainParams.ID, chainDB, consensusParams.Namespace, consensusParams.Metrics); err != nil {
+		ctx.Log.Warn("not tracking the size of the chain's database: %s", err)
+	}
+
+	frozen, err := m.frozenDB.Has(chainParams.ID[:])
+	if err != nil {
+		return nil, fmt.Errorf("couldn't read whether chain %s is frozen: %w", chainParams.ID, err)
+	}
+	if frozen {
+		ctx.Log.Warn("chain %s is frozen. It won't build blocks or vote until it is unfrozen", chainParams.ID)
+	}
+	chain.Handler.SetFrozen(frozen)
+
+	if gossipConfig, ok := m.SubnetGossipConfigs[chainParams.SubnetID]; ok {
+		chain.Handler.SetGossipFrequency(gossipConfig.Frequency)
+		m.Net.SetGossipSize(chainParams.ID, gossipConfig.Size)
+	}
+	info.Frozen = frozen
+
+	// Lets the handler answer Get requests for recently accepted containers
+	// without calling into the VM. If the chain is being restarted, this
+	// replaces the handler of the failed instance.
+	_ = m.ConsensusEvents.DeregisterChain(chainParams.ID, decidedCacheIdentifier)
+	if err := m.ConsensusEvents.RegisterChain(chainParams.ID, decidedCacheIdentifier, chain.Handler); err != nil {
+		return nil, err
+	}
+
+	m.chainsLock.Lock()
+	m.chainInfo[chainParams.ID] = info
+	m.chainsLock.Unlock()
+
 	// Allows messages to be routed to the new chain
 	m.ManagerConfig.Router.AddChain(chain.Handler)
 
-	// If the X or P Chain panics, do not attempt to recover
+	// If the X or P Chain fails, do not attempt to recover. The router will
+	// shut down the node once the chain has been removed.
 	if m.CriticalChains.Contains(chainParams.ID) {
 		go ctx.Log.RecoverAndPanic(chain.Handler.Dispatch)
 	} else {
-		go ctx.Log.RecoverAndExit(chain.Handler.Dispatch, func() {
-			ctx.Log.Error("Chain with ID: %s was shutdown due to a panic", chainParams.ID)
-		})
+		go func() {
+			ctx.Log.RecoverAndExit(chain.Handler.Dispatch, func() {
+				ctx.Log.Error("Chain with ID: %s was shutdown due to a panic", chainParams.ID)
+			})
+			m.restartChain(chainParams, chain.Handler)
+		}()
 	}
 	return chain, nil
 }
 
+// chainLog returns the log of the chain with ID [chainID], creating it if this
+// is the first time the chain is being created.
+func (m *manager) chainLog(chainID ids.ID, primaryAlias string) (logging.Logger, error) {
+	m.chainsLock.Lock()
+	defer m.chainsLock.Unlock()
+
+	if log, exists := m.logs[chainID]; exists {
+		return log, nil
+	}
+	log, err := m.LogFactory.MakeChain(primaryAlias, "")
+	if err != nil {
+		return nil, err
+	}
+	m.logs[chainID] = log
+	return log, nil
+}
+
+// registerHealthCheck registers the health check of [chain]. If the chain is
+// being restarted, its existing health check is updated instead.
+func (m *manager) registerHealthCheck(chain *chain) error {
+	m.chainsLock.Lock()
+	defer m.chainsLock.Unlock()
+
+	chainID := chain.Ctx.ChainID
+	if hc, exists := m.healthChecks[chainID]; exists {
+		hc.setHandler(chain.Handler)
+		return nil
+	}
+
+	hc := &healthCheckWrapper{
+		chain:        chain.Name,
+		handler:      chain.Handler,
+		stallTimeout: m.ChainStallTimeout,
+	}
+	if err := m.HealthService.RegisterCheck(hc); err != nil {
+		return fmt.Errorf("couldn't add health check for chain %s: %w", chain.Name, err)
+	}
+	m.healthChecks[chainID] = hc
+	return nil
+}
+
+// restartChain re-creates the chain described by [chainParams] after [handler]
+// stopped dispatching, if the chain stopped due to a failure and hasn't been
+// restarted [ChainRestartLimit] times yet. If the chain isn't restarted, it's
+// no longer reported by Chains and no longer counts towards its subnet's
+// [MaxSubnetChains] limit.
+func (m *manager) restartChain(chainParams ChainParameters, handler *router.Handler) {
+	failure := handler.Failure()
+	if failure == nil {
+		// The chain was shut down gracefully
+		m.removeChain(chainParams)
+		return
+	}
+
+	m.chainsLock.Lock()
+	restarts := m.restarts[chainParams.ID]
+	if restarts >= m.ChainRestartLimit {
+		m.chainsLock.Unlock()
+		m.removeChain(chainParams)
+		m.Log.Error("chain %s failed and won't be restarted: %s", chainParams.ID, failure)
+		return
+	}
+	restarts++
+	m.restarts[chainParams.ID] = restarts
+	m.chainsLock.Unlock()
+
+	m.Log.Warn("restarting chain %s (attempt %d/%d) after failure: %s",
+		chainParams.ID,
+		restarts,
+		m.ChainRestartLimit,
+		failure,
+	)
+
+	m.createLock.Lock()
+	defer m.createLock.Unlock()
+
+	chain, err := m.safeBuildChain(chainParams, restarts)
+	if err != nil {
+		m.removeChain(chainParams)
+		m.Log.Error("Error while restarting chain: %s", err)
+		return
+	}
+
+	m.chainsLock.Lock()
+	m.chains[chainParams.ID] = chain.Handler
+	m.chainsLock.Unlock()
+
+	// The chain's aliases are kept from when it was first created. Registrants
+	// are notified again so that they point to the new instance of the VM.
+	m.notifyRegistrants(chain.Name, chain.Ctx, chain.VM)
+}
+
 // Implements Manager.AddRegistrant
 func (m *manager) AddRegistrant(r Registrant) { m.registrants = append(m.registrants, r) }
 
+// externalSender returns what chains send consensus messages to other
+// validators with
+func (m *manager) externalSender() sender.ExternalSender {
+	if m.ChaosSender != nil {
+		return m.ChaosSender
+	}
+	return m.Net
+}
+
 func (m *manager) unblockChains() {
 	m.unblocked = true
 	blocked := m.blockedChains
 	m.blockedChains = nil
 	for _, chainParams := range blocked {
-		m.ForceCreateChain(chainParams)
+		m.queueChain(chainParams)
 	}
 }
 
@@ -397,6 +749,10 @@ func (m *manager) createAvalancheChain(
 	defer ctx.Lock.Unlock()
 
 	db := prefixdb.New(ctx.ChainID[:], m.DB)
+	requestEpoch, err := nextRequestEpoch(db)
+	if err != nil {
+		return nil, fmt.Errorf("couldn't get the chain's request epoch: %w", err)
+	}
 	vmDB := prefixdb.New([]byte("vm"), db)
 	vertexDB := prefixdb.New([]byte("vertex"), db)
 	vertexBootstrappingDB := prefixdb.New([]byte("vertex_bs"), db)
@@ -426,7 +782,7 @@ func (m *manager) createAvalancheChain(
 
 	// Passes messages from the consensus engine to the network
 	sender := sender.Sender{}
-	sender.Initialize(ctx, m.Net, m.ManagerConfig.Router, m.TimeoutManager)
+	sender.Initialize(ctx, m.externalSender(), m.ManagerConfig.Router, m.TimeoutManager)
 
 	sampleK := consensusParams.K
 	if uint64(sampleK) > bootstrapWeight {
@@ -445,6 +801,10 @@ func (m *manager) createAvalancheChain(
 				StartupAlpha: (3*bootstrapWeight + 3) / 4,
 				Alpha:        bootstrapWeight/2 + 1, // must be > 50%
 				Sender:       &sender,
+
+				RequestEpoch:           requestEpoch,
+				MaxOutstandingRequests: m.BootstrapMaxOutstandingRequests,
+				BeaconQuality:          m.BeaconQuality,
 			},
 			VtxBlocked: vtxBlocker,
 			TxBlocked:  txBlocker,
@@ -457,20 +817,6 @@ func (m *manager) createAvalancheChain(
 		return nil, fmt.Errorf("error initializing avalanche engine: %w", err)
 	}
 
-	// Register health checks
-	chainAlias, err := m.PrimaryAlias(ctx.ChainID)
-	if err != nil {
-		chainAlias = ctx.ChainID.String()
-	}
-	wrapperHc := &healthCheckWrapper{
-		chain: chainAlias,
-		lock:  &ctx.Lock,
-		check: engine.Health,
-	}
-	if err := m.HealthService.RegisterCheck(wrapperHc); err != nil {
-		return nil, fmt.Errorf("couldn't add health check for chain %s: %w", chainAlias, err)
-	}
-
 	// Asynchronously passes messages from the network to the consensus engine
 	handler := &router.Handler{}
 	handler.Initialize(
@@ -484,6 +830,12 @@ func (m *manager) createAvalancheChain(
 		fmt.Sprintf("%s_handler", consensusParams.Namespace),
 		consensusParams.Metrics,
 	)
+	handler.SetDecidedSender(&sender)
+
+	chainAlias, err := m.PrimaryAlias(ctx.ChainID)
+	if err != nil {
+		chainAlias = ctx.ChainID.String()
+	}
 
 	return &chain{
 		Name:    chainAlias,
@@ -509,6 +861,10 @@ func (m *manager) createSnowmanChain(
 	defer ctx.Lock.Unlock()
 
 	db := prefixdb.New(ctx.ChainID[:], m.DB)
+	requestEpoch, err := nextRequestEpoch(db)
+	if err != nil {
+		return nil, fmt.Errorf("couldn't get the chain's request epoch: %w", err)
+	}
 	vmDB := prefixdb.New([]byte("vm"), db)
 	bootstrappingDB := prefixdb.New([]byte("bs"), db)
 
@@ -528,13 +884,20 @@ func (m *manager) createSnowmanChain(
 
 	// Passes messages from the consensus engine to the network
 	sender := sender.Sender{}
-	sender.Initialize(ctx, m.Net, m.ManagerConfig.Router, m.TimeoutManager)
+	sender.Initialize(ctx, m.externalSender(), m.ManagerConfig.Router, m.TimeoutManager)
 
 	sampleK := consensusParams.K
 	if uint64(sampleK) > bootstrapWeight {
 		sampleK = int(bootstrapWeight)
 	}
 
+	// Blocks rejected at the same time are rejected together if the VM
+	// supports it
+	consensus := &smcon.Topological{}
+	if rejecter, ok := vm.(smcon.BatchRejecter); ok {
+		consensus.Rejecter = rejecter
+	}
+
 	// The engine handles consensus
 	engine := &smeng.Transitive{}
 	if err := engine.Initialize(smeng.Config{
@@ -547,13 +910,17 @@ func (m *manager) createSnowmanChain(
 				StartupAlpha: (3*bootstrapWeight + 3) / 4,
 				Alpha:        bootstrapWeight/2 + 1, // must be > 50%
 				Sender:       &sender,
+
+				RequestEpoch:           requestEpoch,
+				MaxOutstandingRequests: m.BootstrapMaxOutstandingRequests,
+				BeaconQuality:          m.BeaconQuality,
 			},
 			Blocked:      blocked,
 			VM:           vm,
 			Bootstrapped: m.unblockChains,
 		},
 		Params:    consensusParams,
-		Consensus: &smcon.Topological{},
+		Consensus: consensus,
 	}); err != nil {
 		return nil, fmt.Errorf("error initializing snowman engine: %w", err)
 	}
@@ -571,22 +938,13 @@ func (m *manager) createSnowmanChain(
 		fmt.Sprintf("%s_handler", consensusParams.Namespace),
 		consensusParams.Metrics,
 	)
+	handler.SetDecidedSender(&sender)
 
-	// Register health checks
 	chainAlias, err := m.PrimaryAlias(ctx.ChainID)
 	if err != nil {
 		chainAlias = ctx.ChainID.String()
 	}
 
-	wrapperHc := &healthCheckWrapper{
-		chain: chainAlias,
-		lock:  &ctx.Lock,
-		check: engine.Health,
-	}
-	if err := m.HealthService.RegisterCheck(wrapperHc); err != nil {
-		return nil, fmt.Errorf("couldn't add health check for chain %s: %w", chainAlias, err)
-	}
-
 	return &chain{
 		Name:    chainAlias,
 		Engine:  engine,
@@ -618,8 +976,116 @@ func (m *manager) IsBootstrapped(id ids.ID) bool {
 	return chain.Engine().IsBootstrapped()
 }
 
+// Chains returns a description of each running chain, sorted by chain ID
+func (m *manager) Chains() []ChainInfo {
+	m.chainsLock.Lock()
+	chainIDs := make([]ids.ID, 0, len(m.chainInfo))
+	for chainID := range m.chainInfo {
+		chainIDs = append(chainIDs, chainID)
+	}
+	ids.SortIDs(chainIDs)
+
+	chains := make([]ChainInfo, len(chainIDs))
+	for i, chainID := range chainIDs {
+		chains[i] = m.chainInfo[chainID]
+	}
+	m.chainsLock.Unlock()
+
+	for i := range chains {
+		chains[i].Aliases = m.Aliases(chains[i].ID)
+	}
+	return chains
+}
+
+// SetFrozen freezes or unfreezes the chain with ID [chainID]
+func (m *manager) SetFrozen(chainID ids.ID, frozen bool) error {
+	m.chainsLock.Lock()
+	defer m.chainsLock.Unlock()
+
+	handler, exists := m.chains[chainID]
+	if !exists {
+		return fmt.Errorf("%w: %s", errUnknownChain, chainID)
+	}
+
+	if frozen {
+		if err := m.frozenDB.Put(chainID[:], nil); err != nil {
+			return err
+		}
+		m.Log.Warn("freezing chain %s", chainID)
+	} else {
+		if err := m.frozenDB.Delete(chainID[:]); err != nil {
+			return err
+		}
+		m.Log.Info("unfreezing chain %s", chainID)
+	}
+	handler.SetFrozen(frozen)
+
+	info := m.chainInfo[chainID]
+	info.Frozen = frozen
+	m.chainInfo[chainID] = info
+	return nil
+}
+
+// processingTreeEngine is implemented by engines that run Snowman consensus
+type processingTreeEngine interface {
+	ProcessingTree() smcon.ProcessingTree
+}
+
+// ProcessingTree returns a description of the blocks being processed by the
+// Snowman chain with ID [chainID]
+func (m *manager) ProcessingTree(chainID ids.ID) (smcon.ProcessingTree, error) {
+	m.chainsLock.Lock()
+	handler, exists := m.chains[chainID]
+	m.chainsLock.Unlock()
+	if !exists {
+		return smcon.ProcessingTree{}, fmt.Errorf("%w: %s", errUnknownChain, chainID)
+	}
+
+	engine, ok := handler.Engine().(processingTreeEngine)
+	if !ok {
+		return smcon.ProcessingTree{}, fmt.Errorf("%w: %s", errNotSnowmanChain, chainID)
+	}
+
+	ctx := handler.Context()
+	ctx.Lock.Lock()
+	defer ctx.Lock.Unlock()
+
+	if !ctx.IsBootstrapped() {
+		return smcon.ProcessingTree{}, fmt.Errorf("%w: %s", errChainNotBootstrapped, chainID)
+	}
+	return engine.ProcessingTree(), nil
+}
+
+// blockLifecyclesEngine is implemented by engines that run Snowman consensus
+type blockLifecyclesEngine interface {
+	BlockLifecycles() []smeng.BlockLifecycle
+}
+
+// BlockLifecycles returns the lifecycles of the most recently accepted blocks
+// of the Snowman chain with ID [chainID]
+func (m *manager) BlockLifecycles(chainID ids.ID) ([]smeng.BlockLifecycle, error) {
+	m.chainsLock.Lock()
+	handler, exists := m.chains[chainID]
+	m.chainsLock.Unlock()
+	if !exists {
+		return nil, fmt.Errorf("%w: %s", errUnknownChain, chainID)
+	}
+
+	engine, ok := handler.Engine().(blockLifecyclesEngine)
+	if !ok {
+		return nil, fmt.Errorf("%w: %s", errNotSnowmanChain, chainID)
+	}
+
+	ctx := handler.Context()
+	ctx.Lock.Lock()
+	defer ctx.Lock.Unlock()
+
+	return engine.BlockLifecycles(), nil
+}
+
 // Shutdown stops all the chains
 func (m *manager) Shutdown() {
+	close(m.closer)
 	m.ManagerConfig.Router.Shutdown()
 }
 
@@ -646,16 +1112,28 @@ func (m *manager) isChainWithAlias(aliases ...string) (string, bool) {
 	return "", false
 }
 
-// Wraps a health check.
-// Grabs [Lock] before executing the health check
+// Wraps a chain's health check.
+// Grabs the chain's lock before executing the engine's health check
 type healthCheckWrapper struct {
-	check func() (interface{}, error)
-
-	// Grabs/releases this before/after health check func
-	lock *sync.RWMutex
-
 	// Alias/ID of chain this health check is for
 	chain string
+
+	// Handler of the chain. If the chain has failed or has been shutdown, it
+	// is reported as unhealthy without calling into the engine.
+	handlerLock sync.RWMutex
+	handler     *router.Handler
+
+	// The chain is reported as stalled if it hasn't accepted anything for
+	// this long while it has work to do. If 0, it's never reported as
+	// stalled.
+	stallTimeout time.Duration
+}
+
+func (hc *healthCheckWrapper) setHandler(handler *router.Handler) {
+	hc.handlerLock.Lock()
+	defer hc.handlerLock.Unlock()
+
+	hc.handler = handler
 }
 
 // Name is this health check's formatted name
@@ -663,9 +1141,27 @@ func (hc *healthCheckWrapper) Name() string {
 	return hc.chain
 }
 
-// Execute executes the health check function with the lock
+// Execute executes the engine's health check with the chain's lock
 func (hc *healthCheckWrapper) Execute() (interface{}, error) {
-	hc.lock.Lock()
-	defer hc.lock.Unlock()
-	return hc.check()
+	hc.handlerLock.RLock()
+	handler := hc.handler
+	hc.handlerLock.RUnlock()
+
+	if err := handler.Failure(); err != nil {
+		return map[string]string{"failure": err.Error()}, err
+	}
+	if handler.Closing() {
+		return nil, errChainShutdown
+	}
+	if handler.Frozen() {
+		return map[string]bool{"frozen": true}, errChainFrozen
+	}
+	if details, stalled := handler.Stalled(hc.stallTimeout, stallMinConnectedStake); stalled {
+		return details, errChainStalled
+	}
+
+	ctx := handler.Context()
+	ctx.Lock.Lock()
+	defer ctx.Lock.Unlock()
+	return handler.Engine().Health()
 }
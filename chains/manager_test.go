@@ -0,0 +1,186 @@
+// (c) 2019-2020, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package chains
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/snow"
+	"github.com/ava-labs/avalanchego/snow/engine/common"
+	"github.com/ava-labs/avalanchego/snow/networking/router"
+	"github.com/ava-labs/avalanchego/snow/validators"
+	"github.com/ava-labs/avalanchego/utils/constants"
+	"github.com/ava-labs/avalanchego/utils/logging"
+)
+
+func newTestHandler(t *testing.T, engine *common.EngineTest) *router.Handler {
+	ctx := snow.DefaultContextTest()
+	engine.ContextF = func() *snow.Context { return ctx }
+
+	handler := &router.Handler{}
+	handler.Initialize(
+		engine,
+		validators.NewSet(),
+		nil,
+		16,
+		router.DefaultMaxNonStakerPendingMsgs,
+		router.DefaultStakerPortion,
+		router.DefaultStakerPortion,
+		"",
+		prometheus.NewRegistry(),
+	)
+	return handler
+}
+
+func TestHealthCheckReportsEngineHealth(t *testing.T) {
+	engine := &common.EngineTest{T: t}
+	engine.Default(true)
+
+	errUnhealthy := errors.New("unhealthy")
+	engine.HealthF = func() (interface{}, error) { return "details", errUnhealthy }
+
+	hc := &healthCheckWrapper{chain: "X"}
+	hc.setHandler(newTestHandler(t, engine))
+
+	details, err := hc.Execute()
+	if err != errUnhealthy {
+		t.Fatalf("Should have returned the engine's health error but returned %v", err)
+	}
+	if details != "details" {
+		t.Fatalf("Should have returned the engine's health details but returned %v", details)
+	}
+}
+
+func TestHealthCheckReportsFailure(t *testing.T) {
+	engine := &common.EngineTest{T: t}
+	engine.Default(true)
+
+	errFailed := errors.New("failed")
+	engine.GetAcceptedFrontierF = func(ids.ShortID, uint32) error { return errFailed }
+	engine.ShutdownF = func() error { return nil }
+
+	handler := newTestHandler(t, engine)
+	hc := &healthCheckWrapper{chain: "X"}
+	hc.setHandler(handler)
+
+	go handler.Dispatch()
+	handler.GetAcceptedFrontier(ids.ShortEmpty, 1, time.Now().Add(time.Second))
+
+	deadline := time.Now().Add(time.Second)
+	for handler.Failure() == nil {
+		if time.Now().After(deadline) {
+			t.Fatalf("Handler should have been marked as failed")
+		}
+		time.Sleep(time.Millisecond)
+	}
+
+	// Health isn't mocked, so calling into the engine fails the test
+	details, err := hc.Execute()
+	if err != errFailed {
+		t.Fatalf("Should have returned the chain's failure but returned %v", err)
+	}
+	if details.(map[string]string)["failure"] != errFailed.Error() {
+		t.Fatalf("Should have reported the chain's failure but reported %v", details)
+	}
+}
+
+func TestHealthCheckReportsShutdown(t *testing.T) {
+	engine := &common.EngineTest{T: t}
+	engine.Default(true)
+	engine.ShutdownF = func() error { return nil }
+
+	handler := newTestHandler(t, engine)
+	hc := &healthCheckWrapper{chain: "X"}
+	hc.setHandler(handler)
+
+	go handler.Dispatch()
+	handler.Shutdown()
+
+	deadline := time.Now().Add(time.Second)
+	for !handler.Closing() {
+		if time.Now().After(deadline) {
+			t.Fatalf("Handler should have been shutdown")
+		}
+		time.Sleep(time.Millisecond)
+	}
+
+	// Health isn't mocked, so calling into the engine fails the test
+	if _, err := hc.Execute(); err != errChainShutdown {
+		t.Fatalf("Should have reported the chain as shutdown but returned %v", err)
+	}
+}
+
+func TestSubnetChainLimit(t *testing.T) {
+	subnetID := ids.GenerateTestID()
+	m := &manager{
+		ManagerConfig: ManagerConfig{
+			Log:             logging.NoLog{},
+			MaxSubnetChains: 1,
+		},
+		subnetChains: make(map[ids.ID]int),
+	}
+
+	if !m.reserveSubnetChain(subnetID) {
+		t.Fatal("the subnet's first chain should have been allowed")
+	}
+	if m.reserveSubnetChain(subnetID) {
+		t.Fatal("the subnet's second chain shouldn't have been allowed")
+	}
+	if !m.reserveSubnetChain(constants.PrimaryNetworkID) {
+		t.Fatal("the primary network shouldn't be limited")
+	}
+
+	// A chain that is shut down no longer counts towards the limit
+	engine := &common.EngineTest{T: t}
+	engine.Default(true)
+	engine.ShutdownF = func() error { return nil }
+	handler := newTestHandler(t, engine)
+	m.restartChain(ChainParameters{ID: ids.GenerateTestID(), SubnetID: subnetID}, handler)
+	if !m.reserveSubnetChain(subnetID) {
+		t.Fatal("the subnet's chain should have been allowed after its other chain shut down")
+	}
+}
+
+func TestFailedChainNotReported(t *testing.T) {
+	chainID := ids.GenerateTestID()
+	m := &manager{
+		ManagerConfig: ManagerConfig{
+			Log: logging.NoLog{},
+		},
+		subnetChains: map[ids.ID]int{constants.PrimaryNetworkID: 1},
+		restarts:     make(map[ids.ID]int),
+		chainInfo: map[ids.ID]ChainInfo{
+			chainID: {ID: chainID, SubnetID: constants.PrimaryNetworkID},
+		},
+	}
+
+	errFailed := errors.New("failed")
+	engine := &common.EngineTest{T: t}
+	engine.Default(true)
+	engine.GetAcceptedFrontierF = func(ids.ShortID, uint32) error { return errFailed }
+	engine.ShutdownF = func() error { return nil }
+
+	handler := newTestHandler(t, engine)
+	go handler.Dispatch()
+	handler.GetAcceptedFrontier(ids.ShortEmpty, 1, time.Now().Add(time.Second))
+
+	deadline := time.Now().Add(time.Second)
+	for handler.Failure() == nil {
+		if time.Now().After(deadline) {
+			t.Fatalf("Handler should have been marked as failed")
+		}
+		time.Sleep(time.Millisecond)
+	}
+
+	// Restarts are disabled, so the chain is left shut down
+	m.restartChain(ChainParameters{ID: chainID, SubnetID: constants.PrimaryNetworkID}, handler)
+	if chains := m.Chains(); len(chains) != 0 {
+		t.Fatalf("A chain that won't be restarted shouldn't be reported but got %v", chains)
+	}
+}
@@ -2,6 +2,8 @@ package chains
 
 import (
 	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/snow/consensus/snowman"
+	smeng "github.com/ava-labs/avalanchego/snow/engine/snowman"
 	"github.com/ava-labs/avalanchego/snow/networking/router"
 )
 
@@ -53,3 +55,22 @@ func (mm MockManager) SubnetID(ids.ID) (ids.ID, error) { return ids.ID{}, nil }
 
 // IsBootstrapped ...
 func (mm MockManager) IsBootstrapped(ids.ID) bool { return false }
+
+// Chains ...
+func (mm MockManager) Chains() []ChainInfo { return nil }
+
+// DBSizes ...
+func (mm MockManager) DBSizes() DBSizeReport { return DBSizeReport{} }
+
+// SetFrozen ...
+func (mm MockManager) SetFrozen(ids.ID, bool) error { return nil }
+
+// ProcessingTree ...
+func (mm MockManager) ProcessingTree(ids.ID) (snowman.ProcessingTree, error) {
+	return snowman.ProcessingTree{}, nil
+}
+
+// BlockLifecycles ...
+func (mm MockManager) BlockLifecycles(ids.ID) ([]smeng.BlockLifecycle, error) {
+	return nil, nil
+}
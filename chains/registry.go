@@ -0,0 +1,55 @@
+// (c) 2019-2020, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package chains
+
+import (
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/snow/engine/avalanche/vertex"
+	"github.com/ava-labs/avalanchego/snow/engine/common"
+	"github.com/ava-labs/avalanchego/snow/engine/snowman/block"
+)
+
+// Consensus engines a chain can run
+const (
+	AvalancheEngine = "avalanche"
+	SnowmanEngine   = "snowman"
+)
+
+// Interfaces a chain's VM can implement
+const (
+	DAGVMInterface    = "avalanche.DAGVM"
+	ChainVMInterface  = "snowman.ChainVM"
+	StaticVMInterface = "common.StaticVM"
+)
+
+// ChainInfo describes a running chain
+type ChainInfo struct {
+	ID       ids.ID `json:"id"`
+	SubnetID ids.ID `json:"subnetID"`
+	VMID     ids.ID `json:"vmID"`
+	// Consensus engine the chain runs
+	Engine string `json:"engine"`
+	// Interfaces implemented by the chain's VM
+	Interfaces []string `json:"interfaces"`
+	Aliases    []string `json:"aliases"`
+	// Number of times the chain has been restarted after failing
+	Restarts int `json:"restarts"`
+	// True if the chain has been frozen by an operator
+	Frozen bool `json:"frozen"`
+}
+
+// vmInterfaces returns the interfaces that [vm] implements
+func vmInterfaces(vm interface{}) []string {
+	interfaces := []string(nil)
+	if _, ok := vm.(vertex.DAGVM); ok {
+		interfaces = append(interfaces, DAGVMInterface)
+	}
+	if _, ok := vm.(block.ChainVM); ok {
+		interfaces = append(interfaces, ChainVMInterface)
+	}
+	if _, ok := vm.(common.StaticVM); ok {
+		interfaces = append(interfaces, StaticVMInterface)
+	}
+	return interfaces
+}
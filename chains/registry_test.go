@@ -0,0 +1,25 @@
+// (c) 2019-2020, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package chains
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/ava-labs/avalanchego/snow/engine/avalanche/vertex"
+	"github.com/ava-labs/avalanchego/snow/engine/snowman/block"
+)
+
+func TestVMInterfaces(t *testing.T) {
+	assert.Equal(t,
+		[]string{ChainVMInterface, StaticVMInterface},
+		vmInterfaces(&block.TestVM{}),
+	)
+	assert.Equal(t,
+		[]string{DAGVMInterface, StaticVMInterface},
+		vmInterfaces(&vertex.TestVM{}),
+	)
+	assert.Empty(t, vmInterfaces(struct{}{}))
+}
@@ -0,0 +1,39 @@
+// (c) 2019-2020, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package chains
+
+import (
+	"encoding/binary"
+
+	"github.com/ava-labs/avalanchego/database"
+	"github.com/ava-labs/avalanchego/snow/engine/common"
+)
+
+// numRequestEpochs is the number of distinct request epochs. The last epoch
+// isn't used as it contains the ID reserved for gossip. Engines skip that ID
+// regardless; see common.Bootstrapper.NextRequestID.
+const numRequestEpochs = 1<<common.RequestEpochBits - 1
+
+var requestEpochKey = []byte("request epoch")
+
+// nextRequestEpoch returns the request epoch the chain whose database is [db]
+// should use, and persists it so that the next instance of the chain uses a
+// different one. This prevents responses to requests sent by a previous
+// instance of the chain, before a restart, from matching requests sent by the
+// new instance.
+func nextRequestEpoch(db database.Database) (uint32, error) {
+	epoch := uint32(0)
+	epochBytes, err := db.Get(requestEpochKey)
+	switch {
+	case err == database.ErrNotFound:
+	case err != nil:
+		return 0, err
+	case len(epochBytes) == 4:
+		epoch = (binary.BigEndian.Uint32(epochBytes) + 1) % numRequestEpochs
+	}
+
+	epochBytes = make([]byte, 4)
+	binary.BigEndian.PutUint32(epochBytes, epoch)
+	return epoch, db.Put(requestEpochKey, epochBytes)
+}
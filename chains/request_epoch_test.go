@@ -0,0 +1,33 @@
+// (c) 2019-2020, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package chains
+
+import (
+	"testing"
+
+	"github.com/ava-labs/avalanchego/database/memdb"
+)
+
+func TestNextRequestEpoch(t *testing.T) {
+	db := memdb.New()
+
+	for expected := uint32(0); expected < numRequestEpochs; expected++ {
+		epoch, err := nextRequestEpoch(db)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if epoch != expected {
+			t.Fatalf("expected epoch %d but got %d", expected, epoch)
+		}
+	}
+
+	// The epoch wraps around without using the last epoch
+	epoch, err := nextRequestEpoch(db)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if epoch != 0 {
+		t.Fatalf("expected epoch to wrap around to 0 but got %d", epoch)
+	}
+}
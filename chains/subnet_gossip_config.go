@@ -0,0 +1,43 @@
+// (c) 2019-2020, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package chains
+
+import (
+	"errors"
+	"time"
+)
+
+// maxGossipSize is the max number of peers a container can be gossiped to
+const maxGossipSize = 1000
+
+var (
+	errNegativeGossipFrequency = errors.New("gossip frequency can't be negative")
+	errNegativeGossipSize      = errors.New("gossip size can't be negative")
+	errGossipSizeTooLarge      = errors.New("gossip size too large")
+)
+
+// SubnetGossipConfig overrides how the chains of a subnet gossip. A zero field
+// keeps the node's default.
+type SubnetGossipConfig struct {
+	// How often the chains gossip their accepted frontier. The chains gossip
+	// on the node's gossip ticks, so this is rounded up to a multiple of the
+	// node's consensus gossip frequency.
+	Frequency time.Duration
+	// Number of peers each gossiped container is sent to
+	Size int
+}
+
+// Verify returns nil iff this config is valid
+func (c *SubnetGossipConfig) Verify() error {
+	switch {
+	case c.Frequency < 0:
+		return errNegativeGossipFrequency
+	case c.Size < 0:
+		return errNegativeGossipSize
+	case c.Size > maxGossipSize:
+		return errGossipSizeTooLarge
+	default:
+		return nil
+	}
+}
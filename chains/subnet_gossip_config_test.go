@@ -0,0 +1,27 @@
+// (c) 2019-2020, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package chains
+
+import (
+	"testing"
+	"time"
+)
+
+func TestSubnetGossipConfigVerify(t *testing.T) {
+	tests := []struct {
+		config   SubnetGossipConfig
+		expected error
+	}{
+		{SubnetGossipConfig{}, nil},
+		{SubnetGossipConfig{Frequency: time.Second, Size: 10}, nil},
+		{SubnetGossipConfig{Frequency: -time.Second}, errNegativeGossipFrequency},
+		{SubnetGossipConfig{Size: -1}, errNegativeGossipSize},
+		{SubnetGossipConfig{Size: maxGossipSize + 1}, errGossipSizeTooLarge},
+	}
+	for _, test := range tests {
+		if err := test.config.Verify(); err != test.expected {
+			t.Fatalf("expected %v for %+v but got %v", test.expected, test.config, err)
+		}
+	}
+}
@@ -49,6 +49,17 @@ type Compacter interface {
 	Compact(start []byte, limit []byte) error
 }
 
+// Sizer wraps the SizeOf method of a backing data store. It isn't required by
+// the Database interface, so callers should check for it.
+type Sizer interface {
+	// SizeOf returns the approximate number of bytes used to store the keys
+	// in the range [start, limit).
+	//
+	// A nil start is treated as a key before all keys in the DB.
+	// And a nil limit is treated as a key after all keys in the DB.
+	SizeOf(start []byte, limit []byte) (uint64, error)
+}
+
 // Database contains all the methods required to allow handling different
 // key-value data stores backing the database.
 type Database interface {
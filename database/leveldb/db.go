@@ -161,6 +161,16 @@ func (db *Database) Compact(start []byte, limit []byte) error {
 	return db.handleError(db.DB.CompactRange(util.Range{Start: start, Limit: limit}))
 }
 
+// SizeOf implements the database.Sizer interface. The size is the approximate
+// number of bytes on disk, so recent writes may not be reflected.
+func (db *Database) SizeOf(start []byte, limit []byte) (uint64, error) {
+	sizes, err := db.DB.SizeOf([]util.Range{{Start: start, Limit: limit}})
+	if err != nil {
+		return 0, db.handleError(err)
+	}
+	return uint64(sizes.Sum()), nil
+}
+
 // Close implements the Database interface
 func (db *Database) Close() error { return db.handleError(db.DB.Close()) }
 
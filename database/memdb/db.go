@@ -143,6 +143,28 @@ func (db *Database) NewIteratorWithStartAndPrefix(start, prefix []byte) database
 // Stat implements the Database interface
 func (db *Database) Stat(property string) (string, error) { return "", database.ErrNotFound }
 
+// SizeOf implements the database.Sizer interface. The size is the number of
+// bytes in the keys and values in the range.
+func (db *Database) SizeOf(start []byte, limit []byte) (uint64, error) {
+	db.lock.RLock()
+	defer db.lock.RUnlock()
+
+	if db.db == nil {
+		return 0, database.ErrClosed
+	}
+	size := uint64(0)
+	for key, value := range db.db {
+		if start != nil && key < string(start) {
+			continue
+		}
+		if limit != nil && key >= string(limit) {
+			continue
+		}
+		size += uint64(len(key) + len(value))
+	}
+	return size, nil
+}
+
 // Compact implements the Database interface
 func (db *Database) Compact(start []byte, limit []byte) error {
 	db.lock.RLock()
@@ -114,6 +114,15 @@ func (db *Database) Stat(stat string) (string, error) {
 	return result, err
 }
 
+// SizeOf implements the database.Sizer interface
+func (db *Database) SizeOf(start, limit []byte) (uint64, error) {
+	sizer, ok := db.db.(database.Sizer)
+	if !ok {
+		return 0, database.ErrNotSupported
+	}
+	return sizer.SizeOf(start, limit)
+}
+
 // Compact implements the Database interface
 func (db *Database) Compact(start, limit []byte) error {
 	startTime := db.clock.Time()
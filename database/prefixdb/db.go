@@ -187,6 +187,27 @@ func (db *Database) Compact(start, limit []byte) error {
 	return db.db.Compact(db.prefix(start), db.prefix(limit))
 }
 
+// SizeOf implements the database.Sizer interface
+func (db *Database) SizeOf(start, limit []byte) (uint64, error) {
+	db.lock.RLock()
+	defer db.lock.RUnlock()
+
+	if db.db == nil {
+		return 0, database.ErrClosed
+	}
+	sizer, ok := db.db.(database.Sizer)
+	if !ok {
+		return 0, database.ErrNotSupported
+	}
+	prefixedLimit := []byte(nil)
+	if limit != nil {
+		prefixedLimit = db.prefix(limit)
+	} else {
+		prefixedLimit = prefixEnd(db.dbPrefix)
+	}
+	return sizer.SizeOf(db.prefix(start), prefixedLimit)
+}
+
 // Close implements the Database interface
 func (db *Database) Close() error {
 	db.lock.Lock()
@@ -220,6 +241,20 @@ func (db *Database) prefix(key []byte) []byte {
 	return prefixedKey
 }
 
+// prefixEnd returns the first key after all the keys that start with
+// [prefix], or nil if there is no such key.
+func prefixEnd(prefix []byte) []byte {
+	end := make([]byte, len(prefix))
+	copy(end, prefix)
+	for i := len(end) - 1; i >= 0; i-- {
+		end[i]++
+		if end[i] != 0 {
+			return end[:i+1]
+		}
+	}
+	return nil
+}
+
 type keyValue struct {
 	key    []byte
 	value  []byte
@@ -21,3 +21,45 @@ func TestInterface(t *testing.T) {
 		test(t, NewNested([]byte("ld"), New([]byte("wor"), db)))
 	}
 }
+
+func TestSizeOf(t *testing.T) {
+	db := memdb.New()
+	helloDB := New([]byte("hello"), db)
+	worldDB := New([]byte("world"), db)
+
+	if err := helloDB.Put([]byte{1}, []byte{2, 3}); err != nil {
+		t.Fatal(err)
+	}
+	if err := worldDB.Put([]byte{1}, []byte{2, 3, 4}); err != nil {
+		t.Fatal(err)
+	}
+
+	size, err := helloDB.SizeOf(nil, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	// The stored key is the 32 byte prefix followed by the 1 byte key
+	if expected := uint64(32 + 1 + 2); size != expected {
+		t.Fatalf("expected size %d but got %d", expected, size)
+	}
+	if size, err := helloDB.SizeOf([]byte{2}, nil); err != nil {
+		t.Fatal(err)
+	} else if size != 0 {
+		t.Fatalf("expected size 0 but got %d", size)
+	}
+}
+
+func TestPrefixEnd(t *testing.T) {
+	tests := []struct {
+		prefix, expected []byte
+	}{
+		{[]byte{1, 2}, []byte{1, 3}},
+		{[]byte{1, 0xff}, []byte{2}},
+		{[]byte{0xff, 0xff}, nil},
+	}
+	for _, test := range tests {
+		if end := prefixEnd(test.prefix); string(end) != string(test.expected) {
+			t.Fatalf("expected end of %v to be %v but got %v", test.prefix, test.expected, end)
+		}
+	}
+}
@@ -6,6 +6,8 @@ package genesis
 import (
 	"encoding/hex"
 	"encoding/json"
+	"fmt"
+	"io/ioutil"
 
 	"github.com/ava-labs/avalanchego/ids"
 	"github.com/ava-labs/avalanchego/utils/constants"
@@ -60,12 +62,26 @@ func (s Staker) Unparse(networkID uint32) (UnparsedStaker, error) {
 		s.RewardAddress.Bytes(),
 	)
 	return UnparsedStaker{
-		NodeID:        s.NodeID.PrefixedString(constants.NodeIDPrefix),
+		NodeID:        ids.NodeID(s.NodeID).String(),
 		RewardAddress: avaxAddr,
 		DelegationFee: s.DelegationFee,
 	}, err
 }
 
+// RewardConfig parameterizes the P-Chain's staking reward curve. It's part of
+// the genesis config, rather than a node's config, as every node must reward
+// stakers the same amount.
+type RewardConfig struct {
+	// MinConsumptionRate is the rate, in the range [0, 1000000], at which the
+	// remaining supply is minted when staking for a duration of 0.
+	MinConsumptionRate uint64 `json:"minConsumptionRate"`
+	// MaxConsumptionRate is the rate, in the range [0, 1000000], at which the
+	// remaining supply is minted when staking for the minting period.
+	MaxConsumptionRate uint64 `json:"maxConsumptionRate"`
+	// SupplyCap is the maximum amount of nAVAX that should ever exist
+	SupplyCap uint64 `json:"supplyCap"`
+}
+
 // Config contains the genesis addresses used to construct a genesis
 type Config struct {
 	NetworkID uint32 `json:"networkID"`
@@ -81,6 +97,8 @@ type Config struct {
 	CChainGenesis string `json:"cChainGenesis"`
 
 	Message string `json:"message"`
+
+	RewardConfig RewardConfig `json:"rewardConfig"`
 }
 
 // Unparse ...
@@ -95,6 +113,7 @@ func (c Config) Unparse() (UnparsedConfig, error) {
 		InitialStakers:             make([]UnparsedStaker, len(c.InitialStakers)),
 		CChainGenesis:              c.CChainGenesis,
 		Message:                    c.Message,
+		RewardConfig:               c.RewardConfig,
 	}
 	for i, a := range c.Allocations {
 		ua, err := a.Unparse(uc.NetworkID)
@@ -205,3 +224,22 @@ func GetConfig(networkID uint32) *Config {
 		return &tempConfig
 	}
 }
+
+// GetConfigFile returns the genesis config of a custom network, read from the
+// JSON file at [filepath]. This lets a custom network use its own allocations,
+// initial stakers and reward curve.
+func GetConfigFile(filepath string) (*Config, error) {
+	configBytes, err := ioutil.ReadFile(filepath)
+	if err != nil {
+		return nil, fmt.Errorf("couldn't read genesis config file %q: %w", filepath, err)
+	}
+	unparsedConfig := UnparsedConfig{}
+	if err := json.Unmarshal(configBytes, &unparsedConfig); err != nil {
+		return nil, fmt.Errorf("couldn't unmarshal genesis config file %q: %w", filepath, err)
+	}
+	config, err := unparsedConfig.Parse()
+	if err != nil {
+		return nil, fmt.Errorf("couldn't parse genesis config file %q: %w", filepath, err)
+	}
+	return &config, nil
+}
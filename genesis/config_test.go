@@ -0,0 +1,58 @@
+// (c) 2019-2020, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package genesis
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestGetConfigFile(t *testing.T) {
+	dir, err := ioutil.TempDir("", "genesis")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	customConfig := LocalConfig
+	customConfig.NetworkID = 1337
+	customConfig.RewardConfig = RewardConfig{
+		MinConsumptionRate: 50000,
+		MaxConsumptionRate: 60000,
+		SupplyCap:          customConfig.RewardConfig.SupplyCap / 2,
+	}
+	unparsedConfig, err := customConfig.Unparse()
+	if err != nil {
+		t.Fatal(err)
+	}
+	configBytes, err := json.Marshal(unparsedConfig)
+	if err != nil {
+		t.Fatal(err)
+	}
+	configFile := filepath.Join(dir, "genesis.json")
+	if err := ioutil.WriteFile(configFile, configBytes, 0600); err != nil {
+		t.Fatal(err)
+	}
+
+	config, err := GetConfigFile(configFile)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if config.NetworkID != customConfig.NetworkID {
+		t.Fatalf("expected network ID %d but got %d", customConfig.NetworkID, config.NetworkID)
+	}
+	if config.RewardConfig != customConfig.RewardConfig {
+		t.Fatalf("expected reward config %+v but got %+v", customConfig.RewardConfig, config.RewardConfig)
+	}
+	if _, _, err := FromConfig(config); err != nil {
+		t.Fatalf("couldn't build the genesis of the custom network: %s", err)
+	}
+
+	if _, err := GetConfigFile(filepath.Join(dir, "missing.json")); err == nil {
+		t.Fatal("should have errored due to a missing file")
+	}
+}
@@ -0,0 +1,286 @@
+// (c) 2019-2020, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package genesis
+
+import (
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"sort"
+
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/utils/constants"
+	"github.com/ava-labs/avalanchego/utils/formatting"
+)
+
+// Difference is a field that differs between two genesis configs
+type Difference struct {
+	// Path to the field, like "allocations[X-avax1...].initialAmount"
+	Field string `json:"field"`
+	// Value of the field in the expected config. Empty if the field isn't in
+	// the expected config.
+	Expected string `json:"expected"`
+	// Value of the field in the actual config. Empty if the field isn't in the
+	// actual config.
+	Actual string `json:"actual"`
+}
+
+// Diff returns the differences between the genesis configs [expected] and
+// [actual] that change the genesis they generate, along with differences in
+// the reward config. The reward config isn't part of the generated genesis, but
+// every node of a network must use the same one. Allocations are matched by
+// AVAX address, and initial stakers by node ID, so reordering them isn't a
+// difference. Addresses are formatted for the network of [expected].
+func Diff(expected, actual *Config) ([]Difference, error) {
+	d := differ{hrp: constants.GetHRP(expected.NetworkID)}
+
+	d.compare("networkID", expected.NetworkID, actual.NetworkID)
+
+	expectedSupply, err := expected.InitialSupply()
+	if err != nil {
+		return nil, fmt.Errorf("couldn't calculate expected initial supply: %w", err)
+	}
+	actualSupply, err := actual.InitialSupply()
+	if err != nil {
+		return nil, fmt.Errorf("couldn't calculate actual initial supply: %w", err)
+	}
+	d.compare("initialSupply", expectedSupply, actualSupply)
+
+	if err := d.diffAllocations(expected.Allocations, actual.Allocations); err != nil {
+		return nil, err
+	}
+
+	d.compare("startTime", expected.StartTime, actual.StartTime)
+	d.compare("initialStakeDuration", expected.InitialStakeDuration, actual.InitialStakeDuration)
+	d.compare("initialStakeDurationOffset", expected.InitialStakeDurationOffset, actual.InitialStakeDurationOffset)
+
+	if err := d.diffStakedFunds(expected.InitialStakedFunds, actual.InitialStakedFunds); err != nil {
+		return nil, err
+	}
+	if err := d.diffStakers(expected.InitialStakers, actual.InitialStakers); err != nil {
+		return nil, err
+	}
+
+	var expectedCChain, actualCChain interface{}
+	if json.Unmarshal([]byte(expected.CChainGenesis), &expectedCChain) == nil &&
+		json.Unmarshal([]byte(actual.CChainGenesis), &actualCChain) == nil {
+		d.diffJSON("cChainGenesis", expectedCChain, actualCChain)
+	} else {
+		d.compare("cChainGenesis", expected.CChainGenesis, actual.CChainGenesis)
+	}
+
+	d.compare("message", expected.Message, actual.Message)
+	d.compare("rewardConfig.minConsumptionRate", expected.RewardConfig.MinConsumptionRate, actual.RewardConfig.MinConsumptionRate)
+	d.compare("rewardConfig.maxConsumptionRate", expected.RewardConfig.MaxConsumptionRate, actual.RewardConfig.MaxConsumptionRate)
+	d.compare("rewardConfig.supplyCap", expected.RewardConfig.SupplyCap, actual.RewardConfig.SupplyCap)
+	return d.diffs, nil
+}
+
+// differ accumulates the differences between two genesis configs
+type differ struct {
+	// HRP that addresses are formatted with
+	hrp   string
+	diffs []Difference
+}
+
+// compare records a difference if [expected] and [actual] are formatted
+// differently
+func (d *differ) compare(field string, expected, actual interface{}) {
+	if expectedStr, actualStr := fmt.Sprint(expected), fmt.Sprint(actual); expectedStr != actualStr {
+		d.diffs = append(d.diffs, Difference{
+			Field:    field,
+			Expected: expectedStr,
+			Actual:   actualStr,
+		})
+	}
+}
+
+// address formats [addr] as an X-Chain address
+func (d *differ) address(addr ids.ShortID) (string, error) {
+	return formatting.FormatAddress("X", d.hrp, addr.Bytes())
+}
+
+func (d *differ) diffAllocations(expected, actual []Allocation) error {
+	addrs := ids.ShortSet{}
+	expectedAllocations := make(map[[20]byte]Allocation, len(expected))
+	for _, allocation := range expected {
+		addrs.Add(allocation.AVAXAddr)
+		expectedAllocations[allocation.AVAXAddr.Key()] = allocation
+	}
+	actualAllocations := make(map[[20]byte]Allocation, len(actual))
+	for _, allocation := range actual {
+		addrs.Add(allocation.AVAXAddr)
+		actualAllocations[allocation.AVAXAddr.Key()] = allocation
+	}
+
+	for _, avaxAddr := range sortedList(addrs) {
+		addr, err := d.address(avaxAddr)
+		if err != nil {
+			return err
+		}
+		field := fmt.Sprintf("allocations[%s]", addr)
+		expectedAllocation, inExpected := expectedAllocations[avaxAddr.Key()]
+		actualAllocation, inActual := actualAllocations[avaxAddr.Key()]
+		if !inExpected || !inActual {
+			d.diffs = append(d.diffs, Difference{
+				Field:    field,
+				Expected: allocationString(expectedAllocation, inExpected),
+				Actual:   allocationString(actualAllocation, inActual),
+			})
+			continue
+		}
+
+		d.compare(field+".ethAddr", ethAddrString(expectedAllocation.ETHAddr), ethAddrString(actualAllocation.ETHAddr))
+		d.compare(field+".initialAmount", expectedAllocation.InitialAmount, actualAllocation.InitialAmount)
+		d.compare(field+".unlockSchedule", scheduleString(expectedAllocation.UnlockSchedule), scheduleString(actualAllocation.UnlockSchedule))
+	}
+	return nil
+}
+
+func (d *differ) diffStakedFunds(expected, actual []ids.ShortID) error {
+	expectedFunds := ids.ShortSet{}
+	expectedFunds.Add(expected...)
+	actualFunds := ids.ShortSet{}
+	actualFunds.Add(actual...)
+	addrs := ids.ShortSet{}
+	addrs.Union(expectedFunds)
+	addrs.Union(actualFunds)
+
+	for _, fundsAddr := range sortedList(addrs) {
+		inExpected := expectedFunds.Contains(fundsAddr)
+		inActual := actualFunds.Contains(fundsAddr)
+		if inExpected == inActual {
+			continue
+		}
+		addr, err := d.address(fundsAddr)
+		if err != nil {
+			return err
+		}
+		d.diffs = append(d.diffs, Difference{
+			Field:    fmt.Sprintf("initialStakedFunds[%s]", addr),
+			Expected: presenceString(inExpected),
+			Actual:   presenceString(inActual),
+		})
+	}
+	return nil
+}
+
+func (d *differ) diffStakers(expected, actual []Staker) error {
+	nodeIDs := ids.ShortSet{}
+	expectedStakers := make(map[[20]byte]Staker, len(expected))
+	for _, staker := range expected {
+		nodeIDs.Add(staker.NodeID)
+		expectedStakers[staker.NodeID.Key()] = staker
+	}
+	actualStakers := make(map[[20]byte]Staker, len(actual))
+	for _, staker := range actual {
+		nodeIDs.Add(staker.NodeID)
+		actualStakers[staker.NodeID.Key()] = staker
+	}
+
+	for _, nodeID := range sortedList(nodeIDs) {
+		field := fmt.Sprintf("initialStakers[%s]", ids.NodeID(nodeID).String())
+		expectedStaker, inExpected := expectedStakers[nodeID.Key()]
+		actualStaker, inActual := actualStakers[nodeID.Key()]
+		if !inExpected || !inActual {
+			d.diffs = append(d.diffs, Difference{
+				Field:    field,
+				Expected: presenceString(inExpected),
+				Actual:   presenceString(inActual),
+			})
+			continue
+		}
+
+		expectedRewardAddr, err := d.address(expectedStaker.RewardAddress)
+		if err != nil {
+			return err
+		}
+		actualRewardAddr, err := d.address(actualStaker.RewardAddress)
+		if err != nil {
+			return err
+		}
+		d.compare(field+".rewardAddress", expectedRewardAddr, actualRewardAddr)
+		d.compare(field+".delegationFee", expectedStaker.DelegationFee, actualStaker.DelegationFee)
+	}
+	return nil
+}
+
+// diffJSON records the differences between two decoded JSON values. Objects
+// are compared key by key, so that a difference deep in the C-Chain genesis is
+// reported by its path, rather than as a difference in the whole genesis.
+func (d *differ) diffJSON(field string, expected, actual interface{}) {
+	if reflect.DeepEqual(expected, actual) {
+		return
+	}
+	expectedObj, expectedIsObj := expected.(map[string]interface{})
+	actualObj, actualIsObj := actual.(map[string]interface{})
+	if !expectedIsObj || !actualIsObj {
+		d.diffs = append(d.diffs, Difference{
+			Field:    field,
+			Expected: jsonString(expected),
+			Actual:   jsonString(actual),
+		})
+		return
+	}
+
+	keys := make([]string, 0, len(expectedObj)+len(actualObj))
+	for key := range expectedObj {
+		keys = append(keys, key)
+	}
+	for key := range actualObj {
+		if _, ok := expectedObj[key]; !ok {
+			keys = append(keys, key)
+		}
+	}
+	sort.Strings(keys)
+	for _, key := range keys {
+		d.diffJSON(field+"."+key, expectedObj[key], actualObj[key])
+	}
+}
+
+// sortedList returns the elements of [set] in sorted order, so that differences
+// are reported deterministically
+func sortedList(set ids.ShortSet) []ids.ShortID {
+	list := set.List()
+	ids.SortShortIDs(list)
+	return list
+}
+
+func presenceString(present bool) string {
+	if present {
+		return "present"
+	}
+	return ""
+}
+
+func ethAddrString(addr ids.ShortID) string {
+	return "0x" + hex.EncodeToString(addr.Bytes())
+}
+
+func scheduleString(schedule []LockedAmount) string {
+	if len(schedule) == 0 {
+		return "[]"
+	}
+	return jsonString(schedule)
+}
+
+func allocationString(allocation Allocation, present bool) string {
+	if !present {
+		return ""
+	}
+	return fmt.Sprintf("initialAmount: %d, unlockSchedule: %s", allocation.InitialAmount, scheduleString(allocation.UnlockSchedule))
+}
+
+// jsonString returns the JSON encoding of [v], or "" if [v] is nil
+func jsonString(v interface{}) string {
+	if v == nil {
+		return ""
+	}
+	b, err := json.Marshal(v)
+	if err != nil {
+		return fmt.Sprint(v)
+	}
+	return string(b)
+}
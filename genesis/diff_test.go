@@ -0,0 +1,90 @@
+// (c) 2019-2020, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package genesis
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/ava-labs/avalanchego/ids"
+)
+
+// copyConfig returns a copy of [config] that can be modified without modifying
+// [config]
+func copyConfig(config Config) Config {
+	config.Allocations = append([]Allocation(nil), config.Allocations...)
+	config.InitialStakedFunds = append([]ids.ShortID(nil), config.InitialStakedFunds...)
+	config.InitialStakers = append([]Staker(nil), config.InitialStakers...)
+	return config
+}
+
+func TestDiffSameConfig(t *testing.T) {
+	for _, config := range []Config{MainnetConfig, FujiConfig, LocalConfig} {
+		config := config
+		diffs, err := Diff(&config, &config)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(diffs) != 0 {
+			t.Fatalf("expected network %d's config to match itself but got %v", config.NetworkID, diffs)
+		}
+	}
+}
+
+func TestDiffReordered(t *testing.T) {
+	actual := copyConfig(LocalConfig)
+	for i, j := 0, len(actual.Allocations)-1; i < j; i, j = i+1, j-1 {
+		actual.Allocations[i], actual.Allocations[j] = actual.Allocations[j], actual.Allocations[i]
+	}
+	for i, j := 0, len(actual.InitialStakers)-1; i < j; i, j = i+1, j-1 {
+		actual.InitialStakers[i], actual.InitialStakers[j] = actual.InitialStakers[j], actual.InitialStakers[i]
+	}
+
+	diffs, err := Diff(&LocalConfig, &actual)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(diffs) != 0 {
+		t.Fatalf("reordering allocations and stakers shouldn't be a difference but got %v", diffs)
+	}
+}
+
+func TestDiff(t *testing.T) {
+	actual := copyConfig(LocalConfig)
+	actual.Allocations[0].InitialAmount++
+	removedStaker := actual.InitialStakers[len(actual.InitialStakers)-1]
+	actual.InitialStakers = actual.InitialStakers[:len(actual.InitialStakers)-1]
+	actual.CChainGenesis = strings.Replace(actual.CChainGenesis, `"chainId":43112`, `"chainId":43113`, 1)
+	actual.Message = "hello"
+
+	diffs, err := Diff(&LocalConfig, &actual)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	addr, err := (&differ{hrp: "local"}).address(actual.Allocations[0].AVAXAddr)
+	if err != nil {
+		t.Fatal(err)
+	}
+	expected := map[string]Difference{
+		"initialSupply": {},
+		"allocations[" + addr + "].initialAmount":                           {},
+		"initialStakers[" + ids.NodeID(removedStaker.NodeID).String() + "]": {Expected: "present", Actual: ""},
+		"cChainGenesis.config.chainId":                                      {Expected: "43112", Actual: "43113"},
+		"message":                                                           {Expected: LocalConfig.Message, Actual: "hello"},
+	}
+	if len(diffs) != len(expected) {
+		t.Fatalf("expected %d differences but got %v", len(expected), diffs)
+	}
+	for _, diff := range diffs {
+		expectedDiff, ok := expected[diff.Field]
+		if !ok {
+			t.Fatalf("unexpected difference %v", diff)
+		}
+		if expectedDiff != (Difference{}) && (diff.Expected != expectedDiff.Expected || diff.Actual != expectedDiff.Actual) {
+			t.Fatalf("expected field %s to be %q and %q but got %q and %q",
+				diff.Field, expectedDiff.Expected, expectedDiff.Actual, diff.Expected, diff.Actual)
+		}
+	}
+}
@@ -197,7 +197,7 @@ func FromConfig(config *Config) ([]byte, ids.ID, error) {
 				APIStaker: platformvm.APIStaker{
 					StartTime: json.Uint64(genesisTime.Unix()),
 					EndTime:   json.Uint64(endStakingTime.Unix()),
-					NodeID:    staker.NodeID.PrefixedString(constants.NodeIDPrefix),
+					NodeID:    ids.NodeID(staker.NodeID).String(),
 				},
 				RewardOwner: &platformvm.APIOwner{
 					Threshold: 1,
@@ -325,6 +325,12 @@ func VMGenesis(networkID uint32, vmID ids.ID) (*platformvm.Tx, error) {
 	if err != nil {
 		return nil, err
 	}
+	return VMGenesisFromBytes(genesisBytes, vmID)
+}
+
+// VMGenesisFromBytes returns the transaction that creates the chain running the
+// VM [vmID] in the network whose genesis state is [genesisBytes]
+func VMGenesisFromBytes(genesisBytes []byte, vmID ids.ID) (*platformvm.Tx, error) {
 	genesis := platformvm.Genesis{}
 	if _, err := platformvm.GenesisCodec.Unmarshal(genesisBytes, &genesis); err != nil {
 		return nil, fmt.Errorf("couldn't unmarshal genesis bytes due to: %w", err)
@@ -8,7 +8,6 @@ import (
 	"errors"
 
 	"github.com/ava-labs/avalanchego/ids"
-	"github.com/ava-labs/avalanchego/utils/constants"
 	"github.com/ava-labs/avalanchego/utils/formatting"
 )
 
@@ -67,11 +66,11 @@ func (us UnparsedStaker) Parse() (Staker, error) {
 		DelegationFee: us.DelegationFee,
 	}
 
-	nodeID, err := ids.ShortFromPrefixedString(us.NodeID, constants.NodeIDPrefix)
+	nodeID, err := ids.NodeIDFromString(us.NodeID)
 	if err != nil {
 		return s, err
 	}
-	s.NodeID = nodeID
+	s.NodeID = nodeID.ShortID()
 
 	_, _, avaxAddrBytes, err := formatting.ParseAddress(us.RewardAddress)
 	if err != nil {
@@ -100,6 +99,8 @@ type UnparsedConfig struct {
 	CChainGenesis string `json:"cChainGenesis"`
 
 	Message string `json:"message"`
+
+	RewardConfig RewardConfig `json:"rewardConfig"`
 }
 
 // Parse ...
@@ -114,6 +115,7 @@ func (uc UnparsedConfig) Parse() (Config, error) {
 		InitialStakers:             make([]Staker, len(uc.InitialStakers)),
 		CChainGenesis:              uc.CChainGenesis,
 		Message:                    uc.Message,
+		RewardConfig:               uc.RewardConfig,
 	}
 	for i, ua := range uc.Allocations {
 		a, err := ua.Parse()
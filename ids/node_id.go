@@ -0,0 +1,95 @@
+// (c) 2019-2020, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package ids
+
+import (
+	"fmt"
+	"strings"
+)
+
+// NodeIDPrefix is prepended to the string representation of a NodeID to
+// distinguish it from other ShortIDs, such as addresses
+const NodeIDPrefix = "NodeID-"
+
+// EmptyNodeID is a useful all zero value
+var EmptyNodeID = NodeID(ShortEmpty)
+
+// NodeID identifies a node. It has the same representation as a ShortID, but is
+// a distinct type so that node IDs and addresses can't be mixed up. Converting
+// between the two must be done explicitly.
+//
+// Node IDs are parsed and printed as NodeIDs, and the P-Chain's staking tx
+// builders take them as NodeIDs. The engine, networking and validator
+// interfaces, and the P-Chain's serialized txs, still use ShortIDs.
+type NodeID ShortID
+
+// NewNodeID creates a node identifier from a 20 byte hash
+func NewNodeID(id [20]byte) NodeID { return NodeID(NewShortID(id)) }
+
+// ToNodeID attempts to convert a byte slice into a node ID
+func ToNodeID(bytes []byte) (NodeID, error) {
+	id, err := ToShortID(bytes)
+	return NodeID(id), err
+}
+
+// NodeIDFromString is the inverse of NodeID.String(). [idStr] must start with
+// [NodeIDPrefix].
+func NodeIDFromString(idStr string) (NodeID, error) {
+	if !strings.HasPrefix(idStr, NodeIDPrefix) {
+		return NodeID{}, fmt.Errorf("node ID: %s is missing the prefix: %s", idStr, NodeIDPrefix)
+	}
+	id, err := ShortFromString(strings.TrimPrefix(idStr, NodeIDPrefix))
+	return NodeID(id), err
+}
+
+// MarshalJSON ...
+func (id NodeID) MarshalJSON() ([]byte, error) {
+	if id.IsZero() {
+		return []byte("null"), nil
+	}
+	return []byte("\"" + id.String() + "\""), nil
+}
+
+// UnmarshalJSON ...
+func (id *NodeID) UnmarshalJSON(b []byte) error {
+	str := string(b)
+	if str == "null" { // If "null", do nothing
+		return nil
+	} else if len(str) < 2 {
+		return errMissingQuotes
+	}
+
+	lastIndex := len(str) - 1
+	if str[0] != '"' || str[lastIndex] != '"' {
+		return errMissingQuotes
+	}
+
+	var err error
+	*id, err = NodeIDFromString(str[1:lastIndex])
+	return err
+}
+
+// ShortID returns this node ID as a ShortID
+func (id NodeID) ShortID() ShortID { return ShortID(id) }
+
+// IsZero returns true if the value has not been initialized
+func (id NodeID) IsZero() bool { return id.ID == nil }
+
+// Key returns a 20 byte hash that this id represents. This is useful to allow
+// for this id to be used as keys in maps.
+func (id NodeID) Key() [20]byte { return *id.ID }
+
+// Equals returns true if the ids have the same byte representation
+func (id NodeID) Equals(oID NodeID) bool { return ShortID(id).Equals(ShortID(oID)) }
+
+// Bytes returns the 20 byte hash as a slice. It is assumed this slice is not
+// modified.
+func (id NodeID) Bytes() []byte { return id.ID[:] }
+
+func (id NodeID) String() string {
+	if id.IsZero() {
+		return "nil"
+	}
+	return NodeIDPrefix + ShortID(id).String()
+}
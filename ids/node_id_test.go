@@ -0,0 +1,72 @@
+// (c) 2019-2020, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package ids
+
+import (
+	"encoding/json"
+	"testing"
+)
+
+func TestNodeIDString(t *testing.T) {
+	id := NewNodeID([20]byte{1})
+
+	idStr := id.String()
+	if want := NodeIDPrefix + ShortID(id).String(); idStr != want {
+		t.Fatalf("expected %s but got %s", want, idStr)
+	}
+
+	newID, err := NodeIDFromString(idStr)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !newID.Equals(id) {
+		t.Fatalf("NodeIDFromString did not produce the identical ID")
+	}
+
+	if _, err := NodeIDFromString(ShortID(id).String()); err == nil {
+		t.Fatal("parsing a node ID without its prefix should have errored")
+	}
+	if _, err := NodeIDFromString(ShortID(id).PrefixedString("X-")); err == nil {
+		t.Fatal("parsing a node ID with the wrong prefix should have errored")
+	}
+}
+
+func TestNodeIDJSON(t *testing.T) {
+	id := NewNodeID([20]byte{1, 2, 3})
+
+	idBytes, err := json.Marshal(id)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := "\"" + id.String() + "\""; string(idBytes) != want {
+		t.Fatalf("expected %s but got %s", want, idBytes)
+	}
+
+	var newID NodeID
+	if err := json.Unmarshal(idBytes, &newID); err != nil {
+		t.Fatal(err)
+	}
+	if !newID.Equals(id) {
+		t.Fatalf("unmarshalled ID %s doesn't match %s", newID, id)
+	}
+
+	shortBytes, err := json.Marshal(ShortID(id))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := json.Unmarshal(shortBytes, &newID); err == nil {
+		t.Fatal("unmarshalling an unprefixed ShortID as a NodeID should have errored")
+	}
+}
+
+func TestNodeIDShortID(t *testing.T) {
+	shortID := GenerateTestShortID()
+	nodeID := NodeID(shortID)
+	if !nodeID.ShortID().Equals(shortID) {
+		t.Fatalf("converting back to a ShortID should be lossless")
+	}
+	if !EmptyNodeID.ShortID().Equals(ShortEmpty) {
+		t.Fatalf("EmptyNodeID should be ShortEmpty")
+	}
+}
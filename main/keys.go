@@ -8,6 +8,7 @@ const (
 	configFileKey                   = "config-file"
 	versionKey                      = "version"
 	networkNameKey                  = "network-id"
+	genesisConfigFileKey            = "genesis"
 	txFeeKey                        = "tx-fee"
 	creationTxFeeKey                = "creation-tx-fee"
 	uptimeRequirementKey            = "uptime-requirement"
@@ -36,7 +37,10 @@ const (
 	apiAuthPasswordKey              = "api-auth-password" // #nosec G101
 	bootstrapIPsKey                 = "bootstrap-ips"
 	bootstrapIDsKey                 = "bootstrap-ids"
+	stakingHostKey                  = "staking-host"
 	stakingPortKey                  = "staking-port"
+	stakingOutboundIPKey            = "staking-outbound-ip"
+	stakingProxyKey                 = "staking-proxy"
 	stakingEnabledKey               = "staking-enabled"
 	p2pTLSEnabledKey                = "p2p-tls-enabled"
 	stakingKeyPathKey               = "staking-tls-key-file"
@@ -55,6 +59,8 @@ const (
 	benchlistDurationKey            = "benchlist-duration"
 	benchlistMinFailingDurationKey  = "benchlist-min-failing-duration"
 	pluginDirKey                    = "plugin-dir"
+	pluginManifestSignersKey        = "plugin-manifest-signers"
+	pluginManifestFileKey           = "plugin-manifest-file"
 	logsDirKey                      = "log-dir"
 	logLevelKey                     = "log-level"
 	logDisplayLevelKey              = "log-display-level"
@@ -79,6 +85,11 @@ const (
 	ipcsPathKey                     = "ipcs-path"
 	consensusGossipFrequencyKey     = "consensus-gossip-frequency"
 	consensusShutdownTimeoutKey     = "consensus-shutdown-timeout"
+	chainRestartLimitKey            = "chain-restart-limit"
+	chainStallTimeoutKey            = "chain-stall-timeout"
+	bootstrapMaxOutstandingKey      = "bootstrap-max-outstanding-requests"
+	maxSubnetChainsKey              = "max-subnet-chains"
 	fdLimitKey                      = "fd-limit"
 	corethConfigKey                 = "coreth-config"
+	subnetGossipConfigsKey          = "subnet-gossip-configs"
 )
@@ -164,3 +164,8 @@ func (m Builder) Chits(chainID ids.ID, requestID uint32, containerIDs []ids.ID)
 		ContainerIDs: containerIDBytes,
 	})
 }
+
+// Reachability message
+func (m Builder) Reachability(filter []byte) (Msg, error) {
+	return m.Pack(Reachability, map[Field]interface{}{ReachableFilter: filter})
+}
@@ -0,0 +1,39 @@
+// (c) 2019-2020, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package network
+
+import (
+	"sort"
+	"sync/atomic"
+	"time"
+)
+
+// ClockSkew implements the Network interface
+// assumes the stateLock is not held.
+func (n *network) ClockSkew() time.Duration {
+	offsets := []int64(nil)
+	for _, peer := range n.getAllPeers() {
+		if peer.connected.GetValue() {
+			offsets = append(offsets, atomic.LoadInt64(&peer.clockOffset))
+		}
+	}
+	return medianClockOffset(offsets)
+}
+
+// medianClockOffset returns the median of [offsets], which are in seconds, or 0
+// if there are none. Using the median means a few peers with bad clocks can't
+// make this node's clock look skewed.
+func medianClockOffset(offsets []int64) time.Duration {
+	if len(offsets) == 0 {
+		return 0
+	}
+	sort.Slice(offsets, func(i, j int) bool { return offsets[i] < offsets[j] })
+	return time.Duration(offsets[len(offsets)/2]) * time.Second
+}
+
+// updateClockSkew reports how far this node's clock is behind its peers'
+// assumes the stateLock is not held.
+func (n *network) updateClockSkew() {
+	n.clockSkew.Set(n.ClockSkew().Seconds())
+}
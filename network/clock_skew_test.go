@@ -0,0 +1,19 @@
+// (c) 2019-2020, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package network
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMedianClockOffset(t *testing.T) {
+	assert.Equal(t, time.Duration(0), medianClockOffset(nil))
+	assert.Equal(t, -2*time.Second, medianClockOffset([]int64{-2}))
+
+	// A few peers with bad clocks don't skew the result
+	assert.Equal(t, 3*time.Second, medianClockOffset([]int64{3, 50, 2, -40, 3}))
+}
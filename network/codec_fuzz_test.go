@@ -0,0 +1,48 @@
+// (c) 2019-2020, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+//go:build go1.18
+// +build go1.18
+
+package network
+
+import (
+	"bytes"
+	"testing"
+)
+
+// FuzzCodecParse ensures that parsing arbitrary bytes never panics and that
+// every message that parses successfully is re-packed into the same bytes.
+//
+// Run with: go test -run=^$ -fuzz=FuzzCodecParse ./network
+func FuzzCodecParse(f *testing.F) {
+	for _, seed := range representativeMessages(Builder{}) {
+		msg, err := seed()
+		if err != nil {
+			f.Fatal(err)
+		}
+		f.Add(msg.Bytes())
+	}
+	f.Add([]byte{})
+	f.Add([]byte{byte(PeerList), 0xff, 0xff, 0xff, 0xff})
+	f.Add([]byte{byte(MultiPut), 0x00})
+
+	f.Fuzz(func(t *testing.T, msgBytes []byte) {
+		parsed, err := TestCodec.Parse(msgBytes)
+		if err != nil {
+			return
+		}
+
+		if !bytes.Equal(parsed.Bytes(), msgBytes) {
+			t.Fatalf("parsed message reports different bytes than were parsed")
+		}
+
+		repacked, err := TestCodec.Pack(parsed.Op(), parsed.(*msg).fields)
+		if err != nil {
+			t.Fatalf("failed to re-pack parsed %s message: %s", parsed.Op(), err)
+		}
+		if !bytes.Equal(repacked.Bytes(), msgBytes) {
+			t.Fatalf("re-packed %s message doesn't match the parsed bytes", parsed.Op())
+		}
+	})
+}
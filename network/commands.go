@@ -25,6 +25,7 @@ const (
 	ContainerBytes                   // Used for gossiping
 	ContainerIDs                     // Used for querying
 	MultiContainerBytes              // Used in MultiPut
+	ReachableFilter                  // Used in Reachability
 )
 
 // Packer returns the packer function that can be used to pack this field.
@@ -56,6 +57,8 @@ func (f Field) Packer() func(*wrappers.Packer, interface{}) {
 		return wrappers.TryPackHashes
 	case MultiContainerBytes:
 		return wrappers.TryPack2DBytes
+	case ReachableFilter:
+		return wrappers.TryPackBytes
 	default:
 		return nil
 	}
@@ -90,6 +93,8 @@ func (f Field) Unpacker() func(*wrappers.Packer) interface{} {
 		return wrappers.TryUnpackHashes
 	case MultiContainerBytes:
 		return wrappers.TryUnpack2DBytes
+	case ReachableFilter:
+		return wrappers.TryUnpackBytes
 	default:
 		return nil
 	}
@@ -123,6 +128,8 @@ func (f Field) String() string {
 		return "Container IDs"
 	case MultiContainerBytes:
 		return "MultiContainerBytes"
+	case ReachableFilter:
+		return "ReachableFilter"
 	default:
 		return "Unknown Field"
 	}
@@ -167,6 +174,8 @@ func (op Op) String() string {
 		return "pull_query"
 	case Chits:
 		return "chits"
+	case Reachability:
+		return "reachability"
 	default:
 		return "Unknown Op"
 	}
@@ -194,6 +203,9 @@ const (
 	PushQuery
 	PullQuery
 	Chits
+	// Connectivity. Only sent to peers that are at least
+	// [minReachabilityVersion]:
+	Reachability
 )
 
 // Defines the messages that can be sent/received with this network
@@ -219,5 +231,7 @@ var (
 		PushQuery: {ChainID, RequestID, Deadline, ContainerID, ContainerBytes},
 		PullQuery: {ChainID, RequestID, Deadline, ContainerID},
 		Chits:     {ChainID, RequestID, ContainerIDs},
+		// Connectivity:
+		Reachability: {ReachableFilter},
 	}
 )
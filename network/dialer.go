@@ -16,10 +16,24 @@ type Dialer interface {
 
 type dialer struct {
 	network string
+	dialer  net.Dialer
 }
 
 // NewDialer returns a new Dialer that calls `net.Dial` with the provided
 // network.
 func NewDialer(network string) Dialer { return &dialer{network: network} }
 
-func (d *dialer) Dial(ip utils.IPDesc) (net.Conn, error) { return net.Dial(d.network, ip.String()) }
+// NewBoundDialer returns a new Dialer that makes connections over the provided
+// network from [localIP]. If [localIP] is nil, the local address is chosen by
+// the OS.
+func NewBoundDialer(network string, localIP net.IP) Dialer {
+	d := &dialer{network: network}
+	if localIP != nil {
+		d.dialer.LocalAddr = &net.TCPAddr{IP: localIP}
+	}
+	return d
+}
+
+func (d *dialer) Dial(ip utils.IPDesc) (net.Conn, error) {
+	return d.dialer.Dial(d.network, ip.String())
+}
@@ -0,0 +1,39 @@
+// (c) 2019-2020, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package network
+
+import (
+	"net"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/ava-labs/avalanchego/utils"
+)
+
+func TestBoundDialerUsesLocalIP(t *testing.T) {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer listener.Close()
+
+	go func() {
+		conn, err := listener.Accept()
+		if err == nil {
+			_ = conn.Close()
+		}
+	}()
+
+	addr := listener.Addr().(*net.TCPAddr)
+	localIP := net.IPv4(127, 0, 0, 1)
+	dialer := NewBoundDialer("tcp", localIP)
+	conn, err := dialer.Dial(utils.IPDesc{IP: addr.IP, Port: uint16(addr.Port)})
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer conn.Close()
+
+	assert.True(t, conn.LocalAddr().(*net.TCPAddr).IP.Equal(localIP))
+}
@@ -0,0 +1,134 @@
+// (c) 2019-2020, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package network
+
+import (
+	"encoding/hex"
+	"encoding/json"
+	"flag"
+	"io/ioutil"
+	"net"
+	"path/filepath"
+	"reflect"
+	"testing"
+
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/utils"
+)
+
+// snapshotMessagesFile holds a snapshot of the serialized form of every
+// message. It's generated by this code, so it doesn't show that messages are
+// compatible with any release. It only makes changes to the wire format
+// visible: a change to this file should be reviewed as a wire format change.
+// Compatibility with the last release is checked against [releaseMessagesFile].
+var snapshotMessagesFile = filepath.Join("testdata", "messages.snapshot.json")
+
+var updateSnapshot = flag.Bool("update-snapshot", false, "overwrite the network message snapshot with the current serialization")
+
+// representativeMessages returns a builder of a representative instance of
+// every message that can be sent on the network
+func representativeMessages(b Builder) map[Op]func() (Msg, error) {
+	chainID := ids.ID{1}
+	containerID := ids.ID{2}
+	ip := utils.IPDesc{IP: net.IPv6loopback, Port: 9651}
+
+	return map[Op]func() (Msg, error){
+		GetVersion:          b.GetVersion,
+		Version:             func() (Msg, error) { return b.Version(12345, 1, 2, ip, "avalanche/1.0.0") },
+		GetPeerList:         b.GetPeerList,
+		PeerList:            func() (Msg, error) { return b.PeerList([]utils.IPDesc{ip, ip}) },
+		Ping:                b.Ping,
+		Pong:                b.Pong,
+		GetAcceptedFrontier: func() (Msg, error) { return b.GetAcceptedFrontier(chainID, 1, 2) },
+		AcceptedFrontier:    func() (Msg, error) { return b.AcceptedFrontier(chainID, 1, []ids.ID{containerID}) },
+		GetAccepted:         func() (Msg, error) { return b.GetAccepted(chainID, 1, 2, []ids.ID{containerID}) },
+		Accepted:            func() (Msg, error) { return b.Accepted(chainID, 1, []ids.ID{containerID}) },
+		GetAncestors:        func() (Msg, error) { return b.GetAncestors(chainID, 1, 2, containerID) },
+		MultiPut:            func() (Msg, error) { return b.MultiPut(chainID, 1, [][]byte{{3}, {4, 5}}) },
+		Get:                 func() (Msg, error) { return b.Get(chainID, 1, 2, containerID) },
+		Put:                 func() (Msg, error) { return b.Put(chainID, 1, containerID, []byte{3}) },
+		PushQuery:           func() (Msg, error) { return b.PushQuery(chainID, 1, 2, containerID, []byte{3}) },
+		PullQuery:           func() (Msg, error) { return b.PullQuery(chainID, 1, 2, containerID) },
+		Chits:               func() (Msg, error) { return b.Chits(chainID, 1, []ids.ID{containerID}) },
+		Reachability:        func() (Msg, error) { return b.Reachability([]byte{1, 2}) },
+	}
+}
+
+// TestMessagesSnapshot ensures that every message is serialized the same way
+// as in the snapshot and that the snapshot's messages still parse.
+//
+// After an intentional wire format change, regenerate the snapshot with:
+// go test ./network -run TestMessagesSnapshot -update-snapshot
+func TestMessagesSnapshot(t *testing.T) {
+	messages := representativeMessages(Builder{})
+	for op := range Messages {
+		if _, ok := messages[op]; !ok {
+			t.Fatalf("no representative %s message to check", op)
+		}
+	}
+
+	current := make(map[string]string, len(messages))
+	for op, build := range messages {
+		msg, err := build()
+		if err != nil {
+			t.Fatal(err)
+		}
+		current[op.String()] = hex.EncodeToString(msg.Bytes())
+	}
+
+	if *updateSnapshot {
+		snapshotBytes, err := json.MarshalIndent(current, "", "\t")
+		if err != nil {
+			t.Fatal(err)
+		}
+		if err := ioutil.WriteFile(snapshotMessagesFile, append(snapshotBytes, '\n'), 0600); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	snapshotBytes, err := ioutil.ReadFile(snapshotMessagesFile)
+	if err != nil {
+		t.Fatal(err)
+	}
+	snapshot := make(map[string]string)
+	if err := json.Unmarshal(snapshotBytes, &snapshot); err != nil {
+		t.Fatal(err)
+	}
+
+	for op, build := range messages {
+		name := op.String()
+		snapshotHex, ok := snapshot[name]
+		if !ok {
+			t.Errorf("%s message is missing from %s", name, snapshotMessagesFile)
+			continue
+		}
+		if current[name] != snapshotHex {
+			t.Errorf("%s message serialization changed:\nsnapshot: %s\ncurrent:  %s", name, snapshotHex, current[name])
+			continue
+		}
+
+		msgBytes, err := hex.DecodeString(snapshotHex)
+		if err != nil {
+			t.Fatal(err)
+		}
+		parsed, err := TestCodec.Parse(msgBytes)
+		if err != nil {
+			t.Errorf("couldn't parse snapshot %s message: %s", name, err)
+			continue
+		}
+		expected, err := build()
+		if err != nil {
+			t.Fatal(err)
+		}
+		if parsed.Op() != op {
+			t.Errorf("snapshot %s message parsed as %s", name, parsed.Op())
+			continue
+		}
+		for _, field := range Messages[op] {
+			if !reflect.DeepEqual(parsed.Get(field), expected.Get(field)) {
+				t.Errorf("snapshot %s message parsed %s as %v but expected %v", name, field, parsed.Get(field), expected.Get(field))
+			}
+		}
+	}
+}
@@ -9,6 +9,7 @@ import (
 	"github.com/prometheus/client_golang/prometheus"
 
 	"github.com/ava-labs/avalanchego/utils/constants"
+	"github.com/ava-labs/avalanchego/utils/timer"
 	"github.com/ava-labs/avalanchego/utils/wrappers"
 )
 
@@ -49,7 +50,26 @@ func (mm *messageMetrics) initialize(msgType Op, registerer prometheus.Registere
 }
 
 type metrics struct {
-	numPeers prometheus.Gauge
+	numPeers  prometheus.Gauge
+	malformed prometheus.Counter
+
+	// Distribution of stake across the primary network's validators
+	stakeGini, stakeTop1Share, stakeTop10Share, connectedStake prometheus.Gauge
+
+	// Portion of the stake reachable through this node's validator peers
+	reachableStake prometheus.Gauge
+
+	// Median number of seconds the peers' clocks are ahead of this node's
+	clockSkew prometheus.Gauge
+
+	// Connection handshakes, and how many of them resumed a previous TLS
+	// session rather than performing a full handshake
+	handshakeLatency                    prometheus.Histogram
+	handshakesResumed, handshakesFailed prometheus.Counter
+
+	// Gossiped containers whose serialized message was reused, and the bytes
+	// that didn't need to be serialized because of it
+	gossipMsgCacheHits, gossipBytesSaved prometheus.Counter
 
 	getVersion, version,
 	getPeerlist, peerlist,
@@ -57,7 +77,8 @@ type metrics struct {
 	getAcceptedFrontier, acceptedFrontier,
 	getAccepted, accepted,
 	get, getAncestors, put, multiPut,
-	pushQuery, pullQuery, chits messageMetrics
+	pushQuery, pullQuery, chits,
+	reachability messageMetrics
 }
 
 func (m *metrics) initialize(registerer prometheus.Registerer) error {
@@ -66,12 +87,114 @@ func (m *metrics) initialize(registerer prometheus.Registerer) error {
 		Name:      "peers",
 		Help:      "Number of network peers",
 	})
+	m.malformed = prometheus.NewCounter(prometheus.CounterOpts{
+		Namespace: constants.PlatformName,
+		Name:      "malformed_messages",
+		Help:      "Number of messages received from peers that failed to parse",
+	})
+
+	m.stakeGini = prometheus.NewGauge(prometheus.GaugeOpts{
+		Namespace: constants.PlatformName,
+		Name:      "stake_gini",
+		Help:      "Gini coefficient of the stake of the current validators, where 0 is an equal distribution",
+	})
+	m.stakeTop1Share = prometheus.NewGauge(prometheus.GaugeOpts{
+		Namespace: constants.PlatformName,
+		Name:      "stake_top_1_share",
+		Help:      "Portion of the total stake held by the largest validator",
+	})
+	m.stakeTop10Share = prometheus.NewGauge(prometheus.GaugeOpts{
+		Namespace: constants.PlatformName,
+		Name:      "stake_top_10_share",
+		Help:      "Portion of the total stake held by the 10 largest validators",
+	})
+	m.connectedStake = prometheus.NewGauge(prometheus.GaugeOpts{
+		Namespace: constants.PlatformName,
+		Name:      "connected_stake_fraction",
+		Help:      "Portion of the total stake held by validators this node is connected to, including itself",
+	})
+	m.reachableStake = prometheus.NewGauge(prometheus.GaugeOpts{
+		Namespace: constants.PlatformName,
+		Name:      "reachable_stake_fraction",
+		Help:      "Portion of the total stake held by validators this node is connected to or that its validator peers report being connected to",
+	})
+
+	m.clockSkew = prometheus.NewGauge(prometheus.GaugeOpts{
+		Namespace: constants.PlatformName,
+		Name:      "clock_skew",
+		Help:      "Median number of seconds the clocks of connected peers are ahead of this node's clock",
+	})
+
+	m.handshakeLatency = prometheus.NewHistogram(prometheus.HistogramOpts{
+		Namespace: constants.PlatformName,
+		Name:      "handshake_latency",
+		Help:      "Time spent upgrading a peer connection, including the TLS handshake, in milliseconds",
+		Buckets:   timer.MillisecondsBuckets,
+	})
+	m.handshakesResumed = prometheus.NewCounter(prometheus.CounterOpts{
+		Namespace: constants.PlatformName,
+		Name:      "handshakes_resumed",
+		Help:      "Number of peer connections that resumed a previous TLS session",
+	})
+	m.handshakesFailed = prometheus.NewCounter(prometheus.CounterOpts{
+		Namespace: constants.PlatformName,
+		Name:      "handshakes_failed",
+		Help:      "Number of peer connections that failed to be upgraded",
+	})
+
+	m.gossipMsgCacheHits = prometheus.NewCounter(prometheus.CounterOpts{
+		Namespace: constants.PlatformName,
+		Name:      "gossip_msg_cache_hits",
+		Help:      "Number of gossiped containers whose serialized message was reused from an earlier gossip",
+	})
+	m.gossipBytesSaved = prometheus.NewCounter(prometheus.CounterOpts{
+		Namespace: constants.PlatformName,
+		Name:      "gossip_bytes_saved",
+		Help:      "Number of bytes that didn't need to be serialized because a gossiped container's serialized message was reused from an earlier gossip",
+	})
 
 	errs := wrappers.Errs{}
+	for name, gauge := range map[string]prometheus.Gauge{
+		"stake gini":               m.stakeGini,
+		"stake top 1 share":        m.stakeTop1Share,
+		"stake top 10 share":       m.stakeTop10Share,
+		"connected stake fraction": m.connectedStake,
+		"reachable stake fraction": m.reachableStake,
+		"clock skew":               m.clockSkew,
+	} {
+		if err := registerer.Register(gauge); err != nil {
+			errs.Add(fmt.Errorf("failed to register %s statistics due to %s",
+				name, err))
+		}
+	}
 	if err := registerer.Register(m.numPeers); err != nil {
 		errs.Add(fmt.Errorf("failed to register peers statistics due to %s",
 			err))
 	}
+	if err := registerer.Register(m.malformed); err != nil {
+		errs.Add(fmt.Errorf("failed to register malformed messages statistics due to %s",
+			err))
+	}
+	if err := registerer.Register(m.handshakeLatency); err != nil {
+		errs.Add(fmt.Errorf("failed to register handshake latency statistics due to %s",
+			err))
+	}
+	if err := registerer.Register(m.handshakesResumed); err != nil {
+		errs.Add(fmt.Errorf("failed to register resumed handshakes statistics due to %s",
+			err))
+	}
+	if err := registerer.Register(m.handshakesFailed); err != nil {
+		errs.Add(fmt.Errorf("failed to register failed handshakes statistics due to %s",
+			err))
+	}
+	if err := registerer.Register(m.gossipMsgCacheHits); err != nil {
+		errs.Add(fmt.Errorf("failed to register gossip message cache hits statistics due to %s",
+			err))
+	}
+	if err := registerer.Register(m.gossipBytesSaved); err != nil {
+		errs.Add(fmt.Errorf("failed to register gossip bytes saved statistics due to %s",
+			err))
+	}
 	errs.Add(
 		m.getVersion.initialize(GetVersion, registerer),
 		m.version.initialize(Version, registerer),
@@ -90,6 +213,7 @@ func (m *metrics) initialize(registerer prometheus.Registerer) error {
 		m.pushQuery.initialize(PushQuery, registerer),
 		m.pullQuery.initialize(PullQuery, registerer),
 		m.chits.initialize(Chits, registerer),
+		m.reachability.initialize(Reachability, registerer),
 	)
 	return errs.Err
 }
@@ -130,6 +254,8 @@ func (m *metrics) message(msgType Op) *messageMetrics {
 		return &m.pullQuery
 	case Chits:
 		return &m.chits
+	case Reachability:
+		return &m.reachability
 	default:
 		return nil
 	}
@@ -4,6 +4,7 @@
 package network
 
 import (
+	"crypto/tls"
 	"errors"
 	"fmt"
 	"math/rand"
@@ -15,6 +16,7 @@ import (
 	"github.com/prometheus/client_golang/prometheus"
 
 	"github.com/ava-labs/avalanchego/api/health"
+	"github.com/ava-labs/avalanchego/cache"
 	"github.com/ava-labs/avalanchego/ids"
 	"github.com/ava-labs/avalanchego/snow"
 	"github.com/ava-labs/avalanchego/snow/networking/router"
@@ -24,6 +26,8 @@ import (
 	"github.com/ava-labs/avalanchego/utils"
 	"github.com/ava-labs/avalanchego/utils/constants"
 	"github.com/ava-labs/avalanchego/utils/formatting"
+	"github.com/ava-labs/avalanchego/utils/hashing"
+	"github.com/ava-labs/avalanchego/utils/json"
 	"github.com/ava-labs/avalanchego/utils/logging"
 	"github.com/ava-labs/avalanchego/utils/sampler"
 	"github.com/ava-labs/avalanchego/utils/timer"
@@ -50,6 +54,7 @@ const (
 	defaultReadBufferSize                            = 16 * 1024
 	defaultReadHandshakeTimeout                      = 15 * time.Second
 	defaultConnMeterCacheSize                        = 10000
+	defaultGossipMsgCacheSize                        = 64
 )
 
 var (
@@ -93,6 +98,29 @@ type Network interface {
 
 	// Return the IP of the node
 	IP() utils.IPDesc
+
+	// Returns the most recent malformed messages received from peers, oldest
+	// first. Thread safety must be managed internally to the network.
+	MalformedMessages() []MalformedMessage
+
+	// Returns the portion of the stake held by validators that this node is
+	// connected to, or that a connected validator reports being connected to.
+	// A low value indicates that this node may be partitioned from the rest
+	// of the validators. Thread safety must be managed internally to the
+	// network.
+	ReachableStake() float64
+
+	// Returns the median of how far the clocks of the connected peers were
+	// ahead of this node's clock when they connected, with a resolution of a
+	// second. A large value in either direction indicates that this node's
+	// clock has drifted. Thread safety must be managed internally to the
+	// network.
+	ClockSkew() time.Duration
+
+	// Sets the number of peers each container of the chain [chainID] is
+	// gossiped to. If [size] is 0, the default is used. Thread safety must be
+	// managed internally to the network.
+	SetGossipSize(chainID ids.ID, size int)
 }
 
 type network struct {
@@ -138,10 +166,21 @@ type network struct {
 	connMeterMaxConns                  int
 	connMeter                          ConnMeter
 
+	// malformed messages received from peers
+	quarantine quarantine
+
+	// Reachability message sent along with Pongs, or nil if this node isn't a
+	// validator
+	reachabilityMsg utils.AtomicInterface
+
 	executor timer.Executor
 
 	b Builder
 
+	// Serialized Put messages that gossip recently gossiped containers, so
+	// that gossiping a container again doesn't serialize it again
+	gossipMsgs cache.LRU
+
 	// stateLock should never be held when grabbing a peer lock
 	stateLock sync.RWMutex
 
@@ -153,6 +192,10 @@ type network struct {
 	// TODO: bound the size of [myIPs] to avoid DoS. LRU caching would be ideal
 	myIPs map[string]struct{} // set of IPs that resulted in my ID.
 	peers map[[20]byte]*peer
+
+	// Chain ID --> Number of peers each of the chain's containers is gossiped
+	// to, if it isn't [gossipSize]
+	chainGossipSizes map[ids.ID]int
 }
 
 // NewDefaultNetwork returns a new Network implementation with the provided
@@ -289,14 +332,17 @@ func NewNetwork(
 		retryDelay:                         make(map[string]time.Duration),
 		myIPs:                              map[string]struct{}{ip.IP().String(): {}},
 		peers:                              make(map[[20]byte]*peer),
+		chainGossipSizes:                   make(map[ids.ID]int),
 		readBufferSize:                     readBufferSize,
 		readHandshakeTimeout:               readHandshakeTimeout,
 		connMeter:                          NewConnMeter(connMeterResetDuration, connMeterCacheSize),
 		connMeterMaxConns:                  connMeterMaxConns,
+		gossipMsgs:                         cache.LRU{Size: defaultGossipMsgCacheSize},
 	}
 	if err := netw.initialize(registerer); err != nil {
 		log.Warn("initializing network metrics failed with: %s", err)
 	}
+	netw.quarantine.initialize(defaultQuarantineSize, defaultQuarantineRate, defaultQuarantineMaxBytes, defaultQuarantinePeriod)
 	netw.executor.Initialize()
 	go netw.executor.Dispatch()
 	netw.heartbeat()
@@ -682,16 +728,21 @@ func (n *network) Peers() []PeerID {
 			peers = append(peers, PeerID{
 				IP:           peer.conn.RemoteAddr().String(),
 				PublicIP:     peer.getIP().String(),
-				ID:           peer.id.PrefixedString(constants.NodeIDPrefix),
+				ID:           ids.NodeID(peer.id).String(),
 				Version:      peer.versionStr.GetValue().(string),
 				LastSent:     time.Unix(atomic.LoadInt64(&peer.lastSent), 0),
 				LastReceived: time.Unix(atomic.LoadInt64(&peer.lastReceived), 0),
+				ConnectedAt:  time.Unix(atomic.LoadInt64(&peer.connectedTime), 0),
+				Latency:      json.Uint64(time.Duration(atomic.LoadInt64(&peer.latency)) / time.Millisecond),
 			})
 		}
 	}
 	return peers
 }
 
+// MalformedMessages implements the Network interface
+func (n *network) MalformedMessages() []MalformedMessage { return n.quarantine.list() }
+
 // Close implements the Network interface
 // assumes the stateLock is not held.
 func (n *network) Close() error {
@@ -741,14 +792,14 @@ func (n *network) IP() utils.IPDesc {
 
 // assumes the stateLock is not held.
 func (n *network) gossipContainer(chainID, containerID ids.ID, container []byte) error {
-	msg, err := n.b.Put(chainID, constants.GossipMsgRequestID, containerID, container)
+	msg, _, err := n.gossipMsg(chainID, containerID, container)
 	if err != nil {
 		return fmt.Errorf("attempted to pack too large of a Put message.\nContainer length: %d", len(container))
 	}
 
 	allPeers := n.getAllPeers()
 
-	numToGossip := n.gossipSize
+	numToGossip := n.chainGossipSize(chainID)
 	if numToGossip > len(allPeers) {
 		numToGossip = len(allPeers)
 	}
@@ -771,6 +822,52 @@ func (n *network) gossipContainer(chainID, containerID ids.ID, container []byte)
 	return nil
 }
 
+// SetGossipSize implements the Network interface
+// assumes the stateLock is not held.
+func (n *network) SetGossipSize(chainID ids.ID, size int) {
+	n.stateLock.Lock()
+	defer n.stateLock.Unlock()
+
+	if size == 0 {
+		delete(n.chainGossipSizes, chainID)
+	} else {
+		n.chainGossipSizes[chainID] = size
+	}
+}
+
+// chainGossipSize returns the number of peers each container of the chain
+// [chainID] is gossiped to
+// assumes the stateLock is not held.
+func (n *network) chainGossipSize(chainID ids.ID) int {
+	n.stateLock.RLock()
+	defer n.stateLock.RUnlock()
+
+	if size, ok := n.chainGossipSizes[chainID]; ok {
+		return size
+	}
+	return n.gossipSize
+}
+
+// gossipMsg returns the Put message that gossips [container], which is only
+// serialized if it wasn't recently gossiped. Returns true if the message was
+// cached.
+func (n *network) gossipMsg(chainID, containerID ids.ID, container []byte) (Msg, bool, error) {
+	key := hashing.ComputeHash256Array(append(chainID[:], containerID[:]...))
+	if cached, ok := n.gossipMsgs.Get(key); ok {
+		msg := cached.(Msg)
+		n.gossipMsgCacheHits.Inc()
+		n.gossipBytesSaved.Add(float64(len(msg.Bytes())))
+		return msg, true, nil
+	}
+
+	msg, err := n.b.Put(chainID, constants.GossipMsgRequestID, containerID, container)
+	if err != nil {
+		return nil, false, err
+	}
+	n.gossipMsgs.Put(key, msg)
+	return msg, false, nil
+}
+
 // assumes the stateLock is held.
 func (n *network) track(ip utils.IPDesc) {
 	if n.closed.GetValue() {
@@ -802,6 +899,10 @@ func (n *network) gossip() {
 			return
 		}
 
+		n.updateStakeMetrics()
+		n.updateReachability()
+		n.updateClockSkew()
+
 		allPeers := n.getAllPeers()
 		if len(allPeers) == 0 {
 			continue
@@ -974,12 +1075,18 @@ func (n *network) upgrade(p *peer, upgrader Upgrader) error {
 		return err
 	}
 
+	startTime := n.clock.Time()
 	id, conn, err := upgrader.Upgrade(p.conn)
 	if err != nil {
+		n.handshakesFailed.Inc()
 		_ = p.conn.Close()
 		n.log.Verbo("failed to upgrade connection with %s", err)
 		return err
 	}
+	n.handshakeLatency.Observe(float64(n.clock.Time().Sub(startTime).Milliseconds()))
+	if tlsConn, ok := conn.(*tls.Conn); ok && tlsConn.ConnectionState().DidResume {
+		n.handshakesResumed.Inc()
+	}
 
 	if err := conn.SetReadDeadline(time.Time{}); err != nil {
 		_ = p.conn.Close()
@@ -1040,7 +1147,7 @@ func (n *network) tryAddPeer(p *peer) error {
 			delete(n.disconnectedIPs, str)
 			delete(n.retryDelay, str)
 		}
-		return fmt.Errorf("duplicated connection from %s at %s", p.id.PrefixedString(constants.NodeIDPrefix), ip)
+		return fmt.Errorf("duplicated connection from %s at %s", ids.NodeID(p.id).String(), ip)
 	}
 
 	n.peers[key] = p
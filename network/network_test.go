@@ -901,3 +901,85 @@ func TestTrackConnectedRace(t *testing.T) {
 	err = net1.Close()
 	assert.NoError(t, err)
 }
+
+func TestGossipMsgReuse(t *testing.T) {
+	log := logging.NoLog{}
+	ip := utils.NewDynamicIPDesc(
+		net.IPv6loopback,
+		0,
+	)
+	id := ids.NewShortID(hashing.ComputeHash160Array([]byte(ip.IP().String())))
+	listener := &testListener{
+		addr: &net.TCPAddr{
+			IP:   net.IPv6loopback,
+			Port: 0,
+		},
+		inbound: make(chan net.Conn, 1<<10),
+		closed:  make(chan struct{}),
+	}
+	caller := &testDialer{
+		addr: &net.TCPAddr{
+			IP:   net.IPv6loopback,
+			Port: 0,
+		},
+		outbounds: make(map[string]*testListener),
+	}
+	vdrs := validators.NewSet()
+	registry := prometheus.NewRegistry()
+
+	netw := NewDefaultNetwork(
+		registry,
+		log,
+		id,
+		ip,
+		0,
+		version.NewDefaultVersion("app", 0, 1, 0),
+		version.NewDefaultParser(),
+		listener,
+		caller,
+		NewIPUpgrader(),
+		NewIPUpgrader(),
+		vdrs,
+		vdrs,
+		&testHandler{},
+		time.Duration(0),
+		0,
+	).(*network)
+	defer func() {
+		assert.NoError(t, netw.Close())
+	}()
+
+	chainID := ids.GenerateTestID()
+	containerID := ids.GenerateTestID()
+	container := []byte{1, 2, 3}
+
+	msg, cached, err := netw.gossipMsg(chainID, containerID, container)
+	assert.NoError(t, err)
+	assert.False(t, cached)
+
+	reusedMsg, cached, err := netw.gossipMsg(chainID, containerID, container)
+	assert.NoError(t, err)
+	assert.True(t, cached)
+	assert.Equal(t, msg, reusedMsg)
+
+	// Gossiping the same container on another chain needs a different message
+	_, cached, err = netw.gossipMsg(ids.GenerateTestID(), containerID, container)
+	assert.NoError(t, err)
+	assert.False(t, cached)
+
+	// Without peers, nothing is sent
+	assert.NoError(t, netw.gossipContainer(chainID, containerID, container))
+
+	// Only the serializations skipped by reusing a cached message are counted,
+	// once per gossip regardless of the number of peers
+	metrics, err := registry.Gather()
+	assert.NoError(t, err)
+	counts := make(map[string]float64)
+	for _, metric := range metrics {
+		if counter := metric.GetMetric()[0].GetCounter(); counter != nil {
+			counts[metric.GetName()] = counter.GetValue()
+		}
+	}
+	assert.Equal(t, 2.0, counts["avalanche_gossip_msg_cache_hits"])
+	assert.Equal(t, float64(2*len(msg.Bytes())), counts["avalanche_gossip_bytes_saved"])
+}
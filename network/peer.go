@@ -61,9 +61,27 @@ type peer struct {
 	// version that the peer reported during the handshake
 	versionStr utils.AtomicInterface
 
+	// if the peer is able to parse Reachability messages
+	supportsReachability utils.AtomicBool
+
+	// the most recent reachability filter reported by this peer, if it is a
+	// validator
+	reachableFilter utils.AtomicInterface
+
 	// unix time of the last message sent and received respectively
 	lastSent, lastReceived int64
 
+	// unix time that the connection was marked as connected
+	connectedTime int64
+
+	// unix time, in nanoseconds, of the last ping sent and the most recently
+	// observed ping round trip time, in nanoseconds
+	lastPingSent, latency int64
+
+	// seconds the peer's clock was ahead of this node's clock during the
+	// handshake. Negative if the peer's clock was behind.
+	clockOffset int64
+
 	tickerCloser chan struct{}
 
 	// ticker processes
@@ -190,10 +208,13 @@ func (p *peer) ReadMessages() {
 
 		msg, err := p.net.b.Parse(msgBytes)
 		if err != nil {
-			p.net.log.Debug("failed to parse new message from %s:\n%s\n%s",
-				p.id,
-				formatting.DumpBytes{Bytes: msgBytes},
-				err)
+			p.net.malformed.Inc()
+			if p.net.quarantine.record(p.net.clock.Time(), p.id, p.getIP().String(), msgBytes, err) {
+				p.net.log.Debug("failed to parse new message from %s:\n%s\n%s",
+					p.id,
+					formatting.DumpBytes{Bytes: msgBytes},
+					err)
+			}
 			return
 		}
 
@@ -353,6 +374,8 @@ func (p *peer) handle(msg Msg) {
 		p.pullQuery(msg)
 	case Chits:
 		p.chits(msg)
+	case Reachability:
+		p.reachability(msg)
 	default:
 		p.net.log.Debug("dropping an unknown message from %s with op %s", p.id, op.String())
 	}
@@ -442,6 +465,7 @@ func (p *peer) PeerList(peers []utils.IPDesc) {
 func (p *peer) Ping() {
 	msg, err := p.net.b.Ping()
 	p.net.log.AssertNoError(err)
+	atomic.StoreInt64(&p.lastPingSent, p.net.clock.Time().UnixNano())
 	if p.Send(msg) {
 		p.net.ping.numSent.Inc()
 	} else {
@@ -458,6 +482,17 @@ func (p *peer) Pong() {
 	} else {
 		p.net.pong.numFailed.Inc()
 	}
+
+	if !p.supportsReachability.GetValue() {
+		return
+	}
+	if msg, ok := p.net.reachabilityMsg.GetValue().(Msg); ok {
+		if p.Send(msg) {
+			p.net.reachability.numSent.Inc()
+		} else {
+			p.net.reachability.numFailed.Inc()
+		}
+	}
 }
 
 // assumes the stateLock is not held
@@ -487,7 +522,8 @@ func (p *peer) version(msg Msg) {
 	}
 
 	myTime := float64(p.net.clock.Unix())
-	if peerTime := float64(msg.Get(MyTime).(uint64)); math.Abs(peerTime-myTime) > p.net.maxClockDifference.Seconds() {
+	peerTime := float64(msg.Get(MyTime).(uint64))
+	if math.Abs(peerTime-myTime) > p.net.maxClockDifference.Seconds() {
 		if p.net.beacons.Contains(p.id) {
 			p.net.log.Warn("beacon %s has a clock that is too far out of sync with mine. Peer's = %d, Ours = %d (seconds)",
 				p.id,
@@ -557,6 +593,8 @@ func (p *peer) version(msg Msg) {
 	p.SendPeerList()
 
 	p.versionStr.SetValue(peerVersion.String())
+	p.supportsReachability.SetValue(supportsReachability(peerVersion))
+	atomic.StoreInt64(&p.clockOffset, int64(peerTime-myTime))
 	p.gotVersion.SetValue(true)
 
 	p.tryMarkConnected()
@@ -592,7 +630,28 @@ func (p *peer) peerList(msg Msg) {
 func (p *peer) ping(_ Msg) { p.Pong() }
 
 // assumes the stateLock is not held
-func (p *peer) pong(_ Msg) {}
+func (p *peer) pong(_ Msg) {
+	// Pongs aren't matched to pings, so this is only an estimate if a pong is
+	// delayed past the next ping
+	if lastPingSent := atomic.LoadInt64(&p.lastPingSent); lastPingSent != 0 {
+		atomic.StoreInt64(&p.latency, p.net.clock.Time().UnixNano()-lastPingSent)
+	}
+}
+
+// assumes the stateLock is not held
+func (p *peer) reachability(msg Msg) {
+	if !p.net.vdrs.Contains(p.id) {
+		p.net.log.Verbo("dropping reachability message from non-validator %s", p.id)
+		return
+	}
+
+	filter := msg.Get(ReachableFilter).([]byte)
+	if len(filter) != reachabilityFilterSize {
+		p.net.log.Debug("dropping reachability message from %s with filter of length %d", p.id, len(filter))
+		return
+	}
+	p.reachableFilter.SetValue(filter)
+}
 
 // assumes the stateLock is not held
 func (p *peer) getAcceptedFrontier(msg Msg) {
@@ -786,6 +845,7 @@ func (p *peer) tryMarkConnected() {
 		p.gotPeerList.GetValue() && // not waiting for peerlist
 		!p.closed.GetValue() { // and not already disconnected
 
+		atomic.StoreInt64(&p.connectedTime, p.net.clock.Time().Unix())
 		p.connected.SetValue(true)
 		p.net.connected(p)
 	}
@@ -5,6 +5,8 @@ package network
 
 import (
 	"time"
+
+	"github.com/ava-labs/avalanchego/utils/json"
 )
 
 // PeerID ...
@@ -15,4 +17,7 @@ type PeerID struct {
 	Version      string    `json:"version"`
 	LastSent     time.Time `json:"lastSent"`
 	LastReceived time.Time `json:"lastReceived"`
+	ConnectedAt  time.Time `json:"connectedAt"`
+	// Most recently observed ping round trip time, in milliseconds
+	Latency json.Uint64 `json:"latency"`
 }
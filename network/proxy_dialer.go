@@ -0,0 +1,181 @@
+// (c) 2019-2020, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package network
+
+import (
+	"bufio"
+	"context"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"net/url"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"golang.org/x/net/proxy"
+
+	"github.com/ava-labs/avalanchego/utils"
+	"github.com/ava-labs/avalanchego/utils/constants"
+)
+
+const (
+	// Time allowed to connect to the proxy and for the proxy to connect to the
+	// peer, including the SOCKS or CONNECT exchange
+	defaultProxyDialTimeout = 30 * time.Second
+)
+
+var (
+	errUnknownProxyScheme = errors.New("proxy scheme must be socks5 or http")
+)
+
+type proxyDialer struct {
+	network string
+	dialer  proxy.ContextDialer
+	timeout time.Duration
+
+	numDials, numFailed prometheus.Counter
+}
+
+// NewProxyDialer returns a new Dialer that makes connections to peers through
+// the proxy at [proxyURL]. Supported schemes are socks5 and http, which uses
+// HTTP CONNECT. Credentials can be provided in the URL's user info.
+// Connections to the proxy are made from [localIP] if it is non-nil.
+func NewProxyDialer(
+	network string,
+	localIP net.IP,
+	proxyURL *url.URL,
+	registerer prometheus.Registerer,
+) (Dialer, error) {
+	forward := &net.Dialer{}
+	if localIP != nil {
+		forward.LocalAddr = &net.TCPAddr{IP: localIP}
+	}
+
+	d := &proxyDialer{
+		network: network,
+		timeout: defaultProxyDialTimeout,
+	}
+	switch proxyURL.Scheme {
+	case "socks5":
+		socksDialer, err := proxy.FromURL(proxyURL, forward)
+		if err != nil {
+			return nil, err
+		}
+		contextDialer, ok := socksDialer.(proxy.ContextDialer)
+		if !ok {
+			return nil, fmt.Errorf("socks5 dialer %T doesn't support timeouts", socksDialer)
+		}
+		d.dialer = contextDialer
+	case "http":
+		d.dialer = &httpConnectDialer{
+			network:   network,
+			proxyAddr: proxyURL.Host,
+			user:      proxyURL.User,
+			forward:   forward,
+		}
+	default:
+		return nil, fmt.Errorf("%w but is %q", errUnknownProxyScheme, proxyURL.Scheme)
+	}
+
+	d.numDials = prometheus.NewCounter(prometheus.CounterOpts{
+		Namespace: constants.PlatformName,
+		Name:      "proxy_dials",
+		Help:      "Number of outbound connections attempted through the proxy",
+	})
+	d.numFailed = prometheus.NewCounter(prometheus.CounterOpts{
+		Namespace: constants.PlatformName,
+		Name:      "proxy_dial_failures",
+		Help:      "Number of outbound connections through the proxy that failed",
+	})
+	if err := registerer.Register(d.numDials); err != nil {
+		return nil, fmt.Errorf("failed to register proxy dials statistics due to %w", err)
+	}
+	if err := registerer.Register(d.numFailed); err != nil {
+		return nil, fmt.Errorf("failed to register proxy dial failures statistics due to %w", err)
+	}
+	return d, nil
+}
+
+func (d *proxyDialer) Dial(ip utils.IPDesc) (net.Conn, error) {
+	d.numDials.Inc()
+	ctx, cancel := context.WithTimeout(context.Background(), d.timeout)
+	defer cancel()
+	conn, err := d.dialer.DialContext(ctx, d.network, ip.String())
+	if err != nil {
+		d.numFailed.Inc()
+	}
+	return conn, err
+}
+
+// httpConnectDialer tunnels connections through an HTTP proxy using the
+// CONNECT method
+type httpConnectDialer struct {
+	network   string
+	proxyAddr string
+	user      *url.Userinfo
+	forward   *net.Dialer
+}
+
+func (d *httpConnectDialer) DialContext(ctx context.Context, _, addr string) (net.Conn, error) {
+	conn, err := d.forward.DialContext(ctx, d.network, d.proxyAddr)
+	if err != nil {
+		return nil, err
+	}
+	// Don't wait forever on a proxy that accepted the connection but never
+	// answers the CONNECT request
+	if deadline, ok := ctx.Deadline(); ok {
+		if err := conn.SetDeadline(deadline); err != nil {
+			_ = conn.Close()
+			return nil, err
+		}
+	}
+
+	req := &http.Request{
+		Method: http.MethodConnect,
+		URL:    &url.URL{Opaque: addr},
+		Host:   addr,
+		Header: make(http.Header),
+	}
+	if d.user != nil {
+		password, _ := d.user.Password()
+		credentials := base64.StdEncoding.EncodeToString([]byte(d.user.Username() + ":" + password))
+		req.Header.Set("Proxy-Authorization", "Basic "+credentials)
+	}
+	if err := req.Write(conn); err != nil {
+		_ = conn.Close()
+		return nil, err
+	}
+
+	reader := bufio.NewReader(conn)
+	resp, err := http.ReadResponse(reader, req)
+	if err != nil {
+		_ = conn.Close()
+		return nil, err
+	}
+	_ = resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		_ = conn.Close()
+		return nil, fmt.Errorf("proxy refused connection to %s: %s", addr, resp.Status)
+	}
+	if err := conn.SetDeadline(time.Time{}); err != nil {
+		_ = conn.Close()
+		return nil, err
+	}
+
+	// The peer doesn't speak before we do, but don't drop anything the proxy
+	// forwarded along with its response.
+	if reader.Buffered() > 0 {
+		return &bufferedConn{Conn: conn, reader: reader}, nil
+	}
+	return conn, nil
+}
+
+type bufferedConn struct {
+	net.Conn
+	reader *bufio.Reader
+}
+
+func (c *bufferedConn) Read(b []byte) (int, error) { return c.reader.Read(b) }
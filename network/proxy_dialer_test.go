@@ -0,0 +1,183 @@
+// (c) 2019-2020, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package network
+
+import (
+	"bufio"
+	"io"
+	"net"
+	"net/http"
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/ava-labs/avalanchego/utils"
+)
+
+// startConnectProxy starts an HTTP CONNECT proxy that only accepts requests
+// authorized as [user]:[password]
+func startConnectProxy(t *testing.T, user, password string) net.Listener {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	go func() {
+		for {
+			conn, err := listener.Accept()
+			if err != nil {
+				return
+			}
+			go func() {
+				defer conn.Close()
+
+				req, err := http.ReadRequest(bufio.NewReader(conn))
+				if err != nil {
+					return
+				}
+				if u, p, ok := (&http.Request{Header: http.Header{"Authorization": req.Header["Proxy-Authorization"]}}).BasicAuth(); !ok || u != user || p != password {
+					_, _ = io.WriteString(conn, "HTTP/1.1 407 Proxy Authentication Required\r\n\r\n")
+					return
+				}
+
+				target, err := net.Dial("tcp", req.Host)
+				if err != nil {
+					_, _ = io.WriteString(conn, "HTTP/1.1 502 Bad Gateway\r\n\r\n")
+					return
+				}
+				defer target.Close()
+
+				_, _ = io.WriteString(conn, "HTTP/1.1 200 Connection Established\r\n\r\n")
+				go func() { _, _ = io.Copy(target, conn) }()
+				_, _ = io.Copy(conn, target)
+			}()
+		}
+	}()
+	return listener
+}
+
+func TestProxyDialerHTTPConnect(t *testing.T) {
+	target, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer target.Close()
+
+	received := make(chan []byte, 1)
+	go func() {
+		conn, err := target.Accept()
+		if err != nil {
+			return
+		}
+		defer conn.Close()
+
+		msg := make([]byte, 5)
+		if _, err := io.ReadFull(conn, msg); err == nil {
+			received <- msg
+		}
+	}()
+
+	proxyListener := startConnectProxy(t, "user", "password")
+	defer proxyListener.Close()
+
+	proxyURL := &url.URL{
+		Scheme: "http",
+		Host:   proxyListener.Addr().String(),
+		User:   url.UserPassword("user", "password"),
+	}
+	dialer, err := NewProxyDialer("tcp", nil, proxyURL, prometheus.NewRegistry())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	addr := target.Addr().(*net.TCPAddr)
+	conn, err := dialer.Dial(utils.IPDesc{IP: addr.IP, Port: uint16(addr.Port)})
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer conn.Close()
+
+	if _, err := conn.Write([]byte("hello")); err != nil {
+		t.Fatal(err)
+	}
+	assert.Equal(t, []byte("hello"), <-received)
+}
+
+func TestProxyDialerHTTPConnectRefused(t *testing.T) {
+	proxyListener := startConnectProxy(t, "user", "password")
+	defer proxyListener.Close()
+
+	proxyURL := &url.URL{
+		Scheme: "http",
+		Host:   proxyListener.Addr().String(),
+		User:   url.UserPassword("user", "wrong"),
+	}
+	registry := prometheus.NewRegistry()
+	dialer, err := NewProxyDialer("tcp", nil, proxyURL, registry)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	_, err = dialer.Dial(utils.IPDesc{IP: net.IPv4(127, 0, 0, 1), Port: 1})
+	assert.Error(t, err)
+
+	metrics, err := registry.Gather()
+	if err != nil {
+		t.Fatal(err)
+	}
+	counts := make(map[string]float64)
+	for _, metric := range metrics {
+		counts[metric.GetName()] = metric.GetMetric()[0].GetCounter().GetValue()
+	}
+	assert.Equal(t, 1.0, counts["avalanche_proxy_dials"])
+	assert.Equal(t, 1.0, counts["avalanche_proxy_dial_failures"])
+}
+
+func TestProxyDialerHTTPConnectTimeout(t *testing.T) {
+	// The proxy accepts connections but never answers the CONNECT request
+	proxyListener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer proxyListener.Close()
+	go func() {
+		for {
+			conn, err := proxyListener.Accept()
+			if err != nil {
+				return
+			}
+			defer conn.Close()
+		}
+	}()
+
+	proxyURL := &url.URL{
+		Scheme: "http",
+		Host:   proxyListener.Addr().String(),
+	}
+	dialer, err := NewProxyDialer("tcp", nil, proxyURL, prometheus.NewRegistry())
+	if err != nil {
+		t.Fatal(err)
+	}
+	dialer.(*proxyDialer).timeout = 100 * time.Millisecond
+
+	done := make(chan error, 1)
+	go func() {
+		_, err := dialer.Dial(utils.IPDesc{IP: net.IPv4(127, 0, 0, 1), Port: 1})
+		done <- err
+	}()
+	select {
+	case err := <-done:
+		assert.Error(t, err)
+	case <-time.After(5 * time.Second):
+		t.Fatal("dial through an unresponsive proxy didn't time out")
+	}
+}
+
+func TestProxyDialerUnknownScheme(t *testing.T) {
+	_, err := NewProxyDialer("tcp", nil, &url.URL{Scheme: "ftp", Host: "127.0.0.1:21"}, prometheus.NewRegistry())
+	assert.Error(t, err)
+}
@@ -0,0 +1,117 @@
+// (c) 2019-2020, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package network
+
+import (
+	"sync"
+	"time"
+
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/utils/formatting"
+)
+
+// reasonable default values
+const (
+	defaultQuarantineSize     = 64
+	defaultQuarantinePeriod   = time.Minute
+	defaultQuarantineRate     = 16
+	defaultQuarantineMaxBytes = 1024
+)
+
+// MalformedMessage describes an inbound message that couldn't be parsed
+type MalformedMessage struct {
+	NodeID    string    `json:"nodeID"`
+	IP        string    `json:"ip"`
+	Received  time.Time `json:"received"`
+	Length    int       `json:"length"`
+	Bytes     string    `json:"bytes"`
+	Truncated bool      `json:"truncated"`
+	Error     string    `json:"error"`
+}
+
+// quarantine keeps the most recent malformed messages received from peers so
+// that they can be analyzed later. Because the messages are controlled by
+// potentially malicious peers, only [maxBytes] of each message are kept and at
+// most [rate] messages are recorded every [period]. Messages that arrive once
+// the rate limit has been reached are only counted.
+type quarantine struct {
+	lock sync.Mutex
+
+	size, rate, maxBytes int
+	period               time.Duration
+
+	// records is a ring buffer of the most recently recorded messages
+	records []MalformedMessage
+	next    int
+
+	windowStart time.Time
+	recorded    int
+}
+
+func (q *quarantine) initialize(size, rate, maxBytes int, period time.Duration) {
+	q.size = size
+	q.rate = rate
+	q.maxBytes = maxBytes
+	q.period = period
+	q.records = make([]MalformedMessage, 0, size)
+}
+
+// record [msgBytes] that were received from [nodeID] at [ip] and failed to
+// parse with [err]. Returns true if the message was recorded, false if it was
+// dropped due to rate limiting.
+func (q *quarantine) record(now time.Time, nodeID ids.ShortID, ip string, msgBytes []byte, err error) bool {
+	q.lock.Lock()
+	defer q.lock.Unlock()
+
+	if q.size <= 0 {
+		return false
+	}
+
+	if now.Sub(q.windowStart) >= q.period {
+		q.windowStart = now
+		q.recorded = 0
+	}
+	if q.recorded >= q.rate {
+		return false
+	}
+	q.recorded++
+
+	length := len(msgBytes)
+	truncated := length > q.maxBytes
+	if truncated {
+		msgBytes = msgBytes[:q.maxBytes]
+	}
+	record := MalformedMessage{
+		NodeID:    ids.NodeID(nodeID).String(),
+		IP:        ip,
+		Received:  now,
+		Length:    length,
+		Bytes:     formatting.DumpBytes{Bytes: msgBytes}.String(),
+		Truncated: truncated,
+		Error:     err.Error(),
+	}
+
+	if len(q.records) < q.size {
+		q.records = append(q.records, record)
+	} else {
+		q.records[q.next] = record
+	}
+	q.next = (q.next + 1) % q.size
+	return true
+}
+
+// list returns the recorded messages, oldest first
+func (q *quarantine) list() []MalformedMessage {
+	q.lock.Lock()
+	defer q.lock.Unlock()
+
+	records := make([]MalformedMessage, 0, len(q.records))
+	if len(q.records) == q.size {
+		records = append(records, q.records[q.next:]...)
+		records = append(records, q.records[:q.next]...)
+	} else {
+		records = append(records, q.records...)
+	}
+	return records
+}
@@ -0,0 +1,58 @@
+// (c) 2019-2020, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package network
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/ava-labs/avalanchego/ids"
+)
+
+func TestQuarantineRateLimits(t *testing.T) {
+	q := quarantine{}
+	q.initialize(10, 2, 4, time.Minute)
+
+	now := time.Unix(1000, 0)
+	errParse := errors.New("parse error")
+
+	assert.True(t, q.record(now, ids.ShortEmpty, "127.0.0.1:9651", []byte{1}, errParse))
+	assert.True(t, q.record(now, ids.ShortEmpty, "127.0.0.1:9651", []byte{2}, errParse))
+	assert.False(t, q.record(now.Add(time.Second), ids.ShortEmpty, "127.0.0.1:9651", []byte{3}, errParse))
+	assert.Len(t, q.list(), 2)
+
+	// A new period allows more messages to be recorded
+	assert.True(t, q.record(now.Add(time.Minute), ids.ShortEmpty, "127.0.0.1:9651", []byte{4}, errParse))
+	assert.Len(t, q.list(), 3)
+}
+
+func TestQuarantineKeepsMostRecent(t *testing.T) {
+	q := quarantine{}
+	q.initialize(2, 10, 4, time.Minute)
+
+	now := time.Unix(1000, 0)
+	for i := 0; i < 3; i++ {
+		assert.True(t, q.record(now, ids.ShortEmpty, "", []byte{byte(i)}, errors.New(string(rune('a'+i)))))
+	}
+
+	records := q.list()
+	assert.Len(t, records, 2)
+	assert.Equal(t, "b", records[0].Error)
+	assert.Equal(t, "c", records[1].Error)
+}
+
+func TestQuarantineTruncates(t *testing.T) {
+	q := quarantine{}
+	q.initialize(1, 1, 4, time.Minute)
+
+	assert.True(t, q.record(time.Unix(1000, 0), ids.ShortEmpty, "", make([]byte, 10), errors.New("too long")))
+
+	records := q.list()
+	assert.Len(t, records, 1)
+	assert.Equal(t, 10, records[0].Length)
+	assert.True(t, records[0].Truncated)
+}
@@ -0,0 +1,137 @@
+// (c) 2019-2020, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package network
+
+import (
+	"encoding/binary"
+
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/utils/constants"
+	"github.com/ava-labs/avalanchego/version"
+)
+
+const (
+	// reachabilityFilterSize is the number of bytes in a reachability filter.
+	// With the default number of hashes, a filter of 2048 bits holding 200
+	// node IDs has a false positive rate of ~3%.
+	reachabilityFilterSize = 256
+
+	// reachabilityFilterHashes is the number of bits set for each node ID.
+	// Node IDs are hashes, so each of these is read directly from its bytes.
+	reachabilityFilterHashes = 3
+)
+
+// minReachabilityVersion is the first version that is able to parse
+// Reachability messages. Older peers would drop the connection. It's the
+// release that added them, so it must not be newer than node.Version.
+var minReachabilityVersion = version.NewDefaultVersion(constants.PlatformName, 1, 0, 6)
+
+// supportsReachability returns true if a peer running [peerVersion] is able to
+// parse Reachability messages
+func supportsReachability(peerVersion version.Version) bool {
+	return peerVersion.App() == minReachabilityVersion.App() &&
+		!peerVersion.Before(minReachabilityVersion)
+}
+
+// newReachabilityFilter returns a bloom filter containing [nodeIDs]
+func newReachabilityFilter(nodeIDs []ids.ShortID) []byte {
+	filter := make([]byte, reachabilityFilterSize)
+	for _, nodeID := range nodeIDs {
+		nodeIDBytes := nodeID.Bytes()
+		for i := 0; i < reachabilityFilterHashes; i++ {
+			bit := binary.BigEndian.Uint32(nodeIDBytes[4*i:]) % (8 * reachabilityFilterSize)
+			filter[bit/8] |= 1 << (bit % 8)
+		}
+	}
+	return filter
+}
+
+// reachabilityFilterContains returns true if [nodeID] may have been added to
+// [filter]. False positives are possible, false negatives are not.
+func reachabilityFilterContains(filter []byte, nodeID ids.ShortID) bool {
+	if len(filter) != reachabilityFilterSize {
+		return false
+	}
+	nodeIDBytes := nodeID.Bytes()
+	for i := 0; i < reachabilityFilterHashes; i++ {
+		bit := binary.BigEndian.Uint32(nodeIDBytes[4*i:]) % (8 * reachabilityFilterSize)
+		if filter[bit/8]&(1<<(bit%8)) == 0 {
+			return false
+		}
+	}
+	return true
+}
+
+// connectedValidators returns the IDs of the validators this node is currently
+// connected to.
+// assumes the stateLock is not held.
+func (n *network) connectedValidators() []ids.ShortID {
+	allPeers := n.getAllPeers()
+	nodeIDs := make([]ids.ShortID, 0, len(allPeers))
+	for _, peer := range allPeers {
+		if peer.connected.GetValue() && n.vdrs.Contains(peer.id) {
+			nodeIDs = append(nodeIDs, peer.id)
+		}
+	}
+	return nodeIDs
+}
+
+// ReachableStake implements the Network interface
+// assumes the stateLock is not held.
+func (n *network) ReachableStake() float64 {
+	totalWeight := n.vdrs.Weight()
+	if totalWeight == 0 {
+		return 1
+	}
+
+	// Filters reported by the validators we are connected to
+	filters := [][]byte(nil)
+	reachable := ids.ShortSet{}
+	reachable.Add(n.id)
+	for _, peer := range n.getAllPeers() {
+		if !peer.connected.GetValue() || !n.vdrs.Contains(peer.id) {
+			continue
+		}
+		reachable.Add(peer.id)
+		if filter, ok := peer.reachableFilter.GetValue().([]byte); ok {
+			filters = append(filters, filter)
+		}
+	}
+
+	reachableWeight := uint64(0)
+	for _, vdr := range n.vdrs.List() {
+		vdrID := vdr.ID()
+		if !reachable.Contains(vdrID) {
+			for _, filter := range filters {
+				if reachabilityFilterContains(filter, vdrID) {
+					reachable.Add(vdrID)
+					break
+				}
+			}
+		}
+		if reachable.Contains(vdrID) {
+			reachableWeight += vdr.Weight()
+		}
+	}
+	return float64(reachableWeight) / float64(totalWeight)
+}
+
+// updateReachability rebuilds the Reachability message that is sent along
+// with Pongs and reports the reachable stake.
+// assumes the stateLock is not held.
+func (n *network) updateReachability() {
+	n.reachableStake.Set(n.ReachableStake())
+
+	// Only the reports of validators are used by peers
+	if !n.vdrs.Contains(n.id) {
+		n.reachabilityMsg.SetValue(nil)
+		return
+	}
+	msg, err := n.b.Reachability(newReachabilityFilter(n.connectedValidators()))
+	if err != nil {
+		n.log.Error("failed to build reachability message: %s", err)
+		return
+	}
+	n.reachabilityMsg.SetValue(msg)
+}
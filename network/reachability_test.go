@@ -0,0 +1,52 @@
+// (c) 2019-2020, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package network
+
+import (
+	"testing"
+
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/utils/constants"
+	"github.com/ava-labs/avalanchego/version"
+)
+
+func TestReachabilityFilter(t *testing.T) {
+	nodeIDs := []ids.ShortID{
+		ids.GenerateTestShortID(),
+		ids.GenerateTestShortID(),
+		ids.GenerateTestShortID(),
+	}
+	filter := newReachabilityFilter(nodeIDs[:2])
+	if len(filter) != reachabilityFilterSize {
+		t.Fatalf("expected filter of length %d but got %d", reachabilityFilterSize, len(filter))
+	}
+	for _, nodeID := range nodeIDs[:2] {
+		if !reachabilityFilterContains(filter, nodeID) {
+			t.Fatalf("filter should contain %s", nodeID)
+		}
+	}
+	if reachabilityFilterContains(newReachabilityFilter(nil), nodeIDs[2]) {
+		t.Fatalf("empty filter shouldn't contain %s", nodeIDs[2])
+	}
+	if reachabilityFilterContains(filter[1:], nodeIDs[0]) {
+		t.Fatalf("filter with the wrong length shouldn't contain anything")
+	}
+}
+
+func TestSupportsReachability(t *testing.T) {
+	tests := []struct {
+		version  version.Version
+		expected bool
+	}{
+		{version.NewDefaultVersion(constants.PlatformName, 1, 0, 5), false},
+		{version.NewDefaultVersion(constants.PlatformName, 1, 0, 6), true},
+		{version.NewDefaultVersion(constants.PlatformName, 1, 1, 0), true},
+		{version.NewDefaultVersion("other", 1, 1, 0), false},
+	}
+	for _, test := range tests {
+		if supports := supportsReachability(test.version); supports != test.expected {
+			t.Fatalf("expected %s support to be %t but was %t", test.version, test.expected, supports)
+		}
+	}
+}
@@ -0,0 +1,206 @@
+// (c) 2019-2020, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package network
+
+import (
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"math"
+	"path/filepath"
+	"reflect"
+	"testing"
+
+	"github.com/ava-labs/avalanchego/utils/wrappers"
+)
+
+// releaseMessagesFile holds every message of the v1.0.5 release, serialized by
+// [releaseCodec]. Unlike the snapshot, it must never be regenerated: nodes
+// running that release are still on the network.
+var releaseMessagesFile = filepath.Join("testdata", "messages.v1.0.5.json")
+
+// releaseField is a field of a message as serialized by the v1.0.5 release
+type releaseField struct {
+	field  Field
+	pack   func(*wrappers.Packer, interface{})
+	unpack func(*wrappers.Packer) interface{}
+}
+
+var (
+	releaseNetworkID           = releaseField{NetworkID, wrappers.TryPackInt, wrappers.TryUnpackInt}
+	releaseNodeID              = releaseField{NodeID, wrappers.TryPackInt, wrappers.TryUnpackInt}
+	releaseMyTime              = releaseField{MyTime, wrappers.TryPackLong, wrappers.TryUnpackLong}
+	releaseIP                  = releaseField{IP, wrappers.TryPackIP, wrappers.TryUnpackIP}
+	releaseVersionStr          = releaseField{VersionStr, wrappers.TryPackStr, wrappers.TryUnpackStr}
+	releasePeers               = releaseField{Peers, wrappers.TryPackIPList, wrappers.TryUnpackIPList}
+	releaseChainID             = releaseField{ChainID, wrappers.TryPackHash, wrappers.TryUnpackHash}
+	releaseRequestID           = releaseField{RequestID, wrappers.TryPackInt, wrappers.TryUnpackInt}
+	releaseDeadline            = releaseField{Deadline, wrappers.TryPackLong, wrappers.TryUnpackLong}
+	releaseContainerID         = releaseField{ContainerID, wrappers.TryPackHash, wrappers.TryUnpackHash}
+	releaseContainerBytes      = releaseField{ContainerBytes, wrappers.TryPackBytes, wrappers.TryUnpackBytes}
+	releaseContainerIDs        = releaseField{ContainerIDs, wrappers.TryPackHashes, wrappers.TryUnpackHashes}
+	releaseMultiContainerBytes = releaseField{MultiContainerBytes, wrappers.TryPack2DBytes, wrappers.TryUnpack2DBytes}
+)
+
+// releaseMessage is a message as serialized by the v1.0.5 release
+type releaseMessage struct {
+	opcode byte
+	fields []releaseField
+}
+
+// releaseMessages is a frozen copy of the messages of the v1.0.5 release,
+// keyed by name. It's deliberately independent of [Messages] and of the
+// opcodes in commands.go, so that changing them doesn't change it.
+var releaseMessages = map[string]releaseMessage{
+	"get_version":           {0, nil},
+	"version":               {1, []releaseField{releaseNetworkID, releaseNodeID, releaseMyTime, releaseIP, releaseVersionStr}},
+	"get_peerlist":          {2, nil},
+	"peerlist":              {3, []releaseField{releasePeers}},
+	"ping":                  {4, nil},
+	"pong":                  {5, nil},
+	"get_accepted_frontier": {6, []releaseField{releaseChainID, releaseRequestID, releaseDeadline}},
+	"accepted_frontier":     {7, []releaseField{releaseChainID, releaseRequestID, releaseContainerIDs}},
+	"get_accepted":          {8, []releaseField{releaseChainID, releaseRequestID, releaseDeadline, releaseContainerIDs}},
+	"accepted":              {9, []releaseField{releaseChainID, releaseRequestID, releaseContainerIDs}},
+	"get_ancestors":         {10, []releaseField{releaseChainID, releaseRequestID, releaseDeadline, releaseContainerID}},
+	"multi_put":             {11, []releaseField{releaseChainID, releaseRequestID, releaseMultiContainerBytes}},
+	"get":                   {12, []releaseField{releaseChainID, releaseRequestID, releaseDeadline, releaseContainerID}},
+	"put":                   {13, []releaseField{releaseChainID, releaseRequestID, releaseContainerID, releaseContainerBytes}},
+	"push_query":            {14, []releaseField{releaseChainID, releaseRequestID, releaseDeadline, releaseContainerID, releaseContainerBytes}},
+	"pull_query":            {15, []releaseField{releaseChainID, releaseRequestID, releaseDeadline, releaseContainerID}},
+	"chits":                 {16, []releaseField{releaseChainID, releaseRequestID, releaseContainerIDs}},
+}
+
+// releaseCodec serializes messages the way the v1.0.5 release does
+type releaseCodec struct{}
+
+func (releaseCodec) pack(name string, fields map[Field]interface{}) ([]byte, error) {
+	message, ok := releaseMessages[name]
+	if !ok {
+		return nil, errBadOp
+	}
+	p := wrappers.Packer{MaxSize: math.MaxInt32}
+	p.PackByte(message.opcode)
+	for _, field := range message.fields {
+		data, ok := fields[field.field]
+		if !ok {
+			return nil, errMissingField
+		}
+		field.pack(&p, data)
+	}
+	return p.Bytes, p.Err
+}
+
+func (releaseCodec) parse(b []byte) (string, map[Field]interface{}, error) {
+	p := wrappers.Packer{Bytes: b}
+	opcode := p.UnpackByte()
+	for name, message := range releaseMessages {
+		if message.opcode != opcode {
+			continue
+		}
+		fields := make(map[Field]interface{}, len(message.fields))
+		for _, field := range message.fields {
+			fields[field.field] = field.unpack(&p)
+		}
+		if p.Offset != len(b) {
+			p.Add(fmt.Errorf("expected length %d got %d", len(b), p.Offset))
+		}
+		return name, fields, p.Err
+	}
+	return "", nil, errBadOp
+}
+
+// TestReleaseMessages ensures that the messages of the v1.0.5 release are
+// still serialized the same way, and that messages serialized by that release
+// and by this code can be parsed by the other.
+func TestReleaseMessages(t *testing.T) {
+	releaseBytes, err := ioutil.ReadFile(releaseMessagesFile)
+	if err != nil {
+		t.Fatal(err)
+	}
+	release := make(map[string]string)
+	if err := json.Unmarshal(releaseBytes, &release); err != nil {
+		t.Fatal(err)
+	}
+
+	messages := representativeMessages(Builder{})
+	checked := 0
+	for op, build := range messages {
+		name := op.String()
+		releaseMessage, ok := releaseMessages[name]
+		if !ok {
+			// Added after the release, so it must never be sent to nodes
+			// running it
+			continue
+		}
+		checked++
+
+		expected, err := build()
+		if err != nil {
+			t.Fatal(err)
+		}
+		releaseHex, ok := release[name]
+		if !ok {
+			t.Errorf("%s message is missing from %s", name, releaseMessagesFile)
+			continue
+		}
+		releaseMsgBytes, err := hex.DecodeString(releaseHex)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		// The frozen codec still produces the checked-in bytes
+		fields := make(map[Field]interface{}, len(releaseMessage.fields))
+		for _, field := range releaseMessage.fields {
+			fields[field.field] = expected.Get(field.field)
+		}
+		packed, err := releaseCodec{}.pack(name, fields)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if hex.EncodeToString(packed) != releaseHex {
+			t.Errorf("release codec serialized %s message as %x but %s has %s", name, packed, releaseMessagesFile, releaseHex)
+			continue
+		}
+
+		// This code serializes the message the same way as the release
+		if current := hex.EncodeToString(expected.Bytes()); current != releaseHex {
+			t.Errorf("%s message serialization changed:\nrelease: %s\ncurrent: %s", name, releaseHex, current)
+		}
+
+		// The release parses the message serialized by this code
+		parsedName, parsedFields, err := releaseCodec{}.parse(expected.Bytes())
+		switch {
+		case err != nil:
+			t.Errorf("release couldn't parse %s message: %s", name, err)
+		case parsedName != name:
+			t.Errorf("release parsed %s message as %s", name, parsedName)
+		default:
+			for _, field := range releaseMessage.fields {
+				if !reflect.DeepEqual(parsedFields[field.field], expected.Get(field.field)) {
+					t.Errorf("release parsed %s of %s message as %v but expected %v", field.field, name, parsedFields[field.field], expected.Get(field.field))
+				}
+			}
+		}
+
+		// This code parses the message serialized by the release
+		parsed, err := TestCodec.Parse(releaseMsgBytes)
+		switch {
+		case err != nil:
+			t.Errorf("couldn't parse release %s message: %s", name, err)
+		case parsed.Op() != op:
+			t.Errorf("release %s message parsed as %s", name, parsed.Op())
+		default:
+			for _, field := range Messages[op] {
+				if !reflect.DeepEqual(parsed.Get(field), expected.Get(field)) {
+					t.Errorf("release %s message parsed %s as %v but expected %v", name, field, parsed.Get(field), expected.Get(field))
+				}
+			}
+		}
+	}
+	if checked != len(releaseMessages) {
+		t.Fatalf("checked %d of the %d release messages", checked, len(releaseMessages))
+	}
+}
@@ -0,0 +1,74 @@
+// (c) 2019-2020, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package network
+
+import (
+	"sort"
+)
+
+// giniCoefficient returns the Gini coefficient of [weights], which must be
+// sorted in increasing order. 0 means every weight is equal and values close
+// to 1 mean that a single weight dominates.
+func giniCoefficient(weights []uint64) float64 {
+	n := float64(len(weights))
+	total := float64(0)
+	weightedSum := float64(0)
+	for i, weight := range weights {
+		total += float64(weight)
+		weightedSum += float64(i+1) * float64(weight)
+	}
+	if total == 0 {
+		return 0
+	}
+	return (2*weightedSum)/(n*total) - (n+1)/n
+}
+
+// topShare returns the portion of the total of [weights] held by the [n]
+// largest weights. [weights] must be sorted in increasing order.
+func topShare(weights []uint64, n int) float64 {
+	total := float64(0)
+	top := float64(0)
+	for i, weight := range weights {
+		total += float64(weight)
+		if i >= len(weights)-n {
+			top += float64(weight)
+		}
+	}
+	if total == 0 {
+		return 0
+	}
+	return top / total
+}
+
+// updateStakeMetrics reports how the stake of the current validators is
+// distributed and how much of it this node is connected to.
+// assumes the stateLock is not held.
+func (n *network) updateStakeMetrics() {
+	vdrs := n.vdrs.List()
+	weights := make([]uint64, len(vdrs))
+	for i, vdr := range vdrs {
+		weights[i] = vdr.Weight()
+	}
+	sort.Slice(weights, func(i, j int) bool { return weights[i] < weights[j] })
+
+	n.stakeGini.Set(giniCoefficient(weights))
+	n.stakeTop1Share.Set(topShare(weights, 1))
+	n.stakeTop10Share.Set(topShare(weights, 10))
+
+	totalWeight := n.vdrs.Weight()
+	if totalWeight == 0 {
+		n.connectedStake.Set(0)
+		return
+	}
+	connectedWeight, _ := n.vdrs.GetWeight(n.id)
+	for _, peer := range n.getAllPeers() {
+		if !peer.connected.GetValue() {
+			continue
+		}
+		if weight, ok := n.vdrs.GetWeight(peer.id); ok {
+			connectedWeight += weight
+		}
+	}
+	n.connectedStake.Set(float64(connectedWeight) / float64(totalWeight))
+}
@@ -0,0 +1,27 @@
+// (c) 2019-2020, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package network
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGiniCoefficient(t *testing.T) {
+	assert.Equal(t, 0.0, giniCoefficient(nil))
+	assert.Equal(t, 0.0, giniCoefficient([]uint64{0, 0}))
+	assert.InDelta(t, 0.0, giniCoefficient([]uint64{5, 5, 5, 5}), 1e-9)
+	// A single validator holds all the stake
+	assert.InDelta(t, 0.75, giniCoefficient([]uint64{0, 0, 0, 10}), 1e-9)
+	assert.InDelta(t, 0.25, giniCoefficient([]uint64{1, 1, 3, 3}), 1e-9)
+}
+
+func TestTopShare(t *testing.T) {
+	weights := []uint64{1, 2, 3, 4}
+	assert.Equal(t, 0.0, topShare(nil, 1))
+	assert.InDelta(t, 0.4, topShare(weights, 1), 1e-9)
+	assert.InDelta(t, 0.7, topShare(weights, 2), 1e-9)
+	assert.InDelta(t, 1.0, topShare(weights, 10), 1e-9)
+}
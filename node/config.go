@@ -4,8 +4,11 @@
 package node
 
 import (
+	"net"
+	"net/url"
 	"time"
 
+	"github.com/ava-labs/avalanchego/chains"
 	"github.com/ava-labs/avalanchego/database"
 	"github.com/ava-labs/avalanchego/genesis"
 	"github.com/ava-labs/avalanchego/ids"
@@ -32,6 +35,9 @@ type Config struct {
 	// ID of the network this node should connect to
 	NetworkID uint32
 
+	// Genesis config of the network this node should connect to
+	GenesisConfig *genesis.Config
+
 	// Assertions configuration
 	EnableAssertions bool
 
@@ -43,6 +49,9 @@ type Config struct {
 
 	// Staking configuration
 	StakingIP               utils.DynamicIPDesc
+	StakingHost             string
+	StakingOutboundIP       net.IP
+	StakingProxy            *url.URL
 	EnableP2PTLS            bool
 	EnableStaking           bool
 	StakingKeyFile          string
@@ -84,6 +93,12 @@ type Config struct {
 	// Plugin directory
 	PluginDir string
 
+	// If non-empty, plugins are only run if they're listed in a manifest
+	// signed by one of these addresses
+	PluginManifestSigners ids.ShortSet
+	// File the plugin manifest is loaded from and persisted to
+	PluginManifestFile string
+
 	// Consensus configuration
 	ConsensusParams avalanche.Parameters
 
@@ -101,6 +116,21 @@ type Config struct {
 	ConsensusGossipFrequency time.Duration
 	ConsensusShutdownTimeout time.Duration
 
+	// Number of times a non-critical chain that failed is restarted
+	ChainRestartLimit int
+
+	// A chain with pending work is reported as stalled if it hasn't accepted
+	// anything for this long. If 0, chains are never reported as stalled.
+	ChainStallTimeout time.Duration
+
+	// Maximum number of GetAncestors requests outstanding at once while
+	// bootstrapping a chain
+	BootstrapMaxOutstandingRequests int
+
+	// Maximum number of chains of the same subnet this node runs. See
+	// chains.ManagerConfig.
+	MaxSubnetChains int
+
 	// Dynamic Update duration for IP or NAT traversal
 	DynamicUpdateDuration time.Duration
 
@@ -113,6 +143,9 @@ type Config struct {
 	// Subnet Whitelist
 	WhitelistedSubnets ids.Set
 
+	// Subnet ID --> How the chains of that subnet gossip
+	SubnetGossipConfigs map[ids.ID]chains.SubnetGossipConfig
+
 	// Coreth
 	CorethConfig string
 }
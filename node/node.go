@@ -13,6 +13,7 @@ import (
 	"net"
 	"os"
 	"path/filepath"
+	"strconv"
 	"sync"
 	"syscall"
 	"time"
@@ -32,12 +33,15 @@ import (
 	"github.com/ava-labs/avalanchego/ids"
 	"github.com/ava-labs/avalanchego/ipcs"
 	"github.com/ava-labs/avalanchego/network"
+	"github.com/ava-labs/avalanchego/snow/engine/common"
 	"github.com/ava-labs/avalanchego/snow/networking/benchlist"
 	"github.com/ava-labs/avalanchego/snow/networking/router"
+	"github.com/ava-labs/avalanchego/snow/networking/sender"
 	"github.com/ava-labs/avalanchego/snow/networking/timeout"
 	"github.com/ava-labs/avalanchego/snow/triggers"
 	"github.com/ava-labs/avalanchego/snow/validators"
 	"github.com/ava-labs/avalanchego/utils"
+	"github.com/ava-labs/avalanchego/utils/codec"
 	"github.com/ava-labs/avalanchego/utils/constants"
 	"github.com/ava-labs/avalanchego/utils/hashing"
 	"github.com/ava-labs/avalanchego/utils/logging"
@@ -52,6 +56,7 @@ import (
 	"github.com/ava-labs/avalanchego/vms/platformvm"
 	"github.com/ava-labs/avalanchego/vms/propertyfx"
 	"github.com/ava-labs/avalanchego/vms/rpcchainvm"
+	"github.com/ava-labs/avalanchego/vms/rpcchainvm/manifest"
 	"github.com/ava-labs/avalanchego/vms/secp256k1fx"
 	"github.com/ava-labs/avalanchego/vms/timestampvm"
 
@@ -61,14 +66,30 @@ import (
 // Networking constants
 const (
 	TCP = "tcp"
+
+	// Maximum number of peers whose TLS sessions are cached for resumption
+	tlsSessionCacheSize = 4096
+
+	// Portion of the stake that must be reachable for the node to be healthy
+	minReachableStake = .8
+
+	// Max difference between this node's clock and its peers' for the node to
+	// be healthy. Blocks whose timestamps are more than the platform chain's
+	// synchrony bound ahead of this node's clock are rejected.
+	maxClockSkew = 5 * time.Second
 )
 
 var (
 	genesisHashKey = []byte("genesisID")
 
 	// Version is the version of this code
-	Version       = version.NewDefaultVersion(constants.PlatformName, 1, 0, 5)
+	Version       = version.NewDefaultVersion(constants.PlatformName, 1, 0, 6)
 	versionParser = version.NewDefaultParser()
+
+	// API methods that will be removed --> what to use instead
+	deprecatedAPIMethods = map[string]string{
+		"avm.importAVAX": "use avm.import instead",
+	}
 )
 
 // Node is an instance of an Avalanche node.
@@ -108,6 +129,14 @@ type Node struct {
 	// Net runs the networking stack
 	Net network.Network
 
+	// Injects faults into the consensus messages this node sends. Only
+	// created on private networks, and only when the admin API is enabled.
+	chaosSender *sender.ChaosSender
+
+	// Verifies plugin binaries before they're run. Nil if plugins aren't
+	// verified.
+	pluginVerifier *manifest.Verifier
+
 	// this node's initial connections to the network
 	beacons validators.Set
 
@@ -131,11 +160,17 @@ type Node struct {
  */
 
 func (n *Node) initNetworking() error {
-	listener, err := net.Listen(TCP, fmt.Sprintf(":%d", n.Config.StakingIP.Port))
+	listener, err := net.Listen(TCP, net.JoinHostPort(n.Config.StakingHost, strconv.Itoa(int(n.Config.StakingIP.Port))))
 	if err != nil {
 		return err
 	}
-	dialer := network.NewDialer(TCP)
+	dialer := network.NewBoundDialer(TCP, n.Config.StakingOutboundIP)
+	if n.Config.StakingProxy != nil {
+		dialer, err = network.NewProxyDialer(TCP, n.Config.StakingOutboundIP, n.Config.StakingProxy, n.Config.ConsensusParams.Metrics)
+		if err != nil {
+			return err
+		}
+	}
 
 	var serverUpgrader, clientUpgrader network.Upgrader
 	if n.Config.EnableP2PTLS {
@@ -155,6 +190,11 @@ func (n *Node) initNetworking() error {
 			// During our security audit by Quantstamp, this was investigated
 			// and confirmed to be safe and correct.
 			InsecureSkipVerify: true,
+			// Cache session tickets so that reconnecting to a peer resumes
+			// the previous session rather than performing a full handshake.
+			// The peer's certificate is kept in the session, so the peer is
+			// still authenticated by its staking key.
+			ClientSessionCache: tls.NewLRUClientSessionCache(tlsSessionCacheSize),
 		}
 
 		serverUpgrader = network.NewTLSServerUpgrader(tlsConfig)
@@ -329,7 +369,7 @@ func (n *Node) Dispatch() error {
 func (n *Node) initDatabase() error {
 	n.DB = n.Config.DB
 
-	expectedGenesis, _, err := genesis.Genesis(n.Config.NetworkID)
+	expectedGenesis, _, err := genesis.FromConfig(n.Config.GenesisConfig)
 	if err != nil {
 		return err
 	}
@@ -448,23 +488,31 @@ func (n *Node) initChains(genesisBytes []byte, avaxAssetID ids.ID) error {
 func (n *Node) initAPIServer() error {
 	n.Log.Info("Initializing API server")
 
-	return n.APIServer.Initialize(
+	err := n.APIServer.Initialize(
 		n.Log,
 		n.LogFactory,
 		n.Config.HTTPHost,
 		n.Config.HTTPPort,
 		n.Config.APIRequireAuthToken,
 		n.Config.APIAuthPassword,
+		prefixdb.New([]byte("api auth"), n.DB),
 	)
+	if err != nil {
+		return err
+	}
+	for method, notice := range deprecatedAPIMethods {
+		n.APIServer.DeprecateMethod(method, notice)
+	}
+	return nil
 }
 
 // Create the vmManager, chainManager and register the following vms:
 // AVM, Simple Payments DAG, Simple Payments Chain, and Platform VM
 // Assumes n.DB, n.vdrs all initialized (non-nil)
-func (n *Node) initChainManager(avaxAssetID ids.ID) error {
+func (n *Node) initChainManager(genesisBytes []byte, avaxAssetID ids.ID) error {
 	n.vmManager = vms.NewManager(&n.APIServer, n.HTTPLog)
 
-	createAVMTx, err := genesis.VMGenesis(n.Config.NetworkID, avm.ID)
+	createAVMTx, err := genesis.VMGenesisFromBytes(genesisBytes, avm.ID)
 	if err != nil {
 		return err
 	}
@@ -499,6 +547,10 @@ func (n *Node) initChainManager(avaxAssetID ids.ID) error {
 		},
 	)
 
+	if n.Config.AdminAPIEnabled && n.Config.NetworkID != constants.MainnetID && n.Config.NetworkID != constants.FujiID {
+		n.chaosSender = sender.NewChaosSender(n.Net)
+	}
+
 	n.chainManager = chains.New(&chains.ManagerConfig{
 		StakingEnabled:          n.Config.EnableStaking,
 		MaxNonStakerPendingMsgs: uint32(n.Config.MaxNonStakerPendingMsgs),
@@ -525,6 +577,16 @@ func (n *Node) initChainManager(avaxAssetID ids.ID) error {
 		TimeoutManager:          &timeoutManager,
 		HealthService:           n.healthService,
 		WhitelistedSubnets:      n.Config.WhitelistedSubnets,
+		ChainRestartLimit:       n.Config.ChainRestartLimit,
+		ChainStallTimeout:       n.Config.ChainStallTimeout,
+
+		BootstrapMaxOutstandingRequests: n.Config.BootstrapMaxOutstandingRequests,
+		BeaconQuality:                   common.NewBeaconQuality(n.Log, prefixdb.New([]byte("beacon quality"), n.DB)),
+
+		MaxSubnetChains:     n.Config.MaxSubnetChains,
+		SubnetGossipConfigs: n.Config.SubnetGossipConfigs,
+
+		ChaosSender: n.chaosSender,
 	})
 
 	vdrs := n.vdrs
@@ -536,6 +598,32 @@ func (n *Node) initChainManager(avaxAssetID ids.ID) error {
 		vdrs = validators.NewManager()
 	}
 
+	if n.Config.PluginManifestSigners.Len() > 0 {
+		verifier, err := manifest.NewVerifier(
+			n.Config.PluginManifestSigners,
+			n.Config.PluginManifestFile,
+			filepath.Join(n.Config.PluginDir, "verified"),
+		)
+		if err != nil {
+			return err
+		}
+		if _, ok := verifier.Manifest(); !ok {
+			n.Log.Warn("no plugin manifest has been set. Plugins won't be run until one is")
+		}
+		n.pluginVerifier = verifier
+	}
+
+	rewardConfig := n.Config.GenesisConfig.RewardConfig
+	rewards, err := platformvm.NewRewardCalculator(platformvm.RewardConfig{
+		MaxConsumptionRate: rewardConfig.MaxConsumptionRate,
+		MinConsumptionRate: rewardConfig.MinConsumptionRate,
+		MintingPeriod:      n.Config.StakeMintingPeriod,
+		SupplyCap:          rewardConfig.SupplyCap,
+	})
+	if err != nil {
+		return fmt.Errorf("invalid reward config: %w", err)
+	}
+
 	errs := wrappers.Errs{}
 	errs.Add(
 		n.vmManager.RegisterVMFactory(platformvm.ID, &platformvm.Factory{
@@ -552,14 +640,19 @@ func (n *Node) initChainManager(avaxAssetID ids.ID) error {
 			MinStakeDuration:   n.Config.MinStakeDuration,
 			MaxStakeDuration:   n.Config.MaxStakeDuration,
 			StakeMintingPeriod: n.Config.StakeMintingPeriod,
+			Rewards:            rewards,
 		}),
 		n.vmManager.RegisterVMFactory(avm.ID, &avm.Factory{
 			CreationFee: n.Config.CreationTxFee,
 			Fee:         n.Config.TxFee,
 		}),
-		n.vmManager.RegisterVMFactory(evm.ID, &rpcchainvm.Factory{
-			Path:   filepath.Join(n.Config.PluginDir, "evm"),
-			Config: n.Config.CorethConfig,
+		n.vmManager.RegisterVMFactory(evm.ID, &evm.Factory{
+			Factory: rpcchainvm.Factory{
+				Path:     filepath.Join(n.Config.PluginDir, "evm"),
+				Config:   n.Config.CorethConfig,
+				VMID:     evm.ID,
+				Verifier: n.pluginVerifier,
+			},
 		}),
 		n.vmManager.RegisterVMFactory(timestampvm.ID, &timestampvm.Factory{}),
 		n.vmManager.RegisterVMFactory(secp256k1fx.ID, &secp256k1fx.Factory{}),
@@ -608,6 +701,10 @@ func (n *Node) initMetricsAPI() error {
 	// It is assumed by components of the system that the Metrics interface is
 	// non-nil. So, it is set regardless of if the metrics API is available or not.
 	n.Config.ConsensusParams.Metrics = registry
+	apiNamespace := fmt.Sprintf("%s_api", constants.PlatformName)
+	if err := n.APIServer.RegisterDeprecationMetrics(apiNamespace, registry); err != nil {
+		return err
+	}
 	if !n.Config.MetricsAPIEnabled {
 		n.Log.Info("skipping metrics API initialization because it has been disabled")
 		return nil
@@ -633,7 +730,10 @@ func (n *Node) initAdminAPI() error {
 		return nil
 	}
 	n.Log.Info("initializing admin API")
-	service, err := admin.NewService(n.Log, n.chainManager, &n.APIServer)
+	service, err := admin.NewService(n.Log, n.chainManager, &n.APIServer, n.Net, map[string]codec.Manager{
+		"platformvm":         platformvm.Codec,
+		"platformvm.genesis": platformvm.GenesisCodec,
+	}, n.chaosSender, n.pluginVerifier)
 	if err != nil {
 		return err
 	}
@@ -651,8 +751,10 @@ func (n *Node) initInfoAPI() error {
 		Version,
 		n.ID,
 		n.Config.NetworkID,
+		n.Config.GenesisConfig,
 		n.chainManager,
 		n.Net,
+		n.vdrs,
 		n.Config.CreationTxFee,
 		n.Config.TxFee,
 	)
@@ -674,6 +776,29 @@ func (n *Node) initHealthAPI() error {
 	if err := service.RegisterHeartbeat("network.validators.heartbeat", n.Net, 5*time.Minute); err != nil {
 		return fmt.Errorf("couldn't register heartbeat health check: %w", err)
 	}
+	// Passes if this node can reach most of the stake, either directly or
+	// through one of its validator peers
+	reachableStakeFunc := func() (interface{}, error) {
+		reachableStake := n.Net.ReachableStake()
+		if reachableStake < minReachableStake {
+			return reachableStake, fmt.Errorf("only %.2f of the stake is reachable", reachableStake)
+		}
+		return reachableStake, nil
+	}
+	if err := service.RegisterCheck(health.NewCheck("network.validators.reachable", reachableStakeFunc)); err != nil {
+		return fmt.Errorf("couldn't register reachable stake health check: %w", err)
+	}
+	// Passes if this node's clock agrees with its peers' clocks
+	clockSkewFunc := func() (interface{}, error) {
+		clockSkew := n.Net.ClockSkew()
+		if clockSkew > maxClockSkew || clockSkew < -maxClockSkew {
+			return clockSkew.String(), fmt.Errorf("peers' clocks are %s ahead of this node's clock", clockSkew)
+		}
+		return clockSkew.String(), nil
+	}
+	if err := service.RegisterCheck(health.NewCheck("network.clock.skew", clockSkewFunc)); err != nil {
+		return fmt.Errorf("couldn't register clock skew health check: %w", err)
+	}
 	isBootstrappedFunc := func() (interface{}, error) {
 		if pChainID, err := n.chainManager.Lookup("P"); err != nil {
 			return nil, errors.New("P-Chain not created")
@@ -797,7 +922,7 @@ func (n *Node) Initialize(config *Config, logger logging.Logger, logFactory logg
 		return fmt.Errorf("problem initializing event dispatcher: %w", err)
 	}
 
-	genesisBytes, avaxAssetID, err := genesis.Genesis(n.Config.NetworkID)
+	genesisBytes, avaxAssetID, err := genesis.FromConfig(n.Config.GenesisConfig)
 	if err != nil {
 		return fmt.Errorf("couldn't create genesis bytes: %w", err)
 	}
@@ -806,7 +931,7 @@ func (n *Node) Initialize(config *Config, logger logging.Logger, logFactory logg
 	if err := n.initHealthAPI(); err != nil {
 		return fmt.Errorf("couldn't initialize health API: %w", err)
 	}
-	if err := n.initChainManager(avaxAssetID); err != nil { // Set up the chain manager
+	if err := n.initChainManager(genesisBytes, avaxAssetID); err != nil { // Set up the chain manager
 		return fmt.Errorf("couldn't initialize chain manager: %w", err)
 	}
 	if err := n.initAdminAPI(); err != nil { // Start the Admin API
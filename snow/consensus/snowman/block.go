@@ -39,3 +39,14 @@ type Block interface {
 	// parsed into the same block on another node.
 	Bytes() []byte
 }
+
+// BatchRejecter rejects several blocks at once. It's optionally implemented by
+// a VM, so that the status changes of blocks rejected together, such as the
+// descendants of a rejected block, are written to its database at once rather
+// than once per block.
+type BatchRejecter interface {
+	// RejectBlocks rejects [blks] as though Reject was called on each of them
+	// in order. If a block's parent is also being rejected, the parent
+	// precedes it.
+	RejectBlocks(blks []Block) error
+}
@@ -37,4 +37,8 @@ type Consensus interface {
 	// finalized. Note, it is possible that after returning finalized, a new
 	// decision may be added such that this instance is no longer finalized.
 	Finalized() bool
+
+	// ProcessingTree returns a description of the blocks that are currently
+	// being processed
+	ProcessingTree() ProcessingTree
 }
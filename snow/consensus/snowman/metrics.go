@@ -16,6 +16,7 @@ import (
 
 type metrics struct {
 	numProcessing            prometheus.Gauge
+	maxDepth, numBranches    prometheus.Gauge
 	latAccepted, latRejected prometheus.Histogram
 
 	clock      timer.Clock
@@ -31,6 +32,16 @@ func (m *metrics) Initialize(log logging.Logger, namespace string, registerer pr
 		Name:      "processing",
 		Help:      "Number of currently processing blocks",
 	})
+	m.maxDepth = prometheus.NewGauge(prometheus.GaugeOpts{
+		Namespace: namespace,
+		Name:      "processing_max_depth",
+		Help:      "Number of blocks from the last accepted block to the deepest processing block",
+	})
+	m.numBranches = prometheus.NewGauge(prometheus.GaugeOpts{
+		Namespace: namespace,
+		Name:      "processing_branches",
+		Help:      "Number of blocks with more than one processing child. Non-zero iff the chain is forked",
+	})
 	m.latAccepted = prometheus.NewHistogram(prometheus.HistogramOpts{
 		Namespace: namespace,
 		Name:      "accepted",
@@ -47,6 +58,12 @@ func (m *metrics) Initialize(log logging.Logger, namespace string, registerer pr
 	if err := registerer.Register(m.numProcessing); err != nil {
 		return fmt.Errorf("failed to register processing statistics due to %w", err)
 	}
+	if err := registerer.Register(m.maxDepth); err != nil {
+		return fmt.Errorf("failed to register processing depth statistics due to %w", err)
+	}
+	if err := registerer.Register(m.numBranches); err != nil {
+		return fmt.Errorf("failed to register processing branches statistics due to %w", err)
+	}
 	if err := registerer.Register(m.latAccepted); err != nil {
 		return fmt.Errorf("failed to register accepted statistics due to %w", err)
 	}
@@ -80,3 +97,8 @@ func (m *metrics) Rejected(id ids.ID) {
 	m.latRejected.Observe(float64(end.Sub(start).Milliseconds()))
 	m.numProcessing.Dec()
 }
+
+func (m *metrics) Shape(maxDepth, numBranches int) {
+	m.maxDepth.Set(float64(maxDepth))
+	m.numBranches.Set(float64(numBranches))
+}
@@ -0,0 +1,117 @@
+// (c) 2019-2020, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package snowman
+
+import (
+	"bytes"
+	"sort"
+
+	"github.com/ava-labs/avalanchego/ids"
+)
+
+// ProcessingBlock describes a block that is being processed by consensus
+type ProcessingBlock struct {
+	ID       ids.ID
+	ParentID ids.ID
+	// Height of the block, or 0 if the block doesn't report its height
+	Height uint64
+	// Number of blocks from the last accepted block to this block. The
+	// children of the last accepted block have depth 1.
+	Depth int
+	// Number of processing blocks that name this block as their parent
+	NumChildren int
+	// True iff this block is on the preferred chain
+	Preferred bool
+}
+
+// ProcessingTree describes the tree of blocks that are being processed by
+// consensus, rooted at the last accepted block
+type ProcessingTree struct {
+	LastAccepted ids.ID
+	Preference   ids.ID
+	// Depth of the deepest processing block
+	MaxDepth int
+	// IDs of the blocks, including the last accepted block, that more than
+	// one processing block names as its parent. If there are none, the chain
+	// isn't forked.
+	BranchPoints []ids.ID
+	// Sorted by depth, and then by ID
+	Blocks []ProcessingBlock
+}
+
+// heightBlock is implemented by blocks that know their height
+type heightBlock interface {
+	Height() uint64
+}
+
+// ProcessingTree implements the Consensus interface
+func (ts *Topological) ProcessingTree() ProcessingTree {
+	tree := ProcessingTree{
+		LastAccepted: ts.head,
+		Preference:   ts.tail,
+		BranchPoints: []ids.ID{},
+		Blocks:       []ProcessingBlock{},
+	}
+	if len(ts.blocks) == 0 {
+		// Consensus hasn't been initialized
+		return tree
+	}
+
+	preferred := ids.Set{}
+	for blkID := ts.head; ; {
+		node := ts.blocks[blkID]
+		if node == nil || node.sb == nil {
+			break
+		}
+		blkID = node.sb.Preference()
+		preferred.Add(blkID)
+	}
+
+	// Walk the tree from the last accepted block, one depth at a time
+	parents := []ids.ID{ts.head}
+	for depth := 1; len(parents) > 0; depth++ {
+		var children []ids.ID
+		for _, parentID := range parents {
+			parent := ts.blocks[parentID]
+			if len(parent.children) > 1 {
+				tree.BranchPoints = append(tree.BranchPoints, parentID)
+			}
+			for childID, child := range parent.children {
+				if _, ok := ts.blocks[childID]; !ok {
+					continue
+				}
+				blk := ProcessingBlock{
+					ID:          childID,
+					ParentID:    parentID,
+					Depth:       depth,
+					NumChildren: len(ts.blocks[childID].children),
+					Preferred:   preferred.Contains(childID),
+				}
+				if heightBlk, ok := child.(heightBlock); ok {
+					blk.Height = heightBlk.Height()
+				}
+				tree.Blocks = append(tree.Blocks, blk)
+				children = append(children, childID)
+				tree.MaxDepth = depth
+			}
+		}
+		parents = children
+	}
+
+	sort.Slice(tree.Blocks, func(i, j int) bool {
+		if tree.Blocks[i].Depth != tree.Blocks[j].Depth {
+			return tree.Blocks[i].Depth < tree.Blocks[j].Depth
+		}
+		return bytes.Compare(tree.Blocks[i].ID[:], tree.Blocks[j].ID[:]) == -1
+	})
+	ids.SortIDs(tree.BranchPoints)
+	return tree
+}
+
+// updateShape reports the shape of the processing tree to the metrics. The
+// shape is maintained as blocks are added and removed, so this doesn't walk
+// the tree.
+func (ts *Topological) updateShape() {
+	ts.metrics.Shape(len(ts.processingDepths), ts.numBranchPoints)
+}
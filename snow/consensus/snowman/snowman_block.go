@@ -17,6 +17,10 @@ type snowmanBlock struct {
 	// block that this node contains. For the genesis, this value will be nil
 	blk Block
 
+	// depth is the number of blocks between the block consensus was
+	// initialized with and this block
+	depth int
+
 	// shouldFalter is set to true if this node, and all its descendants received
 	// less than Alpha votes
 	shouldFalter bool
@@ -40,6 +40,20 @@ type Topological struct {
 
 	// tail is the preferred block with no children
 	tail ids.ID
+
+	// depth --> number of processing blocks at that depth. Every depth between
+	// the last accepted block and the deepest processing block has a
+	// processing block, so the number of entries is the depth of the
+	// processing tree.
+	processingDepths map[int]int
+
+	// numBranchPoints is the number of blocks in [blocks] that more than one
+	// block names as its parent
+	numBranchPoints int
+
+	// Rejecter, if non-nil, is used to reject blocks that are rejected at the
+	// same time together, rather than one by one
+	Rejecter BatchRejecter
 }
 
 // Used to track the kahn topological sort status
@@ -73,6 +87,7 @@ func (ts *Topological) Initialize(ctx *snow.Context, params snowball.Parameters,
 		rootID: {sm: ts},
 	}
 	ts.tail = rootID
+	ts.processingDepths = make(map[int]int, minMapSize)
 	return nil
 }
 
@@ -110,15 +125,22 @@ func (ts *Topological) Add(blk Block) error {
 
 	// add the block as a child of its parent, and add the block to the tree
 	parentNode.AddChild(blk)
-	ts.blocks[blkID] = &snowmanBlock{
-		sm:  ts,
-		blk: blk,
+	node := &snowmanBlock{
+		sm:    ts,
+		blk:   blk,
+		depth: parentNode.depth + 1,
+	}
+	ts.blocks[blkID] = node
+	ts.processingDepths[node.depth]++
+	if len(parentNode.children) == 2 {
+		ts.numBranchPoints++
 	}
 
 	// If we are extending the tail, this is the new tail
 	if ts.tail == parentID {
 		ts.tail = blkID
 	}
+	ts.updateShape()
 	return nil
 }
 
@@ -180,6 +202,7 @@ func (ts *Topological) RecordPoll(voteBag ids.Bag) error {
 
 	// Runtime = |live set| ; Space = Constant
 	ts.tail = ts.getPreferredDescendant(preferred)
+	ts.updateShape()
 	return nil
 }
 
@@ -356,6 +379,7 @@ func (ts *Topological) vote(voteStack []votes) (ids.ID, error) {
 			// by accepting the child of parentBlock, the last accepted block is
 			// no longer voteParentID, but its child. So, voteParentID can be
 			// removed from the tree.
+			ts.removeBranchPoint(parentBlock)
 			delete(ts.blocks, vote.parentID)
 		}
 
@@ -438,63 +462,91 @@ func (ts *Topological) accept(n *snowmanBlock) error {
 
 	// Because this is the newest accepted block, this is the new head.
 	ts.head = pref
+	ts.removeProcessing(ts.blocks[pref])
 
 	// Because ts.blocks contains the last accepted block, we don't delete the
 	// block from the blocks map here.
 
-	rejects := make([]ids.ID, 0, len(n.children)-1)
+	// The siblings of the accepted block, and all their descendants, are
+	// rejected. They're collected first so that they can be rejected together.
+	rejects := make([]Block, 0, len(n.children)-1)
 	for childID, child := range n.children {
 		if childID == pref {
 			// don't reject the block we just accepted
 			continue
 		}
-
-		if err := child.Reject(); err != nil {
-			return err
-		}
-
-		// Notify anyone listening that this block was rejected.
-		bytes := child.Bytes()
-		ts.ctx.DecisionDispatcher.Reject(ts.ctx, childID, bytes)
-		ts.ctx.ConsensusDispatcher.Reject(ts.ctx, childID, bytes)
-		ts.metrics.Rejected(childID)
-
-		// Track which blocks have been directly rejected
-		rejects = append(rejects, childID)
+		rejects = append(rejects, child)
 	}
-
-	// reject all the descendants of the blocks we just rejected
-	return ts.rejectTransitively(rejects)
+	return ts.reject(ts.collectDescendants(rejects))
 }
 
-// Takes in a list of rejected ids and rejects all descendants of these IDs
-func (ts *Topological) rejectTransitively(rejected []ids.ID) error {
-	// the rejected array is treated as a queue, with the next element at index
-	// 0 and the last element at the end of the slice.
-	for len(rejected) > 0 {
-		// pop the rejected ID off the queue
-		newRejectedSize := len(rejected) - 1
-		rejectedID := rejected[newRejectedSize]
-		rejected = rejected[:newRejectedSize]
+// collectDescendants returns [rejected] followed by all of their descendants,
+// and removes them from the tree. A block's parent always precedes it in the
+// returned list.
+func (ts *Topological) collectDescendants(rejected []Block) []Block {
+	// the rejected array is also used as a queue of blocks whose children
+	// haven't been collected yet, with the next element at index [next]
+	for next := 0; next < len(rejected); next++ {
+		rejectedID := rejected[next].ID()
 
 		// get the rejected node, and remove it from the tree
-		rejectedNode := ts.blocks[rejectedID]
+		rejectedNode, ok := ts.blocks[rejectedID]
+		if !ok {
+			continue
+		}
 		delete(ts.blocks, rejectedID)
+		ts.removeProcessing(rejectedNode)
+		ts.removeBranchPoint(rejectedNode)
+
+		for _, child := range rejectedNode.children {
+			rejected = append(rejected, child)
+		}
+	}
+	return rejected
+}
 
-		for childID, child := range rejectedNode.children {
-			if err := child.Reject(); err != nil {
+// reject rejects [blks], which must be ordered such that a block's parent
+// precedes it. If a Rejecter was provided, the blocks are rejected together.
+func (ts *Topological) reject(blks []Block) error {
+	if len(blks) == 0 {
+		return nil
+	}
+	if ts.Rejecter != nil {
+		if err := ts.Rejecter.RejectBlocks(blks); err != nil {
+			return err
+		}
+	} else {
+		for _, blk := range blks {
+			if err := blk.Reject(); err != nil {
 				return err
 			}
-
-			// Notify anyone listening that this block was rejected.
-			bytes := child.Bytes()
-			ts.ctx.DecisionDispatcher.Reject(ts.ctx, childID, bytes)
-			ts.ctx.ConsensusDispatcher.Reject(ts.ctx, childID, bytes)
-			ts.metrics.Rejected(childID)
-
-			// add the newly rejected block to the end of the queue
-			rejected = append(rejected, childID)
 		}
 	}
+
+	for _, blk := range blks {
+		// Notify anyone listening that this block was rejected.
+		blkID := blk.ID()
+		bytes := blk.Bytes()
+		ts.ctx.DecisionDispatcher.Reject(ts.ctx, blkID, bytes)
+		ts.ctx.ConsensusDispatcher.Reject(ts.ctx, blkID, bytes)
+		ts.metrics.Rejected(blkID)
+	}
 	return nil
 }
+
+// removeProcessing removes [n], which is no longer processing, from the shape
+// of the processing tree
+func (ts *Topological) removeProcessing(n *snowmanBlock) {
+	ts.processingDepths[n.depth]--
+	if ts.processingDepths[n.depth] == 0 {
+		delete(ts.processingDepths, n.depth)
+	}
+}
+
+// removeBranchPoint removes [n], which is being removed from the tree, from
+// the number of branch points
+func (ts *Topological) removeBranchPoint(n *snowmanBlock) {
+	if len(n.children) > 1 {
+		ts.numBranchPoints--
+	}
+}
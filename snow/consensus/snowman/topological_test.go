@@ -5,6 +5,351 @@ package snowman
 
 import (
 	"testing"
+
+	"github.com/prometheus/client_golang/prometheus"
+
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/snow"
+	"github.com/ava-labs/avalanchego/snow/choices"
+	"github.com/ava-labs/avalanchego/snow/consensus/snowball"
 )
 
 func TestTopological(t *testing.T) { ConsensusTest(t, TopologicalFactory{}) }
+
+type testBatchRejecter struct {
+	batches [][]Block
+}
+
+func (r *testBatchRejecter) RejectBlocks(blks []Block) error {
+	r.batches = append(r.batches, blks)
+	for _, blk := range blks {
+		if err := blk.Reject(); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func TestTopologicalBatchReject(t *testing.T) {
+	rejecter := &testBatchRejecter{}
+	sm := &Topological{Rejecter: rejecter}
+
+	ctx := snow.DefaultContextTest()
+	params := snowball.Parameters{
+		Metrics:           prometheus.NewRegistry(),
+		K:                 1,
+		Alpha:             1,
+		BetaVirtuous:      1,
+		BetaRogue:         1,
+		ConcurrentRepolls: 1,
+	}
+	if err := sm.Initialize(ctx, params, GenesisID); err != nil {
+		t.Fatal(err)
+	}
+
+	block0 := &TestBlock{
+		TestDecidable: choices.TestDecidable{
+			IDV:     ids.Empty.Prefix(1),
+			StatusV: choices.Processing,
+		},
+		ParentV: Genesis,
+	}
+	block1 := &TestBlock{
+		TestDecidable: choices.TestDecidable{
+			IDV:     ids.Empty.Prefix(2),
+			StatusV: choices.Processing,
+		},
+		ParentV: Genesis,
+	}
+	block2 := &TestBlock{
+		TestDecidable: choices.TestDecidable{
+			IDV:     ids.Empty.Prefix(3),
+			StatusV: choices.Processing,
+		},
+		ParentV: block1,
+	}
+	block3 := &TestBlock{
+		TestDecidable: choices.TestDecidable{
+			IDV:     ids.Empty.Prefix(4),
+			StatusV: choices.Processing,
+		},
+		ParentV: block1,
+	}
+	block4 := &TestBlock{
+		TestDecidable: choices.TestDecidable{
+			IDV:     ids.Empty.Prefix(5),
+			StatusV: choices.Processing,
+		},
+		ParentV: block2,
+	}
+
+	for _, blk := range []*TestBlock{block0, block1, block2, block3, block4} {
+		if err := sm.Add(blk); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	// Current graph structure:
+	//   G
+	//  / \
+	// 0   1
+	//    / \
+	//   2   3
+	//   |
+	//   4
+	// Tail = 0
+
+	votes := ids.Bag{}
+	votes.Add(block0.ID())
+	if err := sm.RecordPoll(votes); err != nil {
+		t.Fatal(err)
+	}
+
+	if !sm.Finalized() {
+		t.Fatalf("Finalized too late")
+	}
+	if len(rejecter.batches) != 1 {
+		t.Fatalf("expected the blocks to be rejected in 1 batch but got %d", len(rejecter.batches))
+	}
+
+	batch := rejecter.batches[0]
+	if len(batch) != 4 {
+		t.Fatalf("expected 4 blocks to be rejected but got %d", len(batch))
+	}
+	positions := make(map[ids.ID]int, len(batch))
+	for i, blk := range batch {
+		positions[blk.ID()] = i
+		if status := blk.Status(); status != choices.Rejected {
+			t.Fatalf("block %s should have been rejected but has status %s", blk.ID(), status)
+		}
+	}
+	for _, blk := range []*TestBlock{block2, block3, block4} {
+		if positions[blk.Parent().ID()] > positions[blk.ID()] {
+			t.Fatalf("block %s was rejected before its parent", blk.ID())
+		}
+	}
+}
+
+func TestTopologicalProcessingTree(t *testing.T) {
+	sm := &Topological{}
+
+	ctx := snow.DefaultContextTest()
+	params := snowball.Parameters{
+		Metrics:           prometheus.NewRegistry(),
+		K:                 1,
+		Alpha:             1,
+		BetaVirtuous:      3,
+		BetaRogue:         5,
+		ConcurrentRepolls: 1,
+	}
+	if err := sm.Initialize(ctx, params, GenesisID); err != nil {
+		t.Fatal(err)
+	}
+
+	if tree := sm.ProcessingTree(); tree.MaxDepth != 0 || len(tree.BranchPoints) != 0 || len(tree.Blocks) != 0 {
+		t.Fatalf("expected an empty processing tree but got %+v", tree)
+	}
+
+	block0 := &TestBlock{
+		TestDecidable: choices.TestDecidable{
+			IDV:     ids.Empty.Prefix(1),
+			StatusV: choices.Processing,
+		},
+		ParentV: Genesis,
+		HeightV: 1,
+	}
+	block1 := &TestBlock{
+		TestDecidable: choices.TestDecidable{
+			IDV:     ids.Empty.Prefix(2),
+			StatusV: choices.Processing,
+		},
+		ParentV: Genesis,
+		HeightV: 1,
+	}
+	block2 := &TestBlock{
+		TestDecidable: choices.TestDecidable{
+			IDV:     ids.Empty.Prefix(3),
+			StatusV: choices.Processing,
+		},
+		ParentV: block1,
+		HeightV: 2,
+	}
+	block3 := &TestBlock{
+		TestDecidable: choices.TestDecidable{
+			IDV:     ids.Empty.Prefix(4),
+			StatusV: choices.Processing,
+		},
+		ParentV: block1,
+		HeightV: 2,
+	}
+	block4 := &TestBlock{
+		TestDecidable: choices.TestDecidable{
+			IDV:     ids.Empty.Prefix(5),
+			StatusV: choices.Processing,
+		},
+		ParentV: block2,
+		HeightV: 3,
+	}
+	for _, blk := range []*TestBlock{block0, block1, block2, block3, block4} {
+		if err := sm.Add(blk); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	// Current graph structure:
+	//   G
+	//  / \
+	// 0   1
+	//    / \
+	//   2   3
+	//   |
+	//   4
+	// Tail = 0
+
+	tree := sm.ProcessingTree()
+	if tree.LastAccepted != GenesisID {
+		t.Fatalf("expected last accepted block %s but got %s", GenesisID, tree.LastAccepted)
+	}
+	if tree.Preference != block0.ID() {
+		t.Fatalf("expected preference %s but got %s", block0.ID(), tree.Preference)
+	}
+	if tree.MaxDepth != 3 {
+		t.Fatalf("expected max depth 3 but got %d", tree.MaxDepth)
+	}
+	expectedBranchPoints := []ids.ID{GenesisID, block1.ID()}
+	ids.SortIDs(expectedBranchPoints)
+	if len(tree.BranchPoints) != 2 || tree.BranchPoints[0] != expectedBranchPoints[0] || tree.BranchPoints[1] != expectedBranchPoints[1] {
+		t.Fatalf("expected branch points %v but got %v", expectedBranchPoints, tree.BranchPoints)
+	}
+
+	expectedBlocks := []ProcessingBlock{
+		{ID: block0.ID(), ParentID: GenesisID, Height: 1, Depth: 1, NumChildren: 0, Preferred: true},
+		{ID: block1.ID(), ParentID: GenesisID, Height: 1, Depth: 1, NumChildren: 2},
+		{ID: block2.ID(), ParentID: block1.ID(), Height: 2, Depth: 2, NumChildren: 1},
+		{ID: block3.ID(), ParentID: block1.ID(), Height: 2, Depth: 2, NumChildren: 0},
+		{ID: block4.ID(), ParentID: block2.ID(), Height: 3, Depth: 3, NumChildren: 0},
+	}
+	if len(tree.Blocks) != len(expectedBlocks) {
+		t.Fatalf("expected %d processing blocks but got %d", len(expectedBlocks), len(tree.Blocks))
+	}
+	blocks := make(map[ids.ID]ProcessingBlock, len(tree.Blocks))
+	for i, blk := range tree.Blocks {
+		blocks[blk.ID] = blk
+		if i > 0 && tree.Blocks[i-1].Depth > blk.Depth {
+			t.Fatalf("blocks should be sorted by depth")
+		}
+	}
+	for _, expected := range expectedBlocks {
+		if blk := blocks[expected.ID]; blk != expected {
+			t.Fatalf("expected block %+v but got %+v", expected, blk)
+		}
+	}
+}
+
+// The shape reported to the metrics is maintained as blocks are added,
+// accepted and rejected, and must match the processing tree
+func TestTopologicalShape(t *testing.T) {
+	sm := &Topological{}
+
+	ctx := snow.DefaultContextTest()
+	params := snowball.Parameters{
+		Metrics:           prometheus.NewRegistry(),
+		K:                 1,
+		Alpha:             1,
+		BetaVirtuous:      3,
+		BetaRogue:         5,
+		ConcurrentRepolls: 1,
+	}
+	if err := sm.Initialize(ctx, params, GenesisID); err != nil {
+		t.Fatal(err)
+	}
+
+	checkShape := func() {
+		tree := sm.ProcessingTree()
+		if maxDepth := len(sm.processingDepths); maxDepth != tree.MaxDepth {
+			t.Fatalf("expected max depth %d but got %d", tree.MaxDepth, maxDepth)
+		}
+		if sm.numBranchPoints != len(tree.BranchPoints) {
+			t.Fatalf("expected %d branch points but got %d", len(tree.BranchPoints), sm.numBranchPoints)
+		}
+	}
+	checkShape()
+
+	block0 := &TestBlock{
+		TestDecidable: choices.TestDecidable{
+			IDV:     ids.Empty.Prefix(1),
+			StatusV: choices.Processing,
+		},
+		ParentV: Genesis,
+	}
+	block1 := &TestBlock{
+		TestDecidable: choices.TestDecidable{
+			IDV:     ids.Empty.Prefix(2),
+			StatusV: choices.Processing,
+		},
+		ParentV: Genesis,
+	}
+	block2 := &TestBlock{
+		TestDecidable: choices.TestDecidable{
+			IDV:     ids.Empty.Prefix(3),
+			StatusV: choices.Processing,
+		},
+		ParentV: block1,
+	}
+	block3 := &TestBlock{
+		TestDecidable: choices.TestDecidable{
+			IDV:     ids.Empty.Prefix(4),
+			StatusV: choices.Processing,
+		},
+		ParentV: block1,
+	}
+	block4 := &TestBlock{
+		TestDecidable: choices.TestDecidable{
+			IDV:     ids.Empty.Prefix(5),
+			StatusV: choices.Processing,
+		},
+		ParentV: block2,
+	}
+	block5 := &TestBlock{
+		TestDecidable: choices.TestDecidable{
+			IDV:     ids.Empty.Prefix(6),
+			StatusV: choices.Processing,
+		},
+		ParentV: block0,
+	}
+	for _, blk := range []*TestBlock{block0, block1, block2, block3, block4, block5} {
+		if err := sm.Add(blk); err != nil {
+			t.Fatal(err)
+		}
+		checkShape()
+	}
+
+	// Current graph structure:
+	//     G
+	//    / \
+	//   0   1
+	//   |  / \
+	//   5 2   3
+	//     |
+	//     4
+	if len(sm.processingDepths) != 3 || sm.numBranchPoints != 2 {
+		t.Fatalf("expected max depth 3 and 2 branch points but got %d and %d", len(sm.processingDepths), sm.numBranchPoints)
+	}
+
+	votes := ids.Bag{}
+	votes.Add(block4.ID())
+	for !sm.Finalized() {
+		if err := sm.RecordPoll(votes); err != nil {
+			t.Fatal(err)
+		}
+		checkShape()
+	}
+	if len(sm.processingDepths) != 0 || sm.numBranchPoints != 0 {
+		t.Fatalf("expected an empty shape but got max depth %d and %d branch points", len(sm.processingDepths), sm.numBranchPoints)
+	}
+	for _, blk := range []*TestBlock{block0, block3, block5} {
+		if blk.Status() != choices.Rejected {
+			t.Fatalf("block %s should have been rejected", blk.ID())
+		}
+	}
+}
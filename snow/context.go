@@ -16,6 +16,7 @@ import (
 	"github.com/ava-labs/avalanchego/database"
 	"github.com/ava-labs/avalanchego/ids"
 	"github.com/ava-labs/avalanchego/utils/logging"
+	"github.com/ava-labs/avalanchego/version"
 )
 
 // Callable ...
@@ -67,6 +68,8 @@ type Context struct {
 	SharedMemory        atomic.SharedMemory
 	BCLookup            AliasLookup
 	SNLookup            SubnetLookup
+	// When each network upgrade activates on this network
+	NetworkUpgrades version.NetworkUpgrades
 
 	// Non-zero iff this chain bootstrapped. Should only be accessed atomically.
 	bootstrapped uint32
@@ -97,6 +100,7 @@ func DefaultContextTest() *Context {
 		DecisionDispatcher:  emptyEventDispatcher{},
 		ConsensusDispatcher: emptyEventDispatcher{},
 		BCLookup:            aliaser,
+		NetworkUpgrades:     version.GetNetworkUpgrades(0),
 		Namespace:           "",
 		Metrics:             prometheus.NewRegistry(),
 	}
@@ -7,6 +7,7 @@ import (
 	"github.com/ava-labs/avalanchego/ids"
 	"github.com/ava-labs/avalanchego/snow/consensus/avalanche"
 	"github.com/ava-labs/avalanchego/snow/consensus/snowstorm"
+	"github.com/ava-labs/avalanchego/snow/validators"
 )
 
 // issuer issues [vtx] into consensus after its dependencies are met.
@@ -86,7 +87,7 @@ func (i *issuer) Update() {
 
 	// Issue a poll for this vertex.
 	p := i.t.Consensus.Parameters()
-	vdrs, err := i.t.Validators.Sample(p.K) // Validators to sample
+	vdrs, err := validators.SampleSnapshot(i.t.Validators, p.K) // Validators to sample
 
 	vdrBag := ids.ShortBag{} // Validators to sample repr. as a set
 	for _, vdr := range vdrs {
@@ -96,7 +97,7 @@ func (i *issuer) Update() {
 	vdrSet := ids.ShortSet{}
 	vdrSet.Add(vdrBag.List()...)
 
-	i.t.RequestID++
+	i.t.NextRequestID()
 	if err == nil && i.t.polls.Add(i.t.RequestID, vdrBag) {
 		i.t.Sender.PushQuery(vdrSet, i.t.RequestID, vtxID, i.vtx.Bytes())
 	} else if err != nil {
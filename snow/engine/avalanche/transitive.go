@@ -17,6 +17,7 @@ import (
 	"github.com/ava-labs/avalanchego/snow/engine/avalanche/vertex"
 	"github.com/ava-labs/avalanchego/snow/engine/common"
 	"github.com/ava-labs/avalanchego/snow/events"
+	"github.com/ava-labs/avalanchego/snow/validators"
 	"github.com/ava-labs/avalanchego/utils/constants"
 	"github.com/ava-labs/avalanchego/utils/formatting"
 	"github.com/ava-labs/avalanchego/utils/sampler"
@@ -335,7 +336,7 @@ func (t *Transitive) Notify(msg common.Message) error {
 		return nil
 	}
 
-	switch msg {
+	switch msg.Type() {
 	case common.PendingTxs:
 		txs := t.VM.PendingTxs()
 		return t.batch(txs, false /*=force*/, false /*=empty*/)
@@ -551,8 +552,8 @@ func (t *Transitive) issueRepoll() {
 	}
 
 	vtxID := preferredIDs.CappedList(1)[0]
-	vdrs, err := t.Validators.Sample(t.Params.K) // Validators to sample
-	vdrBag := ids.ShortBag{}                     // IDs of validators to be sampled
+	vdrs, err := validators.SampleSnapshot(t.Validators, t.Params.K) // Validators to sample
+	vdrBag := ids.ShortBag{}                                         // IDs of validators to be sampled
 	for _, vdr := range vdrs {
 		vdrBag.Add(vdr.ID())
 	}
@@ -561,7 +562,7 @@ func (t *Transitive) issueRepoll() {
 	vdrSet.Add(vdrBag.List()...)
 
 	// Poll the network
-	t.RequestID++
+	t.NextRequestID()
 	if err == nil && t.polls.Add(t.RequestID, vdrBag) {
 		t.Sender.PullQuery(vdrSet, t.RequestID, vtxID)
 	} else if err != nil {
@@ -606,7 +607,7 @@ func (t *Transitive) sendRequest(vdr ids.ShortID, vtxID ids.ID) {
 		t.Ctx.Log.Debug("not sending request for vertex %s because there is already an outstanding request for it", vtxID)
 		return
 	}
-	t.RequestID++
+	t.NextRequestID()
 	t.outstandingVtxReqs.Add(vdr, t.RequestID, vtxID) // Mark that there is an outstanding request for this vertex
 	t.Sender.Get(vdr, t.RequestID, vtxID)
 	t.numVtxRequests.Set(float64(t.outstandingVtxReqs.Len())) // Tracks performance statistics
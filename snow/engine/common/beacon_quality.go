@@ -0,0 +1,216 @@
+// (c) 2019-2020, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package common
+
+import (
+	"sync"
+	"time"
+
+	"github.com/ava-labs/avalanchego/database"
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/snow/validators"
+	"github.com/ava-labs/avalanchego/utils/logging"
+	"github.com/ava-labs/avalanchego/utils/math"
+	"github.com/ava-labs/avalanchego/utils/wrappers"
+)
+
+// When a node restarts, it samples beacons to ask for their accepted frontier.
+// If some of the beacons are offline, every restart waits for the requests to
+// them to time out. To avoid this, how beacons respond to bootstrapping
+// requests is persisted, and beacons that have failed to respond, or that
+// respond slowly, are sampled less often.
+
+const (
+	// Once a beacon has this many recorded responses and failures, they're
+	// halved, so that recent behavior outweighs old behavior
+	maxBeaconObservations = 32
+
+	// A beacon whose average latency is [referenceBeaconLatency] is sampled
+	// half as often as one that responds instantly
+	referenceBeaconLatency = time.Second
+
+	// Beacon weights are scaled by this before they're adjusted, so that the
+	// quality of beacons with small weights, such as the beacons a node is
+	// configured with, which have weight 1, affects how often they're sampled
+	beaconWeightResolution = 1000
+
+	beaconQualitySize = 2*wrappers.IntLen + wrappers.LongLen
+)
+
+// BeaconQuality tracks how beacons have responded to bootstrapping requests
+type BeaconQuality interface {
+	// RegisterResponse records that [vdrID] responded to a request after
+	// [latency]
+	RegisterResponse(vdrID ids.ShortID, latency time.Duration)
+
+	// RegisterFailure records that a request to [vdrID] failed
+	RegisterFailure(vdrID ids.ShortID)
+
+	// Weight returns the weight that [vdrID], which has weight [weight] in the
+	// beacon set, should be sampled with. The returned weights are only
+	// comparable with each other, as [weight] is scaled up so that beacons
+	// with small weights can be told apart. The returned weight is at least
+	// 1, unless [weight] is 0.
+	Weight(vdrID ids.ShortID, weight uint64) uint64
+}
+
+type beaconStats struct {
+	successes uint32
+	failures  uint32
+	// Moving average of the latency of the responses
+	latency time.Duration
+}
+
+// score returns a value in (0, 1) that is higher the more reliably and
+// quickly the beacon has responded. A beacon with no history scores 1/2.
+func (s *beaconStats) score() float64 {
+	successRate := float64(s.successes+1) / float64(s.successes+s.failures+2)
+	latencyFactor := float64(referenceBeaconLatency) / float64(referenceBeaconLatency+s.latency)
+	return successRate * latencyFactor
+}
+
+func (s *beaconStats) decay() {
+	if s.successes+s.failures >= maxBeaconObservations {
+		s.successes /= 2
+		s.failures /= 2
+	}
+}
+
+type beaconQuality struct {
+	log logging.Logger
+	db  database.Database
+
+	lock  sync.Mutex
+	stats map[[20]byte]*beaconStats
+}
+
+// NewBeaconQuality returns a BeaconQuality that persists its observations in
+// [db], so they outlive restarts of the node
+func NewBeaconQuality(log logging.Logger, db database.Database) BeaconQuality {
+	return &beaconQuality{
+		log:   log,
+		db:    db,
+		stats: make(map[[20]byte]*beaconStats),
+	}
+}
+
+// RegisterResponse implements the BeaconQuality interface
+func (q *beaconQuality) RegisterResponse(vdrID ids.ShortID, latency time.Duration) {
+	q.lock.Lock()
+	defer q.lock.Unlock()
+
+	stats := q.get(vdrID)
+	stats.decay()
+	if stats.successes == 0 && stats.latency == 0 {
+		stats.latency = latency
+	} else {
+		stats.latency = (3*stats.latency + latency) / 4
+	}
+	stats.successes++
+	q.put(vdrID, stats)
+}
+
+// RegisterFailure implements the BeaconQuality interface
+func (q *beaconQuality) RegisterFailure(vdrID ids.ShortID) {
+	q.lock.Lock()
+	defer q.lock.Unlock()
+
+	stats := q.get(vdrID)
+	stats.decay()
+	stats.failures++
+	q.put(vdrID, stats)
+}
+
+// Weight implements the BeaconQuality interface
+func (q *beaconQuality) Weight(vdrID ids.ShortID, weight uint64) uint64 {
+	q.lock.Lock()
+	defer q.lock.Unlock()
+
+	if weight == 0 {
+		return 0
+	}
+	scaledWeight, err := math.Mul64(weight, beaconWeightResolution)
+	if err != nil {
+		// The weight is too large to scale, and large enough not to need it
+		scaledWeight = weight
+	}
+	qualityWeight := uint64(float64(scaledWeight) * q.get(vdrID).score())
+	if qualityWeight == 0 {
+		return 1
+	}
+	if qualityWeight > scaledWeight {
+		return scaledWeight
+	}
+	return qualityWeight
+}
+
+// get returns the stats of [vdrID], loading them from the database if they
+// aren't cached. Assumes [q.lock] is held.
+func (q *beaconQuality) get(vdrID ids.ShortID) *beaconStats {
+	key := vdrID.Key()
+	if stats, ok := q.stats[key]; ok {
+		return stats
+	}
+
+	stats := &beaconStats{}
+	q.stats[key] = stats
+
+	statsBytes, err := q.db.Get(vdrID.Bytes())
+	switch {
+	case err == database.ErrNotFound:
+		return stats
+	case err != nil:
+		q.log.Warn("couldn't load the bootstrapping history of %s: %s", vdrID, err)
+		return stats
+	}
+
+	p := wrappers.Packer{Bytes: statsBytes}
+	successes := p.UnpackInt()
+	failures := p.UnpackInt()
+	latency := p.UnpackLong()
+	if p.Errored() {
+		q.log.Warn("couldn't parse the bootstrapping history of %s: %s", vdrID, p.Err)
+		return stats
+	}
+	stats.successes = successes
+	stats.failures = failures
+	stats.latency = time.Duration(latency)
+	return stats
+}
+
+// put persists the stats of [vdrID]. Assumes [q.lock] is held.
+func (q *beaconQuality) put(vdrID ids.ShortID, stats *beaconStats) {
+	p := wrappers.Packer{MaxSize: beaconQualitySize}
+	p.PackInt(stats.successes)
+	p.PackInt(stats.failures)
+	p.PackLong(uint64(stats.latency))
+	if p.Errored() {
+		q.log.Warn("couldn't serialize the bootstrapping history of %s: %s", vdrID, p.Err)
+		return
+	}
+	if err := q.db.Put(vdrID.Bytes(), p.Bytes); err != nil {
+		q.log.Warn("couldn't persist the bootstrapping history of %s: %s", vdrID, err)
+	}
+}
+
+// sampleBeacons samples [size] beacons from [beacons]. If [quality] isn't
+// nil, beacons are sampled in proportion to their weight as adjusted by
+// [quality], rather than in proportion to their weight.
+func sampleBeacons(beacons validators.Set, quality BeaconQuality, size int) ([]validators.Validator, error) {
+	if quality == nil {
+		return beacons.Sample(size)
+	}
+
+	vdrs := beacons.List()
+	weighted := make([]validators.Validator, 0, len(vdrs))
+	for _, vdr := range vdrs {
+		vdrID := vdr.ID()
+		weighted = append(weighted, validators.NewValidator(vdrID, quality.Weight(vdrID, vdr.Weight())))
+	}
+	weightedBeacons := validators.NewSet()
+	if err := weightedBeacons.Set(weighted); err != nil {
+		return nil, err
+	}
+	return weightedBeacons.Sample(size)
+}
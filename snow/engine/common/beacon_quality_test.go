@@ -0,0 +1,136 @@
+// (c) 2019-2020, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package common
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/ava-labs/avalanchego/database/memdb"
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/snow/validators"
+	"github.com/ava-labs/avalanchego/utils/logging"
+)
+
+func TestBeaconQualityWeight(t *testing.T) {
+	quality := NewBeaconQuality(logging.NoLog{}, memdb.New())
+
+	fast := ids.GenerateTestShortID()
+	slow := ids.GenerateTestShortID()
+	dead := ids.GenerateTestShortID()
+	unknown := ids.GenerateTestShortID()
+	for i := 0; i < 10; i++ {
+		quality.RegisterResponse(fast, 10*time.Millisecond)
+		quality.RegisterResponse(slow, 2*time.Second)
+		quality.RegisterFailure(dead)
+	}
+
+	weight := uint64(1000)
+	fastWeight := quality.Weight(fast, weight)
+	slowWeight := quality.Weight(slow, weight)
+	deadWeight := quality.Weight(dead, weight)
+	unknownWeight := quality.Weight(unknown, weight)
+
+	assert.LessOrEqual(t, fastWeight, weight*beaconWeightResolution)
+	assert.Greater(t, fastWeight, unknownWeight)
+	assert.Greater(t, unknownWeight, slowWeight)
+	assert.Greater(t, unknownWeight, deadWeight)
+	assert.GreaterOrEqual(t, deadWeight, uint64(1))
+
+	assert.Equal(t, uint64(0), quality.Weight(dead, 0))
+	assert.GreaterOrEqual(t, quality.Weight(dead, 1), uint64(1), "beacons should always be sampleable")
+	assert.Greater(t, quality.Weight(fast, 1), quality.Weight(dead, 1), "quality should matter for weight 1 beacons")
+}
+
+func TestSampleBeaconsWeightOne(t *testing.T) {
+	quality := NewBeaconQuality(logging.NoLog{}, memdb.New())
+
+	responsive := ids.GenerateTestShortID()
+	unresponsive := ids.GenerateTestShortID()
+	for i := 0; i < 10; i++ {
+		quality.RegisterResponse(responsive, 10*time.Millisecond)
+		quality.RegisterFailure(unresponsive)
+	}
+
+	beacons := validators.NewSet()
+	assert.NoError(t, beacons.AddWeight(responsive, 1))
+	assert.NoError(t, beacons.AddWeight(unresponsive, 1))
+
+	const numSamples = 1000
+	responsiveSamples := 0
+	for i := 0; i < numSamples; i++ {
+		sampled, err := sampleBeacons(beacons, quality, 1)
+		assert.NoError(t, err)
+		assert.Len(t, sampled, 1)
+		if sampled[0].ID() == responsive {
+			responsiveSamples++
+		}
+	}
+	// The responsive beacon is sampled about 90% of the time. Without
+	// adjusting for quality, it would be sampled about half the time.
+	assert.Greater(t, responsiveSamples, 3*numSamples/4)
+}
+
+func TestBeaconQualityPersisted(t *testing.T) {
+	db := memdb.New()
+	quality := NewBeaconQuality(logging.NoLog{}, db)
+
+	dead := ids.GenerateTestShortID()
+	for i := 0; i < 5; i++ {
+		quality.RegisterFailure(dead)
+	}
+	weight := quality.Weight(dead, 1000)
+
+	restartedQuality := NewBeaconQuality(logging.NoLog{}, db)
+	assert.Equal(t, weight, restartedQuality.Weight(dead, 1000))
+}
+
+func TestBeaconQualityRecovers(t *testing.T) {
+	quality := NewBeaconQuality(logging.NoLog{}, memdb.New())
+
+	vdrID := ids.GenerateTestShortID()
+	for i := 0; i < 2*maxBeaconObservations; i++ {
+		quality.RegisterFailure(vdrID)
+	}
+	deadWeight := quality.Weight(vdrID, 1000)
+
+	// Old failures are forgotten once the beacon starts responding again
+	for i := 0; i < maxBeaconObservations; i++ {
+		quality.RegisterResponse(vdrID, 10*time.Millisecond)
+	}
+	assert.Greater(t, quality.Weight(vdrID, 1000), 10*deadWeight)
+}
+
+func TestBootstrapperRegistersBeaconQuality(t *testing.T) {
+	config := DefaultConfigTest()
+	quality := NewBeaconQuality(logging.NoLog{}, memdb.New())
+	config.BeaconQuality = quality
+
+	responsive := ids.GenerateTestShortID()
+	unresponsive := ids.GenerateTestShortID()
+	assert.NoError(t, config.Beacons.Set([]validators.Validator{
+		validators.NewValidator(responsive, 1000),
+		validators.NewValidator(unresponsive, 1000),
+	}))
+	config.SampleK = 2
+	config.Alpha = 1
+
+	bootstrapable := config.Bootstrapable.(*BootstrapableTest)
+	bootstrapable.CantForceAccepted = false
+
+	b := Bootstrapper{}
+	assert.NoError(t, b.Initialize(config))
+
+	assert.NoError(t, b.AcceptedFrontier(responsive, b.RequestID, nil))
+	assert.NoError(t, b.GetAcceptedFrontierFailed(unresponsive, b.RequestID))
+	assert.NoError(t, b.Accepted(responsive, b.RequestID, nil))
+	assert.NoError(t, b.GetAcceptedFailed(unresponsive, b.RequestID))
+
+	// Unexpected responses aren't recorded
+	assert.NoError(t, b.AcceptedFrontier(unresponsive, b.RequestID, nil))
+
+	assert.Greater(t, quality.Weight(responsive, 1000), quality.Weight(unresponsive, 1000))
+}
@@ -9,7 +9,9 @@ import (
 	stdmath "math"
 
 	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/utils/constants"
 	"github.com/ava-labs/avalanchego/utils/math"
+	"github.com/ava-labs/avalanchego/utils/timer"
 )
 
 const (
@@ -28,8 +30,20 @@ const (
 	// MaxTimeFetchingAncestors is the maximum amount of time to spend fetching
 	// vertices during a call to GetAncestors
 	MaxTimeFetchingAncestors = 50 * time.Millisecond
+
+	// RequestEpochBits is the number of high bits of a request ID that hold
+	// the epoch of the engine that sent the request
+	RequestEpochBits = 8
+
+	requestEpochShift = 32 - RequestEpochBits
+	requestEpochMask  = 1<<RequestEpochBits - 1
+	requestIDMask     = 1<<requestEpochShift - 1
 )
 
+// RequestEpoch returns the epoch of the engine that sent the request with ID
+// [requestID]
+func RequestEpoch(requestID uint32) uint32 { return requestID >> requestEpochShift }
+
 // Bootstrapper implements the Engine interface.
 type Bootstrapper struct {
 	Config
@@ -44,6 +58,12 @@ type Bootstrapper struct {
 	pendingAccepted ids.ShortSet
 	acceptedVotes   map[ids.ID]uint64
 
+	// Times the GetAcceptedFrontier and GetAccepted requests were sent, used
+	// to measure how long beacons take to respond
+	acceptedFrontierRequestTime time.Time
+	acceptedRequestTime         time.Time
+	clock                       timer.Clock
+
 	// current weight
 	started bool
 	weight  uint64
@@ -52,8 +72,9 @@ type Bootstrapper struct {
 // Initialize implements the Engine interface.
 func (b *Bootstrapper) Initialize(config Config) error {
 	b.Config = config
+	b.RequestID = (config.RequestEpoch & requestEpochMask) << requestEpochShift
 
-	beacons, err := b.Beacons.Sample(config.SampleK)
+	beacons, err := sampleBeacons(b.Beacons, b.BeaconQuality, config.SampleK)
 	if err != nil {
 		return err
 	}
@@ -75,6 +96,19 @@ func (b *Bootstrapper) Initialize(config Config) error {
 	return b.Startup()
 }
 
+// NextRequestID advances [b.RequestID] to the ID of the next request to send
+// and returns it. Only the low bits are advanced, wrapping around, so that the
+// request ID stays in this engine's epoch. The ID reserved for gossip is
+// skipped.
+func (b *Bootstrapper) NextRequestID() uint32 {
+	epochBits := b.RequestID &^ requestIDMask
+	b.RequestID = epochBits | (b.RequestID+1)&requestIDMask
+	if b.RequestID == constants.GossipMsgRequestID {
+		b.RequestID = epochBits
+	}
+	return b.RequestID
+}
+
 // Startup implements the Engine interface.
 func (b *Bootstrapper) Startup() error {
 	b.started = true
@@ -87,7 +121,8 @@ func (b *Bootstrapper) Startup() error {
 	vdrs := ids.ShortSet{}
 	vdrs.Union(b.pendingAcceptedFrontier)
 
-	b.RequestID++
+	b.NextRequestID()
+	b.acceptedFrontierRequestTime = b.clock.Time()
 	b.Sender.GetAcceptedFrontier(vdrs, b.RequestID)
 	return nil
 }
@@ -100,12 +135,22 @@ func (b *Bootstrapper) GetAcceptedFrontier(validatorID ids.ShortID, requestID ui
 
 // GetAcceptedFrontierFailed implements the Engine interface.
 func (b *Bootstrapper) GetAcceptedFrontierFailed(validatorID ids.ShortID, requestID uint32) error {
+	if b.BeaconQuality != nil && b.pendingAcceptedFrontier.Contains(validatorID) {
+		b.BeaconQuality.RegisterFailure(validatorID)
+	}
 	// If we can't get a response from [validatorID], act as though they said their accepted frontier is empty
-	return b.AcceptedFrontier(validatorID, requestID, nil)
+	return b.handleAcceptedFrontier(validatorID, nil)
 }
 
 // AcceptedFrontier implements the Engine interface.
 func (b *Bootstrapper) AcceptedFrontier(validatorID ids.ShortID, requestID uint32, containerIDs []ids.ID) error {
+	if b.BeaconQuality != nil && b.pendingAcceptedFrontier.Contains(validatorID) {
+		b.BeaconQuality.RegisterResponse(validatorID, b.clock.Time().Sub(b.acceptedFrontierRequestTime))
+	}
+	return b.handleAcceptedFrontier(validatorID, containerIDs)
+}
+
+func (b *Bootstrapper) handleAcceptedFrontier(validatorID ids.ShortID, containerIDs []ids.ID) error {
 	if !b.pendingAcceptedFrontier.Contains(validatorID) {
 		b.Ctx.Log.Debug("Received an AcceptedFrontier message from %s unexpectedly", validatorID)
 		return nil
@@ -124,7 +169,8 @@ func (b *Bootstrapper) AcceptedFrontier(validatorID ids.ShortID, requestID uint3
 		vdrs := ids.ShortSet{}
 		vdrs.Union(b.pendingAccepted)
 
-		b.RequestID++
+		b.NextRequestID()
+		b.acceptedRequestTime = b.clock.Time()
 		b.Sender.GetAccepted(vdrs, b.RequestID, b.acceptedFrontier.List())
 	}
 	return nil
@@ -138,13 +184,23 @@ func (b *Bootstrapper) GetAccepted(validatorID ids.ShortID, requestID uint32, co
 
 // GetAcceptedFailed implements the Engine interface.
 func (b *Bootstrapper) GetAcceptedFailed(validatorID ids.ShortID, requestID uint32) error {
+	if b.BeaconQuality != nil && b.pendingAccepted.Contains(validatorID) {
+		b.BeaconQuality.RegisterFailure(validatorID)
+	}
 	// If we can't get a response from [validatorID], act as though they said
 	// that they think none of the containers we sent them in GetAccepted are accepted
-	return b.Accepted(validatorID, requestID, nil)
+	return b.handleAccepted(validatorID, nil)
 }
 
 // Accepted implements the Engine interface.
 func (b *Bootstrapper) Accepted(validatorID ids.ShortID, requestID uint32, containerIDs []ids.ID) error {
+	if b.BeaconQuality != nil && b.pendingAccepted.Contains(validatorID) {
+		b.BeaconQuality.RegisterResponse(validatorID, b.clock.Time().Sub(b.acceptedRequestTime))
+	}
+	return b.handleAccepted(validatorID, containerIDs)
+}
+
+func (b *Bootstrapper) handleAccepted(validatorID ids.ShortID, containerIDs []ids.ID) error {
 	if !b.pendingAccepted.Contains(validatorID) {
 		b.Ctx.Log.Debug("Received an Accepted message from %s unexpectedly", validatorID)
 		return nil
@@ -0,0 +1,53 @@
+// (c) 2019-2020, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package common
+
+import (
+	"testing"
+
+	"github.com/ava-labs/avalanchego/utils/constants"
+)
+
+func TestNextRequestID(t *testing.T) {
+	tests := []struct {
+		name      string
+		requestID uint32
+		expected  uint32
+	}{
+		{
+			name:      "increments",
+			requestID: 1<<requestEpochShift | 5,
+			expected:  1<<requestEpochShift | 6,
+		},
+		{
+			name:      "wraps within the epoch",
+			requestID: 1<<requestEpochShift | requestIDMask,
+			expected:  1 << requestEpochShift,
+		},
+		{
+			name:      "last epoch wraps",
+			requestID: requestEpochMask<<requestEpochShift | requestIDMask,
+			expected:  requestEpochMask << requestEpochShift,
+		},
+		{
+			name:      "skips the gossip ID",
+			requestID: requestEpochMask<<requestEpochShift | (requestIDMask - 1),
+			expected:  requestEpochMask << requestEpochShift,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			b := Bootstrapper{RequestID: test.requestID}
+			requestID := b.NextRequestID()
+			switch {
+			case requestID != test.expected:
+				t.Fatalf("expected request ID %#x but got %#x", test.expected, requestID)
+			case b.RequestID != requestID:
+				t.Fatalf("expected the request ID to be stored but got %#x", b.RequestID)
+			case requestID == constants.GossipMsgRequestID:
+				t.Fatal("shouldn't use the gossip request ID")
+			}
+		})
+	}
+}
@@ -20,6 +20,22 @@ type Config struct {
 	Alpha         uint64
 	Sender        Sender
 	Bootstrapable Bootstrapable
+
+	// RequestEpoch is mixed into the high bits of the IDs of the requests this
+	// engine sends, so that responses to requests sent before the chain was
+	// restarted don't match requests sent after it. Only the low
+	// [RequestEpochBits] bits are used.
+	RequestEpoch uint32
+
+	// MaxOutstandingRequests is the maximum number of GetAncestors requests
+	// that may be outstanding at once while bootstrapping. Each request is for
+	// a distinct missing container. If 0, [MaxOutstandingRequests] is used.
+	MaxOutstandingRequests int
+
+	// BeaconQuality, if non-nil, records how beacons respond to bootstrapping
+	// requests, and biases which beacons are asked for their accepted frontier
+	// towards those that have responded reliably and quickly
+	BeaconQuality BeaconQuality
 }
 
 // Context implements the Engine interface
@@ -27,3 +43,12 @@ func (c *Config) Context() *snow.Context { return c.Ctx }
 
 // IsBootstrapped returns true iff this chain is done bootstrapping
 func (c *Config) IsBootstrapped() bool { return c.Ctx.IsBootstrapped() }
+
+// MaxOutstanding returns the maximum number of GetAncestors requests that may
+// be outstanding at once while bootstrapping
+func (c *Config) MaxOutstanding() int {
+	if c.MaxOutstandingRequests <= 0 {
+		return MaxOutstandingRequests
+	}
+	return c.MaxOutstandingRequests
+}
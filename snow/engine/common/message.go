@@ -9,7 +9,12 @@ import (
 
 // TODO: Consider renaming Message to, say, VMMessage
 
-// Message is an enum of the message types that vms can send to consensus
+// Message is an enum of the message types that vms can send to consensus.
+//
+// The low [messageTypeBits] bits hold the type of the message. The remaining
+// bits may hold a hint of how much work the VM has pending, such as an
+// estimate of the number of pending transactions. A hint of 0 means the VM
+// didn't provide one.
 type Message uint32
 
 const (
@@ -19,9 +24,40 @@ const (
 	PendingTxs Message = iota
 )
 
+const (
+	messageTypeBits = 8
+	messageTypeMask = 1<<messageTypeBits - 1
+
+	// MaxPendingHint is the largest pending work hint a Message can carry.
+	// Larger hints are capped to this value.
+	MaxPendingHint = 1<<(32-messageTypeBits) - 1
+)
+
+// PendingTxsHint returns a PendingTxs message that also reports that the VM
+// has approximately [numPending] units of work waiting to be issued
+func PendingTxsHint(numPending int) Message {
+	switch {
+	case numPending < 0:
+		numPending = 0
+	case numPending > MaxPendingHint:
+		numPending = MaxPendingHint
+	}
+	return PendingTxs | Message(numPending)<<messageTypeBits
+}
+
+// Type returns this message with its pending work hint removed
+func (msg Message) Type() Message { return msg & messageTypeMask }
+
+// PendingHint returns the pending work hint carried by this message, or 0 if
+// there isn't one
+func (msg Message) PendingHint() int { return int(msg >> messageTypeBits) }
+
 func (msg Message) String() string {
-	switch msg {
+	switch msg.Type() {
 	case PendingTxs:
+		if hint := msg.PendingHint(); hint != 0 {
+			return fmt.Sprintf("Pending Transactions (~%d)", hint)
+		}
 		return "Pending Transactions"
 	default:
 		return fmt.Sprintf("Unknown Message: %d", msg)
@@ -0,0 +1,37 @@
+// (c) 2019-2020, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package common
+
+import (
+	"testing"
+)
+
+func TestMessagePendingHint(t *testing.T) {
+	tests := []struct {
+		numPending int
+		expected   int
+	}{
+		{numPending: -1, expected: 0},
+		{numPending: 0, expected: 0},
+		{numPending: 1, expected: 1},
+		{numPending: 1000, expected: 1000},
+		{numPending: MaxPendingHint + 1, expected: MaxPendingHint},
+	}
+	for _, test := range tests {
+		msg := PendingTxsHint(test.numPending)
+		if msgType := msg.Type(); msgType != PendingTxs {
+			t.Fatalf("PendingTxsHint(%d) has type %s", test.numPending, msgType)
+		}
+		if hint := msg.PendingHint(); hint != test.expected {
+			t.Fatalf("PendingTxsHint(%d) should carry a hint of %d but carries %d", test.numPending, test.expected, hint)
+		}
+	}
+
+	if hint := PendingTxs.PendingHint(); hint != 0 {
+		t.Fatalf("PendingTxs shouldn't carry a hint but carries %d", hint)
+	}
+	if PendingTxs.Type() != PendingTxs {
+		t.Fatalf("PendingTxs should be unchanged by Type")
+	}
+}
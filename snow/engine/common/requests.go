@@ -90,3 +90,10 @@ func (r *Requests) Contains(containerID ids.ID) bool {
 	_, ok := r.idToReq[containerID]
 	return ok
 }
+
+// ContainsValidator returns true if there is an outstanding request to the
+// validator.
+func (r *Requests) ContainsValidator(vdr ids.ShortID) bool {
+	_, ok := r.reqsToID[vdr.Key()]
+	return ok
+}
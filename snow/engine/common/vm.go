@@ -65,6 +65,14 @@ type VM interface {
 	Health() (interface{}, error)
 }
 
+// FeeTracker is implemented by VMs that keep track of the fees burned on their
+// chain
+type FeeTracker interface {
+	// Returns the amount of AVAX, denominated in nAVAX, that transactions
+	// accepted on this chain have burned as fees.
+	BurnedFees() (uint64, error)
+}
+
 // StaticVM describes the functionality that allows a user to interact with a VM
 // statically.
 type StaticVM interface {
@@ -43,6 +43,10 @@ type Bootstrapper struct {
 
 	Bootstrapped func()
 
+	// IDs of blocks that we will send a GetAncestors request for once we are
+	// not at the max number of outstanding requests
+	needToFetch ids.Set
+
 	// true if all of the vertices in the original accepted frontier have been processed
 	processedStartingAcceptedFrontier bool
 }
@@ -98,48 +102,85 @@ func (b *Bootstrapper) ForceAccepted(acceptedContainerIDs []ids.ID) error {
 			err)
 	}
 
+	toFetch := make([]ids.ID, 0, len(acceptedContainerIDs))
 	for _, blkID := range acceptedContainerIDs {
 		if blk, err := b.VM.GetBlock(blkID); err == nil {
 			if err := b.process(blk); err != nil {
 				return err
 			}
-		} else if err := b.fetch(blkID); err != nil {
-			return err
+		} else {
+			toFetch = append(toFetch, blkID)
 		}
 	}
 
 	b.processedStartingAcceptedFrontier = true
-	if numPending := b.OutstandingRequests.Len(); numPending == 0 {
-		return b.finish()
-	}
-	return nil
+	return b.fetch(toFetch...)
 }
 
-// Get block [blkID] and its ancestors from a validator
-func (b *Bootstrapper) fetch(blkID ids.ID) error {
-	// Make sure we haven't already requested this block
-	if b.OutstandingRequests.Contains(blkID) {
-		return nil
-	}
+// Add the blocks in [blkIDs] to the set of blocks that we need to fetch, and
+// then fetch blocks (and their ancestors) until either there are no more to
+// fetch or we are at the maximum number of outstanding requests. Each request
+// is sent to a beacon that has no other outstanding request, if there is one.
+// Received blocks are ordered for execution by [Blocked], regardless of the
+// order in which the responses arrive.
+//
+// Only distinct missing blocks, such as the blocks of a multi-block accepted
+// frontier, are fetched in parallel. A block's missing parent is only known
+// once the block is received, so the ancestors of a single tip are still
+// fetched one request at a time.
+func (b *Bootstrapper) fetch(blkIDs ...ids.ID) error {
+	b.needToFetch.Add(blkIDs...)
+	for b.needToFetch.Len() > 0 && b.OutstandingRequests.Len() < b.MaxOutstanding() {
+		blkID := b.needToFetch.CappedList(1)[0]
+		b.needToFetch.Remove(blkID)
+
+		// Make sure we haven't already requested this block
+		if b.OutstandingRequests.Contains(blkID) {
+			continue
+		}
 
-	// Make sure we don't already have this block
-	if _, err := b.VM.GetBlock(blkID); err == nil {
-		if numPending := b.OutstandingRequests.Len(); numPending == 0 && b.processedStartingAcceptedFrontier {
-			return b.finish()
+		// Make sure we don't already have this block. It may have been
+		// received in a response to another request since it was queued.
+		if blk, err := b.VM.GetBlock(blkID); err == nil && blk.Status() != choices.Unknown {
+			if err := b.process(blk); err != nil {
+				return err
+			}
+			continue
 		}
-		return nil
-	}
 
-	validators, err := b.Beacons.Sample(1) // validator to send request to
-	if err != nil {
-		return fmt.Errorf("dropping request for %s as there are no validators", blkID)
+		validatorID, err := b.sampleBeacon()
+		if err != nil {
+			return fmt.Errorf("dropping request for %s as there are no validators", blkID)
+		}
+		b.NextRequestID()
+
+		b.OutstandingRequests.Add(validatorID, b.RequestID, blkID)
+		b.Sender.GetAncestors(validatorID, b.RequestID, blkID) // request block and ancestors
 	}
-	validatorID := validators[0].ID()
-	b.RequestID++
+	return b.checkFinish()
+}
 
-	b.OutstandingRequests.Add(validatorID, b.RequestID, blkID)
-	b.Sender.GetAncestors(validatorID, b.RequestID, blkID) // request block and ancestors
-	return nil
+// sampleBeacon returns a beacon to send a GetAncestors request to, preferring
+// beacons that don't already have an outstanding request
+func (b *Bootstrapper) sampleBeacon() (ids.ShortID, error) {
+	// At most [MaxOutstanding] - 1 beacons are busy, so sampling
+	// [MaxOutstanding] distinct beacons finds an idle one if there is one
+	size := b.MaxOutstanding()
+	if numBeacons := b.Beacons.Len(); size > numBeacons {
+		size = numBeacons
+	}
+	validators, err := b.Beacons.Sample(size)
+	if err != nil || len(validators) == 0 {
+		if validators, err = b.Beacons.Sample(1); err != nil {
+			return ids.ShortID{}, err
+		}
+	}
+	for _, vdr := range validators {
+		if vdrID := vdr.ID(); !b.OutstandingRequests.ContainsValidator(vdrID) {
+			return vdrID, nil
+		}
+	}
+	return validators[0].ID(), nil
 }
 
 // MultiPut handles the receipt of multiple containers. Should be received in response to a GetAncestors message to [vdr]
@@ -229,8 +270,13 @@ func (b *Bootstrapper) process(blk snowman.Block) error {
 	case choices.Rejected: // Should never happen
 		return fmt.Errorf("bootstrapping wants to accept %s, however it was previously rejected", blkID)
 	}
+	return b.checkFinish()
+}
 
-	if numPending := b.OutstandingRequests.Len(); numPending == 0 && b.processedStartingAcceptedFrontier {
+// checkFinish finishes bootstrapping if the starting accepted frontier has
+// been processed and there are no blocks left to fetch
+func (b *Bootstrapper) checkFinish() error {
+	if b.OutstandingRequests.Len() == 0 && b.needToFetch.Len() == 0 && b.processedStartingAcceptedFrontier {
 		return b.finish()
 	}
 	return nil
@@ -809,3 +809,196 @@ func TestBootstrapperFinalized(t *testing.T) {
 		t.Fatalf("Block should be accepted")
 	}
 }
+
+// Requests for disjoint blocks are spread across distinct beacons and are
+// capped at the configured number of outstanding requests
+func TestBootstrapperMaxOutstandingRequests(t *testing.T) {
+	config, peerID0, sender, vm := newConfig(t)
+
+	peerID1 := ids.GenerateTestShortID()
+	if err := config.Beacons.AddWeight(peerID1, 1); err != nil {
+		t.Fatal(err)
+	}
+	config.MaxOutstandingRequests = 2
+
+	blkID1 := ids.Empty.Prefix(1)
+	blkID2 := ids.Empty.Prefix(2)
+	blkID3 := ids.Empty.Prefix(3)
+
+	finished := new(bool)
+	bs := Bootstrapper{}
+	err := bs.Initialize(
+		config,
+		func() error { *finished = true; return nil },
+		fmt.Sprintf("%s_%s", constants.PlatformName, config.Ctx.ChainID),
+		prometheus.NewRegistry(),
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	vm.GetBlockF = func(blkID ids.ID) (snowman.Block, error) {
+		return nil, errUnknownBlock
+	}
+
+	type request struct {
+		vdr   ids.ShortID
+		reqID uint32
+	}
+	requests := map[ids.ID]request{}
+	sender.GetAncestorsF = func(vdr ids.ShortID, reqID uint32, blkID ids.ID) {
+		requests[blkID] = request{vdr: vdr, reqID: reqID}
+	}
+
+	vm.CantBootstrapping = false
+
+	if err := bs.ForceAccepted([]ids.ID{blkID1, blkID2, blkID3}); err != nil {
+		t.Fatal(err)
+	}
+
+	if len(requests) != 2 {
+		t.Fatalf("should have sent 2 requests but sent %d", len(requests))
+	}
+	vdrs := ids.ShortSet{}
+	for _, req := range requests {
+		vdrs.Add(req.vdr)
+	}
+	if !vdrs.Contains(peerID0) || !vdrs.Contains(peerID1) {
+		t.Fatalf("should have sent requests to distinct beacons")
+	}
+
+	// Failing a request frees a slot for the queued block, and the failed
+	// block is queued again
+	var failedID ids.ID
+	for blkID, req := range requests {
+		failedID = blkID
+		delete(requests, blkID)
+		if err := bs.GetAncestorsFailed(req.vdr, req.reqID); err != nil {
+			t.Fatal(err)
+		}
+		break
+	}
+
+	switch {
+	case len(requests) != 2:
+		t.Fatalf("should have sent another request")
+	case bs.OutstandingRequests.Len() != 2:
+		t.Fatalf("should have 2 outstanding requests but have %d", bs.OutstandingRequests.Len())
+	case !bs.needToFetch.Contains(failedID) && !bs.OutstandingRequests.Contains(failedID):
+		t.Fatalf("should still need to fetch %s", failedID)
+	case *finished:
+		t.Fatalf("Bootstrapping shouldn't have finished")
+	}
+}
+
+// The ancestors of a single tip are fetched one request at a time, as each
+// block's missing parent is only known once the block is received
+func TestBootstrapperSingleTipSequential(t *testing.T) {
+	config, peerID0, sender, vm := newConfig(t)
+
+	peerID1 := ids.GenerateTestShortID()
+	if err := config.Beacons.AddWeight(peerID1, 1); err != nil {
+		t.Fatal(err)
+	}
+	config.MaxOutstandingRequests = 2
+
+	blk0 := &snowman.TestBlock{
+		TestDecidable: choices.TestDecidable{
+			IDV:     ids.Empty.Prefix(0),
+			StatusV: choices.Accepted,
+		},
+		HeightV: 0,
+		BytesV:  []byte{0},
+	}
+	blks := []*snowman.TestBlock{blk0}
+	for i := 1; i <= 3; i++ {
+		blks = append(blks, &snowman.TestBlock{
+			TestDecidable: choices.TestDecidable{
+				IDV:     ids.Empty.Prefix(uint64(i)),
+				StatusV: choices.Unknown,
+			},
+			ParentV: blks[i-1],
+			HeightV: uint64(i),
+			BytesV:  []byte{byte(i)},
+		})
+	}
+
+	finished := new(bool)
+	bs := Bootstrapper{}
+	err := bs.Initialize(
+		config,
+		func() error { *finished = true; return nil },
+		fmt.Sprintf("%s_%s", constants.PlatformName, config.Ctx.ChainID),
+		prometheus.NewRegistry(),
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	vm.GetBlockF = func(blkID ids.ID) (snowman.Block, error) {
+		for _, blk := range blks {
+			if blk.ID() == blkID && blk.Status() != choices.Unknown {
+				return blk, nil
+			}
+		}
+		return nil, errUnknownBlock
+	}
+	vm.ParseBlockF = func(blkBytes []byte) (snowman.Block, error) {
+		for _, blk := range blks {
+			if bytes.Equal(blk.Bytes(), blkBytes) {
+				if blk.Status() == choices.Unknown {
+					blk.StatusV = choices.Processing
+				}
+				return blk, nil
+			}
+		}
+		t.Fatal(errUnknownBlock)
+		return nil, errUnknownBlock
+	}
+
+	var (
+		requestedVdr ids.ShortID
+		requestID    uint32
+		requested    ids.ID
+	)
+	sender.GetAncestorsF = func(vdr ids.ShortID, reqID uint32, blkID ids.ID) {
+		if !vdr.Equals(peerID0) && !vdr.Equals(peerID1) {
+			t.Fatalf("requested block from unknown beacon %s", vdr)
+		}
+		requestedVdr = vdr
+		requestID = reqID
+		requested = blkID
+	}
+
+	vm.CantBootstrapping = false
+
+	if err := bs.ForceAccepted([]ids.ID{blks[3].ID()}); err != nil {
+		t.Fatal(err)
+	}
+
+	vm.CantBootstrapped = false
+
+	for i := 3; i > 0; i-- {
+		switch {
+		case requested != blks[i].ID():
+			t.Fatalf("should have requested block %d", i)
+		case bs.OutstandingRequests.Len() != 1:
+			t.Fatalf("should have 1 outstanding request but have %d", bs.OutstandingRequests.Len())
+		}
+		if err := bs.MultiPut(requestedVdr, requestID, [][]byte{blks[i].Bytes()}); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	switch {
+	case !*finished:
+		t.Fatalf("Bootstrapping should have finished")
+	case bs.OutstandingRequests.Len() != 0:
+		t.Fatalf("should have no outstanding requests")
+	}
+	for i, blk := range blks {
+		if blk.Status() != choices.Accepted {
+			t.Fatalf("block %d should be accepted", i)
+		}
+	}
+}
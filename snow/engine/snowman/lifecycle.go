@@ -0,0 +1,204 @@
+// (c) 2019-2020, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package snowman
+
+import (
+	"time"
+
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/snow/consensus/snowman"
+	"github.com/ava-labs/avalanchego/utils/timer"
+)
+
+const (
+	// Max number of undecided blocks whose lifecycles are tracked at once
+	maxProcessingLifecycles = 1024
+	// Number of accepted blocks whose lifecycles are remembered
+	maxAcceptedLifecycles = 128
+)
+
+// BlockLifecycle records when a block reached each stage of being processed by
+// this node. A stage the block skipped is the zero time. For example, a block
+// fetched from the VM as an option of an oracle block is never received.
+type BlockLifecycle struct {
+	ID ids.ID
+	// Height of the block, or 0 if the block doesn't report its height
+	Height uint64
+	// When the block's bytes were received from a peer. For a block built by
+	// this node, when the VM was asked to build it.
+	Received time.Time
+	// When the block was parsed, or built
+	Parsed time.Time
+	// When the block passed verification
+	Verified time.Time
+	// When the block was added to consensus
+	Issued time.Time
+	// When consensus accepted the block
+	Accepted time.Time
+}
+
+// lifecycles tracks the lifecycles of the blocks being processed, and
+// remembers the lifecycles of the most recently accepted blocks
+type lifecycles struct {
+	clock timer.Clock
+
+	processing map[ids.ID]*BlockLifecycle
+	// Ring buffer of accepted lifecycles. [next] is the index the next one is
+	// written to.
+	history []BlockLifecycle
+	next    int
+}
+
+// track returns the lifecycle of [blk], starting to track it if it isn't
+// already tracked. Returns nil if [blk] is decided.
+func (l *lifecycles) track(blk snowman.Block) *BlockLifecycle {
+	if l.processing == nil {
+		l.processing = make(map[ids.ID]*BlockLifecycle)
+	}
+
+	blkID := blk.ID()
+	if lifecycle, ok := l.processing[blkID]; ok {
+		return lifecycle
+	}
+	if blk.Status().Decided() {
+		return nil
+	}
+	if len(l.processing) >= maxProcessingLifecycles {
+		l.evictOldest()
+	}
+
+	lifecycle := &BlockLifecycle{ID: blkID}
+	if heightBlk, ok := blk.(interface{ Height() uint64 }); ok {
+		lifecycle.Height = heightBlk.Height()
+	}
+	l.processing[blkID] = lifecycle
+	return lifecycle
+}
+
+// evictOldest stops tracking the block that started being tracked first. This
+// is typically a block that will never be issued, because it's missing an
+// ancestor that was never fetched.
+func (l *lifecycles) evictOldest() {
+	var (
+		oldestID   ids.ID
+		oldestTime time.Time
+	)
+	for blkID, lifecycle := range l.processing {
+		if start := lifecycle.start(); oldestTime.IsZero() || start.Before(oldestTime) {
+			oldestID = blkID
+			oldestTime = start
+		}
+	}
+	delete(l.processing, oldestID)
+}
+
+// parsed records that [blk], whose bytes were received at [received], has
+// been parsed. If [blk] was already parsed, this is a noop.
+func (l *lifecycles) parsed(blk snowman.Block, received time.Time) {
+	if lifecycle := l.track(blk); lifecycle != nil && lifecycle.Parsed.IsZero() {
+		lifecycle.Received = received
+		lifecycle.Parsed = l.clock.Time()
+	}
+}
+
+// verified records that [blk] passed verification
+func (l *lifecycles) verified(blk snowman.Block) {
+	if lifecycle := l.track(blk); lifecycle != nil && lifecycle.Verified.IsZero() {
+		lifecycle.Verified = l.clock.Time()
+	}
+}
+
+// issued records that [blk] was added to consensus
+func (l *lifecycles) issued(blk snowman.Block) {
+	if lifecycle := l.track(blk); lifecycle != nil && lifecycle.Issued.IsZero() {
+		lifecycle.Issued = l.clock.Time()
+	}
+}
+
+// dropped stops tracking the block with ID [blkID], because it won't be issued
+func (l *lifecycles) dropped(blkID ids.ID) {
+	delete(l.processing, blkID)
+}
+
+// accepted remembers the lifecycle of the block with ID [blkID], which was
+// just accepted, and stops tracking it
+func (l *lifecycles) accepted(blkID ids.ID) {
+	lifecycle, ok := l.processing[blkID]
+	if !ok {
+		return
+	}
+	delete(l.processing, blkID)
+	lifecycle.Accepted = l.clock.Time()
+	l.remember(*lifecycle)
+}
+
+// rejected stops tracking the block with ID [blkID], which was just rejected
+func (l *lifecycles) rejected(blkID ids.ID) {
+	delete(l.processing, blkID)
+}
+
+// remember adds [lifecycle] to the accepted lifecycles, overwriting the oldest
+// one if there are too many
+func (l *lifecycles) remember(lifecycle BlockLifecycle) {
+	if len(l.history) < maxAcceptedLifecycles {
+		l.history = append(l.history, lifecycle)
+		return
+	}
+	l.history[l.next] = lifecycle
+	l.next = (l.next + 1) % maxAcceptedLifecycles
+}
+
+// recent returns the lifecycles of the most recently accepted blocks, from the
+// most recently accepted to the least
+func (l *lifecycles) recent() []BlockLifecycle {
+	lifecycles := make([]BlockLifecycle, 0, len(l.history))
+	for i := 1; i <= len(l.history); i++ {
+		lifecycles = append(lifecycles, l.history[(l.next-i+len(l.history))%len(l.history)])
+	}
+	return lifecycles
+}
+
+// start returns the time of the first stage this block reached
+func (l *BlockLifecycle) start() time.Time {
+	for _, t := range []time.Time{l.Received, l.Parsed, l.Verified, l.Issued} {
+		if !t.IsZero() {
+			return t
+		}
+	}
+	return l.Accepted
+}
+
+// lifecycleBlock wraps a block that is added to consensus, so that its
+// lifecycle is updated as soon as consensus decides it
+type lifecycleBlock struct {
+	snowman.Block
+	lifecycles *lifecycles
+}
+
+// Height returns the height of the wrapped block, or 0 if it doesn't report
+// its height
+func (b *lifecycleBlock) Height() uint64 {
+	if heightBlk, ok := b.Block.(interface{ Height() uint64 }); ok {
+		return heightBlk.Height()
+	}
+	return 0
+}
+
+// Accept implements the snowman.Block interface
+func (b *lifecycleBlock) Accept() error {
+	if err := b.Block.Accept(); err != nil {
+		return err
+	}
+	b.lifecycles.accepted(b.ID())
+	return nil
+}
+
+// Reject implements the snowman.Block interface
+func (b *lifecycleBlock) Reject() error {
+	if err := b.Block.Reject(); err != nil {
+		return err
+	}
+	b.lifecycles.rejected(b.ID())
+	return nil
+}
@@ -0,0 +1,150 @@
+// (c) 2019-2020, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package snowman
+
+import (
+	"testing"
+	"time"
+
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/snow/choices"
+	"github.com/ava-labs/avalanchego/snow/consensus/snowman"
+)
+
+func TestLifecycles(t *testing.T) {
+	l := lifecycles{}
+	start := time.Unix(1000, 0)
+	l.clock.Set(start)
+
+	blk0 := &snowman.TestBlock{
+		TestDecidable: choices.TestDecidable{
+			IDV:     ids.GenerateTestID(),
+			StatusV: choices.Processing,
+		},
+		HeightV: 1,
+	}
+	blk1 := &snowman.TestBlock{
+		TestDecidable: choices.TestDecidable{
+			IDV:     ids.GenerateTestID(),
+			StatusV: choices.Processing,
+		},
+		ParentV: blk0,
+		HeightV: 2,
+	}
+	rejected := &snowman.TestBlock{
+		TestDecidable: choices.TestDecidable{
+			IDV:     ids.GenerateTestID(),
+			StatusV: choices.Processing,
+		},
+		ParentV: blk0,
+		HeightV: 2,
+	}
+
+	l.clock.Set(start.Add(time.Second))
+	l.parsed(blk0, start)
+	l.parsed(blk1, start)
+	l.parsed(rejected, start)
+	l.clock.Set(start.Add(2 * time.Second))
+	l.verified(blk0)
+	l.verified(blk1)
+	l.verified(rejected)
+	l.clock.Set(start.Add(3 * time.Second))
+	l.issued(blk0)
+	l.issued(blk1)
+	l.issued(rejected)
+
+	// Parsing a block again shouldn't change when it was received
+	l.parsed(blk0, start.Add(3*time.Second))
+
+	if recent := l.recent(); len(recent) != 0 {
+		t.Fatalf("Shouldn't have recorded undecided blocks as accepted")
+	}
+
+	// Deciding a block that was added to consensus updates its lifecycle
+	l.clock.Set(start.Add(4 * time.Second))
+	if err := (&lifecycleBlock{Block: blk0, lifecycles: &l}).Accept(); err != nil {
+		t.Fatal(err)
+	}
+	if err := (&lifecycleBlock{Block: rejected, lifecycles: &l}).Reject(); err != nil {
+		t.Fatal(err)
+	}
+	if _, ok := l.processing[rejected.ID()]; ok {
+		t.Fatalf("Should have stopped tracking the rejected block")
+	}
+	l.clock.Set(start.Add(5 * time.Second))
+	if err := (&lifecycleBlock{Block: blk1, lifecycles: &l}).Accept(); err != nil {
+		t.Fatal(err)
+	}
+
+	recent := l.recent()
+	if len(recent) != 2 {
+		t.Fatalf("Should have recorded 2 accepted blocks but recorded %d", len(recent))
+	}
+	if recent[0].ID != blk1.ID() || recent[1].ID != blk0.ID() {
+		t.Fatalf("Should have sorted the blocks from the most recently accepted")
+	}
+	if recent[0].Accepted != start.Add(5*time.Second) {
+		t.Fatalf("Should have recorded when the block was accepted")
+	}
+	expected := BlockLifecycle{
+		ID:       blk0.ID(),
+		Height:   1,
+		Received: start,
+		Parsed:   start.Add(time.Second),
+		Verified: start.Add(2 * time.Second),
+		Issued:   start.Add(3 * time.Second),
+		Accepted: start.Add(4 * time.Second),
+	}
+	if recent[1] != expected {
+		t.Fatalf("Expected lifecycle %+v but got %+v", expected, recent[1])
+	}
+	if len(l.processing) != 0 {
+		t.Fatalf("Should have stopped tracking decided blocks")
+	}
+
+	// Decided blocks shouldn't be tracked again
+	l.verified(blk0)
+	if len(l.processing) != 0 {
+		t.Fatalf("Shouldn't track a decided block")
+	}
+}
+
+func TestLifecyclesLimits(t *testing.T) {
+	l := lifecycles{}
+
+	blks := make([]*snowman.TestBlock, maxProcessingLifecycles+1)
+	for i := range blks {
+		blks[i] = &snowman.TestBlock{
+			TestDecidable: choices.TestDecidable{
+				IDV:     ids.GenerateTestID(),
+				StatusV: choices.Processing,
+			},
+			HeightV: uint64(i),
+		}
+		l.clock.Set(time.Unix(int64(i+1), 0))
+		l.parsed(blks[i], l.clock.Time())
+	}
+	if len(l.processing) != maxProcessingLifecycles {
+		t.Fatalf("Should have tracked at most %d blocks but tracked %d", maxProcessingLifecycles, len(l.processing))
+	}
+	if _, ok := l.processing[blks[0].ID()]; ok {
+		t.Fatalf("Should have evicted the oldest block")
+	}
+
+	for _, blk := range blks {
+		blk.StatusV = choices.Accepted
+		l.accepted(blk.ID())
+	}
+
+	recent := l.recent()
+	if len(recent) != maxAcceptedLifecycles {
+		t.Fatalf("Should have remembered %d accepted blocks but remembered %d", maxAcceptedLifecycles, len(recent))
+	}
+	if recent[0].ID != blks[len(blks)-1].ID() {
+		t.Fatalf("Should have remembered the most recently accepted block first")
+	}
+	if last := recent[len(recent)-1]; last.ID != blks[len(blks)-maxAcceptedLifecycles].ID() {
+		t.Fatalf("Should have forgotten the least recently accepted blocks")
+	}
+}
@@ -16,6 +16,7 @@ import (
 	"github.com/ava-labs/avalanchego/snow/engine/common"
 	"github.com/ava-labs/avalanchego/snow/engine/snowman/bootstrap"
 	"github.com/ava-labs/avalanchego/snow/events"
+	"github.com/ava-labs/avalanchego/snow/validators"
 	"github.com/ava-labs/avalanchego/utils/constants"
 	"github.com/ava-labs/avalanchego/utils/formatting"
 	"github.com/ava-labs/avalanchego/utils/wrappers"
@@ -49,6 +50,10 @@ type Transitive struct {
 	// issuing another block, responding to a query, or applying votes to consensus
 	blocked events.Blocker
 
+	// timestamps of the stages blocks pass through on their way to being
+	// accepted
+	lifecycles lifecycles
+
 	// errs tracks if an error has occurred in a callback
 	errs wrappers.Errs
 }
@@ -199,6 +204,7 @@ func (t *Transitive) Put(vdr ids.ShortID, requestID uint32, blkID ids.ID, blkByt
 		return nil
 	}
 
+	received := t.lifecycles.clock.Time()
 	blk, err := t.VM.ParseBlock(blkBytes)
 	if err != nil {
 		t.Ctx.Log.Debug("failed to parse block %s: %s", blkID, err)
@@ -208,6 +214,7 @@ func (t *Transitive) Put(vdr ids.ShortID, requestID uint32, blkID ids.ID, blkByt
 		// abandon the request.
 		return t.GetFailed(vdr, requestID)
 	}
+	t.lifecycles.parsed(blk, received)
 
 	// issue the block into consensus. If the block has already been issued,
 	// this will be a noop. If this block has missing dependencies, vdr will
@@ -280,6 +287,7 @@ func (t *Transitive) PushQuery(vdr ids.ShortID, requestID uint32, blkID ids.ID,
 		return nil
 	}
 
+	received := t.lifecycles.clock.Time()
 	blk, err := t.VM.ParseBlock(blkBytes)
 	// If parsing fails, we just drop the request, as we didn't ask for it
 	if err != nil {
@@ -287,6 +295,7 @@ func (t *Transitive) PushQuery(vdr ids.ShortID, requestID uint32, blkID ids.ID,
 		t.Ctx.Log.Verbo("block:\n%s", formatting.DumpBytes{Bytes: blkBytes})
 		return nil
 	}
+	t.lifecycles.parsed(blk, received)
 
 	// issue the block into consensus. If the block has already been issued,
 	// this will be a noop. If this block has missing dependencies, vdr will
@@ -368,14 +377,16 @@ func (t *Transitive) Notify(msg common.Message) error {
 	}
 
 	t.Ctx.Log.Verbo("snowman engine notified of %s from the vm", msg)
-	switch msg {
+	switch msg.Type() {
 	case common.PendingTxs:
 		// the pending txs message means we should attempt to build a block.
+		buildStart := t.lifecycles.clock.Time()
 		blk, err := t.VM.BuildBlock()
 		if err != nil {
 			t.Ctx.Log.Debug("VM.BuildBlock errored with: %s", err)
 			return nil
 		}
+		t.lifecycles.parsed(blk, buildStart)
 
 		// a newly created block is expected to be processing. If this check
 		// fails, there is potentially an error in the VM this engine is running
@@ -525,7 +536,7 @@ func (t *Transitive) sendRequest(vdr ids.ShortID, blkID ids.ID) {
 		return
 	}
 
-	t.RequestID++
+	t.NextRequestID()
 	t.blkReqs.Add(vdr, t.RequestID, blkID)
 	t.Ctx.Log.Verbo("sending Get(%s, %d, %s)", vdr, t.RequestID, blkID)
 	t.Sender.Get(vdr, t.RequestID, blkID)
@@ -538,13 +549,13 @@ func (t *Transitive) sendRequest(vdr ids.ShortID, blkID ids.ID) {
 func (t *Transitive) pullSample(blkID ids.ID) {
 	t.Ctx.Log.Verbo("about to sample from: %s", t.Validators)
 	// The validators we will query
-	vdrs, err := t.Validators.Sample(t.Params.K)
+	vdrs, err := validators.SampleSnapshot(t.Validators, t.Params.K)
 	vdrBag := ids.ShortBag{}
 	for _, vdr := range vdrs {
 		vdrBag.Add(vdr.ID())
 	}
 
-	t.RequestID++
+	t.NextRequestID()
 	if err == nil && t.polls.Add(t.RequestID, vdrBag) {
 		vdrSet := ids.ShortSet{}
 		vdrSet.Add(vdrBag.List()...)
@@ -558,13 +569,13 @@ func (t *Transitive) pullSample(blkID ids.ID) {
 // send a push request for this block
 func (t *Transitive) pushSample(blk snowman.Block) {
 	t.Ctx.Log.Verbo("about to sample from: %s", t.Validators)
-	vdrs, err := t.Validators.Sample(t.Params.K)
+	vdrs, err := validators.SampleSnapshot(t.Validators, t.Params.K)
 	vdrBag := ids.ShortBag{}
 	for _, vdr := range vdrs {
 		vdrBag.Add(vdr.ID())
 	}
 
-	t.RequestID++
+	t.NextRequestID()
 	if err == nil && t.polls.Add(t.RequestID, vdrBag) {
 		vdrSet := ids.ShortSet{}
 		vdrSet.Add(vdrBag.List()...)
@@ -590,15 +601,19 @@ func (t *Transitive) deliver(blk snowman.Block) error {
 		t.Ctx.Log.Debug("block failed verification due to %s, dropping block", err)
 
 		// if verify fails, then all descendants are also invalid
+		t.lifecycles.dropped(blkID)
 		t.blocked.Abandon(blkID)
 		t.numBlocked.Set(float64(t.pending.Len())) // Tracks performance statistics
 		return t.errs.Err
 	}
 
+	t.lifecycles.verified(blk)
+
 	t.Ctx.Log.Verbo("adding block to consensus: %s", blkID)
-	if err := t.Consensus.Add(blk); err != nil {
+	if err := t.Consensus.Add(&lifecycleBlock{Block: blk, lifecycles: &t.lifecycles}); err != nil {
 		return err
 	}
+	t.lifecycles.issued(blk)
 
 	// Add all the oracle blocks if they exist. We call verify on all the blocks
 	// and add them to consensus before marking anything as fulfilled to avoid
@@ -615,9 +630,11 @@ func (t *Transitive) deliver(blk snowman.Block) error {
 				t.Ctx.Log.Debug("block failed verification due to %s, dropping block", err)
 				dropped = append(dropped, blk)
 			} else {
-				if err := t.Consensus.Add(blk); err != nil {
+				t.lifecycles.verified(blk)
+				if err := t.Consensus.Add(&lifecycleBlock{Block: blk, lifecycles: &t.lifecycles}); err != nil {
 					return err
 				}
+				t.lifecycles.issued(blk)
 				added = append(added, blk)
 			}
 		}
@@ -639,6 +656,7 @@ func (t *Transitive) deliver(blk snowman.Block) error {
 	}
 	for _, blk := range dropped {
 		blkID := blk.ID()
+		t.lifecycles.dropped(blkID)
 		t.pending.Remove(blkID)
 		t.blocked.Abandon(blkID)
 		t.blkReqs.RemoveAny(blkID)
@@ -658,6 +676,19 @@ func (t *Transitive) IsBootstrapped() bool {
 	return t.Ctx.IsBootstrapped()
 }
 
+// ProcessingTree returns a description of the blocks being processed by
+// consensus. Must only be called once the chain has finished bootstrapping.
+func (t *Transitive) ProcessingTree() snowman.ProcessingTree {
+	return t.Consensus.ProcessingTree()
+}
+
+// BlockLifecycles returns when each of the most recently accepted blocks
+// reached each stage of being processed, from the most recently accepted block
+// to the least
+func (t *Transitive) BlockLifecycles() []BlockLifecycle {
+	return t.lifecycles.recent()
+}
+
 // Health implements the common.Engine interface
 func (t *Transitive) Health() (interface{}, error) {
 	// TODO add more health checks
@@ -368,6 +368,95 @@ func TestEngineQuery(t *testing.T) {
 	}
 }
 
+func TestEngineBlockLifecycles(t *testing.T) {
+	vdr, _, sender, vm, te, gBlk := setup(t)
+
+	sender.Default(true)
+
+	blk := &snowman.TestBlock{
+		TestDecidable: choices.TestDecidable{
+			IDV:     ids.GenerateTestID(),
+			StatusV: choices.Processing,
+		},
+		ParentV: gBlk,
+		HeightV: 1,
+		BytesV:  []byte{1},
+	}
+	conflict := &snowman.TestBlock{
+		TestDecidable: choices.TestDecidable{
+			IDV:     ids.GenerateTestID(),
+			StatusV: choices.Processing,
+		},
+		ParentV: gBlk,
+		HeightV: 1,
+		BytesV:  []byte{2},
+	}
+
+	vm.ParseBlockF = func(b []byte) (snowman.Block, error) {
+		switch {
+		case bytes.Equal(b, blk.Bytes()):
+			return blk, nil
+		case bytes.Equal(b, conflict.Bytes()):
+			return conflict, nil
+		}
+		return nil, errUnknownBytes
+	}
+	vm.GetBlockF = func(blkID ids.ID) (snowman.Block, error) {
+		switch blkID {
+		case gBlk.ID():
+			return gBlk, nil
+		case blk.ID():
+			return blk, nil
+		case conflict.ID():
+			return conflict, nil
+		}
+		return nil, errUnknownBlock
+	}
+
+	queryRequestIDs := []uint32{}
+	sender.PushQueryF = func(_ ids.ShortSet, requestID uint32, _ ids.ID, _ []byte) {
+		queryRequestIDs = append(queryRequestIDs, requestID)
+	}
+	sender.PullQueryF = func(ids.ShortSet, uint32, ids.ID) {}
+	sender.ChitsF = func(ids.ShortID, uint32, []ids.ID) {}
+
+	if err := te.PushQuery(vdr, 0, blk.ID(), blk.Bytes()); err != nil {
+		t.Fatal(err)
+	}
+	if err := te.PushQuery(vdr, 1, conflict.ID(), conflict.Bytes()); err != nil {
+		t.Fatal(err)
+	}
+	if len(queryRequestIDs) != 2 {
+		t.Fatalf("Should have queried the peer about both blocks")
+	}
+	if lifecycles := te.BlockLifecycles(); len(lifecycles) != 0 {
+		t.Fatalf("Shouldn't have recorded the lifecycles of undecided blocks")
+	}
+
+	// The blocks conflict, so two successful polls are needed to decide them
+	for _, requestID := range queryRequestIDs {
+		if err := te.Chits(vdr, requestID, []ids.ID{blk.ID()}); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if blk.Status() != choices.Accepted || conflict.Status() != choices.Rejected {
+		t.Fatalf("Should have accepted the block and rejected its conflict")
+	}
+
+	lifecycles := te.BlockLifecycles()
+	if len(lifecycles) != 1 || lifecycles[0].ID != blk.ID() {
+		t.Fatalf("Should have recorded the lifecycle of only the accepted block")
+	}
+	lifecycle := lifecycles[0]
+	if lifecycle.Height != 1 || lifecycle.Received.IsZero() || lifecycle.Parsed.IsZero() ||
+		lifecycle.Verified.IsZero() || lifecycle.Issued.IsZero() || lifecycle.Accepted.IsZero() {
+		t.Fatalf("Should have recorded every stage of the accepted block but got %+v", lifecycle)
+	}
+	if len(te.lifecycles.processing) != 0 {
+		t.Fatalf("Should have stopped tracking the decided blocks")
+	}
+}
+
 func TestEngineMultipleQuery(t *testing.T) {
 	config := DefaultConfig()
 
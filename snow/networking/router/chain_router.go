@@ -38,6 +38,10 @@ type ChainRouter struct {
 	peers            ids.ShortSet
 	criticalChains   ids.Set
 	onFatal          func()
+
+	// Outstanding Get requests, which concurrent Get requests for the same
+	// container share
+	gets coalescedGets
 }
 
 // Initialize the router.
@@ -65,6 +69,7 @@ func (sr *ChainRouter) Initialize(
 	sr.closeTimeout = closeTimeout
 	sr.criticalChains = criticalChains
 	sr.onFatal = onFatal
+	sr.gets.initialize()
 
 	sr.peers.Add(nodeID)
 
@@ -109,7 +114,7 @@ func (sr *ChainRouter) AddChain(chain *Handler) {
 
 	chainID := chain.Context().ChainID
 	sr.log.Debug("registering chain %s with chain router", chainID)
-	chain.toClose = func() { sr.RemoveChain(chainID) }
+	chain.toClose = func() { sr.removeChain(chainID, chain) }
 	sr.chains[chainID] = chain
 
 	for validatorID := range sr.peers {
@@ -119,10 +124,15 @@ func (sr *ChainRouter) AddChain(chain *Handler) {
 
 // RemoveChain removes the specified chain so that incoming
 // messages can't be routed to it
-func (sr *ChainRouter) RemoveChain(chainID ids.ID) {
+func (sr *ChainRouter) RemoveChain(chainID ids.ID) { sr.removeChain(chainID, nil) }
+
+// removeChain removes the chain with ID [chainID]. If [handler] is non-nil, the
+// chain is only removed if it is still routed to [handler]. This prevents a
+// handler that is shutting down from removing the chain that replaced it.
+func (sr *ChainRouter) removeChain(chainID ids.ID, handler *Handler) {
 	sr.lock.Lock()
 	chain, exists := sr.chains[chainID]
-	if !exists {
+	if !exists || (handler != nil && chain != handler) {
 		sr.log.Debug("can't remove unknown chain %s", chainID)
 		sr.lock.Unlock()
 		return
@@ -306,6 +316,15 @@ func (sr *ChainRouter) Put(validatorID ids.ShortID, chainID ids.ID, requestID ui
 	case exists:
 		if chain.Put(validatorID, requestID, containerID, container) {
 			sr.timeouts.Cancel(validatorID, chainID, requestID)
+
+			// The requests that waited on this one are answered with the
+			// same container
+			for _, follower := range sr.gets.remove(newRequestKey(validatorID, chainID, requestID)) {
+				followerID := ids.NewShortID(follower.validatorID)
+				if !chain.Put(followerID, follower.requestID, containerID, container) {
+					chain.GetFailed(followerID, follower.requestID)
+				}
+			}
 		}
 	case requestID == constants.GossipMsgRequestID:
 		sr.log.Verbo("Gossiped Put(%s, %s, %d, %s) dropped due to unknown chain. Container:",
@@ -324,13 +343,27 @@ func (sr *ChainRouter) GetFailed(validatorID ids.ShortID, chainID ids.ID, reques
 	defer sr.lock.RUnlock()
 
 	sr.timeouts.Cancel(validatorID, chainID, requestID)
+	followers := sr.gets.remove(newRequestKey(validatorID, chainID, requestID))
 	if chain, exists := sr.chains[chainID]; exists {
 		chain.GetFailed(validatorID, requestID)
+		for _, follower := range followers {
+			chain.GetFailed(ids.NewShortID(follower.validatorID), follower.requestID)
+		}
 	} else {
 		sr.log.Error("GetFailed(%s, %s, %d) dropped due to unknown chain", validatorID, chainID, requestID)
 	}
 }
 
+// CoalesceGet implements the InternalRouter interface
+func (sr *ChainRouter) CoalesceGet(validatorID ids.ShortID, chainID ids.ID, requestID uint32, containerID ids.ID) bool {
+	coalesced := sr.gets.add(newRequestKey(validatorID, chainID, requestID), containerID)
+	if coalesced {
+		sr.log.Verbo("Get(%s, %s, %d, %s) coalesced with an outstanding Get for the same container",
+			validatorID, chainID, requestID, containerID)
+	}
+	return coalesced
+}
+
 // PushQuery routes an incoming PushQuery request from the validator with ID [validatorID]
 // to the consensus engine working on the chain with ID [chainID]
 func (sr *ChainRouter) PushQuery(validatorID ids.ShortID, chainID ids.ID, requestID uint32, deadline time.Time, containerID ids.ID, container []byte) {
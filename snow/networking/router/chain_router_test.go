@@ -153,3 +153,158 @@ func TestShutdownTimesOut(t *testing.T) {
 	case <-shutdownFinished:
 	}
 }
+
+func TestFailedChainDoesntAffectOtherChains(t *testing.T) {
+	vdrs := validators.NewSet()
+	benchlist := benchlist.NewNoBenchlist()
+	tm := timeout.Manager{}
+	err := tm.Initialize(&timer.AdaptiveTimeoutConfig{
+		InitialTimeout: time.Millisecond,
+		MinimumTimeout: time.Millisecond,
+		MaximumTimeout: 10 * time.Second,
+		TimeoutInc:     2 * time.Millisecond,
+		TimeoutDec:     time.Millisecond,
+		Namespace:      "",
+		Registerer:     prometheus.NewRegistry(),
+	}, benchlist)
+	if err != nil {
+		t.Fatal(err)
+	}
+	go tm.Dispatch()
+
+	criticalChainID := ids.GenerateTestID()
+	fatal := make(chan struct{}, 1)
+
+	chainRouter := ChainRouter{}
+	chainRouter.Initialize(ids.ShortEmpty, logging.NoLog{}, &tm, time.Hour, time.Second, ids.Set{criticalChainID: true}, func() {
+		fatal <- struct{}{}
+	})
+	defer chainRouter.Shutdown()
+
+	failingCtx := snow.DefaultContextTest()
+	failingCtx.ChainID = ids.GenerateTestID()
+	failingEngine := common.EngineTest{T: t}
+	failingEngine.Default(false)
+	failingEngine.ContextF = func() *snow.Context { return failingCtx }
+	failingEngine.GetAcceptedFrontierF = func(validatorID ids.ShortID, requestID uint32) error {
+		panic("Engine panic should only shutdown this chain")
+	}
+
+	healthyCtx := snow.DefaultContextTest()
+	healthyCtx.ChainID = ids.GenerateTestID()
+	healthyEngine := common.EngineTest{T: t}
+	healthyEngine.Default(false)
+	called := make(chan struct{}, 1)
+	healthyEngine.ContextF = func() *snow.Context { return healthyCtx }
+	healthyEngine.GetAcceptedFrontierF = func(validatorID ids.ShortID, requestID uint32) error {
+		called <- struct{}{}
+		return nil
+	}
+
+	handlers := make([]*Handler, 2)
+	for i, engine := range []*common.EngineTest{&failingEngine, &healthyEngine} {
+		handler := &Handler{}
+		handler.Initialize(
+			engine,
+			vdrs,
+			nil,
+			16,
+			DefaultMaxNonStakerPendingMsgs,
+			DefaultStakerPortion,
+			DefaultStakerPortion,
+			"",
+			prometheus.NewRegistry(),
+		)
+		chainRouter.AddChain(handler)
+		go handler.Dispatch()
+		handlers[i] = handler
+	}
+
+	deadline := time.Now().Add(time.Second)
+	chainRouter.GetAcceptedFrontier(ids.ShortEmpty, failingCtx.ChainID, 1, deadline)
+
+	select {
+	case <-handlers[0].closed:
+	case <-time.After(time.Second):
+		t.Fatalf("Failing chain should have been shutdown")
+	}
+	if err := handlers[0].Failure(); err == nil {
+		t.Fatalf("Failing chain should have reported its failure")
+	}
+
+	chainRouter.GetAcceptedFrontier(ids.ShortEmpty, healthyCtx.ChainID, 1, deadline)
+
+	select {
+	case <-called:
+	case <-time.After(time.Second):
+		t.Fatalf("Healthy chain should still be handling messages")
+	}
+	if handlers[1].Closing() {
+		t.Fatalf("Healthy chain shouldn't have been shutdown")
+	}
+
+	select {
+	case <-fatal:
+		t.Fatalf("Failure of a non-critical chain shouldn't be fatal")
+	default:
+	}
+}
+
+func TestFailedCriticalChainIsFatal(t *testing.T) {
+	vdrs := validators.NewSet()
+	benchlist := benchlist.NewNoBenchlist()
+	tm := timeout.Manager{}
+	err := tm.Initialize(&timer.AdaptiveTimeoutConfig{
+		InitialTimeout: time.Millisecond,
+		MinimumTimeout: time.Millisecond,
+		MaximumTimeout: 10 * time.Second,
+		TimeoutInc:     2 * time.Millisecond,
+		TimeoutDec:     time.Millisecond,
+		Namespace:      "",
+		Registerer:     prometheus.NewRegistry(),
+	}, benchlist)
+	if err != nil {
+		t.Fatal(err)
+	}
+	go tm.Dispatch()
+
+	ctx := snow.DefaultContextTest()
+	ctx.ChainID = ids.GenerateTestID()
+	fatal := make(chan struct{}, 1)
+
+	chainRouter := ChainRouter{}
+	chainRouter.Initialize(ids.ShortEmpty, logging.NoLog{}, &tm, time.Hour, time.Second, ids.Set{ctx.ChainID: true}, func() {
+		fatal <- struct{}{}
+	})
+	defer chainRouter.Shutdown()
+
+	engine := common.EngineTest{T: t}
+	engine.Default(false)
+	engine.ContextF = func() *snow.Context { return ctx }
+	engine.GetAcceptedFrontierF = func(validatorID ids.ShortID, requestID uint32) error {
+		panic("Engine panic on a critical chain should be fatal")
+	}
+
+	handler := &Handler{}
+	handler.Initialize(
+		&engine,
+		vdrs,
+		nil,
+		16,
+		DefaultMaxNonStakerPendingMsgs,
+		DefaultStakerPortion,
+		DefaultStakerPortion,
+		"",
+		prometheus.NewRegistry(),
+	)
+	chainRouter.AddChain(handler)
+	go handler.Dispatch()
+
+	chainRouter.GetAcceptedFrontier(ids.ShortEmpty, ctx.ChainID, 1, time.Now().Add(time.Second))
+
+	select {
+	case <-fatal:
+	case <-time.After(time.Second):
+		t.Fatalf("Failure of a critical chain should be fatal")
+	}
+}
@@ -0,0 +1,90 @@
+// (c) 2019-2020, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package router
+
+import (
+	"sync"
+
+	"github.com/ava-labs/avalanchego/ids"
+)
+
+// requestKey identifies a request sent by this node
+type requestKey struct {
+	validatorID [20]byte
+	chainID     ids.ID
+	requestID   uint32
+}
+
+func newRequestKey(validatorID ids.ShortID, chainID ids.ID, requestID uint32) requestKey {
+	return requestKey{
+		validatorID: validatorID.Key(),
+		chainID:     chainID,
+		requestID:   requestID,
+	}
+}
+
+// containerKey identifies a container of a chain
+type containerKey struct {
+	chainID     ids.ID
+	containerID ids.ID
+}
+
+// coalescedGet is an outstanding Get request that later Get requests for the
+// same container wait on instead of being sent
+type coalescedGet struct {
+	container containerKey
+	followers []requestKey
+}
+
+// coalescedGets tracks the outstanding Get requests sent by this node, so that
+// concurrent Get requests for the same container result in a single message
+// being sent over the network
+type coalescedGets struct {
+	lock sync.Mutex
+	// Container --> The outstanding Get request for it
+	leaders map[containerKey]requestKey
+	// Outstanding Get request --> The requests waiting on it
+	gets map[requestKey]*coalescedGet
+}
+
+func (g *coalescedGets) initialize() {
+	g.leaders = make(map[containerKey]requestKey)
+	g.gets = make(map[requestKey]*coalescedGet)
+}
+
+// add records that [request] is a Get request for [containerID]. Returns true
+// if a Get request for [containerID] is already outstanding, in which case
+// [request] completes when that request does and shouldn't be sent.
+func (g *coalescedGets) add(request requestKey, containerID ids.ID) bool {
+	g.lock.Lock()
+	defer g.lock.Unlock()
+
+	container := containerKey{
+		chainID:     request.chainID,
+		containerID: containerID,
+	}
+	if leader, ok := g.leaders[container]; ok {
+		get := g.gets[leader]
+		get.followers = append(get.followers, request)
+		return true
+	}
+	g.leaders[container] = request
+	g.gets[request] = &coalescedGet{container: container}
+	return false
+}
+
+// remove marks the outstanding Get request [request] as completed. Returns the
+// requests that were waiting on it.
+func (g *coalescedGets) remove(request requestKey) []requestKey {
+	g.lock.Lock()
+	defer g.lock.Unlock()
+
+	get, ok := g.gets[request]
+	if !ok {
+		return nil
+	}
+	delete(g.gets, request)
+	delete(g.leaders, get.container)
+	return get.followers
+}
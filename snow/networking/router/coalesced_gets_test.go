@@ -0,0 +1,54 @@
+// (c) 2019-2020, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package router
+
+import (
+	"testing"
+
+	"github.com/ava-labs/avalanchego/ids"
+)
+
+func TestCoalescedGets(t *testing.T) {
+	gets := coalescedGets{}
+	gets.initialize()
+
+	chainID := ids.GenerateTestID()
+	containerID := ids.GenerateTestID()
+	vdr0 := ids.GenerateTestShortID()
+	vdr1 := ids.GenerateTestShortID()
+
+	leader := newRequestKey(vdr0, chainID, 0)
+	follower := newRequestKey(vdr1, chainID, 1)
+	if gets.add(leader, containerID) {
+		t.Fatal("the first request for a container should be sent")
+	}
+	if !gets.add(follower, containerID) {
+		t.Fatal("a concurrent request for the same container shouldn't be sent")
+	}
+
+	// Requests for other containers, or for the same container on other
+	// chains, aren't coalesced
+	if gets.add(newRequestKey(vdr1, chainID, 2), ids.GenerateTestID()) {
+		t.Fatal("a request for another container should be sent")
+	}
+	if gets.add(newRequestKey(vdr1, ids.GenerateTestID(), 0), containerID) {
+		t.Fatal("a request for a container of another chain should be sent")
+	}
+
+	if followers := gets.remove(follower); len(followers) != 0 {
+		t.Fatalf("a coalesced request shouldn't have followers but got %d", len(followers))
+	}
+	followers := gets.remove(leader)
+	if len(followers) != 1 || followers[0] != follower {
+		t.Fatalf("expected the follower to complete with the leader but got %v", followers)
+	}
+	if followers := gets.remove(leader); len(followers) != 0 {
+		t.Fatal("a request should only complete once")
+	}
+
+	// Once the outstanding request completed, the container is requested again
+	if gets.add(newRequestKey(vdr1, chainID, 3), containerID) {
+		t.Fatal("a request for a container that is no longer requested should be sent")
+	}
+}
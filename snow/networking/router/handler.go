@@ -4,22 +4,37 @@
 package router
 
 import (
+	"errors"
+	"fmt"
 	"math"
 	"sync"
 	"time"
 
 	"github.com/prometheus/client_golang/prometheus"
 
+	"github.com/ava-labs/avalanchego/cache"
 	"github.com/ava-labs/avalanchego/ids"
 	"github.com/ava-labs/avalanchego/snow"
 	"github.com/ava-labs/avalanchego/snow/engine/common"
 	"github.com/ava-labs/avalanchego/snow/networking/tracker"
 	"github.com/ava-labs/avalanchego/snow/validators"
+	"github.com/ava-labs/avalanchego/utils"
 	"github.com/ava-labs/avalanchego/utils/constants"
+	"github.com/ava-labs/avalanchego/utils/logging"
 	"github.com/ava-labs/avalanchego/utils/timer"
 	"github.com/ava-labs/avalanchego/utils/uptime"
 )
 
+const (
+	// Number of recently accepted containers a handler keeps to answer Get
+	// requests with
+	decidedCacheSize = 512
+)
+
+var (
+	errPanicked = errors.New("chain panicked")
+)
+
 // Requirement: A set of nodes spamming messages (potentially costly) shouldn't
 //              impact other node's queries.
 
@@ -98,6 +113,7 @@ type Handler struct {
 	msgChan          <-chan common.Message
 
 	cpuTracker tracker.TimeTracker
+	msgManager MsgManager
 
 	clock timer.Clock
 
@@ -108,7 +124,38 @@ type Handler struct {
 	engine common.Engine
 
 	toClose func()
-	closing bool
+	closing utils.AtomicBool
+
+	// frozen is set while an operator has frozen this chain. A frozen chain
+	// doesn't build blocks and doesn't vote in polls, but still serves
+	// containers to its peers.
+	frozen utils.AtomicBool
+
+	// failure is set if the engine panicked or returned an error while
+	// handling a message. Once set, the handler stops dispatching and the
+	// chain is shut down without affecting the other chains running on this
+	// node.
+	//
+	// Only panics raised while the engine is called by this handler are
+	// recovered. Panics on goroutines started by the VM itself still
+	// terminate the node.
+	failureLock sync.RWMutex
+	failure     error
+
+	// If non-zero, gossip requests are dropped until this much time has
+	// passed since the last one was passed to the engine
+	gossipFrequency time.Duration
+	lastGossip      time.Time
+
+	// Container ID --> bytes of the recently accepted container. Get requests
+	// for these containers are answered with [decidedSender] without going
+	// through the engine. If [decidedSender] is nil, all Get requests are
+	// passed to the engine.
+	decided       cache.LRU
+	decidedSender common.Sender
+
+	// Used to tell a stalled chain apart from an idle one
+	stall stallDetector
 }
 
 // Initialize this consensus handler
@@ -153,7 +200,7 @@ func (h *Handler) Initialize(
 
 	h.cpuTracker = tracker.NewCPUTracker(uptime.IntervalFactory{}, cpuInterval)
 	msgTracker := tracker.NewMessageTracker()
-	msgManager := NewMsgManager(
+	h.msgManager = NewMsgManager(
 		validators,
 		h.ctx.Log,
 		msgTracker,
@@ -165,7 +212,7 @@ func (h *Handler) Initialize(
 	)
 
 	h.serviceQueue, h.msgSema = newMultiLevelQueue(
-		msgManager,
+		h.msgManager,
 		consumptionRanges,
 		consumptionAllotments,
 		bufferSize,
@@ -174,6 +221,8 @@ func (h *Handler) Initialize(
 	)
 	h.engine = engine
 	h.validators = validators
+	h.decided.Size = decidedCacheSize
+	h.stall.initialize(h.ctx.Log, h.clock.Time())
 }
 
 // Context of this Handler
@@ -190,72 +239,158 @@ func (h *Handler) SetEngine(engine common.Engine) { h.engine = engine }
 func (h *Handler) Dispatch() {
 	defer h.shutdownDispatch()
 
+	// When both notifications from the VM and network messages are pending,
+	// they're handled alternately. This way block building isn't starved
+	// while the chain is under load, and a VM that notifies continuously
+	// can't starve consensus.
+	preferNotify := true
 	for {
+		if preferNotify {
+			select {
+			case msg := <-h.msgChan:
+				h.dispatchNotify(msg)
+				preferNotify = false
+				if h.closing.GetValue() {
+					return
+				}
+				continue
+			default:
+			}
+		} else {
+			select {
+			case _, ok := <-h.msgSema:
+				if !ok {
+					// the msgSema channel has been closed, so this dispatcher should exit
+					return
+				}
+				h.dispatchQueued()
+				preferNotify = true
+				if h.closing.GetValue() {
+					return
+				}
+				continue
+			case <-h.reliableMsgsSema:
+				h.dispatchReliable()
+				preferNotify = true
+				if h.closing.GetValue() {
+					return
+				}
+				continue
+			default:
+			}
+		}
+
 		select {
 		case _, ok := <-h.msgSema:
 			if !ok {
 				// the msgSema channel has been closed, so this dispatcher should exit
 				return
 			}
-
-			msg, err := h.serviceQueue.PopMessage()
-			if err != nil {
-				h.ctx.Log.Warn("Could not pop messsage from service queue")
-				continue
-			}
-			if !msg.deadline.IsZero() && h.clock.Time().After(msg.deadline) {
-				h.ctx.Log.Verbo("Dropping message due to likely timeout: %s", msg)
-				h.metrics.dropped.Inc()
-				h.metrics.expired.Inc()
-				continue
-			}
-
-			h.dispatchMsg(msg)
+			h.dispatchQueued()
+			preferNotify = true
 		case <-h.reliableMsgsSema:
-			// get all the reliable messages
-			h.reliableMsgsLock.Lock()
-			msgs := h.reliableMsgs
-			h.reliableMsgs = nil
-			h.reliableMsgsLock.Unlock()
-
-			// fire all the reliable messages
-			for _, msg := range msgs {
-				h.metrics.pending.Dec()
-				h.dispatchMsg(msg)
-			}
+			h.dispatchReliable()
+			preferNotify = true
 		case msg := <-h.msgChan:
-			// handle a message from the VM
-			h.dispatchMsg(message{messageType: constants.NotifyMsg, notification: msg})
+			h.dispatchNotify(msg)
+			preferNotify = false
 		}
 
-		if h.closing {
+		if h.closing.GetValue() {
 			return
 		}
 	}
 }
 
+// dispatchQueued passes the next message in the service queue to the consensus
+// engine
+func (h *Handler) dispatchQueued() {
+	msg, err := h.serviceQueue.PopMessage()
+	if err != nil {
+		h.ctx.Log.Warn("Could not pop messsage from service queue")
+		return
+	}
+	if !msg.deadline.IsZero() && h.clock.Time().After(msg.deadline) {
+		h.ctx.Log.Verbo("Dropping message due to likely timeout: %s", msg)
+		h.metrics.dropped.Inc()
+		h.metrics.expired.Inc()
+		return
+	}
+
+	h.dispatchMsg(msg)
+}
+
+// dispatchReliable passes all the pending reliable messages to the consensus
+// engine
+func (h *Handler) dispatchReliable() {
+	// get all the reliable messages
+	h.reliableMsgsLock.Lock()
+	msgs := h.reliableMsgs
+	h.reliableMsgs = nil
+	h.reliableMsgsLock.Unlock()
+
+	// fire all the reliable messages
+	for _, msg := range msgs {
+		h.metrics.pending.Dec()
+		h.dispatchMsg(msg)
+	}
+}
+
+// dispatchNotify passes a message from the VM to the consensus engine
+func (h *Handler) dispatchNotify(msg common.Message) {
+	h.metrics.vmPendingWork.Set(float64(msg.PendingHint()))
+	h.stall.setPendingWork(msg.PendingHint())
+	h.dispatchMsg(message{messageType: constants.NotifyMsg, notification: msg})
+}
+
 // Dispatch a message to the consensus engine.
 func (h *Handler) dispatchMsg(msg message) {
-	if h.closing {
+	if h.closing.GetValue() {
 		h.ctx.Log.Debug("dropping message due to closing:\n%s", msg)
 		h.metrics.dropped.Inc()
 		return
 	}
 
-	startTime := h.clock.Time()
+	if h.frozen.GetValue() && isFrozenMsg(msg.messageType) {
+		h.ctx.Log.Verbo("dropping message due to the chain being frozen:\n%s", msg)
+		h.metrics.dropped.Inc()
+		return
+	}
 
 	h.ctx.Lock.Lock()
 	defer h.ctx.Lock.Unlock()
 
+	// Only the time spent handling the message is charged to the peer, not the
+	// time spent waiting for the chain's lock, which may be held by an API call.
+	startTime := h.clock.Time()
+
 	if msg.IsPeriodic() {
 		h.ctx.Log.Verbo("Forwarding message to consensus: %s", msg)
 	} else {
 		h.ctx.Log.Debug("Forwarding message to consensus: %s", msg)
 	}
 
-	var (
-		err error
-	)
+	if err := h.safeHandleMsg(msg, startTime); err != nil {
+		// Panics have already been logged along with their stacktrace
+		if !errors.Is(err, errPanicked) {
+			h.ctx.Log.Fatal("forcing chain to shutdown due to: %s", err)
+		}
+		h.setFailure(err)
+		h.closing.SetValue(true)
+	}
+}
+
+// safeHandleMsg passes [msg] to the engine. If the engine panics, the panic is
+// recovered and returned as an error so that the rest of the node keeps
+// running.
+func (h *Handler) safeHandleMsg(msg message, startTime time.Time) (err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			h.ctx.Log.Fatal("chain panicked while handling %s:\n%s\nFrom:\n%s", msg.messageType, r, logging.Stacktrace{})
+			err = fmt.Errorf("%w while handling %s: %v", errPanicked, msg.messageType, r)
+		}
+	}()
+
 	switch msg.messageType {
 	case constants.NotifyMsg:
 		err = h.engine.Notify(msg.notification)
@@ -266,10 +401,24 @@ func (h *Handler) dispatchMsg(msg message) {
 	default:
 		err = h.handleValidatorMsg(msg, startTime)
 	}
+	return err
+}
 
-	if err != nil {
-		h.ctx.Log.Fatal("forcing chain to shutdown due to: %s", err)
-		h.closing = true
+// Failure returns the reason this chain was forced to shut down, or nil if
+// the chain hasn't failed.
+func (h *Handler) Failure() error {
+	h.failureLock.RLock()
+	defer h.failureLock.RUnlock()
+
+	return h.failure
+}
+
+func (h *Handler) setFailure(err error) {
+	h.failureLock.Lock()
+	defer h.failureLock.Unlock()
+
+	if h.failure == nil {
+		h.failure = err
 	}
 }
 
@@ -375,7 +524,12 @@ func (h *Handler) GetAncestorsFailed(validatorID ids.ShortID, requestID uint32)
 }
 
 // Get passes a Get message received from the network to the consensus engine.
+// If the container was recently accepted, the request is answered directly
+// instead.
 func (h *Handler) Get(validatorID ids.ShortID, requestID uint32, deadline time.Time, containerID ids.ID) bool {
+	if h.getDecided(validatorID, requestID, deadline, containerID) {
+		return true
+	}
 	return h.serviceQueue.PushMessage(message{
 		messageType: constants.GetMsg,
 		validatorID: validatorID,
@@ -454,6 +608,7 @@ func (h *Handler) QueryFailed(validatorID ids.ShortID, requestID uint32) {
 
 // Connected passes a new connection notification to the consensus engine
 func (h *Handler) Connected(validatorID ids.ShortID) {
+	h.stall.connected(validatorID)
 	h.sendReliableMsg(message{
 		messageType: constants.ConnectedMsg,
 		validatorID: validatorID,
@@ -462,23 +617,104 @@ func (h *Handler) Connected(validatorID ids.ShortID) {
 
 // Disconnected passes a new connection notification to the consensus engine
 func (h *Handler) Disconnected(validatorID ids.ShortID) {
+	h.stall.disconnected(validatorID)
 	h.sendReliableMsg(message{
 		messageType: constants.DisconnectedMsg,
 		validatorID: validatorID,
 	})
 }
 
+// SetGossipFrequency sets the min amount of time between gossip requests
+// passed to the engine. If 0, every gossip request is passed to the engine.
+// Should be called before the handler starts receiving gossip requests.
+func (h *Handler) SetGossipFrequency(frequency time.Duration) {
+	h.gossipFrequency = frequency
+}
+
 // Gossip passes a gossip request to the consensus engine
 func (h *Handler) Gossip() {
 	if !h.ctx.IsBootstrapped() {
 		// Shouldn't send gossiping messages while the chain is bootstrapping
 		return
 	}
+	if h.gossipFrequency != 0 {
+		now := h.clock.Time()
+		if now.Sub(h.lastGossip) < h.gossipFrequency {
+			return
+		}
+		h.lastGossip = now
+	}
 	h.sendReliableMsg(message{
 		messageType: constants.GossipMsg,
 	})
 }
 
+// SetDecidedSender makes this handler answer Get requests for recently
+// accepted containers by sending them with [sender], without calling into the
+// engine or the VM
+func (h *Handler) SetDecidedSender(sender common.Sender) { h.decidedSender = sender }
+
+// Accept implements the triggers.Acceptor interface. It records that the
+// chain made progress, and remembers [container] so that Get requests for it
+// can be answered from memory.
+func (h *Handler) Accept(_ *snow.Context, containerID ids.ID, container []byte) error {
+	h.stall.accepted(h.clock.Time())
+	if h.decidedSender != nil {
+		h.decided.Put(containerID, container)
+	}
+	return nil
+}
+
+// Stalled returns whether this chain has stopped accepting containers even
+// though it has work to do. That is, nothing was accepted for [timeout] while
+// the VM reported pending work and at least [minConnectedStake] of the
+// chain's stake was connected. A chain that isn't accepting anything because
+// there is nothing to accept is idle, not stalled. A chain that is still
+// bootstrapping is never stalled. If [timeout] is 0, the chain is never
+// stalled.
+func (h *Handler) Stalled(timeout time.Duration, minConnectedStake float64) (interface{}, bool) {
+	if !h.ctx.IsBootstrapped() {
+		return nil, false
+	}
+	details, stalled := h.stall.check(h.clock.Time(), timeout, minConnectedStake, h.validators)
+	if stalled {
+		h.metrics.stalled.Set(1)
+	} else {
+		h.metrics.stalled.Set(0)
+	}
+	return details, stalled
+}
+
+// getDecided answers a Get request for [containerID] if the container was
+// recently accepted. Returns true if the request was answered.
+//
+// Answering from the cache is charged to [validatorID] like any other message,
+// so a peer can't repeatedly request accepted containers for free. A peer that
+// is over its CPU allotment isn't answered here; its request goes through the
+// service queue, which throttles it.
+func (h *Handler) getDecided(validatorID ids.ShortID, requestID uint32, deadline time.Time, containerID ids.ID) bool {
+	if h.decidedSender == nil || h.closing.GetValue() || h.clock.Time().After(deadline) {
+		return false
+	}
+	container, ok := h.decided.Get(containerID)
+	if !ok {
+		h.decidedMisses.Inc()
+		return false
+	}
+	if h.msgManager.Utilization(validatorID) > 1 {
+		return false
+	}
+	h.decidedHits.Inc()
+
+	startTime := h.clock.Time()
+	h.decidedSender.Put(validatorID, requestID, containerID, container.([]byte))
+	endTime := h.clock.Time()
+
+	h.cpuTracker.UtilizeTime(validatorID, startTime, endTime)
+	h.serviceQueue.UtilizeCPU(validatorID, endTime.Sub(startTime))
+	return true
+}
+
 // Notify ...
 func (h *Handler) Notify(msg common.Message) {
 	h.sendReliableMsg(message{
@@ -499,18 +735,54 @@ func (h *Handler) shutdownDispatch() {
 	defer h.ctx.Lock.Unlock()
 
 	startTime := time.Now()
-	if err := h.engine.Shutdown(); err != nil {
+	if err := h.safeShutdown(); err != nil {
 		h.ctx.Log.Error("Error while shutting down the chain: %s", err)
 	}
 	h.ctx.Log.Info("finished shutting down chain")
 	if h.toClose != nil {
 		go h.toClose()
 	}
-	h.closing = true
+	h.closing.SetValue(true)
 	h.shutdown.Observe(float64(time.Since(startTime)))
 	close(h.closed)
 }
 
+// Closing returns true once this handler has stopped passing messages to the
+// engine.
+func (h *Handler) Closing() bool { return h.closing.GetValue() }
+
+// SetFrozen freezes or unfreezes this chain. While frozen, notifications from
+// the VM and queries from peers are dropped, so the chain neither builds blocks
+// nor votes. Requests for containers are still answered.
+func (h *Handler) SetFrozen(frozen bool) { h.frozen.SetValue(frozen) }
+
+// Frozen returns true if this chain is frozen
+func (h *Handler) Frozen() bool { return h.frozen.GetValue() }
+
+// isFrozenMsg returns true if messages of type [msgType] are dropped while the
+// chain is frozen
+func isFrozenMsg(msgType constants.MsgType) bool {
+	switch msgType {
+	case constants.NotifyMsg, constants.PushQueryMsg, constants.PullQueryMsg:
+		return true
+	default:
+		return false
+	}
+}
+
+// safeShutdown shuts down the engine, recovering from a panic so that the chain
+// is still removed from the router.
+func (h *Handler) safeShutdown() (err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			h.ctx.Log.Fatal("chain panicked while shutting down:\n%s\nFrom:\n%s", r, logging.Stacktrace{})
+			err = fmt.Errorf("%w while shutting down: %v", errPanicked, r)
+			h.setFailure(err)
+		}
+	}()
+	return h.engine.Shutdown()
+}
+
 func (h *Handler) handleValidatorMsg(msg message, startTime time.Time) error {
 	var (
 		err error
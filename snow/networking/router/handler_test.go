@@ -4,6 +4,7 @@
 package router
 
 import (
+	"bytes"
 	"errors"
 	"testing"
 	"time"
@@ -14,6 +15,7 @@ import (
 	"github.com/ava-labs/avalanchego/snow"
 	"github.com/ava-labs/avalanchego/snow/engine/common"
 	"github.com/ava-labs/avalanchego/snow/validators"
+	"github.com/ava-labs/avalanchego/utils/constants"
 )
 
 func TestHandlerDropsTimedOutMessages(t *testing.T) {
@@ -105,6 +107,60 @@ func TestHandlerDoesntDrop(t *testing.T) {
 	}
 }
 
+func TestHandlerAlternatesNotifications(t *testing.T) {
+	engine := common.EngineTest{T: t}
+	engine.Default(false)
+	engine.ContextF = snow.DefaultContextTest
+
+	calls := make(chan string, 5)
+	engine.NotifyF = func(common.Message) error {
+		calls <- "notify"
+		return nil
+	}
+	engine.GetAcceptedFrontierF = func(validatorID ids.ShortID, requestID uint32) error {
+		calls <- "network"
+		return nil
+	}
+
+	msgChan := make(chan common.Message, 3)
+	for i := 0; i < 3; i++ {
+		msgChan <- common.PendingTxs
+	}
+
+	handler := &Handler{}
+	handler.Initialize(
+		&engine,
+		validators.NewSet(),
+		msgChan,
+		16,
+		DefaultMaxNonStakerPendingMsgs,
+		DefaultStakerPortion,
+		DefaultStakerPortion,
+		"",
+		prometheus.NewRegistry(),
+	)
+
+	handler.GetAcceptedFrontier(ids.NewShortID([20]byte{}), 1, time.Time{})
+	handler.GetAcceptedFrontier(ids.NewShortID([20]byte{}), 2, time.Time{})
+	go handler.Dispatch()
+
+	// Pending notifications shouldn't keep network messages from being
+	// handled, and vice versa
+	expected := []string{"notify", "network", "notify", "network", "notify"}
+	ticker := time.NewTicker(20 * time.Millisecond)
+	defer ticker.Stop()
+	for i, expectedCall := range expected {
+		select {
+		case <-ticker.C:
+			t.Fatalf("Calling engine function timed out")
+		case call := <-calls:
+			if call != expectedCall {
+				t.Fatalf("expected call %d to be %s but was %s", i, expectedCall, call)
+			}
+		}
+	}
+}
+
 func TestHandlerClosesOnError(t *testing.T) {
 	engine := common.EngineTest{T: t}
 	engine.Default(false)
@@ -185,3 +241,267 @@ func TestHandlerDropsGossipDuringBootstrapping(t *testing.T) {
 	case <-closed:
 	}
 }
+
+func TestHandlerClosesOnPanic(t *testing.T) {
+	engine := common.EngineTest{T: t}
+	engine.Default(false)
+
+	closed := make(chan struct{}, 1)
+
+	engine.ContextF = snow.DefaultContextTest
+	engine.GetAcceptedFrontierF = func(validatorID ids.ShortID, requestID uint32) error {
+		panic("Engine panic should cause handler to close")
+	}
+
+	handler := &Handler{}
+	handler.Initialize(
+		&engine,
+		validators.NewSet(),
+		nil,
+		16,
+		DefaultMaxNonStakerPendingMsgs,
+		DefaultStakerPortion,
+		DefaultStakerPortion,
+		"",
+		prometheus.NewRegistry(),
+	)
+	handler.clock.Set(time.Now())
+
+	handler.toClose = func() {
+		closed <- struct{}{}
+	}
+	go handler.Dispatch()
+
+	handler.GetAcceptedFrontier(ids.NewShortID([20]byte{}), 1, time.Now().Add(time.Second))
+
+	ticker := time.NewTicker(20 * time.Millisecond)
+	select {
+	case <-ticker.C:
+		t.Fatalf("Handler shutdown timed out before calling toClose")
+	case <-closed:
+	}
+
+	if err := handler.Failure(); err == nil {
+		t.Fatalf("Handler should have reported the panic as a failure")
+	}
+}
+
+func TestHandlerFrozenDropsQueries(t *testing.T) {
+	engine := common.EngineTest{T: t}
+	engine.Default(false)
+	engine.ContextF = snow.DefaultContextTest
+
+	engine.PullQueryF = func(validatorID ids.ShortID, requestID uint32, containerID ids.ID) error {
+		t.Fatalf("PullQuery should have been dropped while the chain is frozen")
+		return nil
+	}
+
+	called := make(chan struct{}, 1)
+	engine.GetF = func(validatorID ids.ShortID, requestID uint32, containerID ids.ID) error {
+		called <- struct{}{}
+		return nil
+	}
+
+	handler := &Handler{}
+	handler.Initialize(
+		&engine,
+		validators.NewSet(),
+		nil,
+		16,
+		DefaultMaxNonStakerPendingMsgs,
+		DefaultStakerPortion,
+		DefaultStakerPortion,
+		"",
+		prometheus.NewRegistry(),
+	)
+	handler.SetFrozen(true)
+	if !handler.Frozen() {
+		t.Fatalf("handler should be frozen")
+	}
+
+	handler.PullQuery(ids.ShortEmpty, 1, time.Time{}, ids.Empty)
+	handler.Get(ids.ShortEmpty, 2, time.Time{}, ids.Empty)
+	go handler.Dispatch()
+
+	ticker := time.NewTicker(20 * time.Millisecond)
+	defer ticker.Stop()
+	select {
+	case <-ticker.C:
+		t.Fatalf("Get should still have been handled while the chain is frozen")
+	case <-called:
+	}
+}
+
+func TestHandlerGossipFrequency(t *testing.T) {
+	engine := common.EngineTest{T: t}
+	engine.Default(false)
+
+	ctx := snow.DefaultContextTest()
+	ctx.Bootstrapped()
+	engine.ContextF = func() *snow.Context { return ctx }
+
+	handler := &Handler{}
+	handler.Initialize(
+		&engine,
+		validators.NewSet(),
+		nil,
+		16,
+		DefaultMaxNonStakerPendingMsgs,
+		DefaultStakerPortion,
+		DefaultStakerPortion,
+		"",
+		prometheus.NewRegistry(),
+	)
+	handler.SetGossipFrequency(time.Minute)
+	currentTime := time.Now()
+	handler.clock.Set(currentTime)
+
+	handler.Gossip()
+	handler.clock.Set(currentTime.Add(time.Minute / 2))
+	handler.Gossip() // Too soon after the last gossip
+	if numMsgs := len(handler.reliableMsgs); numMsgs != 1 {
+		t.Fatalf("expected 1 gossip request to be passed to the engine but got %d", numMsgs)
+	}
+
+	handler.clock.Set(currentTime.Add(time.Minute))
+	handler.Gossip()
+	if numMsgs := len(handler.reliableMsgs); numMsgs != 2 {
+		t.Fatalf("expected 2 gossip requests to be passed to the engine but got %d", numMsgs)
+	}
+}
+
+func TestHandlerGetDecided(t *testing.T) {
+	engine := common.EngineTest{T: t}
+	engine.Default(false)
+
+	ctx := snow.DefaultContextTest()
+	ctx.Bootstrapped()
+	engine.ContextF = func() *snow.Context { return ctx }
+
+	handler := &Handler{}
+	handler.Initialize(
+		&engine,
+		validators.NewSet(),
+		nil,
+		16,
+		DefaultMaxNonStakerPendingMsgs,
+		DefaultStakerPortion,
+		DefaultStakerPortion,
+		"",
+		prometheus.NewRegistry(),
+	)
+
+	sender := &common.SenderTest{T: t}
+	sender.Default(true)
+	handler.SetDecidedSender(sender)
+
+	vdr := ids.GenerateTestShortID()
+	containerID := ids.GenerateTestID()
+	container := []byte{1, 2, 3}
+	if err := handler.Accept(ctx, containerID, container); err != nil {
+		t.Fatal(err)
+	}
+
+	sent := false
+	sender.PutF = func(validatorID ids.ShortID, requestID uint32, sentID ids.ID, sentContainer []byte) {
+		switch {
+		case validatorID != vdr:
+			t.Fatal("sent to the wrong validator")
+		case requestID != 1:
+			t.Fatal("sent with the wrong request ID")
+		case sentID != containerID:
+			t.Fatal("sent the wrong container ID")
+		case !bytes.Equal(sentContainer, container):
+			t.Fatal("sent the wrong container")
+		}
+		sent = true
+	}
+	if !handler.Get(vdr, 1, time.Now().Add(time.Minute), containerID) {
+		t.Fatal("expected the Get request to be handled")
+	}
+	if !sent {
+		t.Fatal("expected the accepted container to be sent from the cache")
+	}
+	if _, err := handler.serviceQueue.PopMessage(); err != errNoMessages {
+		t.Fatalf("expected the Get request not to be queued but got: %v", err)
+	}
+
+	// Containers that weren't accepted are left to the engine
+	sender.PutF = nil
+	if !handler.Get(vdr, 2, time.Now().Add(time.Minute), ids.GenerateTestID()) {
+		t.Fatal("expected the Get request to be queued")
+	}
+	if msg, err := handler.serviceQueue.PopMessage(); err != nil {
+		t.Fatal(err)
+	} else if msg.messageType != constants.GetMsg || msg.requestID != 2 {
+		t.Fatalf("expected the Get request to be queued but got %s", msg)
+	}
+}
+
+// overAllotmentManager reports every peer as over its CPU allotment
+type overAllotmentManager struct{}
+
+func (overAllotmentManager) AddPending(ids.ShortID) bool { return true }
+
+func (overAllotmentManager) RemovePending(ids.ShortID) {}
+
+func (overAllotmentManager) Utilization(ids.ShortID) float64 { return 2 }
+
+func TestHandlerGetDecidedOverAllotment(t *testing.T) {
+	engine := common.EngineTest{T: t}
+	engine.Default(false)
+
+	ctx := snow.DefaultContextTest()
+	ctx.Bootstrapped()
+	engine.ContextF = func() *snow.Context { return ctx }
+
+	handler := &Handler{}
+	handler.Initialize(
+		&engine,
+		validators.NewSet(),
+		nil,
+		16,
+		DefaultMaxNonStakerPendingMsgs,
+		DefaultStakerPortion,
+		DefaultStakerPortion,
+		"",
+		prometheus.NewRegistry(),
+	)
+
+	sender := &common.SenderTest{T: t}
+	sender.Default(true)
+	handler.SetDecidedSender(sender)
+
+	containerID := ids.GenerateTestID()
+	if err := handler.Accept(ctx, containerID, []byte{1, 2, 3}); err != nil {
+		t.Fatal(err)
+	}
+
+	// Answering from the cache is charged to the peer
+	vdr := ids.GenerateTestShortID()
+	now := time.Now()
+	handler.clock.Set(now)
+	sender.PutF = func(ids.ShortID, uint32, ids.ID, []byte) {
+		handler.clock.Set(now.Add(time.Second))
+	}
+	if !handler.Get(vdr, 1, now.Add(time.Minute), containerID) {
+		t.Fatal("expected the Get request to be handled")
+	}
+	if handler.cpuTracker.Utilization(vdr, handler.clock.Time()) == 0 {
+		t.Fatal("expected the peer to be charged for the Get request")
+	}
+
+	// A peer over its allotment isn't answered from the cache
+	handler.msgManager = overAllotmentManager{}
+	sender.PutF = func(ids.ShortID, uint32, ids.ID, []byte) {
+		t.Fatal("shouldn't have answered a peer over its allotment from the cache")
+	}
+	if !handler.Get(vdr, 2, now.Add(time.Minute), containerID) {
+		t.Fatal("expected the Get request to be queued")
+	}
+	if msg, err := handler.serviceQueue.PopMessage(); err != nil {
+		t.Fatal(err)
+	} else if msg.messageType != constants.GetMsg || msg.requestID != 2 {
+		t.Fatalf("expected the Get request to be queued but got %s", msg)
+	}
+}
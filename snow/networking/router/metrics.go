@@ -35,8 +35,10 @@ func initHistogram(namespace, name string, registerer prometheus.Registerer, err
 type metrics struct {
 	namespace                   string
 	registerer                  prometheus.Registerer
-	pending                     prometheus.Gauge
+	pending, vmPendingWork      prometheus.Gauge
+	stalled                     prometheus.Gauge
 	dropped, expired, throttled prometheus.Counter
+	decidedHits, decidedMisses  prometheus.Counter
 	getAcceptedFrontier, acceptedFrontier, getAcceptedFrontierFailed,
 	getAccepted, accepted, getAcceptedFailed,
 	getAncestors, multiPut, getAncestorsFailed,
@@ -64,6 +66,24 @@ func (m *metrics) Initialize(namespace string, registerer prometheus.Registerer)
 		errs.Add(fmt.Errorf("failed to register pending statistics due to %w", err))
 	}
 
+	m.vmPendingWork = prometheus.NewGauge(prometheus.GaugeOpts{
+		Namespace: namespace,
+		Name:      "vm_pending_work",
+		Help:      "Amount of pending work most recently reported by the VM",
+	})
+	if err := registerer.Register(m.vmPendingWork); err != nil {
+		errs.Add(fmt.Errorf("failed to register vm pending work statistics due to %w", err))
+	}
+
+	m.stalled = prometheus.NewGauge(prometheus.GaugeOpts{
+		Namespace: namespace,
+		Name:      "stalled",
+		Help:      "1 if the chain has pending work but hasn't accepted anything recently, 0 otherwise",
+	})
+	if err := registerer.Register(m.stalled); err != nil {
+		errs.Add(fmt.Errorf("failed to register stalled statistics due to %w", err))
+	}
+
 	m.dropped = prometheus.NewCounter(prometheus.CounterOpts{
 		Namespace: namespace,
 		Name:      "dropped",
@@ -92,6 +112,24 @@ func (m *metrics) Initialize(namespace string, registerer prometheus.Registerer)
 		errs.Add(fmt.Errorf("failed to register throttled statistics due to %w", err))
 	}
 
+	m.decidedHits = prometheus.NewCounter(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "decided_cache_hits",
+		Help:      "Number of Get requests answered from the cache of recently accepted containers",
+	})
+	if err := registerer.Register(m.decidedHits); err != nil {
+		errs.Add(fmt.Errorf("failed to register decided cache hits statistics due to %w", err))
+	}
+
+	m.decidedMisses = prometheus.NewCounter(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "decided_cache_misses",
+		Help:      "Number of Get requests passed to the engine because the container wasn't in the cache of recently accepted containers",
+	})
+	if err := registerer.Register(m.decidedMisses); err != nil {
+		errs.Add(fmt.Errorf("failed to register decided cache misses statistics due to %w", err))
+	}
+
 	m.getAcceptedFrontier = initHistogram(namespace, "get_accepted_frontier", registerer, &errs)
 	m.acceptedFrontier = initHistogram(namespace, "accepted_frontier", registerer, &errs)
 	m.getAcceptedFrontierFailed = initHistogram(namespace, "get_accepted_frontier_failed", registerer, &errs)
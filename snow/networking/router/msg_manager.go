@@ -79,11 +79,18 @@ func NewMsgManager(
 
 // AddPending marks that there is a message from [vdr] ready to be processed.
 // Return true if the message was added to the processing list.
+//
+// Peers are charged for the time spent processing their messages rather than
+// for the number of messages they send. A peer that has used more than its
+// allotted CPU time can't take messages from the pool, and a staker that has
+// can only have a single message pending.
 func (rm *msgManager) AddPending(vdr ids.ShortID) bool {
+	overAllotment := rm.Utilization(vdr) > 1
+
 	// Attempt to take the message from the pool
 	outstandingPoolMessages := rm.msgTracker.PoolCount()
 	totalPeerMessages, peerPoolMessages := rm.msgTracker.OutstandingCount(vdr)
-	if outstandingPoolMessages < rm.poolMessages && peerPoolMessages < rm.maxNonStakerPendingMsgs {
+	if !overAllotment && outstandingPoolMessages < rm.poolMessages && peerPoolMessages < rm.maxNonStakerPendingMsgs {
 		rm.msgTracker.AddPool(vdr)
 		return true
 	}
@@ -99,13 +106,14 @@ func (rm *msgManager) AddPending(vdr ids.ShortID) bool {
 	messageAllotment := uint32(stakerPortion * float64(rm.reservedMessages))
 	messageCount := totalPeerMessages - peerPoolMessages
 	// Allow at least one message per staker, even when staking
-	// portion rounds message allotment down to 0.
-	if messageCount <= messageAllotment {
+	// portion rounds message allotment down to 0 or the staker has used more
+	// than its allotted CPU time.
+	if messageCount == 0 || (!overAllotment && messageCount <= messageAllotment) {
 		rm.msgTracker.Add(vdr)
 		return true
 	}
 
-	rm.log.Debug("Throttling message from staker %s. %d/%d. %d/%d.", vdr, messageCount, messageAllotment, peerPoolMessages, rm.poolMessages)
+	rm.log.Debug("Throttling message from staker %s. %d/%d. %d/%d. Over CPU allotment: %v.", vdr, messageCount, messageAllotment, peerPoolMessages, rm.poolMessages, overAllotment)
 	return false
 }
 
@@ -98,6 +98,73 @@ func TestStakerGetsThrottled(t *testing.T) {
 	t.Fatal("Staker should have been throttled before taking up the entire message queue.")
 }
 
+func TestPeerOverCPUAllotmentGetsThrottled(t *testing.T) {
+	bufferSize := 8
+	vdrList := make([]validators.Validator, 0, bufferSize)
+	for i := 0; i < bufferSize; i++ {
+		vdr := validators.GenerateRandomValidator(2)
+		vdrList = append(vdrList, vdr)
+	}
+	nonStakerID := ids.NewShortID([20]byte{16})
+
+	stakerID := vdrList[0].ID()
+	// Both peers have had their messages processed for as long as all the
+	// other peers combined
+	cpuTracker := &staticCPUTracker{utilization: map[ids.ShortID]float64{
+		nonStakerID: 0.5,
+		stakerID:    0.5,
+	}}
+	msgTracker := tracker.NewMessageTracker()
+	vdrs := validators.NewSet()
+	if err := vdrs.Set(vdrList); err != nil {
+		t.Fatal(err)
+	}
+	resourceManager := NewMsgManager(
+		vdrs,
+		logging.NoLog{},
+		msgTracker,
+		cpuTracker,
+		uint32(bufferSize),
+		2,   // Allow each peer to take at most two messages from pool
+		0.5, // Allot half of message queue to stakers
+		0.5, // Allot half of CPU time to stakers
+	)
+
+	if success := resourceManager.AddPending(nonStakerID); success {
+		t.Fatal("Should have throttled a non-staker that used more than its CPU allotment")
+	}
+
+	// The staker can still have a single message pending
+	if success := resourceManager.AddPending(stakerID); !success {
+		t.Fatal("Should have allowed a single message from a staker that used more than its CPU allotment")
+	}
+	if success := resourceManager.AddPending(stakerID); success {
+		t.Fatal("Should have throttled a staker that used more than its CPU allotment")
+	}
+
+	// A staker that hasn't used CPU time isn't affected
+	if success := resourceManager.AddPending(vdrList[1].ID()); !success {
+		t.Fatal("Should have allowed a message from a staker under its CPU allotment")
+	}
+}
+
+// staticCPUTracker reports a fixed CPU utilization for each peer
+type staticCPUTracker struct {
+	utilization map[ids.ShortID]float64
+}
+
+func (s *staticCPUTracker) UtilizeTime(ids.ShortID, time.Time, time.Time) {}
+
+func (s *staticCPUTracker) Utilization(vdr ids.ShortID, _ time.Time) float64 {
+	return s.utilization[vdr]
+}
+
+func (s *staticCPUTracker) CumulativeUtilization(time.Time) float64 { return 1 }
+
+func (s *staticCPUTracker) Len() int { return len(s.utilization) }
+
+func (s *staticCPUTracker) EndInterval(time.Time) {}
+
 type infiniteResourceManager struct{}
 
 func (i *infiniteResourceManager) AddPending(vdr ids.ShortID) bool { return true }
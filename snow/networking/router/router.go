@@ -55,6 +55,12 @@ type InternalRouter interface {
 	GetAncestorsFailed(validatorID ids.ShortID, chainID ids.ID, requestID uint32)
 	QueryFailed(validatorID ids.ShortID, chainID ids.ID, requestID uint32)
 
+	// CoalesceGet is called before this node sends a Get request for
+	// [containerID]. Returns true if a Get request for the same container is
+	// already outstanding, in which case the request shouldn't be sent. It is
+	// answered with the response to the outstanding request instead.
+	CoalesceGet(validatorID ids.ShortID, chainID ids.ID, requestID uint32, containerID ids.ID) bool
+
 	Connected(validatorID ids.ShortID)
 	Disconnected(validatorID ids.ShortID)
 }
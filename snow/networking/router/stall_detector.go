@@ -0,0 +1,110 @@
+// (c) 2019-2020, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package router
+
+import (
+	"sync"
+	"time"
+
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/snow/validators"
+	"github.com/ava-labs/avalanchego/utils/logging"
+)
+
+// stallDetector tracks whether a chain is making progress. A chain that hasn't
+// accepted anything in a while is only stalled if it has work to do and
+// enough stake is connected for it to do that work. Otherwise it's idle.
+type stallDetector struct {
+	log logging.Logger
+
+	lock sync.Mutex
+	// Last time a container was accepted, or when the chain was created if
+	// nothing has been accepted since
+	lastAccepted time.Time
+	// Pending work most recently reported by the VM. Reset when a container
+	// is accepted, as the work may have been included in it.
+	pendingWork int
+	// Peers this node is connected to, including itself
+	connectedPeers ids.ShortSet
+	// True if the chain was stalled the last time it was checked
+	stalled bool
+}
+
+func (s *stallDetector) initialize(log logging.Logger, now time.Time) {
+	s.log = log
+	s.lastAccepted = now
+}
+
+// accepted records that a container was accepted at [now]
+func (s *stallDetector) accepted(now time.Time) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	s.lastAccepted = now
+	s.pendingWork = 0
+}
+
+// setPendingWork records the amount of pending work reported by the VM
+func (s *stallDetector) setPendingWork(pendingWork int) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	s.pendingWork = pendingWork
+}
+
+func (s *stallDetector) connected(validatorID ids.ShortID) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	s.connectedPeers.Add(validatorID)
+}
+
+func (s *stallDetector) disconnected(validatorID ids.ShortID) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	s.connectedPeers.Remove(validatorID)
+}
+
+// check returns details about the chain's progress at [now], and whether the
+// chain is stalled. The chain is stalled if nothing was accepted for
+// [timeout] although the VM reported pending work and at least
+// [minConnectedStake] of the stake in [vdrs] is connected.
+func (s *stallDetector) check(
+	now time.Time,
+	timeout time.Duration,
+	minConnectedStake float64,
+	vdrs validators.Set,
+) (map[string]interface{}, bool) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	connectedStake := 0.
+	if totalWeight := vdrs.Weight(); totalWeight > 0 {
+		// SubsetWeight only errors on overflow, which can't happen as the
+		// connected weight is at most the total weight
+		connectedWeight, _ := vdrs.SubsetWeight(s.connectedPeers)
+		connectedStake = float64(connectedWeight) / float64(totalWeight)
+	}
+	sinceAccepted := now.Sub(s.lastAccepted)
+	details := map[string]interface{}{
+		"lastAccepted":   s.lastAccepted,
+		"pendingWork":    s.pendingWork,
+		"connectedStake": connectedStake,
+	}
+
+	stalled := timeout > 0 &&
+		sinceAccepted >= timeout &&
+		s.pendingWork > 0 &&
+		connectedStake >= minConnectedStake
+	switch {
+	case stalled && !s.stalled:
+		s.log.Warn("chain has stalled. Nothing was accepted for %s although the VM reported %d pending items and %.1f%% of the stake is connected",
+			sinceAccepted, s.pendingWork, 100*connectedStake)
+	case !stalled && s.stalled:
+		s.log.Info("chain is no longer stalled")
+	}
+	s.stalled = stalled
+	return details, stalled
+}
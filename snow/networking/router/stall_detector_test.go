@@ -0,0 +1,64 @@
+// (c) 2019-2020, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package router
+
+import (
+	"testing"
+	"time"
+
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/snow/validators"
+	"github.com/ava-labs/avalanchego/utils/logging"
+)
+
+func TestStallDetector(t *testing.T) {
+	vdr0 := ids.GenerateTestShortID()
+	vdr1 := ids.GenerateTestShortID()
+	vdrs := validators.NewSet()
+	if err := vdrs.AddWeight(vdr0, 3); err != nil {
+		t.Fatal(err)
+	}
+	if err := vdrs.AddWeight(vdr1, 1); err != nil {
+		t.Fatal(err)
+	}
+
+	now := time.Now()
+	s := stallDetector{}
+	s.initialize(logging.NoLog{}, now)
+	s.connected(vdr0)
+	s.connected(vdr1)
+
+	// Nothing to do, so the chain is idle
+	now = now.Add(time.Minute)
+	if _, stalled := s.check(now, time.Minute, .75, vdrs); stalled {
+		t.Fatal("a chain without pending work shouldn't be stalled")
+	}
+
+	s.setPendingWork(5)
+	if _, stalled := s.check(now, 2*time.Minute, .75, vdrs); stalled {
+		t.Fatal("a chain that accepted something recently shouldn't be stalled")
+	}
+	if _, stalled := s.check(now, 0, .75, vdrs); stalled {
+		t.Fatal("a chain shouldn't be stalled if stall detection is disabled")
+	}
+	details, stalled := s.check(now, time.Minute, .75, vdrs)
+	if !stalled {
+		t.Fatal("a chain with pending work that hasn't accepted anything should be stalled")
+	}
+	if pendingWork := details["pendingWork"]; pendingWork != 5 {
+		t.Fatalf("expected 5 pending items to be reported but got %v", pendingWork)
+	}
+
+	// Without enough stake connected, the chain can't make progress
+	s.disconnected(vdr0)
+	if _, stalled := s.check(now, time.Minute, .75, vdrs); stalled {
+		t.Fatal("a chain without enough connected stake shouldn't be stalled")
+	}
+	s.connected(vdr0)
+
+	s.accepted(now)
+	if _, stalled := s.check(now.Add(time.Minute), time.Minute, .75, vdrs); stalled {
+		t.Fatal("accepting a container should have cleared the pending work")
+	}
+}
@@ -0,0 +1,230 @@
+// (c) 2019-2020, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package sender
+
+import (
+	"errors"
+	"math/rand"
+	"sync"
+	"time"
+
+	"github.com/ava-labs/avalanchego/ids"
+)
+
+var (
+	errInvalidRate  = errors.New("rates must be in [0, 1]")
+	errInvalidDelay = errors.New("delays must be non-negative, and the minimum delay must not exceed the maximum delay")
+)
+
+// ChaosConfig describes the faults injected into the messages a ChaosSender
+// sends. Faults are injected independently for each recipient of a message.
+type ChaosConfig struct {
+	// Probability that a message is dropped
+	DropRate float64
+	// Probability that a message that isn't dropped is delivered twice
+	DuplicateRate float64
+	// Each delivery of a message is delayed by a duration drawn uniformly from
+	// [MinDelay, MaxDelay]
+	MinDelay time.Duration
+	MaxDelay time.Duration
+	// Seeds the faults, so that a run can be reproduced
+	Seed int64
+}
+
+// Verify returns nil iff this config is valid
+func (c ChaosConfig) Verify() error {
+	switch {
+	case c.DropRate < 0 || c.DropRate > 1 || c.DuplicateRate < 0 || c.DuplicateRate > 1:
+		return errInvalidRate
+	case c.MinDelay < 0 || c.MinDelay > c.MaxDelay:
+		return errInvalidDelay
+	default:
+		return nil
+	}
+}
+
+// ChaosSender is an ExternalSender that drops, delays, and duplicates the
+// messages it sends, to test how consensus holds up on a bad network.
+// It sends messages faithfully until it's configured otherwise.
+type ChaosSender struct {
+	ExternalSender
+
+	lock   sync.Mutex
+	config ChaosConfig
+	rng    *rand.Rand
+}
+
+// NewChaosSender returns a ChaosSender that sends messages with [sender]
+func NewChaosSender(sender ExternalSender) *ChaosSender {
+	return &ChaosSender{
+		ExternalSender: sender,
+		rng:            rand.New(rand.NewSource(0)), // #nosec G404
+	}
+}
+
+// Configure sets the faults to inject into messages sent from now on. The zero
+// config injects no faults.
+func (s *ChaosSender) Configure(config ChaosConfig) error {
+	if err := config.Verify(); err != nil {
+		return err
+	}
+
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	s.config = config
+	s.rng = rand.New(rand.NewSource(config.Seed)) // #nosec G404
+	return nil
+}
+
+// Config returns the faults being injected into messages
+func (s *ChaosSender) Config() ChaosConfig {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	return s.config
+}
+
+// injecting returns true iff faults are being injected into messages
+func (s *ChaosSender) injecting() bool {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	return s.config.DropRate > 0 || s.config.DuplicateRate > 0 || s.config.MaxDelay > 0
+}
+
+// deliveries returns how long to wait before each delivery of a message. If
+// the message is dropped, it's empty.
+func (s *ChaosSender) deliveries() []time.Duration {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	if s.rng.Float64() < s.config.DropRate {
+		return nil
+	}
+	numDeliveries := 1
+	if s.rng.Float64() < s.config.DuplicateRate {
+		numDeliveries++
+	}
+	delays := make([]time.Duration, numDeliveries)
+	for i := range delays {
+		delays[i] = s.config.MinDelay
+		if spread := s.config.MaxDelay - s.config.MinDelay; spread > 0 {
+			delays[i] += time.Duration(s.rng.Int63n(int64(spread) + 1))
+		}
+	}
+	return delays
+}
+
+// send delivers a message with [send], injecting faults
+func (s *ChaosSender) send(send func()) {
+	for _, delay := range s.deliveries() {
+		if delay == 0 {
+			send()
+		} else {
+			time.AfterFunc(delay, send)
+		}
+	}
+}
+
+// sendEach delivers a message to each of [validatorIDs] with [send], injecting
+// faults independently for each of them
+func (s *ChaosSender) sendEach(validatorIDs ids.ShortSet, send func(ids.ShortSet)) {
+	if !s.injecting() {
+		send(validatorIDs)
+		return
+	}
+	for validatorIDKey := range validatorIDs {
+		validatorID := ids.NewShortID(validatorIDKey)
+		s.send(func() {
+			validatorIDs := ids.ShortSet{}
+			validatorIDs.Add(validatorID)
+			send(validatorIDs)
+		})
+	}
+}
+
+// GetAcceptedFrontier implements the ExternalSender interface
+func (s *ChaosSender) GetAcceptedFrontier(validatorIDs ids.ShortSet, chainID ids.ID, requestID uint32, deadline time.Time) {
+	s.sendEach(validatorIDs, func(validatorIDs ids.ShortSet) {
+		s.ExternalSender.GetAcceptedFrontier(validatorIDs, chainID, requestID, deadline)
+	})
+}
+
+// AcceptedFrontier implements the ExternalSender interface
+func (s *ChaosSender) AcceptedFrontier(validatorID ids.ShortID, chainID ids.ID, requestID uint32, containerIDs []ids.ID) {
+	s.send(func() {
+		s.ExternalSender.AcceptedFrontier(validatorID, chainID, requestID, containerIDs)
+	})
+}
+
+// GetAccepted implements the ExternalSender interface
+func (s *ChaosSender) GetAccepted(validatorIDs ids.ShortSet, chainID ids.ID, requestID uint32, deadline time.Time, containerIDs []ids.ID) {
+	s.sendEach(validatorIDs, func(validatorIDs ids.ShortSet) {
+		s.ExternalSender.GetAccepted(validatorIDs, chainID, requestID, deadline, containerIDs)
+	})
+}
+
+// Accepted implements the ExternalSender interface
+func (s *ChaosSender) Accepted(validatorID ids.ShortID, chainID ids.ID, requestID uint32, containerIDs []ids.ID) {
+	s.send(func() {
+		s.ExternalSender.Accepted(validatorID, chainID, requestID, containerIDs)
+	})
+}
+
+// GetAncestors implements the ExternalSender interface
+func (s *ChaosSender) GetAncestors(validatorID ids.ShortID, chainID ids.ID, requestID uint32, deadline time.Time, containerID ids.ID) {
+	s.send(func() {
+		s.ExternalSender.GetAncestors(validatorID, chainID, requestID, deadline, containerID)
+	})
+}
+
+// MultiPut implements the ExternalSender interface
+func (s *ChaosSender) MultiPut(validatorID ids.ShortID, chainID ids.ID, requestID uint32, containers [][]byte) {
+	s.send(func() {
+		s.ExternalSender.MultiPut(validatorID, chainID, requestID, containers)
+	})
+}
+
+// Get implements the ExternalSender interface
+func (s *ChaosSender) Get(validatorID ids.ShortID, chainID ids.ID, requestID uint32, deadline time.Time, containerID ids.ID) {
+	s.send(func() {
+		s.ExternalSender.Get(validatorID, chainID, requestID, deadline, containerID)
+	})
+}
+
+// Put implements the ExternalSender interface
+func (s *ChaosSender) Put(validatorID ids.ShortID, chainID ids.ID, requestID uint32, containerID ids.ID, container []byte) {
+	s.send(func() {
+		s.ExternalSender.Put(validatorID, chainID, requestID, containerID, container)
+	})
+}
+
+// PushQuery implements the ExternalSender interface
+func (s *ChaosSender) PushQuery(validatorIDs ids.ShortSet, chainID ids.ID, requestID uint32, deadline time.Time, containerID ids.ID, container []byte) {
+	s.sendEach(validatorIDs, func(validatorIDs ids.ShortSet) {
+		s.ExternalSender.PushQuery(validatorIDs, chainID, requestID, deadline, containerID, container)
+	})
+}
+
+// PullQuery implements the ExternalSender interface
+func (s *ChaosSender) PullQuery(validatorIDs ids.ShortSet, chainID ids.ID, requestID uint32, deadline time.Time, containerID ids.ID) {
+	s.sendEach(validatorIDs, func(validatorIDs ids.ShortSet) {
+		s.ExternalSender.PullQuery(validatorIDs, chainID, requestID, deadline, containerID)
+	})
+}
+
+// Chits implements the ExternalSender interface
+func (s *ChaosSender) Chits(validatorID ids.ShortID, chainID ids.ID, requestID uint32, votes []ids.ID) {
+	s.send(func() {
+		s.ExternalSender.Chits(validatorID, chainID, requestID, votes)
+	})
+}
+
+// Gossip implements the ExternalSender interface
+func (s *ChaosSender) Gossip(chainID ids.ID, containerID ids.ID, container []byte) {
+	s.send(func() {
+		s.ExternalSender.Gossip(chainID, containerID, container)
+	})
+}
@@ -0,0 +1,105 @@
+// (c) 2019-2020, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package sender
+
+import (
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/ava-labs/avalanchego/ids"
+)
+
+func TestChaosConfigVerify(t *testing.T) {
+	tests := []struct {
+		name   string
+		config ChaosConfig
+		valid  bool
+	}{
+		{"zero", ChaosConfig{}, true},
+		{"valid", ChaosConfig{DropRate: .5, DuplicateRate: 1, MinDelay: time.Second, MaxDelay: time.Second}, true},
+		{"negative drop rate", ChaosConfig{DropRate: -.1}, false},
+		{"duplicate rate too high", ChaosConfig{DuplicateRate: 1.1}, false},
+		{"negative delay", ChaosConfig{MinDelay: -time.Second}, false},
+		{"min delay exceeds max delay", ChaosConfig{MinDelay: 2 * time.Second, MaxDelay: time.Second}, false},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			if err := test.config.Verify(); test.valid && err != nil {
+				t.Fatal(err)
+			} else if !test.valid && err == nil {
+				t.Fatal("should have failed verification")
+			}
+		})
+	}
+}
+
+func TestChaosSender(t *testing.T) {
+	chainID := ids.GenerateTestID()
+	vdrs := ids.ShortSet{}
+	vdrs.Add(ids.GenerateTestShortID(), ids.GenerateTestShortID())
+
+	lock := sync.Mutex{}
+	received := []ids.ShortSet{}
+	external := &ExternalSenderTest{T: t}
+	external.Default(true)
+	external.PullQueryF = func(validatorIDs ids.ShortSet, _ ids.ID, _ uint32, _ time.Time, _ ids.ID) {
+		lock.Lock()
+		defer lock.Unlock()
+		received = append(received, validatorIDs)
+	}
+	numReceived := func() int {
+		lock.Lock()
+		defer lock.Unlock()
+		return len(received)
+	}
+
+	s := NewChaosSender(external)
+
+	// Without faults, messages are passed through as is
+	s.PullQuery(vdrs, chainID, 0, time.Time{}, ids.Empty)
+	if n := numReceived(); n != 1 || received[0].Len() != vdrs.Len() {
+		t.Fatalf("expected the message to be sent once to every validator but got %v", received)
+	}
+
+	// Every message is dropped
+	if err := s.Configure(ChaosConfig{DropRate: 1}); err != nil {
+		t.Fatal(err)
+	}
+	s.PullQuery(vdrs, chainID, 1, time.Time{}, ids.Empty)
+	if n := numReceived(); n != 1 {
+		t.Fatalf("expected the message to be dropped but %d messages were sent", n)
+	}
+
+	// Every message is sent twice, separately to each validator
+	if err := s.Configure(ChaosConfig{DuplicateRate: 1}); err != nil {
+		t.Fatal(err)
+	}
+	s.PullQuery(vdrs, chainID, 2, time.Time{}, ids.Empty)
+	if n := numReceived(); n != 1+2*vdrs.Len() {
+		t.Fatalf("expected the message to be sent twice to each validator but %d messages were sent", n-1)
+	}
+	for _, validatorIDs := range received[1:] {
+		if validatorIDs.Len() != 1 {
+			t.Fatalf("expected the message to be sent separately to each validator")
+		}
+	}
+
+	// Every message is delayed
+	if err := s.Configure(ChaosConfig{MinDelay: 50 * time.Millisecond, MaxDelay: 100 * time.Millisecond}); err != nil {
+		t.Fatal(err)
+	}
+	s.PullQuery(vdrs, chainID, 3, time.Time{}, ids.Empty)
+	if n := numReceived(); n != 1+2*vdrs.Len() {
+		t.Fatalf("expected the message to be delayed but %d messages were sent", n-1-2*vdrs.Len())
+	}
+	time.Sleep(200 * time.Millisecond)
+	if n := numReceived(); n != 1+3*vdrs.Len() {
+		t.Fatalf("expected the message to be sent once to each validator after a delay but %d messages were sent", n-1-2*vdrs.Len())
+	}
+
+	if err := s.Configure(ChaosConfig{DropRate: 2}); err == nil {
+		t.Fatal("should have rejected an invalid config")
+	}
+}
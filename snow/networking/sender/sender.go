@@ -138,6 +138,12 @@ func (s *Sender) Get(validatorID ids.ShortID, requestID uint32, containerID ids.
 		return
 	}
 
+	// If this container is already being fetched, wait for that response
+	// instead of sending another request for it
+	if s.router.CoalesceGet(validatorID, s.ctx.ChainID, requestID, containerID) {
+		return
+	}
+
 	// Add a timeout -- if we don't get a response before the timeout expires,
 	// send this consensus engine a GetFailed message
 	deadline, ok := s.timeouts.Register(validatorID, s.ctx.ChainID, requestID, true, constants.GetMsg, func() {
@@ -0,0 +1,52 @@
+// (c) 2019-2020, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package validators
+
+import (
+	"bytes"
+	"sort"
+
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/utils/hashing"
+	"github.com/ava-labs/avalanchego/utils/wrappers"
+)
+
+// Canonical returns the validators of [vdrs] that are part of its canonical
+// serialization, sorted by node ID. Validators with no weight are skipped, so
+// two sets containing the same weighted validators are always the same.
+func Canonical(vdrs []Validator) []Validator {
+	sorted := make([]Validator, 0, len(vdrs))
+	for _, vdr := range vdrs {
+		if vdr.Weight() != 0 {
+			sorted = append(sorted, vdr)
+		}
+	}
+	sort.Slice(sorted, func(i, j int) bool {
+		return bytes.Compare(sorted[i].ID().Bytes(), sorted[j].ID().Bytes()) == -1
+	})
+	return sorted
+}
+
+// CanonicalBytes returns the canonical serialization of [vdrs]. The validators
+// returned by Canonical are each serialized as their node ID followed by their
+// weight.
+func CanonicalBytes(vdrs []Validator) ([]byte, error) {
+	sorted := Canonical(vdrs)
+	p := wrappers.Packer{MaxSize: wrappers.IntLen + len(sorted)*(hashing.AddrLen+wrappers.LongLen)}
+	p.PackInt(uint32(len(sorted)))
+	for _, vdr := range sorted {
+		p.PackFixedBytes(vdr.ID().Bytes())
+		p.PackLong(vdr.Weight())
+	}
+	return p.Bytes, p.Err
+}
+
+// CanonicalHash returns the hash of the canonical serialization of [vdrs]
+func CanonicalHash(vdrs []Validator) (ids.ID, error) {
+	vdrsBytes, err := CanonicalBytes(vdrs)
+	if err != nil {
+		return ids.ID{}, err
+	}
+	return hashing.ComputeHash256Array(vdrsBytes), nil
+}
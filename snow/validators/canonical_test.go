@@ -0,0 +1,82 @@
+// (c) 2019-2020, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package validators
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/ava-labs/avalanchego/ids"
+)
+
+func TestCanonicalBytesOrderIndependent(t *testing.T) {
+	vdr0 := NewValidator(ids.NewShortID([20]byte{1}), 10)
+	vdr1 := NewValidator(ids.NewShortID([20]byte{2}), 20)
+	vdr2 := NewValidator(ids.NewShortID([20]byte{3}), 30)
+
+	bytes0, err := CanonicalBytes([]Validator{vdr0, vdr1, vdr2})
+	if err != nil {
+		t.Fatal(err)
+	}
+	bytes1, err := CanonicalBytes([]Validator{vdr2, vdr0, vdr1})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !bytes.Equal(bytes0, bytes1) {
+		t.Fatalf("serialization shouldn't depend on the order of the validators")
+	}
+
+	expected := []byte{
+		0x00, 0x00, 0x00, 0x03,
+		0x01, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00,
+		0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00,
+		0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x0a,
+		0x02, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00,
+		0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00,
+		0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x14,
+		0x03, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00,
+		0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00,
+		0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x1e,
+	}
+	if !bytes.Equal(bytes0, expected) {
+		t.Fatalf("expected 0x%x but got 0x%x", expected, bytes0)
+	}
+}
+
+func TestCanonicalHash(t *testing.T) {
+	vdr0 := NewValidator(ids.NewShortID([20]byte{1}), 10)
+	vdr1 := NewValidator(ids.NewShortID([20]byte{2}), 20)
+	zeroWeight := NewValidator(ids.NewShortID([20]byte{3}), 0)
+
+	s := NewSet()
+	if err := s.Set([]Validator{vdr1, vdr0}); err != nil {
+		t.Fatal(err)
+	}
+	setHash, err := CanonicalHash(s.List())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// Validators without weight aren't part of the set
+	hash, err := CanonicalHash([]Validator{vdr0, zeroWeight, vdr1})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if hash != setHash {
+		t.Fatalf("expected hash %s but got %s", setHash, hash)
+	}
+	if canonical := Canonical([]Validator{vdr1, zeroWeight, vdr0}); len(canonical) != 2 ||
+		canonical[0].ID() != vdr0.ID() || canonical[1].ID() != vdr1.ID() {
+		t.Fatalf("expected the weighted validators sorted by node ID but got %v", canonical)
+	}
+
+	// Changing a weight changes the hash
+	hash, err = CanonicalHash([]Validator{vdr0, NewValidator(vdr1.ID(), 21)})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if hash == setHash {
+		t.Fatalf("hash should have changed with the weight of a validator")
+	}
+}
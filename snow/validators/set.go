@@ -64,21 +64,70 @@ type Set interface {
 	// Sample returns a collection of validators, potentially with duplicates.
 	// If sampling the requested size isn't possible, an error will be returned.
 	Sample(size int) ([]Validator, error)
+
+	// Snapshot returns an immutable copy of the validators currently in the
+	// set. Reading the snapshot doesn't contend with changes to the set, so
+	// frequent samplers should sample a snapshot rather than the set.
+	// Snapshots are shared until the set changes, so taking one is cheap.
+	Snapshot() (Snapshot, error)
+}
+
+// Snapshot of a set of validators at some point in time. It never changes.
+type Snapshot interface {
+	fmt.Stringer
+
+	// GetWeight retrieves the validator weight from the snapshot.
+	GetWeight(ids.ShortID) (uint64, bool)
+
+	// SubsetWeight returns the sum of the weights of the validators.
+	SubsetWeight(ids.ShortSet) (uint64, error)
+
+	// Contains returns true if there is a validator with the specified ID in
+	// the snapshot.
+	Contains(ids.ShortID) bool
+
+	// Len returns the number of validators in the snapshot.
+	Len() int
+
+	// List all the validators in the snapshot
+	List() []Validator
+
+	// Weight returns the cumulative weight of all validators in the snapshot.
+	Weight() uint64
+
+	// Sample returns a collection of validators, potentially with duplicates.
+	// If sampling the requested size isn't possible, an error will be returned.
+	Sample(size int) ([]Validator, error)
+}
+
+// SampleSnapshot samples [size] validators from a snapshot of [vdrs], so that
+// sampling doesn't contend with changes to [vdrs]
+func SampleSnapshot(vdrs Set, size int) ([]Validator, error) {
+	snapshot, err := vdrs.Snapshot()
+	if err != nil {
+		return nil, err
+	}
+	return snapshot.Sample(size)
 }
 
 // NewSet returns a new, empty set of validators.
 func NewSet() Set {
 	return &set{
-		vdrMap:  make(map[[20]byte]int),
-		sampler: sampler.NewWeightedWithoutReplacement(),
+		vdrMap:     make(map[[20]byte]int),
+		sampler:    sampler.NewWeightedWithoutReplacement(),
+		newSampler: sampler.NewWeightedWithoutReplacement,
 	}
 }
 
 // NewBestSet returns a new, empty set of validators.
 func NewBestSet(expectedSampleSize int) Set {
+	newSampler := func() sampler.WeightedWithoutReplacement {
+		return sampler.NewBestWeightedWithoutReplacement(expectedSampleSize)
+	}
 	return &set{
-		vdrMap:  make(map[[20]byte]int),
-		sampler: sampler.NewBestWeightedWithoutReplacement(expectedSampleSize),
+		vdrMap:     make(map[[20]byte]int),
+		sampler:    newSampler(),
+		newSampler: newSampler,
 	}
 }
 
@@ -92,6 +141,12 @@ type set struct {
 	vdrWeights  []uint64
 	sampler     sampler.WeightedWithoutReplacement
 	totalWeight uint64
+
+	// Returns a sampler of the same kind as [sampler], for snapshots to use
+	newSampler func() sampler.WeightedWithoutReplacement
+	// The snapshot of the set as it currently is, or nil if the set has
+	// changed since the last snapshot was taken
+	snapshot *set
 }
 
 // Set implements the Set interface.
@@ -103,6 +158,7 @@ func (s *set) Set(vdrs []Validator) error {
 }
 
 func (s *set) set(vdrs []Validator) error {
+	s.snapshot = nil
 	lenVdrs := len(vdrs)
 	// If the underlying arrays are much larger than necessary, resize them to
 	// allow garbage collection of unused memory
@@ -158,6 +214,7 @@ func (s *set) addWeight(vdrID ids.ShortID, weight uint64) error {
 	if weight == 0 {
 		return nil // This validator would never be sampled anyway
 	}
+	s.snapshot = nil
 
 	newTotalWeight, err := safemath.Add64(s.totalWeight, weight)
 	if err != nil {
@@ -238,6 +295,7 @@ func (s *set) removeWeight(vdrID ids.ShortID, weight uint64) error {
 	if !ok {
 		return nil
 	}
+	s.snapshot = nil
 
 	// Validator exists
 	vdr := s.vdrSlice[i]
@@ -363,6 +421,50 @@ func (s *set) sample(size int) ([]Validator, error) {
 	return list, nil
 }
 
+// Snapshot implements the Set interface.
+func (s *set) Snapshot() (Snapshot, error) {
+	s.lock.RLock()
+	snapshot := s.snapshot
+	s.lock.RUnlock()
+	if snapshot != nil {
+		return snapshot, nil
+	}
+
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	// The snapshot may have been taken while the lock was released
+	if s.snapshot == nil {
+		snapshot, err := s.copy()
+		if err != nil {
+			return nil, err
+		}
+		s.snapshot = snapshot
+	}
+	return s.snapshot, nil
+}
+
+// copy returns a copy of this set that shares no state with it
+func (s *set) copy() (*set, error) {
+	c := &set{
+		vdrMap:      make(map[[20]byte]int, len(s.vdrMap)),
+		vdrSlice:    make([]*validator, len(s.vdrSlice)),
+		vdrWeights:  make([]uint64, len(s.vdrWeights)),
+		sampler:     s.newSampler(),
+		totalWeight: s.totalWeight,
+		newSampler:  s.newSampler,
+	}
+	for vdrIDKey, i := range s.vdrMap {
+		c.vdrMap[vdrIDKey] = i
+	}
+	for i, vdr := range s.vdrSlice {
+		vdrCopy := *vdr
+		c.vdrSlice[i] = &vdrCopy
+	}
+	copy(c.vdrWeights, s.vdrWeights)
+	return c, c.sampler.Initialize(c.vdrWeights)
+}
+
 func (s *set) Weight() uint64 {
 	s.lock.RLock()
 	defer s.lock.RUnlock()
@@ -178,3 +178,53 @@ func TestSetSubsetWeight(t *testing.T) {
 	expectedWeight := weight0 + weight1
 	assert.Equal(t, expectedWeight, subsetWeight, "wrong subset weight")
 }
+
+func TestSetSnapshot(t *testing.T) {
+	vdr0 := ids.GenerateTestShortID()
+	vdr1 := ids.GenerateTestShortID()
+
+	s := NewSet()
+	err := s.AddWeight(vdr0, 1)
+	assert.NoError(t, err)
+
+	snapshot, err := s.Snapshot()
+	assert.NoError(t, err)
+
+	sameSnapshot, err := s.Snapshot()
+	assert.NoError(t, err)
+	assert.True(t, snapshot == sameSnapshot, "should have reused the snapshot while the set was unchanged")
+
+	err = s.AddWeight(vdr0, 1)
+	assert.NoError(t, err)
+	err = s.AddWeight(vdr1, 2)
+	assert.NoError(t, err)
+
+	// The snapshot doesn't change with the set
+	assert.Equal(t, 1, snapshot.Len())
+	assert.Equal(t, uint64(1), snapshot.Weight())
+	weight, ok := snapshot.GetWeight(vdr0)
+	assert.True(t, ok)
+	assert.Equal(t, uint64(1), weight)
+	assert.False(t, snapshot.Contains(vdr1))
+	assert.Equal(t, uint64(1), snapshot.List()[0].Weight())
+
+	sampled, err := snapshot.Sample(1)
+	assert.NoError(t, err)
+	assert.Equal(t, vdr0, sampled[0].ID(), "should have sampled vdr0")
+	_, err = snapshot.Sample(2)
+	assert.Error(t, err, "should have errored during sampling")
+
+	// A new snapshot reflects the changes
+	newSnapshot, err := s.Snapshot()
+	assert.NoError(t, err)
+	assert.Equal(t, 2, newSnapshot.Len())
+	assert.Equal(t, uint64(4), newSnapshot.Weight())
+
+	err = s.RemoveWeight(vdr1, 2)
+	assert.NoError(t, err)
+	assert.True(t, newSnapshot.Contains(vdr1))
+
+	sampled, err = SampleSnapshot(s, 1)
+	assert.NoError(t, err)
+	assert.Equal(t, vdr0, sampled[0].ID(), "should have sampled vdr0")
+}
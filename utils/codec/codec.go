@@ -7,6 +7,7 @@ import (
 	"errors"
 	"fmt"
 	"reflect"
+	"sort"
 	"sync"
 	"unicode"
 
@@ -30,6 +31,7 @@ var (
 	errNeedPointer       = errors.New("argument to unmarshal must be a pointer")
 	errCantPackVersion   = errors.New("couldn't pack codec version")
 	errCantUnpackVersion = errors.New("couldn't unpack codec version")
+	errTypeIDCollision   = errors.New("type ID collision")
 )
 
 // Codec handles marshaling and unmarshaling of structs
@@ -56,6 +58,8 @@ type Codec interface {
 	Registry
 	MarshalInto(interface{}, *wrappers.Packer) error
 	Unmarshal([]byte, interface{}) error
+	// RegisteredTypes returns the registered types, ordered by type ID
+	RegisteredTypes() []RegisteredType
 }
 
 // New returns a new, concurrency-safe codec
@@ -90,6 +94,10 @@ func (c *codec) RegisterType(val interface{}) error {
 	if _, exists := c.typeToTypeID[valType]; exists {
 		return fmt.Errorf("type %v has already been registered", valType)
 	}
+	if existingType, exists := c.typeIDToType[c.nextTypeID]; exists {
+		return fmt.Errorf("%w: can't register %v with type ID %d, which is used by %v",
+			errTypeIDCollision, valType, c.nextTypeID, existingType)
+	}
 
 	c.typeIDToType[c.nextTypeID] = reflect.TypeOf(val)
 	c.typeToTypeID[valType] = c.nextTypeID
@@ -97,6 +105,23 @@ func (c *codec) RegisterType(val interface{}) error {
 	return nil
 }
 
+// RegisteredTypes returns the types registered with this codec, ordered by
+// type ID
+func (c *codec) RegisteredTypes() []RegisteredType {
+	c.lock.RLock()
+	defer c.lock.RUnlock()
+
+	types := make([]RegisteredType, 0, len(c.typeIDToType))
+	for typeID, typ := range c.typeIDToType {
+		types = append(types, RegisteredType{
+			ID:   typeID,
+			Type: typ.String(),
+		})
+	}
+	sort.Slice(types, func(i, j int) bool { return types[i].ID < types[j].ID })
+	return types
+}
+
 // A few notes:
 // 1) See codec_test.go for examples of usage
 // 2) We use "marshal" and "serialize" interchangeably, and "unmarshal" and "deserialize" interchangeably
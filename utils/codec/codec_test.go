@@ -5,6 +5,7 @@ package codec
 
 import (
 	"bytes"
+	"errors"
 	"math"
 	"reflect"
 	"testing"
@@ -873,3 +874,56 @@ func TestUnmarshalInvalidInterface(t *testing.T) {
 		}
 	}
 }
+
+func TestRegisterTypeIDCollision(t *testing.T) {
+	codec := NewDefault()
+	if err := codec.RegisterType(&MyInnerStruct{}); err != nil {
+		t.Fatal(err)
+	}
+	// Wrap the next type ID back around to 0
+	codec.Skip(-1)
+	if err := codec.RegisterType(&MyInnerStruct2{}); !errors.Is(err, errTypeIDCollision) {
+		t.Fatalf("expected a type ID collision but got %v", err)
+	}
+}
+
+func TestManagerVerify(t *testing.T) {
+	v0 := NewDefault()
+	v1 := NewDefault()
+	manager := NewDefaultManager()
+	errs := wrappers.Errs{}
+	errs.Add(
+		v0.RegisterType(&MyInnerStruct{}),
+		v0.RegisterType(&MyInnerStruct2{}),
+		v1.RegisterType(&MyInnerStruct{}),
+		v1.RegisterType(&MyInnerStruct2{}),
+		manager.RegisterCodec(0, v0),
+		manager.RegisterCodec(1, v1),
+	)
+	if errs.Errored() {
+		t.Fatal(errs.Err)
+	}
+	if err := manager.Verify(); err != nil {
+		t.Fatal(err)
+	}
+
+	expected := []RegisteredType{
+		{ID: 0, Type: "*codec.MyInnerStruct"},
+		{ID: 1, Type: "*codec.MyInnerStruct2"},
+	}
+	if types := manager.RegisteredTypes(); !reflect.DeepEqual(types[1], expected) {
+		t.Fatalf("expected registered types %v but got %v", expected, types[1])
+	}
+
+	// MyInnerStruct2 moves from type ID 1 to 0
+	v2 := NewDefault()
+	if err := v2.RegisterType(&MyInnerStruct2{}); err != nil {
+		t.Fatal(err)
+	}
+	if err := manager.RegisterCodec(2, v2); err != nil {
+		t.Fatal(err)
+	}
+	if err := manager.Verify(); !errors.Is(err, errTypeIDDrift) {
+		t.Fatalf("expected type ID drift but got %v", err)
+	}
+}
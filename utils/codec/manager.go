@@ -6,6 +6,7 @@ package codec
 import (
 	"errors"
 	"fmt"
+	"sort"
 	"sync"
 
 	"github.com/ava-labs/avalanchego/utils/wrappers"
@@ -24,6 +25,7 @@ const (
 var (
 	errUnknownVersion    = errors.New("unknown codec version")
 	errDuplicatedVersion = errors.New("duplicated codec version")
+	errTypeIDDrift       = errors.New("type IDs differ between codec versions")
 )
 
 // Manager describes the functionality for managing codec versions.
@@ -43,6 +45,14 @@ type Manager interface {
 	// be a pointer or an interface. Returns the version of the codec that
 	// produces the given bytes.
 	Unmarshal(source []byte, destination interface{}) (version uint16, err error)
+
+	// RegisteredTypes returns the types registered with each codec version
+	RegisteredTypes() map[uint16][]RegisteredType
+
+	// Verify returns an error if a type is registered with different type IDs
+	// in different codec versions. A type's ID changing between versions would
+	// cause nodes running different versions to disagree on how to parse it.
+	Verify() error
 }
 
 // NewNewManager returns a new codec manager.
@@ -137,3 +147,47 @@ func (m *manager) Unmarshal(bytes []byte, dest interface{}) (uint16, error) {
 	}
 	return version, c.Unmarshal(p.Bytes[p.Offset:], dest)
 }
+
+// RegisteredTypes returns the types registered with each codec version
+func (m *manager) RegisteredTypes() map[uint16][]RegisteredType {
+	m.lock.RLock()
+	defer m.lock.RUnlock()
+
+	types := make(map[uint16][]RegisteredType, len(m.codecs))
+	for version, codec := range m.codecs {
+		types[version] = codec.RegisteredTypes()
+	}
+	return types
+}
+
+// Verify implements the Manager interface
+func (m *manager) Verify() error {
+	versions := []uint16(nil)
+	types := m.RegisteredTypes()
+	for version := range types {
+		versions = append(versions, version)
+	}
+	sort.Slice(versions, func(i, j int) bool { return versions[i] < versions[j] })
+
+	// Type --> (version, type ID) of the first version it was registered in
+	firstSeen := make(map[string]struct {
+		version uint16
+		typeID  uint32
+	})
+	for _, version := range versions {
+		for _, registered := range types[version] {
+			first, exists := firstSeen[registered.Type]
+			if !exists {
+				first.version = version
+				first.typeID = registered.ID
+				firstSeen[registered.Type] = first
+				continue
+			}
+			if first.typeID != registered.ID {
+				return fmt.Errorf("%w: %s has type ID %d in version %d but %d in version %d",
+					errTypeIDDrift, registered.Type, first.typeID, first.version, registered.ID, version)
+			}
+		}
+	}
+	return nil
+}
@@ -3,6 +3,12 @@
 
 package codec
 
+// RegisteredType is a type that was registered with a codec
+type RegisteredType struct {
+	ID   uint32 `json:"id"`
+	Type string `json:"type"`
+}
+
 // Registry registers new types that can be marshaled into
 type Registry interface {
 	Skip(int)
@@ -0,0 +1,181 @@
+// (c) 2019-2020, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package crypto
+
+import (
+	"crypto/hmac"
+	"crypto/sha512"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"math/big"
+	"strconv"
+	"strings"
+)
+
+const (
+	// HardenedKeyStart is the index of the first hardened child of an HD key
+	HardenedKeyStart uint32 = 1 << 31
+
+	// AVAXCoinType is the coin type registered for AVAX in SLIP-0044
+	AVAXCoinType uint32 = 9000
+
+	// AVAXAccountPath is the BIP44 derivation path of the first AVAX account.
+	// Its external addresses are derived at m/44'/9000'/0'/0/i, and its change
+	// addresses at m/44'/9000'/0'/1/i.
+	AVAXAccountPath = "m/44'/9000'/0'"
+
+	// minSeedLen and maxSeedLen bound the length of a BIP32 seed
+	minSeedLen = 16
+	maxSeedLen = 64
+)
+
+var (
+	errInvalidSeedLen = fmt.Errorf("seed must be between %d and %d bytes", minSeedLen, maxSeedLen)
+	errInvalidPath    = errors.New("derivation path must start with \"m\"")
+	errInvalidChild   = errors.New("derived key is invalid, use the next index")
+
+	// masterKeyHMACKey is the HMAC key used to derive the master key from a
+	// seed, as defined by BIP32
+	masterKeyHMACKey = []byte("Bitcoin seed")
+
+	// secp256k1N is the order of the secp256k1 curve
+	secp256k1N, _ = new(big.Int).SetString("FFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFEBAAEDCE6AF48A03BBFD25E8CD0364141", 16)
+)
+
+// ExtendedPrivateKeySECP256K1R is a BIP32 extended private key, from which
+// child keys can be derived deterministically
+type ExtendedPrivateKeySECP256K1R struct {
+	key       *PrivateKeySECP256K1R
+	chainCode []byte
+	depth     uint8
+}
+
+// NewMasterKey returns the BIP32 master key derived from [seed]
+func NewMasterKey(seed []byte) (*ExtendedPrivateKeySECP256K1R, error) {
+	if len(seed) < minSeedLen || len(seed) > maxSeedLen {
+		return nil, errInvalidSeedLen
+	}
+
+	mac := hmac.New(sha512.New, masterKeyHMACKey)
+	_, _ = mac.Write(seed)
+	i := mac.Sum(nil)
+
+	keyInt := new(big.Int).SetBytes(i[:32])
+	if keyInt.Sign() == 0 || keyInt.Cmp(secp256k1N) >= 0 {
+		return nil, errInvalidChild
+	}
+	return newExtendedKey(keyInt, i[32:], 0)
+}
+
+func newExtendedKey(keyInt *big.Int, chainCode []byte, depth uint8) (*ExtendedPrivateKeySECP256K1R, error) {
+	keyBytes := make([]byte, SECP256K1RSKLen)
+	intBytes := keyInt.Bytes()
+	copy(keyBytes[SECP256K1RSKLen-len(intBytes):], intBytes)
+
+	factory := FactorySECP256K1R{}
+	key, err := factory.ToPrivateKey(keyBytes)
+	if err != nil {
+		return nil, err
+	}
+	return &ExtendedPrivateKeySECP256K1R{
+		key:       key.(*PrivateKeySECP256K1R),
+		chainCode: chainCode,
+		depth:     depth,
+	}, nil
+}
+
+// Key returns the private key of this extended key
+func (k *ExtendedPrivateKeySECP256K1R) Key() *PrivateKeySECP256K1R { return k.key }
+
+// ChainCode returns the chain code of this extended key
+func (k *ExtendedPrivateKeySECP256K1R) ChainCode() []byte { return k.chainCode }
+
+// Depth returns the number of derivations from the master key to this key
+func (k *ExtendedPrivateKeySECP256K1R) Depth() uint8 { return k.depth }
+
+// Child returns the child of this key with index [index]. Indices starting at
+// HardenedKeyStart derive hardened children. In the rare case that the child
+// is invalid, an error is returned and the next index should be used instead.
+func (k *ExtendedPrivateKeySECP256K1R) Child(index uint32) (*ExtendedPrivateKeySECP256K1R, error) {
+	// The data is [0x00 || private key || index] for hardened children, and
+	// [compressed public key || index] otherwise
+	data := make([]byte, 0, SECP256K1RPKLen+4)
+	if index >= HardenedKeyStart {
+		data = append(data, 0)
+		data = append(data, k.key.Bytes()...)
+	} else {
+		data = append(data, k.key.PublicKey().Bytes()...)
+	}
+	indexBytes := make([]byte, 4)
+	binary.BigEndian.PutUint32(indexBytes, index)
+	data = append(data, indexBytes...)
+
+	mac := hmac.New(sha512.New, k.chainCode)
+	_, _ = mac.Write(data)
+	i := mac.Sum(nil)
+
+	tweak := new(big.Int).SetBytes(i[:32])
+	if tweak.Cmp(secp256k1N) >= 0 {
+		return nil, errInvalidChild
+	}
+	keyInt := new(big.Int).SetBytes(k.key.Bytes())
+	keyInt.Add(keyInt, tweak)
+	keyInt.Mod(keyInt, secp256k1N)
+	if keyInt.Sign() == 0 {
+		return nil, errInvalidChild
+	}
+	return newExtendedKey(keyInt, i[32:], k.depth+1)
+}
+
+// Derive returns the key derived from this key along [path], which is
+// relative to this key
+func (k *ExtendedPrivateKeySECP256K1R) Derive(path []uint32) (*ExtendedPrivateKeySECP256K1R, error) {
+	key := k
+	for _, index := range path {
+		child, err := key.Child(index)
+		if err != nil {
+			return nil, err
+		}
+		key = child
+	}
+	return key, nil
+}
+
+// DerivePath returns the key derived from this key along [path], like
+// "m/44'/9000'/0'". This key is treated as the master key.
+func (k *ExtendedPrivateKeySECP256K1R) DerivePath(path string) (*ExtendedPrivateKeySECP256K1R, error) {
+	indices, err := ParseDerivationPath(path)
+	if err != nil {
+		return nil, err
+	}
+	return k.Derive(indices)
+}
+
+// ParseDerivationPath parses a BIP32 derivation path like "m/44'/9000'/0'/0/1"
+// into the indices of the children along it. Hardened indices are marked with
+// a trailing "'" or "h".
+func ParseDerivationPath(path string) ([]uint32, error) {
+	parts := strings.Split(path, "/")
+	if parts[0] != "m" {
+		return nil, errInvalidPath
+	}
+
+	indices := make([]uint32, len(parts)-1)
+	for i, part := range parts[1:] {
+		hardened := strings.HasSuffix(part, "'") || strings.HasSuffix(part, "h")
+		if hardened {
+			part = part[:len(part)-1]
+		}
+		index, err := strconv.ParseUint(part, 10, 32)
+		if err != nil || uint32(index) >= HardenedKeyStart {
+			return nil, fmt.Errorf("invalid index %q in derivation path %q", parts[i+1], path)
+		}
+		indices[i] = uint32(index)
+		if hardened {
+			indices[i] += HardenedKeyStart
+		}
+	}
+	return indices, nil
+}
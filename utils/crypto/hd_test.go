@@ -0,0 +1,83 @@
+// (c) 2019-2020, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package crypto
+
+import (
+	"encoding/hex"
+	"testing"
+)
+
+// Test vector 1 of BIP32
+func TestHDKeyDerivation(t *testing.T) {
+	seed, _ := hex.DecodeString("000102030405060708090a0b0c0d0e0f")
+	master, err := NewMasterKey(seed)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		path      string
+		chainCode string
+		key       string
+	}{
+		{
+			path:      "m",
+			chainCode: "873dff81c02f525623fd1fe5167eac3a55a049de3d314bb42ee227ffed37d508",
+			key:       "e8f32e723decf4051aefac8e2c93c9c5b214313817cdb01a1494b917c8436b35",
+		},
+		{
+			path:      "m/0'",
+			chainCode: "47fdacbd0f1097043b78c63c20c34ef4ed9a111d980047ad16282c7ae6236141",
+			key:       "edb2e14f9ee77d26dd93b4ecede8d16ed408ce149b6cd80b0715a2d911a0afea",
+		},
+		{
+			path:      "m/0h/1",
+			chainCode: "2a7857631386ba23dacac34180dd1983734e444fdbf774041578e9b6adb37c19",
+			key:       "3c6cb8d0f6a264c91ea8b5030fadaa8e538b020f0a387421a12de9319dc93368",
+		},
+	}
+	for _, test := range tests {
+		key, err := master.DerivePath(test.path)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if chainCode := hex.EncodeToString(key.ChainCode()); chainCode != test.chainCode {
+			t.Fatalf("Expected %s to have chain code %s but got %s", test.path, test.chainCode, chainCode)
+		}
+		if keyHex := hex.EncodeToString(key.Key().Bytes()); keyHex != test.key {
+			t.Fatalf("Expected %s to have key %s but got %s", test.path, test.key, keyHex)
+		}
+	}
+}
+
+func TestParseDerivationPath(t *testing.T) {
+	path, err := ParseDerivationPath(AVAXAccountPath + "/1/5")
+	if err != nil {
+		t.Fatal(err)
+	}
+	expected := []uint32{44 + HardenedKeyStart, AVAXCoinType + HardenedKeyStart, HardenedKeyStart, 1, 5}
+	if len(path) != len(expected) {
+		t.Fatalf("Expected path %v but got %v", expected, path)
+	}
+	for i, index := range expected {
+		if path[i] != index {
+			t.Fatalf("Expected path %v but got %v", expected, path)
+		}
+	}
+
+	for _, invalid := range []string{"", "44'/9000'", "m/", "m/a", "m/-1", "m/2147483648"} {
+		if _, err := ParseDerivationPath(invalid); err == nil {
+			t.Fatalf("Should have failed to parse %q", invalid)
+		}
+	}
+}
+
+func TestNewMasterKeyInvalidSeed(t *testing.T) {
+	if _, err := NewMasterKey(make([]byte, minSeedLen-1)); err == nil {
+		t.Fatal("Should have rejected a short seed")
+	}
+	if _, err := NewMasterKey(make([]byte, maxSeedLen+1)); err == nil {
+		t.Fatal("Should have rejected a long seed")
+	}
+}
@@ -50,7 +50,7 @@ func ParseBech32(addrStr string) (string, []byte, error) {
 	if err != nil {
 		return "", nil, err
 	}
-	addrBytes, err := bech32.ConvertBits(decoded, 5, 8, true)
+	addrBytes, err := bech32.ConvertBits(decoded, 5, 8, false)
 	if err != nil {
 		return "", nil, fmt.Errorf("unable to convert address from 5-bit to 8-bit formatting")
 	}
@@ -0,0 +1,33 @@
+// (c) 2019-2020, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package formatting
+
+import (
+	"bytes"
+	"testing"
+)
+
+func TestBech32RoundTrip(t *testing.T) {
+	for _, payload := range [][]byte{
+		{},
+		{1, 2, 3, 4},
+		{0, 1, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11, 12, 13, 14, 15, 16, 17, 18, 19},
+		{0, 1, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11, 12, 13, 14, 15, 16, 17, 18, 19, 20, 21, 22, 23, 24, 25, 26, 27, 28, 29, 30, 31},
+	} {
+		addr, err := FormatBech32("avax", payload)
+		if err != nil {
+			t.Fatal(err)
+		}
+		hrp, parsed, err := ParseBech32(addr)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if hrp != "avax" {
+			t.Fatalf("expected HRP avax but got %s", hrp)
+		}
+		if !bytes.Equal(parsed, payload) {
+			t.Fatalf("expected %v but got %v", payload, parsed)
+		}
+	}
+}
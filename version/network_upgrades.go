@@ -0,0 +1,70 @@
+// (c) 2019-2020, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package version
+
+import (
+	"time"
+
+	"github.com/ava-labs/avalanchego/utils/constants"
+)
+
+var (
+	// MetadataUpgradeTimes maps network IDs to the time the metadata upgrade
+	// activates on that network. The upgrade adds the X-Chain transaction that
+	// updates an asset's metadata and the P-Chain transaction that sets a
+	// subnet's info. Nodes that predate the upgrade can't parse these
+	// transactions, so they're rejected until the upgrade activates.
+	//
+	// The upgrade isn't scheduled on the public networks yet. Their times must
+	// be set in the release that activates it.
+	MetadataUpgradeTimes = map[uint32]time.Time{
+		constants.MainnetID: time.Date(10000, time.January, 1, 0, 0, 0, 0, time.UTC),
+		constants.FujiID:    time.Date(10000, time.January, 1, 0, 0, 0, 0, time.UTC),
+	}
+
+	// DefaultMetadataUpgradeTime is when the metadata upgrade activates on
+	// networks that aren't in MetadataUpgradeTimes, such as local networks
+	DefaultMetadataUpgradeTime = time.Unix(0, 0)
+)
+
+// GetMetadataUpgradeTime returns the time the metadata upgrade activates on
+// the network with ID [networkID]
+func GetMetadataUpgradeTime(networkID uint32) time.Time {
+	if upgradeTime, exists := MetadataUpgradeTimes[networkID]; exists {
+		return upgradeTime
+	}
+	return DefaultMetadataUpgradeTime
+}
+
+// Names of the network upgrades
+const (
+	MetadataUpgrade = "metadata"
+)
+
+// NetworkUpgrades maps the name of each network upgrade to the time it
+// activates on a network. Chains share it through their snow.Context so they
+// agree on when each upgrade activates.
+type NetworkUpgrades map[string]time.Time
+
+// GetNetworkUpgrades returns when each network upgrade activates on the
+// network with ID [networkID]
+func GetNetworkUpgrades(networkID uint32) NetworkUpgrades {
+	return NetworkUpgrades{
+		MetadataUpgrade: GetMetadataUpgradeTime(networkID),
+	}
+}
+
+// ActivationTime returns the time the upgrade named [name] activates. Returns
+// false if there's no such upgrade.
+func (u NetworkUpgrades) ActivationTime(name string) (time.Time, bool) {
+	activationTime, exists := u[name]
+	return activationTime, exists
+}
+
+// IsActivated returns true if the upgrade named [name] is activated at time
+// [t]. Upgrades that don't exist are never activated.
+func (u NetworkUpgrades) IsActivated(name string, t time.Time) bool {
+	activationTime, exists := u[name]
+	return exists && !t.Before(activationTime)
+}
@@ -0,0 +1,54 @@
+// (c) 2019-2020, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package version
+
+import (
+	"testing"
+	"time"
+
+	"github.com/ava-labs/avalanchego/utils/constants"
+)
+
+func TestGetNetworkUpgrades(t *testing.T) {
+	upgrades := GetNetworkUpgrades(constants.MainnetID)
+	activationTime, exists := upgrades.ActivationTime(MetadataUpgrade)
+	if !exists {
+		t.Fatalf("%s upgrade is missing", MetadataUpgrade)
+	}
+	if !activationTime.Equal(GetMetadataUpgradeTime(constants.MainnetID)) {
+		t.Fatalf("expected %s upgrade at %s but got %s", MetadataUpgrade, GetMetadataUpgradeTime(constants.MainnetID), activationTime)
+	}
+	if upgrades.IsActivated(MetadataUpgrade, time.Now()) {
+		t.Fatalf("%s upgrade shouldn't be activated on mainnet", MetadataUpgrade)
+	}
+
+	localUpgrades := GetNetworkUpgrades(constants.LocalID)
+	if !localUpgrades.IsActivated(MetadataUpgrade, time.Now()) {
+		t.Fatalf("%s upgrade should be activated on local networks", MetadataUpgrade)
+	}
+}
+
+func TestNetworkUpgradesIsActivated(t *testing.T) {
+	activationTime := time.Unix(1000, 0)
+	upgrades := NetworkUpgrades{"test": activationTime}
+
+	tests := []struct {
+		name      string
+		time      time.Time
+		activated bool
+	}{
+		{"test", activationTime.Add(-time.Second), false},
+		{"test", activationTime, true},
+		{"test", activationTime.Add(time.Second), true},
+		{"unknown", activationTime.Add(time.Second), false},
+	}
+	for _, test := range tests {
+		if activated := upgrades.IsActivated(test.name, test.time); activated != test.activated {
+			t.Fatalf("expected %s upgrade activated=%v at %s but got %v", test.name, test.activated, test.time, activated)
+		}
+	}
+	if _, exists := upgrades.ActivationTime("unknown"); exists {
+		t.Fatal("unknown upgrade shouldn't have an activation time")
+	}
+}
@@ -12,6 +12,7 @@ import (
 	"github.com/ava-labs/avalanchego/snow/choices"
 	"github.com/ava-labs/avalanchego/utils/formatting"
 	"github.com/ava-labs/avalanchego/utils/rpc"
+	"github.com/ava-labs/avalanchego/vms/secp256k1fx"
 
 	cjson "github.com/ava-labs/avalanchego/utils/json"
 )
@@ -51,6 +52,16 @@ func (c *Client) GetTxStatus(txID ids.ID) (choices.Status, error) {
 	return res.Status, err
 }
 
+// GetIssuedTxStatus returns the status of [txID], which was issued through
+// this node, and the number of times it was re-issued
+func (c *Client) GetIssuedTxStatus(txID ids.ID) (string, uint32, error) {
+	res := &GetIssuedTxStatusReply{}
+	err := c.requester.SendRequest("getIssuedTxStatus", &api.JSONTxID{
+		TxID: txID,
+	}, res)
+	return res.Status, uint32(res.Regossips), err
+}
+
 // GetTx returns the byte representation of [txID]
 func (c *Client) GetTx(txID ids.ID) ([]byte, error) {
 	res := &api.FormattedTx{}
@@ -96,6 +107,29 @@ func (c *Client) GetUTXOs(addrs []string, limit uint32, startAddress, startUTXOI
 	return utxos, res.EndIndex, nil
 }
 
+// UsedAddresses returns a secp256k1fx.UsedAddresses that reports the addresses
+// that control UTXOs on this chain, to scan an HD wallet's addresses with.
+// Addresses are formatted with [chainAlias], like "X", and [hrp], like "avax".
+func (c *Client) UsedAddresses(chainAlias, hrp string) secp256k1fx.UsedAddresses {
+	return func(addrs []ids.ShortID) (ids.ShortSet, error) {
+		used := ids.ShortSet{}
+		for _, addr := range addrs {
+			addrStr, err := formatting.FormatAddress(chainAlias, hrp, addr.Bytes())
+			if err != nil {
+				return nil, err
+			}
+			utxos, _, err := c.GetUTXOs([]string{addrStr}, 1, "", "")
+			if err != nil {
+				return nil, err
+			}
+			if len(utxos) > 0 {
+				used.Add(addr)
+			}
+		}
+		return used, nil
+	}
+}
+
 // GetAssetDescription returns a description of [assetID]
 func (c *Client) GetAssetDescription(assetID string) (*GetAssetDescriptionReply, error) {
 	res := &GetAssetDescriptionReply{}
@@ -105,6 +139,22 @@ func (c *Client) GetAssetDescription(assetID string) (*GetAssetDescriptionReply,
 	return res, err
 }
 
+// GetAssetMetadata returns the most recent metadata of [assetID]
+func (c *Client) GetAssetMetadata(assetID string) (*GetAssetMetadataReply, error) {
+	res := &GetAssetMetadataReply{}
+	err := c.requester.SendRequest("getAssetMetadata", &GetAssetDescriptionArgs{
+		AssetID: assetID,
+	}, res)
+	return res, err
+}
+
+// GetBurnedFees returns the amount of AVAX burned as fees on the X-Chain
+func (c *Client) GetBurnedFees() (uint64, error) {
+	res := &GetBurnedFeesReply{}
+	err := c.requester.SendRequest("getBurnedFees", struct{}{}, res)
+	return uint64(res.BurnedFees), err
+}
+
 // GetBalance returns the balance for [addr] of [assetID]
 func (c *Client) GetBalance(addr string, assetID string) (*GetBalanceReply, error) {
 	res := &GetBalanceReply{}
@@ -1255,7 +1255,7 @@ func TestIssueExportTx(t *testing.T) {
 	ctx.Lock.Unlock()
 
 	msg := <-issuer
-	if msg != common.PendingTxs {
+	if msg.Type() != common.PendingTxs {
 		t.Fatalf("Wrong message")
 	}
 
@@ -1386,7 +1386,7 @@ func TestClearForceAcceptedExportTx(t *testing.T) {
 	ctx.Lock.Unlock()
 
 	msg := <-issuer
-	if msg != common.PendingTxs {
+	if msg.Type() != common.PendingTxs {
 		t.Fatalf("Wrong message")
 	}
 
@@ -331,7 +331,7 @@ func TestIssueImportTx(t *testing.T) {
 	ctx.Lock.Unlock()
 
 	msg := <-issuer
-	if msg != common.PendingTxs {
+	if msg.Type() != common.PendingTxs {
 		t.Fatalf("Wrong message")
 	}
 
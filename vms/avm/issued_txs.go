@@ -0,0 +1,216 @@
+// (c) 2019-2020, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package avm
+
+import (
+	"time"
+
+	"github.com/ava-labs/avalanchego/cache"
+	"github.com/ava-labs/avalanchego/database"
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/snow/choices"
+	"github.com/ava-labs/avalanchego/utils/wrappers"
+)
+
+const (
+	regossipFrequency      = 30 * time.Second
+	persistFrequency       = 5 * time.Second
+	issuedTxTTL            = 10 * time.Minute
+	maxPendingIssuedTxs    = 4096
+	finishedIssuedTxsCache = 4096
+)
+
+// Prefix of the database that stores the pending issued transactions so that
+// they survive restarts
+var issuedTxsPrefix = []byte("issued txs")
+
+// Statuses of a transaction that was issued through this node
+const (
+	IssuedTxPending  = "Pending"
+	IssuedTxAccepted = "Accepted"
+	IssuedTxRejected = "Rejected"
+	IssuedTxExpired  = "Expired"
+)
+
+type issuedTx struct {
+	tx     *UniqueTx
+	issued time.Time
+	// True if the tx is waiting to be handed to the consensus engine
+	queued bool
+	// True if the tx was handed to the consensus engine at least once
+	sent bool
+	// Number of times the tx was handed to the consensus engine again after
+	// the first time while the engine wasn't already processing it
+	regossips int
+	status    string
+}
+
+// issuedTxs tracks the transactions that were issued through this node until
+// they are decided or [ttl] has passed since they were issued. Undecided
+// transactions are periodically re-issued to the consensus engine so that a
+// transaction isn't lost if the engine dropped it or its vertex never reached
+// the network.
+type issuedTxs struct {
+	ttl time.Duration
+
+	// Transactions that haven't been decided or expired yet
+	pending map[ids.ID]*issuedTx
+	// Transactions that were recently decided or expired
+	finished cache.LRU
+
+	// Pending transactions, so that they're re-issued after a restart
+	// Key: Tx ID
+	// Value: [issue time] | [tx bytes]
+	db database.Database
+	// IDs of the transactions in [db]
+	persisted ids.Set
+}
+
+// persistedTx is a transaction that was pending when the issued transactions
+// were last persisted
+type persistedTx struct {
+	bytes  []byte
+	issued time.Time
+}
+
+func (i *issuedTxs) initialize(ttl time.Duration, db database.Database) {
+	i.ttl = ttl
+	i.pending = make(map[ids.ID]*issuedTx)
+	i.finished = cache.LRU{Size: finishedIssuedTxsCache}
+	i.db = db
+}
+
+// add starts tracking [tx], which was issued at [now]. Returns false if too
+// many transactions are already being tracked.
+func (i *issuedTxs) add(tx *UniqueTx, now time.Time) bool {
+	txID := tx.ID()
+	if _, exists := i.pending[txID]; exists {
+		return true
+	}
+	if len(i.pending) >= maxPendingIssuedTxs {
+		return false
+	}
+	i.finished.Evict(txID)
+	i.pending[txID] = &issuedTx{
+		tx:     tx,
+		issued: now,
+		queued: true,
+		status: IssuedTxPending,
+	}
+	return true
+}
+
+// sent records that the tx with ID [txID] was handed to the consensus engine
+func (i *issuedTxs) sent(txID ids.ID) {
+	itx, ok := i.pending[txID]
+	if !ok || !itx.queued {
+		return
+	}
+	itx.queued = false
+	// The engine verifies a tx when it issues it into consensus, and drops the
+	// txs it's already processing when they're handed to it again, so those
+	// aren't counted.
+	if itx.sent && !itx.tx.processing() {
+		itx.regossips++
+	}
+	itx.sent = true
+}
+
+// get returns the tracked state of [txID], if it's known
+func (i *issuedTxs) get(txID ids.ID) (*issuedTx, bool) {
+	if itx, ok := i.pending[txID]; ok {
+		return itx, true
+	}
+	if itx, ok := i.finished.Get(txID); ok {
+		return itx.(*issuedTx), true
+	}
+	return nil, false
+}
+
+// update the tracked transactions as of [now]. Returns the transactions that
+// are still pending and should be re-issued. Transactions that are still
+// waiting to be handed to the consensus engine aren't returned.
+func (i *issuedTxs) update(now time.Time) []*issuedTx {
+	var toRegossip []*issuedTx
+	for txID, itx := range i.pending {
+		switch status := itx.tx.Status(); {
+		case status == choices.Accepted:
+			itx.status = IssuedTxAccepted
+		case status == choices.Rejected:
+			itx.status = IssuedTxRejected
+		case now.Sub(itx.issued) >= i.ttl:
+			itx.status = IssuedTxExpired
+		default:
+			if !itx.queued {
+				itx.queued = true
+				toRegossip = append(toRegossip, itx)
+			}
+			continue
+		}
+		delete(i.pending, txID)
+		i.finished.Put(txID, itx)
+	}
+	return toRegossip
+}
+
+// persist writes the pending transactions to the database and removes the ones
+// that are no longer pending. Must be followed by a commit of the VM's
+// database.
+func (i *issuedTxs) persist() error {
+	for _, txID := range i.persisted.List() {
+		if _, ok := i.pending[txID]; ok {
+			continue
+		}
+		if err := i.db.Delete(txID[:]); err != nil {
+			return err
+		}
+		i.persisted.Remove(txID)
+	}
+	for txID, itx := range i.pending {
+		if i.persisted.Contains(txID) {
+			continue
+		}
+		txBytes := itx.tx.Bytes()
+		p := wrappers.Packer{MaxSize: wrappers.LongLen + len(txBytes)}
+		p.PackLong(uint64(itx.issued.Unix()))
+		p.PackFixedBytes(txBytes)
+		if p.Err != nil {
+			return p.Err
+		}
+		if err := i.db.Put(txID[:], p.Bytes); err != nil {
+			return err
+		}
+		i.persisted.Add(txID)
+	}
+	return nil
+}
+
+// loadPersisted returns the transactions that were pending when the issued
+// transactions were last persisted. The ones that aren't tracked again are
+// removed from the database the next time they're persisted.
+func (i *issuedTxs) loadPersisted() ([]persistedTx, error) {
+	iter := i.db.NewIterator()
+	defer iter.Release()
+
+	var txs []persistedTx
+	for iter.Next() {
+		txID, err := ids.ToID(iter.Key())
+		if err != nil {
+			return nil, err
+		}
+		i.persisted.Add(txID)
+
+		value := iter.Value()
+		if len(value) < wrappers.LongLen {
+			continue
+		}
+		p := wrappers.Packer{Bytes: value}
+		issued := time.Unix(int64(p.UnpackLong()), 0)
+		txs = append(txs, persistedTx{
+			bytes:  append([]byte(nil), p.UnpackFixedBytes(len(value)-wrappers.LongLen)...),
+			issued: issued,
+		})
+	}
+	return txs, iter.Error()
+}
@@ -4,7 +4,10 @@
 package avm
 
 import (
+	"time"
+
 	"github.com/ava-labs/avalanchego/cache"
+	"github.com/ava-labs/avalanchego/database"
 	"github.com/ava-labs/avalanchego/ids"
 	"github.com/ava-labs/avalanchego/snow/choices"
 	"github.com/ava-labs/avalanchego/vms/components/avax"
@@ -15,10 +18,15 @@ const (
 	utxoID
 	txStatusID
 	dbInitializedID
+	assetMetadataID
+	burnedFeesID
+	lastAcceptedTimeID
 )
 
 var (
-	dbInitialized = ids.Empty.Prefix(dbInitializedID)
+	dbInitialized    = ids.Empty.Prefix(dbInitializedID)
+	burnedFees       = ids.Empty.Prefix(burnedFeesID)
+	lastAcceptedTime = ids.Empty.Prefix(lastAcceptedTimeID)
 )
 
 // prefixedState wraps a state object. By prefixing the state, there will be no
@@ -63,6 +71,73 @@ func (s *prefixedState) SetStatus(id ids.ID, status choices.Status) error {
 	return s.state.SetStatus(uniqueID(id, txStatusID, s.txStatus), status)
 }
 
+// AssetMetadata returns the most recent metadata of the asset with ID
+// [assetID]. Returns database.ErrNotFound if the metadata was never set.
+func (s *prefixedState) AssetMetadata(assetID ids.ID) (*AssetMetadata, error) {
+	key := assetID.Prefix(assetMetadataID)
+	metadataBytes, err := s.state.DB.Get(key[:])
+	if err != nil {
+		return nil, err
+	}
+	metadata := &AssetMetadata{}
+	if _, err := s.state.Codec.Unmarshal(metadataBytes, metadata); err != nil {
+		return nil, err
+	}
+	return metadata, nil
+}
+
+// BurnedFees returns the amount of AVAX that accepted transactions have burned
+// as fees
+func (s *prefixedState) BurnedFees() (uint64, error) {
+	feesBytes, err := s.state.DB.Get(burnedFees[:])
+	if err == database.ErrNotFound {
+		return 0, nil
+	}
+	if err != nil {
+		return 0, err
+	}
+	var fees uint64
+	_, err = s.state.Codec.Unmarshal(feesBytes, &fees)
+	return fees, err
+}
+
+// SetBurnedFees saves the amount of AVAX that accepted transactions have
+// burned as fees
+func (s *prefixedState) SetBurnedFees(fees uint64) error {
+	feesBytes, err := s.state.Codec.Marshal(codecVersion, fees)
+	if err != nil {
+		return err
+	}
+	return s.state.DB.Put(burnedFees[:], feesBytes)
+}
+
+// LastAcceptedTime returns the latest time at which this chain accepted
+// transactions. Returns the zero time if it was never saved.
+func (s *prefixedState) LastAcceptedTime() (time.Time, error) {
+	timeBytes, err := s.state.DB.Get(lastAcceptedTime[:])
+	if err == database.ErrNotFound {
+		return time.Time{}, nil
+	}
+	if err != nil {
+		return time.Time{}, err
+	}
+	var unixTime uint64
+	if _, err := s.state.Codec.Unmarshal(timeBytes, &unixTime); err != nil {
+		return time.Time{}, err
+	}
+	return time.Unix(int64(unixTime), 0), nil
+}
+
+// SetLastAcceptedTime saves the latest time at which this chain accepted
+// transactions
+func (s *prefixedState) SetLastAcceptedTime(t time.Time) error {
+	timeBytes, err := s.state.Codec.Marshal(codecVersion, uint64(t.Unix()))
+	if err != nil {
+		return err
+	}
+	return s.state.DB.Put(lastAcceptedTime[:], timeBytes)
+}
+
 // DBInitialized returns the status of this database. If the database is
 // uninitialized, the status will be unknown.
 func (s *prefixedState) DBInitialized() (choices.Status, error) { return s.state.Status(dbInitialized) }
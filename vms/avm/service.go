@@ -11,6 +11,7 @@ import (
 	"strings"
 
 	"github.com/ava-labs/avalanchego/api"
+	"github.com/ava-labs/avalanchego/database"
 	"github.com/ava-labs/avalanchego/ids"
 	"github.com/ava-labs/avalanchego/snow/choices"
 	"github.com/ava-labs/avalanchego/utils/constants"
@@ -47,6 +48,7 @@ var (
 	errNilTxID                = errors.New("nil transaction ID")
 	errNoAddresses            = errors.New("no addresses provided")
 	errNoKeys                 = errors.New("from addresses have no keys or funds")
+	errUntrackedTx            = errors.New("transaction wasn't recently issued through this node")
 )
 
 // Service defines the base service for the asset vm
@@ -96,6 +98,34 @@ func (service *Service) GetTxStatus(r *http.Request, args *api.JSONTxID, reply *
 	return nil
 }
 
+// GetIssuedTxStatusReply defines the GetIssuedTxStatus replies returned from
+// the API
+type GetIssuedTxStatusReply struct {
+	// One of Pending, Accepted, Rejected or Expired
+	Status string `json:"status"`
+	// Number of times the transaction was re-issued to consensus
+	Regossips json.Uint32 `json:"regossips"`
+}
+
+// GetIssuedTxStatus returns the status of a transaction that was issued
+// through this node. Pending transactions are periodically re-issued until they
+// are decided or expire.
+func (service *Service) GetIssuedTxStatus(r *http.Request, args *api.JSONTxID, reply *GetIssuedTxStatusReply) error {
+	service.vm.ctx.Log.Info("AVM: GetIssuedTxStatus called with %s", args.TxID)
+
+	if args.TxID == ids.Empty {
+		return errNilTxID
+	}
+
+	itx, ok := service.vm.issuedTxs.get(args.TxID)
+	if !ok {
+		return errUntrackedTx
+	}
+	reply.Status = itx.status
+	reply.Regossips = json.Uint32(itx.regossips)
+	return nil
+}
+
 // GetTx returns the specified transaction
 func (service *Service) GetTx(r *http.Request, args *api.GetTxArgs, reply *api.FormattedTx) error {
 	service.vm.ctx.Log.Info("AVM: GetTx called with %s", args.TxID)
@@ -259,6 +289,51 @@ func (service *Service) GetAssetDescription(_ *http.Request, args *GetAssetDescr
 	return nil
 }
 
+// GetAssetMetadataReply defines the GetAssetMetadata replies returned from the API
+type GetAssetMetadataReply struct {
+	FormattedAssetID
+	URI                 string     `json:"uri"`
+	DisplayDenomination json.Uint8 `json:"displayDenomination"`
+}
+
+// GetAssetMetadata returns the most recent metadata set for an asset by an
+// UpdateAssetMetadataTx
+func (service *Service) GetAssetMetadata(_ *http.Request, args *GetAssetDescriptionArgs, reply *GetAssetMetadataReply) error {
+	service.vm.ctx.Log.Info("AVM: GetAssetMetadata called with %s", args.AssetID)
+
+	assetID, err := service.vm.lookupAssetID(args.AssetID)
+	if err != nil {
+		return err
+	}
+
+	metadata, err := service.vm.state.AssetMetadata(assetID)
+	if err == database.ErrNotFound {
+		return errNoMetadataForAssetID
+	} else if err != nil {
+		return fmt.Errorf("couldn't read metadata of asset %s: %w", assetID, err)
+	}
+
+	reply.AssetID = assetID
+	reply.URI = metadata.URI
+	reply.DisplayDenomination = json.Uint8(metadata.DisplayDenomination)
+	return nil
+}
+
+// GetBurnedFeesReply are the results from calling GetBurnedFees
+type GetBurnedFeesReply struct {
+	BurnedFees json.Uint64 `json:"burnedFees"`
+}
+
+// GetBurnedFees returns the total amount of AVAX burned as fees by
+// transactions accepted on the X-Chain
+func (service *Service) GetBurnedFees(_ *http.Request, _ *struct{}, reply *GetBurnedFeesReply) error {
+	service.vm.ctx.Log.Info("AVM: GetBurnedFees called")
+
+	burnedFees, err := service.vm.state.BurnedFees()
+	reply.BurnedFees = json.Uint64(burnedFees)
+	return err
+}
+
 // GetBalanceArgs are arguments for passing into GetBalance requests
 type GetBalanceArgs struct {
 	Address string `json:"address"`
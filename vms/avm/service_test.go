@@ -233,6 +233,92 @@ func TestServiceGetTxStatus(t *testing.T) {
 	}
 }
 
+func TestServiceGetIssuedTxStatus(t *testing.T) {
+	genesisBytes, vm, s, _ := setup(t)
+	defer func() {
+		if err := vm.Shutdown(); err != nil {
+			t.Fatal(err)
+		}
+		vm.ctx.Lock.Unlock()
+	}()
+
+	tx := NewTx(t, genesisBytes, vm)
+	statusArgs := &api.JSONTxID{TxID: tx.ID()}
+	statusReply := &GetIssuedTxStatusReply{}
+	if err := s.GetIssuedTxStatus(nil, statusArgs, statusReply); err != errUntrackedTx {
+		t.Fatalf("Expected an unsubmitted tx to be untracked but returned %v", err)
+	}
+
+	if _, err := vm.IssueTx(tx.Bytes()); err != nil {
+		t.Fatal(err)
+	}
+	// The tx is still waiting to be handed to the engine, so it isn't
+	// re-issued
+	vm.regossipIssuedTxs()
+	if txs := vm.PendingTxs(); len(txs) != 1 {
+		t.Fatalf("Expected the tx to be handed to the engine once, got %d txs", len(txs))
+	}
+	if err := s.GetIssuedTxStatus(nil, statusArgs, statusReply); err != nil {
+		t.Fatal(err)
+	}
+	if statusReply.Status != IssuedTxPending || statusReply.Regossips != 0 {
+		t.Fatalf("Expected a pending tx not re-issued, got %q re-issued %d times", statusReply.Status, statusReply.Regossips)
+	}
+
+	// Re-issuing is only counted once the tx is handed to the engine
+	vm.regossipIssuedTxs()
+	vm.regossipIssuedTxs()
+	if err := s.GetIssuedTxStatus(nil, statusArgs, statusReply); err != nil {
+		t.Fatal(err)
+	}
+	if statusReply.Regossips != 0 {
+		t.Fatalf("Expected a queued tx not to be counted as re-issued, got %d", statusReply.Regossips)
+	}
+	if txs := vm.PendingTxs(); len(txs) != 1 {
+		t.Fatalf("Expected the tx to be re-issued once, got %d txs", len(txs))
+	}
+
+	if err := s.GetIssuedTxStatus(nil, statusArgs, statusReply); err != nil {
+		t.Fatal(err)
+	}
+	if statusReply.Status != IssuedTxPending || statusReply.Regossips != 1 {
+		t.Fatalf("Expected a pending tx re-issued once, got %q re-issued %d times", statusReply.Status, statusReply.Regossips)
+	}
+
+	// Once the engine is processing the tx, handing it to the engine again
+	// isn't counted as it's dropped
+	uniqueTx, err := vm.GetTx(tx.ID())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := uniqueTx.Verify(); err != nil {
+		t.Fatal(err)
+	}
+	vm.regossipIssuedTxs()
+	if txs := vm.PendingTxs(); len(txs) != 1 {
+		t.Fatalf("Expected the tx to be re-issued once, got %d txs", len(txs))
+	}
+	if err := s.GetIssuedTxStatus(nil, statusArgs, statusReply); err != nil {
+		t.Fatal(err)
+	}
+	if statusReply.Regossips != 1 {
+		t.Fatalf("Expected a processing tx not to be counted as re-issued, got %d", statusReply.Regossips)
+	}
+
+	if err := uniqueTx.Accept(); err != nil {
+		t.Fatal(err)
+	}
+	vm.regossipIssuedTxs()
+
+	statusReply = &GetIssuedTxStatusReply{}
+	if err := s.GetIssuedTxStatus(nil, statusArgs, statusReply); err != nil {
+		t.Fatal(err)
+	}
+	if statusReply.Status != IssuedTxAccepted || statusReply.Regossips != 1 {
+		t.Fatalf("Expected an accepted tx re-issued once, got %q re-issued %d times", statusReply.Status, statusReply.Regossips)
+	}
+}
+
 func TestServiceGetBalance(t *testing.T) {
 	genesisBytes, vm, s, _ := setup(t)
 	defer func() {
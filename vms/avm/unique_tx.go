@@ -138,6 +138,16 @@ func (tx *UniqueTx) Accept() error {
 		}
 	}
 
+	if err := tx.vm.burnFee(tx.UnsignedTx); err != nil {
+		tx.vm.ctx.Log.Error("Failed to burn the fee of tx %s due to %s", tx.txID, err)
+		return err
+	}
+
+	if err := tx.vm.advanceLastAcceptedTime(); err != nil {
+		tx.vm.ctx.Log.Error("Failed to record the acceptance time of tx %s due to %s", tx.txID, err)
+		return err
+	}
+
 	if err := tx.setStatus(choices.Accepted); err != nil {
 		tx.vm.ctx.Log.Error("Failed to accept tx %s due to %s", tx.txID, err)
 		return err
@@ -321,6 +331,13 @@ func (tx *UniqueTx) SyntacticVerify() error {
 	return tx.validity
 }
 
+// processing returns true if the consensus engine verified this transaction and
+// it hasn't been decided yet
+func (tx *UniqueTx) processing() bool {
+	tx.refresh()
+	return tx.verifiedState && tx.status == choices.Processing
+}
+
 // SemanticVerify the validity of this transaction
 func (tx *UniqueTx) SemanticVerify() error {
 	// SyntacticVerify sets the error on validity and is checked in the next
@@ -0,0 +1,122 @@
+// (c) 2019-2020, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package avm
+
+import (
+	"errors"
+	"fmt"
+	"unicode"
+
+	"github.com/ava-labs/avalanchego/database"
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/snow"
+	"github.com/ava-labs/avalanchego/utils/codec"
+	"github.com/ava-labs/avalanchego/vms/components/verify"
+	"github.com/ava-labs/avalanchego/vms/nftfx"
+	"github.com/ava-labs/avalanchego/vms/propertyfx"
+	"github.com/ava-labs/avalanchego/vms/secp256k1fx"
+)
+
+const (
+	maxMetadataURILen = 256
+)
+
+var (
+	errWrongNumberOfOps         = errors.New("an updateAssetMetadataTx must have exactly one operation")
+	errNotMintOperation         = errors.New("asset metadata can only be updated by a mint operation")
+	errMetadataURITooLong       = fmt.Errorf("metadata URI is too long, maximum size is %d", maxMetadataURILen)
+	errIllegalURICharacter      = errors.New("metadata URI must be made up of only printable ASCII characters")
+	errNoMetadataForAssetID     = errors.New("asset has no metadata")
+	errMetadataUpgradeNotActive = errors.New("asset metadata can't be updated before the metadata upgrade activates")
+)
+
+// AssetMetadata is the mutable metadata of an asset. The name, symbol, and
+// denomination set when the asset was created can't be changed.
+type AssetMetadata struct {
+	// Where additional information about the asset, such as its logo, can be
+	// found
+	URI string `serialize:"true" json:"uri"`
+	// Number of decimal places that wallets should display, which may differ
+	// from the asset's denomination
+	DisplayDenomination byte `serialize:"true" json:"displayDenomination"`
+}
+
+// Verify that the metadata is well-formed
+func (m *AssetMetadata) Verify() error {
+	switch {
+	case len(m.URI) > maxMetadataURILen:
+		return errMetadataURITooLong
+	case m.DisplayDenomination > maxDenomination:
+		return errDenominationTooLarge
+	}
+	for _, r := range m.URI {
+		if r > unicode.MaxASCII || !unicode.IsPrint(r) || unicode.IsSpace(r) {
+			return errIllegalURICharacter
+		}
+	}
+	return nil
+}
+
+// UpdateAssetMetadataTx replaces the metadata of an asset. Its single operation
+// must be a mint operation of the asset, which proves that the issuer controls
+// the asset's mint key. The operation may recreate the mint output unchanged.
+type UpdateAssetMetadataTx struct {
+	OperationTx `serialize:"true"`
+	Metadata    AssetMetadata `serialize:"true" json:"metadata"`
+}
+
+// MetadataAssetID returns the ID of the asset whose metadata is updated
+func (t *UpdateAssetMetadataTx) MetadataAssetID() ids.ID { return t.Ops[0].AssetID() }
+
+// SyntacticVerify that this transaction is well-formed.
+func (t *UpdateAssetMetadataTx) SyntacticVerify(
+	ctx *snow.Context,
+	c codec.Manager,
+	txFeeAssetID ids.ID,
+	txFee uint64,
+	creationTxFee uint64,
+	numFxs int,
+) error {
+	switch {
+	case t == nil:
+		return errNilTx
+	case len(t.Ops) != 1:
+		return errWrongNumberOfOps
+	}
+	switch t.Ops[0].Op.(type) {
+	case *secp256k1fx.MintOperation, *nftfx.MintOperation, *propertyfx.MintOperation:
+	default:
+		return errNotMintOperation
+	}
+	if err := t.Metadata.Verify(); err != nil {
+		return err
+	}
+	return t.OperationTx.SyntacticVerify(ctx, c, txFeeAssetID, txFee, creationTxFee, numFxs)
+}
+
+// SemanticVerify that this transaction is valid to be spent. It is only valid
+// once the metadata upgrade has activated.
+func (t *UpdateAssetMetadataTx) SemanticVerify(vm *VM, tx UnsignedTx, creds []verify.Verifiable) error {
+	activated, err := vm.metadataUpgradeActivated()
+	if err != nil {
+		return err
+	}
+	if !activated {
+		return errMetadataUpgradeNotActive
+	}
+	return t.OperationTx.SemanticVerify(vm, tx, creds)
+}
+
+// ExecuteWithSideEffects writes the new metadata along with the batch
+func (t *UpdateAssetMetadataTx) ExecuteWithSideEffects(vm *VM, batch database.Batch) error {
+	metadataBytes, err := vm.codec.Marshal(codecVersion, &t.Metadata)
+	if err != nil {
+		return err
+	}
+	key := t.MetadataAssetID().Prefix(assetMetadataID)
+	if err := batch.Put(key[:], metadataBytes); err != nil {
+		return err
+	}
+	return batch.Write()
+}
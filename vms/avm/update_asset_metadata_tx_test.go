@@ -0,0 +1,260 @@
+// (c) 2019-2020, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package avm
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/ava-labs/avalanchego/database"
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/utils/crypto"
+	"github.com/ava-labs/avalanchego/version"
+	"github.com/ava-labs/avalanchego/vms/components/avax"
+	"github.com/ava-labs/avalanchego/vms/secp256k1fx"
+)
+
+func TestAssetMetadataVerify(t *testing.T) {
+	tests := []struct {
+		name     string
+		metadata AssetMetadata
+		err      error
+	}{
+		{
+			name:     "valid",
+			metadata: AssetMetadata{URI: "https://example.com/asset.json", DisplayDenomination: 9},
+		},
+		{
+			name:     "empty",
+			metadata: AssetMetadata{},
+		},
+		{
+			name:     "uri too long",
+			metadata: AssetMetadata{URI: strings.Repeat("a", maxMetadataURILen+1)},
+			err:      errMetadataURITooLong,
+		},
+		{
+			name:     "uri with space",
+			metadata: AssetMetadata{URI: "https://example.com/my asset"},
+			err:      errIllegalURICharacter,
+		},
+		{
+			name:     "uri with non-ascii",
+			metadata: AssetMetadata{URI: "https://exämple.com"},
+			err:      errIllegalURICharacter,
+		},
+		{
+			name:     "display denomination too large",
+			metadata: AssetMetadata{DisplayDenomination: maxDenomination + 1},
+			err:      errDenominationTooLarge,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			if err := test.metadata.Verify(); err != test.err {
+				t.Fatalf("expected %v but got %v", test.err, err)
+			}
+		})
+	}
+}
+
+func TestUpdateAssetMetadataTxSyntacticVerifyOps(t *testing.T) {
+	ctx := NewContext(t)
+	_, c := setupCodec()
+
+	assetID := ids.ID{1}
+	mintOp := &Operation{
+		Asset: avax.Asset{ID: assetID},
+		UTXOIDs: []*avax.UTXOID{{
+			TxID:        assetID,
+			OutputIndex: 0,
+		}},
+		Op: &secp256k1fx.MintOperation{
+			MintInput: secp256k1fx.Input{SigIndices: []uint32{0}},
+		},
+	}
+
+	noOpsTx := &UpdateAssetMetadataTx{OperationTx: OperationTx{BaseTx: BaseTx{BaseTx: avax.BaseTx{
+		NetworkID:    networkID,
+		BlockchainID: chainID,
+	}}}}
+	if err := noOpsTx.SyntacticVerify(ctx, c, ids.Empty, 0, 0, 1); err != errWrongNumberOfOps {
+		t.Fatalf("expected %v but got %v", errWrongNumberOfOps, err)
+	}
+
+	twoOpsTx := &UpdateAssetMetadataTx{OperationTx: OperationTx{
+		BaseTx: BaseTx{BaseTx: avax.BaseTx{
+			NetworkID:    networkID,
+			BlockchainID: chainID,
+		}},
+		Ops: []*Operation{mintOp, mintOp},
+	}}
+	if err := twoOpsTx.SyntacticVerify(ctx, c, ids.Empty, 0, 0, 1); err != errWrongNumberOfOps {
+		t.Fatalf("expected %v but got %v", errWrongNumberOfOps, err)
+	}
+
+	notMintTx := &UpdateAssetMetadataTx{OperationTx: OperationTx{
+		BaseTx: BaseTx{BaseTx: avax.BaseTx{
+			NetworkID:    networkID,
+			BlockchainID: chainID,
+		}},
+		Ops: []*Operation{{
+			Asset:   avax.Asset{ID: assetID},
+			UTXOIDs: mintOp.UTXOIDs,
+			Op:      &testOperable{},
+		}},
+	}}
+	if err := notMintTx.SyntacticVerify(ctx, c, ids.Empty, 0, 0, 1); err != errNotMintOperation {
+		t.Fatalf("expected %v but got %v", errNotMintOperation, err)
+	}
+}
+
+func TestUpdateAssetMetadataTxExecute(t *testing.T) {
+	_, _, vm, _ := GenesisVM(t)
+	ctx := vm.ctx
+	defer func() {
+		if err := vm.Shutdown(); err != nil {
+			t.Fatal(err)
+		}
+		ctx.Lock.Unlock()
+	}()
+
+	assetID := ids.ID{1}
+	if _, err := vm.state.AssetMetadata(assetID); err != database.ErrNotFound {
+		t.Fatalf("expected %v but got %v", database.ErrNotFound, err)
+	}
+
+	tx := &UpdateAssetMetadataTx{
+		OperationTx: OperationTx{Ops: []*Operation{{
+			Asset: avax.Asset{ID: assetID},
+			Op:    &secp256k1fx.MintOperation{},
+		}}},
+		Metadata: AssetMetadata{
+			URI:                 "https://example.com/asset.json",
+			DisplayDenomination: 6,
+		},
+	}
+	batch, err := vm.db.CommitBatch()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := tx.ExecuteWithSideEffects(vm, batch); err != nil {
+		t.Fatal(err)
+	}
+	vm.db.Abort()
+
+	metadata, err := vm.state.AssetMetadata(assetID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if *metadata != tx.Metadata {
+		t.Fatalf("expected %+v but got %+v", tx.Metadata, *metadata)
+	}
+}
+
+func TestUpdateAssetMetadataTxBeforeUpgrade(t *testing.T) {
+	_, _, vm, _ := GenesisVM(t)
+	ctx := vm.ctx
+	defer func() {
+		if err := vm.Shutdown(); err != nil {
+			t.Fatal(err)
+		}
+		ctx.Lock.Unlock()
+	}()
+	vm.txFee = 0
+	vm.ctx.NetworkUpgrades = version.NetworkUpgrades{
+		version.MetadataUpgrade: vm.clock.Time().Add(time.Hour),
+	}
+
+	owners := secp256k1fx.OutputOwners{
+		Threshold: 1,
+		Addrs:     []ids.ShortID{keys[0].PublicKey().Address()},
+	}
+	assetID := ids.ID{1}
+	tx := &Tx{UnsignedTx: &UpdateAssetMetadataTx{
+		OperationTx: OperationTx{
+			BaseTx: BaseTx{BaseTx: avax.BaseTx{
+				NetworkID:    networkID,
+				BlockchainID: chainID,
+			}},
+			Ops: []*Operation{{
+				Asset: avax.Asset{ID: assetID},
+				UTXOIDs: []*avax.UTXOID{{
+					TxID:        assetID,
+					OutputIndex: 0,
+				}},
+				Op: &secp256k1fx.MintOperation{
+					MintInput:      secp256k1fx.Input{SigIndices: []uint32{0}},
+					MintOutput:     secp256k1fx.MintOutput{OutputOwners: owners},
+					TransferOutput: secp256k1fx.TransferOutput{Amt: 1, OutputOwners: owners},
+				},
+			}},
+		},
+		Metadata: AssetMetadata{URI: "https://example.com/asset.json"},
+	}}
+	if err := tx.SignSECP256K1Fx(vm.codec, [][]*crypto.PrivateKeySECP256K1R{{keys[0]}}); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := tx.UnsignedTx.SemanticVerify(vm, tx.UnsignedTx, tx.Creds); err != errMetadataUpgradeNotActive {
+		t.Fatalf("expected %v but got %v", errMetadataUpgradeNotActive, err)
+	}
+	if _, err := vm.IssueTx(tx.Bytes()); err != errMetadataUpgradeNotActive {
+		t.Fatalf("expected %v but got %v", errMetadataUpgradeNotActive, err)
+	}
+	if pending := vm.PendingTxs(); len(pending) != 0 {
+		t.Fatalf("expected no pending txs but got %d", len(pending))
+	}
+}
+
+func TestMetadataUpgradeActivatesOnAccept(t *testing.T) {
+	genesisBytes, _, vm, _ := GenesisVM(t)
+	ctx := vm.ctx
+	defer func() {
+		if err := vm.Shutdown(); err != nil {
+			t.Fatal(err)
+		}
+		ctx.Lock.Unlock()
+	}()
+	upgradeTime := vm.clock.Time().Add(time.Hour)
+	vm.ctx.NetworkUpgrades = version.NetworkUpgrades{
+		version.MetadataUpgrade: upgradeTime,
+	}
+
+	// The local clock passing the activation time isn't enough
+	vm.clock.Set(upgradeTime.Add(time.Minute))
+	if activated, err := vm.metadataUpgradeActivated(); err != nil {
+		t.Fatal(err)
+	} else if activated {
+		t.Fatal("the upgrade shouldn't activate before a tx is accepted after its activation time")
+	}
+
+	tx, err := vm.ParseTx(NewTx(t, genesisBytes, vm).Bytes())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := tx.Verify(); err != nil {
+		t.Fatal(err)
+	}
+	if err := tx.Accept(); err != nil {
+		t.Fatal(err)
+	}
+	if activated, err := vm.metadataUpgradeActivated(); err != nil {
+		t.Fatal(err)
+	} else if !activated {
+		t.Fatal("the upgrade should activate once a tx is accepted after its activation time")
+	}
+
+	// The upgrade stays activated even if the clock goes back
+	vm.clock.Set(upgradeTime.Add(-time.Minute))
+	if err := vm.advanceLastAcceptedTime(); err != nil {
+		t.Fatal(err)
+	}
+	if activated, err := vm.metadataUpgradeActivated(); err != nil {
+		t.Fatal(err)
+	} else if !activated {
+		t.Fatal("the upgrade shouldn't deactivate when the clock goes back")
+	}
+}
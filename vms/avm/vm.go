@@ -16,6 +16,7 @@ import (
 
 	"github.com/ava-labs/avalanchego/cache"
 	"github.com/ava-labs/avalanchego/database"
+	"github.com/ava-labs/avalanchego/database/prefixdb"
 	"github.com/ava-labs/avalanchego/database/versiondb"
 	"github.com/ava-labs/avalanchego/ids"
 	"github.com/ava-labs/avalanchego/snow"
@@ -29,6 +30,7 @@ import (
 	"github.com/ava-labs/avalanchego/utils/logging"
 	"github.com/ava-labs/avalanchego/utils/timer"
 	"github.com/ava-labs/avalanchego/utils/wrappers"
+	"github.com/ava-labs/avalanchego/version"
 	"github.com/ava-labs/avalanchego/vms/components/avax"
 	"github.com/ava-labs/avalanchego/vms/components/verify"
 	"github.com/ava-labs/avalanchego/vms/nftfx"
@@ -96,6 +98,13 @@ type VM struct {
 	txs          []snowstorm.Tx
 	toEngine     chan<- common.Message
 
+	// Transactions issued through this node that are re-issued until they are
+	// decided or expire
+	issuedTxs  issuedTxs
+	regossiper *timer.Repeater
+	// Periodically writes the pending issued transactions to the database
+	persister *timer.Repeater
+
 	baseDB database.Database
 	db     *versiondb.Database
 
@@ -183,6 +192,21 @@ func (vm *VM) Initialize(
 			return err
 		}
 	}
+	// Registered after the Fxs so that the IDs of the types registered by the
+	// Fxs are unchanged
+	errs.Add(
+		c.RegisterType(&UpdateAssetMetadataTx{}),
+		genesisCodec.RegisterType(&UpdateAssetMetadataTx{}),
+	)
+	if errs.Errored() {
+		return errs.Err
+	}
+	if err := vm.codec.Verify(); err != nil {
+		return err
+	}
+	if err := vm.genesisCodec.Verify(); err != nil {
+		return err
+	}
 
 	vm.state = &prefixedState{
 		state: &state{State: avax.State{
@@ -218,6 +242,24 @@ func (vm *VM) Initialize(
 	go ctx.Log.RecoverAndPanic(vm.timer.Dispatch)
 	vm.batchTimeout = batchTimeout
 
+	vm.issuedTxs.initialize(issuedTxTTL, prefixdb.New(issuedTxsPrefix, vm.db))
+	vm.regossiper = timer.NewRepeater(func() {
+		ctx.Lock.Lock()
+		defer ctx.Lock.Unlock()
+
+		vm.regossipIssuedTxs()
+	}, regossipFrequency)
+	go ctx.Log.RecoverAndPanic(vm.regossiper.Dispatch)
+	vm.persister = timer.NewRepeater(func() {
+		ctx.Lock.Lock()
+		defer ctx.Lock.Unlock()
+
+		if err := vm.persistIssuedTxs(); err != nil {
+			ctx.Log.Error("failed to persist the issued txs: %s", err)
+		}
+	}, persistFrequency)
+	go ctx.Log.RecoverAndPanic(vm.persister.Dispatch)
+
 	vm.walletService.vm = vm
 	vm.walletService.pendingTxMap = make(map[ids.ID]*list.Element)
 	vm.walletService.pendingTxOrdering = list.New()
@@ -249,7 +291,7 @@ func (vm *VM) Bootstrapped() error {
 		}
 	}
 	vm.bootstrapped = true
-	return nil
+	return vm.reissuePersistedTxs()
 }
 
 // Shutdown implements the avalanche.DAGVM interface
@@ -262,8 +304,13 @@ func (vm *VM) Shutdown() error {
 	// So, the lock must be released before stopping the timer.
 	vm.ctx.Lock.Unlock()
 	vm.timer.Stop()
+	vm.regossiper.Stop()
+	vm.persister.Stop()
 	vm.ctx.Lock.Lock()
 
+	if err := vm.persistIssuedTxs(); err != nil {
+		return err
+	}
 	return vm.baseDB.Close()
 }
 
@@ -306,6 +353,9 @@ func (vm *VM) CreateStaticHandlers() map[string]*common.HTTPHandler {
 	}
 }
 
+// BurnedFees implements the common.FeeTracker interface
+func (vm *VM) BurnedFees() (uint64, error) { return vm.state.BurnedFees() }
+
 // PendingTxs implements the avalanche.DAGVM interface
 func (vm *VM) PendingTxs() []snowstorm.Tx {
 	vm.metrics.numPendingTxsCalls.Inc()
@@ -314,6 +364,9 @@ func (vm *VM) PendingTxs() []snowstorm.Tx {
 
 	txs := vm.txs
 	vm.txs = nil
+	for _, tx := range txs {
+		vm.issuedTxs.sent(tx.ID())
+	}
 	return txs
 }
 
@@ -355,10 +408,22 @@ func (vm *VM) IssueTx(b []byte) (ids.ID, error) {
 	if err != nil {
 		return ids.ID{}, err
 	}
+	if _, ok := tx.UnsignedTx.(*UpdateAssetMetadataTx); ok {
+		activated, err := vm.metadataUpgradeActivated()
+		if err != nil {
+			return ids.ID{}, err
+		}
+		if !activated {
+			return ids.ID{}, errMetadataUpgradeNotActive
+		}
+	}
 	if err := tx.verifyWithoutCacheWrites(); err != nil {
 		return ids.ID{}, err
 	}
 	vm.issueTx(tx)
+	if !vm.issuedTxs.add(tx, vm.clock.Time()) {
+		vm.ctx.Log.Debug("not tracking issued tx %s as too many txs are being tracked", tx.ID())
+	}
 	return tx.ID(), nil
 }
 
@@ -492,12 +557,64 @@ func (vm *VM) GetUTXOs(
  ******************************************************************************
  */
 
+// metadataUpgradeActivated returns true if UpdateAssetMetadataTxs are
+// accepted. Vertices don't carry a timestamp, so the upgrade activates once
+// this chain accepts transactions at or after the upgrade's activation time,
+// rather than as soon as the local clock reaches it.
+func (vm *VM) metadataUpgradeActivated() (bool, error) {
+	lastAccepted, err := vm.state.LastAcceptedTime()
+	if err != nil {
+		return false, err
+	}
+	return vm.ctx.NetworkUpgrades.IsActivated(version.MetadataUpgrade, lastAccepted), nil
+}
+
+// advanceLastAcceptedTime records that this chain accepted transactions at the
+// current time. The saved time never goes back, even if the clock does.
+func (vm *VM) advanceLastAcceptedTime() error {
+	lastAccepted, err := vm.state.LastAcceptedTime()
+	if err != nil {
+		return err
+	}
+	now := vm.clock.Time()
+	if !now.After(lastAccepted) {
+		return nil
+	}
+	return vm.state.SetLastAcceptedTime(now)
+}
+
+// burnFee adds the fee that [tx] is required to pay to the fees burned by
+// accepted transactions
+func (vm *VM) burnFee(tx UnsignedTx) error {
+	fee := vm.txFee
+	if _, ok := tx.(*CreateAssetTx); ok {
+		fee = vm.creationTxFee
+	}
+	burnedFees, err := vm.state.BurnedFees()
+	if err != nil {
+		return err
+	}
+	newBurnedFees, err := safemath.Add64(burnedFees, fee)
+	if err != nil {
+		return err
+	}
+	return vm.state.SetBurnedFees(newBurnedFees)
+}
+
 // Clock returns a reference to the internal clock of this VM
 func (vm *VM) Clock() *timer.Clock { return &vm.clock }
 
 // Codec returns a reference to the internal codec of this VM
 func (vm *VM) Codec() codec.Manager { return vm.codec }
 
+// Codecs returns this VM's codecs, by name
+func (vm *VM) Codecs() map[string]codec.Manager {
+	return map[string]codec.Manager{
+		"avm":         vm.codec,
+		"avm.genesis": vm.genesisCodec,
+	}
+}
+
 // CodecRegistry returns a reference to the internal codec registry of this VM
 func (vm *VM) CodecRegistry() codec.Registry { return vm.codecRegistry }
 
@@ -515,7 +632,7 @@ func (vm *VM) FlushTxs() {
 	vm.timer.Cancel()
 	if len(vm.txs) != 0 {
 		select {
-		case vm.toEngine <- common.PendingTxs:
+		case vm.toEngine <- common.PendingTxsHint(len(vm.txs)):
 		default:
 			vm.ctx.Log.Warn("Delaying issuance of transactions due to contention")
 			vm.timer.SetTimeoutIn(vm.batchTimeout)
@@ -589,6 +706,9 @@ func (vm *VM) initState(genesisBytes []byte) error {
 		}
 	}
 
+	if err := vm.advanceLastAcceptedTime(); err != nil {
+		return err
+	}
 	return vm.state.SetDBInitialized(choices.Processing)
 }
 
@@ -646,6 +766,60 @@ func (vm *VM) issueTx(tx snowstorm.Tx) {
 	}
 }
 
+// regossipIssuedTxs re-issues the transactions issued through this node that
+// haven't been decided yet. The engine drops transactions it is already
+// processing, so only transactions it lost track of are gossiped again.
+func (vm *VM) regossipIssuedTxs() {
+	for _, itx := range vm.issuedTxs.update(vm.clock.Time()) {
+		vm.ctx.Log.Verbo("re-issuing tx %s", itx.tx.ID())
+		vm.issueTx(itx.tx)
+	}
+}
+
+// persistIssuedTxs writes the transactions issued through this node that
+// haven't been decided yet to the database, so that they're re-issued after a
+// restart
+func (vm *VM) persistIssuedTxs() error {
+	if err := vm.issuedTxs.persist(); err != nil {
+		return err
+	}
+	return vm.db.Commit()
+}
+
+// reissuePersistedTxs re-issues the transactions that were issued through this
+// node and were still pending when they were last persisted. Transactions that
+// have since been decided, expired, or become invalid are dropped.
+func (vm *VM) reissuePersistedTxs() error {
+	txs, err := vm.issuedTxs.loadPersisted()
+	if err != nil {
+		return err
+	}
+	now := vm.clock.Time()
+	for _, ptx := range txs {
+		if now.Sub(ptx.issued) >= issuedTxTTL {
+			continue
+		}
+		tx, err := vm.parseTx(ptx.bytes)
+		if err != nil {
+			vm.ctx.Log.Debug("dropping unparsable persisted tx: %s", err)
+			continue
+		}
+		if tx.Status().Decided() {
+			continue
+		}
+		if err := tx.verifyWithoutCacheWrites(); err != nil {
+			vm.ctx.Log.Debug("dropping persisted tx %s: %s", tx.ID(), err)
+			continue
+		}
+		if !vm.issuedTxs.add(tx, ptx.issued) {
+			vm.ctx.Log.Debug("dropping persisted tx %s as too many txs are being tracked", tx.ID())
+			continue
+		}
+		vm.issueTx(tx)
+	}
+	return nil
+}
+
 func (vm *VM) getUTXO(utxoID *avax.UTXOID) (*avax.UTXO, error) {
 	inputID := utxoID.InputID()
 	utxo, err := vm.state.UTXO(inputID)
@@ -595,9 +595,12 @@ func TestIssueTx(t *testing.T) {
 	ctx.Lock.Unlock()
 
 	msg := <-issuer
-	if msg != common.PendingTxs {
+	if msg.Type() != common.PendingTxs {
 		t.Fatalf("Wrong message")
 	}
+	if hint := msg.PendingHint(); hint != 1 {
+		t.Fatalf("expected a pending hint of 1 but got %d", hint)
+	}
 	ctx.Lock.Lock()
 
 	if txs := vm.PendingTxs(); len(txs) != 1 {
@@ -605,6 +608,124 @@ func TestIssueTx(t *testing.T) {
 	}
 }
 
+func TestIssuedTxExpires(t *testing.T) {
+	genesisBytes, _, vm, _ := GenesisVM(t)
+	ctx := vm.ctx
+	defer func() {
+		if err := vm.Shutdown(); err != nil {
+			t.Fatal(err)
+		}
+		ctx.Lock.Unlock()
+	}()
+
+	newTx := NewTx(t, genesisBytes, vm)
+	txID, err := vm.IssueTx(newTx.Bytes())
+	if err != nil {
+		t.Fatal(err)
+	}
+	vm.PendingTxs()
+
+	// The tx hasn't been decided, so it should be handed to the engine again
+	vm.regossipIssuedTxs()
+	if txs := vm.PendingTxs(); len(txs) != 1 || txs[0].ID() != txID {
+		t.Fatalf("Should have re-issued the tx")
+	}
+
+	vm.clock.Set(vm.clock.Time().Add(issuedTxTTL))
+	vm.regossipIssuedTxs()
+	if txs := vm.PendingTxs(); len(txs) != 0 {
+		t.Fatalf("Shouldn't have re-issued an expired tx")
+	}
+	if itx, ok := vm.issuedTxs.get(txID); !ok || itx.status != IssuedTxExpired {
+		t.Fatalf("Should have marked the tx as expired")
+	}
+}
+
+func TestPersistedIssuedTxsReissued(t *testing.T) {
+	genesisBytes, _, vm, _ := GenesisVM(t)
+	ctx := vm.ctx
+	defer func() {
+		if err := vm.Shutdown(); err != nil {
+			t.Fatal(err)
+		}
+		ctx.Lock.Unlock()
+	}()
+
+	newTx := NewTx(t, genesisBytes, vm)
+	txID, err := vm.IssueTx(newTx.Bytes())
+	if err != nil {
+		t.Fatal(err)
+	}
+	vm.PendingTxs()
+	if err := vm.persistIssuedTxs(); err != nil {
+		t.Fatal(err)
+	}
+
+	// Simulate a restart, which loses the issued txs that are in memory
+	vm.issuedTxs.initialize(issuedTxTTL, prefixdb.New(issuedTxsPrefix, vm.db))
+	if err := vm.reissuePersistedTxs(); err != nil {
+		t.Fatal(err)
+	}
+	if txs := vm.PendingTxs(); len(txs) != 1 || txs[0].ID() != txID {
+		t.Fatalf("Should have re-issued the persisted tx")
+	}
+	if _, ok := vm.issuedTxs.get(txID); !ok {
+		t.Fatalf("Should be tracking the persisted tx again")
+	}
+
+	// Once the tx expires, it's removed from the database
+	vm.clock.Set(vm.clock.Time().Add(issuedTxTTL))
+	vm.regossipIssuedTxs()
+	if err := vm.persistIssuedTxs(); err != nil {
+		t.Fatal(err)
+	}
+	if has, err := vm.issuedTxs.db.Has(txID[:]); err != nil {
+		t.Fatal(err)
+	} else if has {
+		t.Fatalf("Should have removed the expired tx from the database")
+	}
+}
+
+func TestAcceptedTxBurnsFee(t *testing.T) {
+	genesisBytes, _, vm, _ := GenesisVM(t)
+	ctx := vm.ctx
+	defer func() {
+		if err := vm.Shutdown(); err != nil {
+			t.Fatal(err)
+		}
+		ctx.Lock.Unlock()
+	}()
+
+	if burnedFees, err := vm.BurnedFees(); err != nil {
+		t.Fatal(err)
+	} else if burnedFees != 0 {
+		t.Fatalf("expected no fees to be burned but got %d", burnedFees)
+	}
+
+	newTx := NewTx(t, genesisBytes, vm)
+	tx, err := vm.ParseTx(newTx.Bytes())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := tx.Verify(); err != nil {
+		t.Fatal(err)
+	}
+	if burnedFees, err := vm.BurnedFees(); err != nil {
+		t.Fatal(err)
+	} else if burnedFees != 0 {
+		t.Fatalf("expected a verified tx not to burn fees but got %d", burnedFees)
+	}
+
+	if err := tx.Accept(); err != nil {
+		t.Fatal(err)
+	}
+	if burnedFees, err := vm.BurnedFees(); err != nil {
+		t.Fatal(err)
+	} else if burnedFees != vm.txFee {
+		t.Fatalf("expected %d fees to be burned but got %d", vm.txFee, burnedFees)
+	}
+}
+
 func TestGenesisGetUTXOs(t *testing.T) {
 	_, _, vm, _ := GenesisVM(t)
 	ctx := vm.ctx
@@ -778,7 +899,7 @@ func TestIssueDependentTx(t *testing.T) {
 	ctx.Lock.Unlock()
 
 	msg := <-issuer
-	if msg != common.PendingTxs {
+	if msg.Type() != common.PendingTxs {
 		t.Fatalf("Wrong message")
 	}
 	ctx.Lock.Lock()
@@ -1281,7 +1402,7 @@ func TestTxVerifyAfterIssueTx(t *testing.T) {
 	ctx.Lock.Unlock()
 
 	msg := <-issuer
-	if msg != common.PendingTxs {
+	if msg.Type() != common.PendingTxs {
 		t.Fatalf("Wrong message")
 	}
 	ctx.Lock.Lock()
@@ -73,10 +73,11 @@ func (b *Block) Accept() error {
 }
 
 // Reject sets this block's status to Rejected and saves the status in state
-// Recall that b.vm.DB.Commit() must be called to persist to the DB
+// Recall that b.vm.DB.Commit() must be called to persist to the DB, unless the
+// block is being rejected by b.vm.RejectBlocks
 func (b *Block) Reject() error {
 	b.SetStatus(choices.Rejected)
-	return b.VM.State.PutStatus(b.VM.DB, b.ID(), choices.Rejected)
+	return b.VM.State.PutStatus(b.VM.statusDB(), b.ID(), choices.Rejected)
 }
 
 // Status returns the status of this block
@@ -1,6 +1,7 @@
 package core
 
 import (
+	"errors"
 	"testing"
 
 	"github.com/ava-labs/avalanchego/database/memdb"
@@ -52,3 +53,53 @@ func TestBlock(t *testing.T) {
 		t.Fatalf("status should be rejected but is %s", status)
 	}
 }
+
+// testBlock makes a *Block implement snowman.Block, as the blocks of a VM that
+// embed it do
+type testBlock struct{ *Block }
+
+func (b testBlock) Verify() error {
+	_, err := b.Block.Verify()
+	return err
+}
+
+func TestRejectBlocks(t *testing.T) {
+	baseDB := memdb.New()
+	state, err := NewSnowmanState(func([]byte) (snowman.Block, error) { return nil, nil })
+	if err != nil {
+		t.Fatal(err)
+	}
+	vm := &SnowmanVM{
+		DB:    versiondb.New(baseDB),
+		State: state,
+	}
+
+	parent := NewBlock(ids.Empty, 1)
+	parent.Initialize([]byte{1}, vm)
+	child := NewBlock(parent.ID(), 2)
+	child.Initialize([]byte{2}, vm)
+	if err := vm.RejectBlocks([]snowman.Block{testBlock{parent}, testBlock{child}}); err != nil {
+		t.Fatal(err)
+	}
+	for _, blk := range []*Block{parent, child} {
+		if status := state.GetStatus(baseDB, blk.ID()); status != choices.Rejected {
+			t.Fatalf("status should have been committed as rejected but is %s", status)
+		}
+	}
+
+	// If a block fails to be rejected, none of the batch is written
+	errReject := errors.New("reject failed")
+	blk := NewBlock(ids.Empty, 1)
+	blk.Initialize([]byte{3}, vm)
+	failing := &snowman.TestBlock{TestDecidable: choices.TestDecidable{
+		IDV:     ids.GenerateTestID(),
+		StatusV: choices.Processing,
+		RejectV: errReject,
+	}}
+	if err := vm.RejectBlocks([]snowman.Block{testBlock{blk}, failing}); !errors.Is(err, errReject) {
+		t.Fatalf("expected %s but got %v", errReject, err)
+	}
+	if status := state.GetStatus(vm.DB, blk.ID()); status != choices.Processing {
+		t.Fatalf("status shouldn't have been written but is %s", status)
+	}
+}
@@ -51,6 +51,10 @@ type SnowmanVM struct {
 
 	// channel to send messages to the consensus engine
 	ToEngine chan<- common.Message
+
+	// Batch that the status changes of rejected blocks are written to while
+	// blocks are being rejected together. Nil otherwise.
+	rejectBatch *versiondb.Database
 }
 
 // SetPreference sets the block with ID [ID] as the preferred block
@@ -79,6 +83,33 @@ func (svm *SnowmanVM) GetBlock(id ids.ID) (snowman.Block, error) {
 	return nil, errBadData // Should never happen
 }
 
+// RejectBlocks rejects [blks] in order. Their status changes are written to
+// one batch, which is committed to the database once every block has been
+// rejected. If a block fails to be rejected, none of the status changes are
+// written.
+func (svm *SnowmanVM) RejectBlocks(blks []snowman.Block) error {
+	svm.rejectBatch = versiondb.New(svm.DB)
+	defer func() { svm.rejectBatch = nil }()
+
+	for _, blk := range blks {
+		if err := blk.Reject(); err != nil {
+			return err
+		}
+	}
+	if err := svm.rejectBatch.Commit(); err != nil {
+		return err
+	}
+	return svm.DB.Commit()
+}
+
+// statusDB returns the database that block status changes are written to
+func (svm *SnowmanVM) statusDB() database.Database {
+	if svm.rejectBatch != nil {
+		return svm.rejectBatch
+	}
+	return svm.DB
+}
+
 // Bootstrapping marks this VM as bootstrapping
 func (svm *SnowmanVM) Bootstrapping() error { return nil }
 
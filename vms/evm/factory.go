@@ -0,0 +1,34 @@
+// (c) 2019-2020, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package evm
+
+import (
+	"errors"
+
+	"github.com/ava-labs/avalanchego/snow"
+	"github.com/ava-labs/avalanchego/vms/rpcchainvm"
+)
+
+var (
+	errWrongVM = errors.New("wrong vm type")
+)
+
+// Factory creates C-Chain VMs by running the plugin described by
+// [rpcchainvm.Factory]
+type Factory struct {
+	rpcchainvm.Factory
+}
+
+// New ...
+func (f *Factory) New(ctx *snow.Context) (interface{}, error) {
+	vmIntf, err := f.Factory.New(ctx)
+	if err != nil {
+		return nil, err
+	}
+	client, ok := vmIntf.(*rpcchainvm.VMClient)
+	if !ok {
+		return nil, errWrongVM
+	}
+	return &VM{VMClient: client}, nil
+}
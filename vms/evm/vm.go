@@ -0,0 +1,123 @@
+// (c) 2019-2020, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package evm
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math/big"
+	"net/http"
+	"strings"
+	"sync"
+
+	"github.com/ava-labs/avalanchego/snow/engine/common"
+	"github.com/ava-labs/avalanchego/vms/rpcchainvm"
+)
+
+// BlackholeAddr is the address that the C-Chain pays transaction fees to. No
+// key controls it, so the AVAX paid to it is burned.
+const BlackholeAddr = "0x0100000000000000000000000000000000000000"
+
+var (
+	// C-Chain balances are denominated in wei. There are 10^18 wei per AVAX
+	// and 10^9 nAVAX per AVAX.
+	weiPerNAVAX = big.NewInt(1e9)
+
+	errNoRPCHandler     = errors.New("C-Chain doesn't serve a JSON-RPC API or its handlers weren't created yet")
+	errInvalidBalance   = errors.New("C-Chain returned an invalid balance")
+	errBurnedFeesTooBig = errors.New("fees burned on the C-Chain don't fit in a uint64")
+)
+
+// VM is the C-Chain's VM, which runs as a plugin. The plugin doesn't report
+// the fees burned on the C-Chain, so they're read from the balance of
+// [BlackholeAddr] through the plugin's JSON-RPC API.
+type VM struct {
+	*rpcchainvm.VMClient
+
+	// The plugin's JSON-RPC API, once its handlers have been created
+	rpcLock sync.RWMutex
+	rpc     *common.HTTPHandler
+}
+
+// CreateHandlers implements the common.VM interface. The plugin's JSON-RPC
+// handler is kept so that BurnedFees doesn't create the handlers again.
+func (vm *VM) CreateHandlers() map[string]*common.HTTPHandler {
+	handlers := vm.VMClient.CreateHandlers()
+
+	vm.rpcLock.Lock()
+	vm.rpc = handlers["/rpc"]
+	vm.rpcLock.Unlock()
+	return handlers
+}
+
+type ethRequest struct {
+	JSONRPC string        `json:"jsonrpc"`
+	ID      int           `json:"id"`
+	Method  string        `json:"method"`
+	Params  []interface{} `json:"params"`
+}
+
+type ethReply struct {
+	Result string `json:"result"`
+	Error  *struct {
+		Message string `json:"message"`
+	} `json:"error"`
+}
+
+// BurnedFees implements the common.FeeTracker interface
+func (vm *VM) BurnedFees() (uint64, error) {
+	vm.rpcLock.RLock()
+	rpc := vm.rpc
+	vm.rpcLock.RUnlock()
+	if rpc == nil {
+		return 0, errNoRPCHandler
+	}
+
+	requestBytes, err := json.Marshal(ethRequest{
+		JSONRPC: "2.0",
+		ID:      1,
+		Method:  "eth_getBalance",
+		Params:  []interface{}{BlackholeAddr, "latest"},
+	})
+	if err != nil {
+		return 0, err
+	}
+	request, err := http.NewRequest(http.MethodPost, "/", bytes.NewReader(requestBytes))
+	if err != nil {
+		return 0, err
+	}
+	request.Header.Set("Content-Type", "application/json")
+
+	writer := &responseWriter{header: make(http.Header)}
+	rpc.Handler.ServeHTTP(writer, request)
+
+	reply := ethReply{}
+	if err := json.Unmarshal(writer.body.Bytes(), &reply); err != nil {
+		return 0, fmt.Errorf("couldn't parse eth_getBalance reply: %w", err)
+	}
+	if reply.Error != nil {
+		return 0, fmt.Errorf("eth_getBalance failed: %s", reply.Error.Message)
+	}
+	balance, ok := new(big.Int).SetString(strings.TrimPrefix(reply.Result, "0x"), 16)
+	if !ok {
+		return 0, fmt.Errorf("%w: %q", errInvalidBalance, reply.Result)
+	}
+	burnedFees := balance.Div(balance, weiPerNAVAX)
+	if !burnedFees.IsUint64() {
+		return 0, errBurnedFeesTooBig
+	}
+	return burnedFees.Uint64(), nil
+}
+
+// responseWriter buffers the response to a request that is served in-process
+type responseWriter struct {
+	header http.Header
+	body   bytes.Buffer
+}
+
+func (w *responseWriter) Header() http.Header         { return w.header }
+func (w *responseWriter) Write(b []byte) (int, error) { return w.body.Write(b) }
+func (w *responseWriter) WriteHeader(int)             {}
@@ -146,11 +146,17 @@ func (tx *UnsignedAddDelegatorTx) SemanticVerify(
 			fmt.Errorf("failed to get timestamp: %w", err),
 		}
 	} else if validatorStartTime := tx.StartTime(); !currentTimestamp.Before(validatorStartTime) {
-		return nil, nil, nil, nil, permError{fmt.Errorf("chain timestamp (%s) not before validator's start time (%s)",
-			currentTimestamp,
-			validatorStartTime)}
+		return nil, nil, nil, nil, permError{&timestampError{
+			reason:   errStartTimeTooEarly,
+			proposed: validatorStartTime,
+			bound:    currentTimestamp,
+		}}
 	} else if validatorStartTime.After(currentTimestamp.Add(maxFutureStartTime)) {
-		return nil, nil, nil, nil, permError{fmt.Errorf("validator start time (%s) more than two weeks after current chain timestamp (%s)", validatorStartTime, currentTimestamp)}
+		return nil, nil, nil, nil, permError{&timestampError{
+			reason:   errStartTimeTooLate,
+			proposed: validatorStartTime,
+			bound:    currentTimestamp.Add(maxFutureStartTime),
+		}}
 	}
 
 	// Ensure that the period this delegator delegates is a subset of the time
@@ -273,7 +279,7 @@ func (vm *VM) newAddDelegatorTx(
 	stakeAmt, // Amount the delegator stakes
 	startTime, // Unix time they start delegating
 	endTime uint64, // Unix time they stop delegating
-	nodeID ids.ShortID, // ID of the node we are delegating to
+	nodeID ids.NodeID, // ID of the node we are delegating to
 	rewardAddress ids.ShortID, // Address to send reward to, if applicable
 	keys []*crypto.PrivateKeySECP256K1R, // Keys providing the staked tokens
 	changeAddr ids.ShortID, // Address to send change to, if there is any
@@ -291,7 +297,7 @@ func (vm *VM) newAddDelegatorTx(
 			Outs:         unlockedOuts,
 		}},
 		Validator: Validator{
-			NodeID: nodeID,
+			NodeID: nodeID.ShortID(),
 			Start:  startTime,
 			End:    endTime,
 			Wght:   stakeAmt,
@@ -46,7 +46,7 @@ func TestAddDelegatorTxSyntacticVerify(t *testing.T) {
 		vm.minDelegatorStake,
 		uint64(defaultValidateStartTime.Unix()),
 		uint64(defaultValidateEndTime.Unix()),
-		nodeID,
+		ids.NodeID(nodeID),
 		rewardAddress,
 		[]*crypto.PrivateKeySECP256K1R{keys[0]},
 		ids.ShortEmpty, // change addr
@@ -72,7 +72,7 @@ func TestAddDelegatorTxSyntacticVerify(t *testing.T) {
 		vm.minDelegatorStake,
 		uint64(defaultValidateStartTime.Unix()),
 		uint64(defaultValidateEndTime.Unix()),
-		nodeID,
+		ids.NodeID(nodeID),
 		rewardAddress,
 		[]*crypto.PrivateKeySECP256K1R{keys[0]},
 		ids.ShortEmpty, // change addr
@@ -98,7 +98,7 @@ func TestAddDelegatorTxSyntacticVerify(t *testing.T) {
 		vm.minDelegatorStake,
 		uint64(defaultValidateStartTime.Unix()),
 		uint64(defaultValidateEndTime.Unix()),
-		nodeID,
+		ids.NodeID(nodeID),
 		rewardAddress,
 		[]*crypto.PrivateKeySECP256K1R{keys[0]},
 		ids.ShortEmpty, // change addr
@@ -124,7 +124,7 @@ func TestAddDelegatorTxSyntacticVerify(t *testing.T) {
 		vm.minDelegatorStake,
 		uint64(defaultValidateStartTime.Unix()),
 		uint64(defaultValidateStartTime.Add(defaultMinStakingDuration).Unix()),
-		nodeID,
+		ids.NodeID(nodeID),
 		rewardAddress,
 		[]*crypto.PrivateKeySECP256K1R{keys[0]},
 		ids.ShortEmpty, // change addr
@@ -150,7 +150,7 @@ func TestAddDelegatorTxSyntacticVerify(t *testing.T) {
 		vm.minDelegatorStake,
 		uint64(defaultValidateStartTime.Unix()),
 		uint64(defaultValidateStartTime.Add(defaultMaxStakingDuration).Unix()),
-		nodeID,
+		ids.NodeID(nodeID),
 		rewardAddress,
 		[]*crypto.PrivateKeySECP256K1R{keys[0]},
 		ids.ShortEmpty, // change addr
@@ -175,7 +175,7 @@ func TestAddDelegatorTxSyntacticVerify(t *testing.T) {
 		vm.minDelegatorStake,
 		uint64(defaultValidateStartTime.Unix()),
 		uint64(defaultValidateEndTime.Unix()),
-		nodeID,
+		ids.NodeID(nodeID),
 		rewardAddress,
 		[]*crypto.PrivateKeySECP256K1R{keys[0]},
 		ids.ShortEmpty, // change addr
@@ -223,7 +223,7 @@ func TestAddDelegatorTxSemanticVerify(t *testing.T) {
 			vm.minValidatorStake,                    // stake amount
 			newValidatorStartTime,                   // start time
 			newValidatorEndTime,                     // end time
-			newValidatorID,                          // node ID
+			ids.NodeID(newValidatorID),              // node ID
 			rewardAddress,                           // Reward Address
 			PercentDenominator,                      // subnet
 			[]*crypto.PrivateKeySECP256K1R{keys[0]}, // key
@@ -369,7 +369,7 @@ func TestAddDelegatorTxSemanticVerify(t *testing.T) {
 				tt.stakeAmount,
 				tt.startTime,
 				tt.endTime,
-				tt.nodeID,
+				ids.NodeID(tt.nodeID),
 				tt.rewardAddress,
 				tt.feeKeys,
 				ids.ShortEmpty, // change addr
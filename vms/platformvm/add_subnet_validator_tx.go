@@ -118,11 +118,17 @@ func (tx *UnsignedAddSubnetValidatorTx) SemanticVerify(
 	if currentTimestamp, err := vm.getTimestamp(db); err != nil {
 		return nil, nil, nil, nil, tempError{fmt.Errorf("couldn't get current timestamp: %v", err)}
 	} else if validatorStartTime := tx.StartTime(); !currentTimestamp.Before(validatorStartTime) {
-		return nil, nil, nil, nil, permError{fmt.Errorf("validator's start time (%s) is at or after current chain timestamp (%s)",
-			currentTimestamp,
-			validatorStartTime)}
+		return nil, nil, nil, nil, permError{&timestampError{
+			reason:   errStartTimeTooEarly,
+			proposed: validatorStartTime,
+			bound:    currentTimestamp,
+		}}
 	} else if validatorStartTime.After(currentTimestamp.Add(maxFutureStartTime)) {
-		return nil, nil, nil, nil, permError{fmt.Errorf("validator start time (%s) more than two weeks after current chain timestamp (%s)", validatorStartTime, currentTimestamp)}
+		return nil, nil, nil, nil, permError{&timestampError{
+			reason:   errStartTimeTooLate,
+			proposed: validatorStartTime,
+			bound:    currentTimestamp.Add(maxFutureStartTime),
+		}}
 	}
 
 	// Ensure that the period this validator validates the specified subnet is a
@@ -196,6 +202,9 @@ func (tx *UnsignedAddSubnetValidatorTx) SemanticVerify(
 	if err := vm.produceOutputs(onCommitDB, txID, tx.Outs); err != nil {
 		return nil, nil, nil, nil, tempError{err}
 	}
+	if err := vm.burnFee(onCommitDB, vm.txFee); err != nil {
+		return nil, nil, nil, nil, tempError{err}
+	}
 	// Add the validator to the set of pending validators
 	if err := vm.enqueueStaker(onCommitDB, tx.Validator.Subnet, stx); err != nil {
 		return nil, nil, nil, nil, tempError{err}
@@ -210,6 +219,9 @@ func (tx *UnsignedAddSubnetValidatorTx) SemanticVerify(
 	if err := vm.produceOutputs(onAbortDB, txID, tx.Outs); err != nil {
 		return nil, nil, nil, nil, tempError{err}
 	}
+	if err := vm.burnFee(onAbortDB, vm.txFee); err != nil {
+		return nil, nil, nil, nil, tempError{err}
+	}
 
 	return onCommitDB, onAbortDB, nil, nil, nil
 }
@@ -225,7 +237,7 @@ func (vm *VM) newAddSubnetValidatorTx(
 	weight, // Sampling weight of the new validator
 	startTime, // Unix time they start delegating
 	endTime uint64, // Unix time they top delegating
-	nodeID ids.ShortID, // ID of the node validating
+	nodeID ids.NodeID, // ID of the node validating
 	subnetID ids.ID, // ID of the subnet the validator will validate
 	keys []*crypto.PrivateKeySECP256K1R, // Keys to use for adding the validator
 	changeAddr ids.ShortID, // Address to send change to, if there is any
@@ -251,7 +263,7 @@ func (vm *VM) newAddSubnetValidatorTx(
 		}},
 		Validator: SubnetValidator{
 			Validator: Validator{
-				NodeID: nodeID,
+				NodeID: nodeID.ShortID(),
 				Start:  startTime,
 				End:    endTime,
 				Wght:   weight,
@@ -45,7 +45,7 @@ func TestAddSubnetValidatorTxSyntacticVerify(t *testing.T) {
 		defaultWeight,
 		uint64(defaultValidateStartTime.Unix()),
 		uint64(defaultValidateEndTime.Unix()),
-		nodeID,
+		ids.NodeID(nodeID),
 		testSubnet1.ID(),
 		[]*crypto.PrivateKeySECP256K1R{testSubnet1ControlKeys[0], testSubnet1ControlKeys[1]},
 		ids.ShortEmpty, // change addr
@@ -72,7 +72,7 @@ func TestAddSubnetValidatorTxSyntacticVerify(t *testing.T) {
 		defaultWeight,
 		uint64(defaultValidateStartTime.Unix()),
 		uint64(defaultValidateEndTime.Unix()),
-		nodeID,
+		ids.NodeID(nodeID),
 		testSubnet1.ID(),
 		[]*crypto.PrivateKeySECP256K1R{testSubnet1ControlKeys[0], testSubnet1ControlKeys[1]},
 		ids.ShortEmpty, // change addr
@@ -99,7 +99,7 @@ func TestAddSubnetValidatorTxSyntacticVerify(t *testing.T) {
 		defaultWeight,
 		uint64(defaultValidateStartTime.Unix()),
 		uint64(defaultValidateEndTime.Unix()),
-		nodeID,
+		ids.NodeID(nodeID),
 		testSubnet1.ID(),
 		[]*crypto.PrivateKeySECP256K1R{testSubnet1ControlKeys[0], testSubnet1ControlKeys[1]},
 		ids.ShortEmpty, // change addr
@@ -126,7 +126,7 @@ func TestAddSubnetValidatorTxSyntacticVerify(t *testing.T) {
 		1,
 		uint64(defaultValidateStartTime.Unix()),
 		uint64(defaultValidateEndTime.Unix()),
-		nodeID,
+		ids.NodeID(nodeID),
 		testSubnet1.ID(),
 		[]*crypto.PrivateKeySECP256K1R{testSubnet1ControlKeys[0], testSubnet1ControlKeys[1]},
 		ids.ShortEmpty, // change addr
@@ -153,7 +153,7 @@ func TestAddSubnetValidatorTxSyntacticVerify(t *testing.T) {
 		defaultWeight,
 		uint64(defaultValidateStartTime.Unix()),
 		uint64(defaultValidateEndTime.Unix())-1,
-		nodeID,
+		ids.NodeID(nodeID),
 		testSubnet1.ID(),
 		[]*crypto.PrivateKeySECP256K1R{testSubnet1ControlKeys[0], testSubnet1ControlKeys[1]},
 		ids.ShortEmpty, // change addr
@@ -181,7 +181,7 @@ func TestAddSubnetValidatorTxSyntacticVerify(t *testing.T) {
 		defaultWeight,
 		uint64(defaultValidateStartTime.Unix()),
 		uint64(defaultValidateStartTime.Add(defaultMinStakingDuration).Unix()),
-		nodeID,
+		ids.NodeID(nodeID),
 		testSubnet1.ID(),
 		[]*crypto.PrivateKeySECP256K1R{testSubnet1ControlKeys[0], testSubnet1ControlKeys[1]},
 		ids.ShortEmpty, // change addr
@@ -208,7 +208,7 @@ func TestAddSubnetValidatorTxSyntacticVerify(t *testing.T) {
 		defaultWeight,
 		uint64(defaultValidateStartTime.Unix()),
 		uint64(defaultValidateStartTime.Add(defaultMaxStakingDuration).Unix()),
-		nodeID,
+		ids.NodeID(nodeID),
 		testSubnet1.ID(),
 		[]*crypto.PrivateKeySECP256K1R{testSubnet1ControlKeys[0], testSubnet1ControlKeys[1]},
 		ids.ShortEmpty, // change addr
@@ -235,7 +235,7 @@ func TestAddSubnetValidatorTxSyntacticVerify(t *testing.T) {
 		defaultWeight,
 		uint64(defaultValidateStartTime.Unix()),
 		uint64(defaultValidateEndTime.Unix()),
-		nodeID,
+		ids.NodeID(nodeID),
 		testSubnet1.ID(),
 		[]*crypto.PrivateKeySECP256K1R{testSubnet1ControlKeys[0], testSubnet1ControlKeys[1]},
 		ids.ShortEmpty, // change addr
@@ -272,7 +272,7 @@ func TestAddSubnetValidatorTxSemanticVerify(t *testing.T) {
 		defaultWeight,
 		uint64(defaultValidateStartTime.Unix()),
 		uint64(defaultValidateEndTime.Unix())+1,
-		nodeID,
+		ids.NodeID(nodeID),
 		testSubnet1.ID(),
 		[]*crypto.PrivateKeySECP256K1R{testSubnet1ControlKeys[0], testSubnet1ControlKeys[1]},
 		ids.ShortEmpty, // change addr
@@ -290,7 +290,7 @@ func TestAddSubnetValidatorTxSemanticVerify(t *testing.T) {
 		defaultWeight,
 		uint64(defaultValidateStartTime.Unix()+1),
 		uint64(defaultValidateEndTime.Unix()),
-		nodeID,
+		ids.NodeID(nodeID),
 		testSubnet1.ID(),
 		[]*crypto.PrivateKeySECP256K1R{testSubnet1ControlKeys[0], testSubnet1ControlKeys[1]},
 		ids.ShortEmpty, // change addr
@@ -315,7 +315,7 @@ func TestAddSubnetValidatorTxSemanticVerify(t *testing.T) {
 		vm.minValidatorStake,                    // stake amount
 		uint64(DSStartTime.Unix()),              // start time
 		uint64(DSEndTime.Unix()),                // end time
-		pendingDSValidatorID,                    // node ID
+		ids.NodeID(pendingDSValidatorID),        // node ID
 		nodeID,                                  // reward address
 		PercentDenominator,                      // shares
 		[]*crypto.PrivateKeySECP256K1R{keys[0]}, // key
@@ -331,7 +331,7 @@ func TestAddSubnetValidatorTxSemanticVerify(t *testing.T) {
 		defaultWeight,
 		uint64(DSStartTime.Unix()), // start validating subnet before primary network
 		uint64(DSEndTime.Unix()),
-		pendingDSValidatorID,
+		ids.NodeID(pendingDSValidatorID),
 		testSubnet1.ID(),
 		[]*crypto.PrivateKeySECP256K1R{testSubnet1ControlKeys[0], testSubnet1ControlKeys[1]},
 		ids.ShortEmpty, // change addr
@@ -355,7 +355,7 @@ func TestAddSubnetValidatorTxSemanticVerify(t *testing.T) {
 		defaultWeight,
 		uint64(DSStartTime.Unix())-1, // start validating subnet before primary network
 		uint64(DSEndTime.Unix()),
-		pendingDSValidatorID,
+		ids.NodeID(pendingDSValidatorID),
 		testSubnet1.ID(),
 		[]*crypto.PrivateKeySECP256K1R{testSubnet1ControlKeys[0], testSubnet1ControlKeys[1]},
 		ids.ShortEmpty, // change addr
@@ -372,7 +372,7 @@ func TestAddSubnetValidatorTxSemanticVerify(t *testing.T) {
 		defaultWeight,
 		uint64(DSStartTime.Unix()),
 		uint64(DSEndTime.Unix())+1, // stop validating subnet after stopping validating primary network
-		pendingDSValidatorID,
+		ids.NodeID(pendingDSValidatorID),
 		testSubnet1.ID(),
 		[]*crypto.PrivateKeySECP256K1R{testSubnet1ControlKeys[0], testSubnet1ControlKeys[1]},
 		ids.ShortEmpty, // change addr
@@ -389,7 +389,7 @@ func TestAddSubnetValidatorTxSemanticVerify(t *testing.T) {
 		defaultWeight,
 		uint64(DSStartTime.Unix()), // same start time as for primary network
 		uint64(DSEndTime.Unix()),   // same end time as for primary network
-		pendingDSValidatorID,
+		ids.NodeID(pendingDSValidatorID),
 		testSubnet1.ID(),
 		[]*crypto.PrivateKeySECP256K1R{testSubnet1ControlKeys[0], testSubnet1ControlKeys[1]},
 		ids.ShortEmpty, // change addr
@@ -410,8 +410,8 @@ func TestAddSubnetValidatorTxSemanticVerify(t *testing.T) {
 		defaultWeight,               // weight
 		uint64(newTimestamp.Unix()), // start time
 		uint64(newTimestamp.Add(defaultMinStakingDuration).Unix()), // end time
-		nodeID,           // node ID
-		testSubnet1.ID(), // subnet ID
+		ids.NodeID(nodeID), // node ID
+		testSubnet1.ID(),   // subnet ID
 		[]*crypto.PrivateKeySECP256K1R{testSubnet1ControlKeys[0], testSubnet1ControlKeys[1]},
 		ids.ShortEmpty, // change addr
 	); err != nil {
@@ -431,7 +431,7 @@ func TestAddSubnetValidatorTxSemanticVerify(t *testing.T) {
 		defaultWeight,                           // weight
 		uint64(defaultValidateStartTime.Unix()), // start time
 		uint64(defaultValidateEndTime.Unix()),   // end time
-		nodeID,                                  // node ID
+		ids.NodeID(nodeID),                      // node ID
 		testSubnet1.ID(),                        // subnet ID
 		[]*crypto.PrivateKeySECP256K1R{testSubnet1ControlKeys[0], testSubnet1ControlKeys[1]},
 		ids.ShortEmpty, // change addr
@@ -449,7 +449,7 @@ func TestAddSubnetValidatorTxSemanticVerify(t *testing.T) {
 		defaultWeight,                           // weight
 		uint64(defaultValidateStartTime.Unix()), // start time
 		uint64(defaultValidateEndTime.Unix()),   // end time
-		nodeID,                                  // node ID
+		ids.NodeID(nodeID),                      // node ID
 		testSubnet1.ID(),                        // subnet ID
 		[]*crypto.PrivateKeySECP256K1R{testSubnet1ControlKeys[0], testSubnet1ControlKeys[1]},
 		ids.ShortEmpty, // change addr
@@ -469,8 +469,8 @@ func TestAddSubnetValidatorTxSemanticVerify(t *testing.T) {
 		defaultWeight,                     // weight
 		uint64(defaultGenesisTime.Unix()), // start time
 		uint64(defaultGenesisTime.Add(defaultMinStakingDuration).Unix())+1, // end time
-		nodeID,           // node ID
-		testSubnet1.ID(), // subnet ID
+		ids.NodeID(nodeID), // node ID
+		testSubnet1.ID(),   // subnet ID
 		[]*crypto.PrivateKeySECP256K1R{testSubnet1ControlKeys[0], testSubnet1ControlKeys[1], testSubnet1ControlKeys[2]},
 		ids.ShortEmpty, // change addr
 	); err != nil {
@@ -484,8 +484,8 @@ func TestAddSubnetValidatorTxSemanticVerify(t *testing.T) {
 		defaultWeight,                     // weight
 		uint64(defaultGenesisTime.Unix()), // start time
 		uint64(defaultGenesisTime.Add(defaultMinStakingDuration).Unix()), // end time
-		nodeID,           // node ID
-		testSubnet1.ID(), // subnet ID
+		ids.NodeID(nodeID), // node ID
+		testSubnet1.ID(),   // subnet ID
 		[]*crypto.PrivateKeySECP256K1R{testSubnet1ControlKeys[0], testSubnet1ControlKeys[2]},
 		ids.ShortEmpty, // change addr
 	)
@@ -506,8 +506,8 @@ func TestAddSubnetValidatorTxSemanticVerify(t *testing.T) {
 		defaultWeight,                     // weight
 		uint64(defaultGenesisTime.Unix()), // start time
 		uint64(defaultGenesisTime.Add(defaultMinStakingDuration).Unix()), // end time
-		nodeID,           // node ID
-		testSubnet1.ID(), // subnet ID
+		ids.NodeID(nodeID), // node ID
+		testSubnet1.ID(),   // subnet ID
 		[]*crypto.PrivateKeySECP256K1R{testSubnet1ControlKeys[0], keys[1]},
 		ids.ShortEmpty, // change addr
 	)
@@ -530,8 +530,8 @@ func TestAddSubnetValidatorTxSemanticVerify(t *testing.T) {
 		defaultWeight,                       // weight
 		uint64(defaultGenesisTime.Unix())+1, // start time
 		uint64(defaultGenesisTime.Add(defaultMinStakingDuration).Unix())+1, // end time
-		nodeID,           // node ID
-		testSubnet1.ID(), // subnet ID
+		ids.NodeID(nodeID), // node ID
+		testSubnet1.ID(),   // subnet ID
 		[]*crypto.PrivateKeySECP256K1R{testSubnet1ControlKeys[0], testSubnet1ControlKeys[1]},
 		ids.ShortEmpty, // change addr
 	); err != nil {
@@ -564,7 +564,7 @@ func TestAddSubnetValidatorMarshal(t *testing.T) {
 		defaultWeight,
 		uint64(defaultValidateStartTime.Unix()),
 		uint64(defaultValidateEndTime.Unix()),
-		keys[0].PublicKey().Address(),
+		ids.NodeID(keys[0].PublicKey().Address()),
 		testSubnet1.ID(),
 		[]*crypto.PrivateKeySECP256K1R{testSubnet1ControlKeys[0], testSubnet1ControlKeys[1]},
 		ids.ShortEmpty, // change addr
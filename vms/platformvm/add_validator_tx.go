@@ -160,20 +160,17 @@ func (tx *UnsignedAddValidatorTx) SemanticVerify(
 			fmt.Errorf("failed to get timestamp: %w", err),
 		}
 	} else if startTime := tx.StartTime(); !currentTime.Before(startTime) {
-		return nil, nil, nil, nil, permError{
-			fmt.Errorf("validator's start time (%s) at or before current timestamp (%s)",
-				startTime,
-				currentTime,
-			),
-		}
+		return nil, nil, nil, nil, permError{&timestampError{
+			reason:   errStartTimeTooEarly,
+			proposed: startTime,
+			bound:    currentTime,
+		}}
 	} else if startTime.After(currentTime.Add(maxFutureStartTime)) {
-		return nil, nil, nil, nil, permError{
-			fmt.Errorf(
-				"validator start time (%s) more than two weeks after current chain timestamp (%s)",
-				startTime,
-				currentTime,
-			),
-		}
+		return nil, nil, nil, nil, permError{&timestampError{
+			reason:   errStartTimeTooLate,
+			proposed: startTime,
+			bound:    currentTime.Add(maxFutureStartTime),
+		}}
 	}
 
 	_, isValidator, err := vm.isValidator(db, constants.PrimaryNetworkID, tx.Validator.NodeID)
@@ -286,7 +283,7 @@ func (vm *VM) newAddValidatorTx(
 	stakeAmt, // Amount the delegator stakes
 	startTime, // Unix time they start delegating
 	endTime uint64, // Unix time they stop delegating
-	nodeID ids.ShortID, // ID of the node we are delegating to
+	nodeID ids.NodeID, // ID of the node we are delegating to
 	rewardAddress ids.ShortID, // Address to send reward to, if applicable
 	shares uint32, // 10,000 times percentage of reward taken from delegators
 	keys []*crypto.PrivateKeySECP256K1R, // Keys providing the staked tokens
@@ -305,7 +302,7 @@ func (vm *VM) newAddValidatorTx(
 			Outs:         unlockedOuts,
 		}},
 		Validator: Validator{
-			NodeID: nodeID,
+			NodeID: nodeID.ShortID(),
 			Start:  startTime,
 			End:    endTime,
 			Wght:   stakeAmt,
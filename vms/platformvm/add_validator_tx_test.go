@@ -4,6 +4,7 @@
 package platformvm
 
 import (
+	"errors"
 	"math"
 	"testing"
 	"time"
@@ -51,7 +52,7 @@ func TestAddValidatorTxSyntacticVerify(t *testing.T) {
 		vm.minValidatorStake,
 		uint64(defaultValidateStartTime.Unix()),
 		uint64(defaultValidateEndTime.Unix()),
-		nodeID,
+		ids.NodeID(nodeID),
 		nodeID,
 		PercentDenominator,
 		[]*crypto.PrivateKeySECP256K1R{keys[0]},
@@ -81,7 +82,7 @@ func TestAddValidatorTxSyntacticVerify(t *testing.T) {
 		vm.minValidatorStake,
 		uint64(defaultValidateStartTime.Unix()),
 		uint64(defaultValidateEndTime.Unix()),
-		nodeID,
+		ids.NodeID(nodeID),
 		nodeID,
 		PercentDenominator,
 		[]*crypto.PrivateKeySECP256K1R{keys[0]},
@@ -111,7 +112,7 @@ func TestAddValidatorTxSyntacticVerify(t *testing.T) {
 		vm.minValidatorStake,
 		uint64(defaultValidateStartTime.Unix()),
 		uint64(defaultValidateEndTime.Unix()),
-		nodeID,
+		ids.NodeID(nodeID),
 		nodeID,
 		PercentDenominator,
 		[]*crypto.PrivateKeySECP256K1R{keys[0]},
@@ -151,7 +152,7 @@ func TestAddValidatorTxSyntacticVerify(t *testing.T) {
 		vm.minValidatorStake,
 		uint64(defaultValidateStartTime.Unix()),
 		uint64(defaultValidateEndTime.Unix()),
-		nodeID,
+		ids.NodeID(nodeID),
 		nodeID,
 		PercentDenominator,
 		[]*crypto.PrivateKeySECP256K1R{keys[0]},
@@ -185,7 +186,7 @@ func TestAddValidatorTxSyntacticVerify(t *testing.T) {
 		vm.minValidatorStake,
 		uint64(defaultValidateStartTime.Unix()),
 		uint64(defaultValidateEndTime.Unix()),
-		nodeID,
+		ids.NodeID(nodeID),
 		nodeID,
 		PercentDenominator,
 		[]*crypto.PrivateKeySECP256K1R{keys[0]},
@@ -215,7 +216,7 @@ func TestAddValidatorTxSyntacticVerify(t *testing.T) {
 		vm.minValidatorStake,
 		uint64(defaultValidateStartTime.Unix()),
 		uint64(defaultValidateEndTime.Unix()),
-		nodeID,
+		ids.NodeID(nodeID),
 		nodeID,
 		PercentDenominator,
 		[]*crypto.PrivateKeySECP256K1R{keys[0]},
@@ -245,7 +246,7 @@ func TestAddValidatorTxSyntacticVerify(t *testing.T) {
 		vm.minValidatorStake,
 		uint64(defaultValidateStartTime.Unix()),
 		uint64(defaultValidateStartTime.Add(defaultMinStakingDuration).Unix()),
-		nodeID,
+		ids.NodeID(nodeID),
 		nodeID,
 		PercentDenominator,
 		[]*crypto.PrivateKeySECP256K1R{keys[0]},
@@ -275,7 +276,7 @@ func TestAddValidatorTxSyntacticVerify(t *testing.T) {
 		vm.minValidatorStake,
 		uint64(defaultValidateStartTime.Unix()),
 		uint64(defaultValidateStartTime.Add(defaultMinStakingDuration).Unix()),
-		nodeID,
+		ids.NodeID(nodeID),
 		nodeID,
 		PercentDenominator,
 		[]*crypto.PrivateKeySECP256K1R{keys[0]},
@@ -305,7 +306,7 @@ func TestAddValidatorTxSyntacticVerify(t *testing.T) {
 		vm.minValidatorStake,
 		uint64(defaultValidateStartTime.Unix()),
 		uint64(defaultValidateStartTime.Add(defaultMaxStakingDuration).Unix()),
-		nodeID,
+		ids.NodeID(nodeID),
 		nodeID,
 		PercentDenominator,
 		[]*crypto.PrivateKeySECP256K1R{keys[0]},
@@ -335,7 +336,7 @@ func TestAddValidatorTxSyntacticVerify(t *testing.T) {
 		vm.minValidatorStake,
 		uint64(defaultValidateStartTime.Unix()),
 		uint64(defaultValidateEndTime.Unix()),
-		nodeID,
+		ids.NodeID(nodeID),
 		nodeID,
 		PercentDenominator,
 		[]*crypto.PrivateKeySECP256K1R{keys[0]},
@@ -379,15 +380,15 @@ func TestAddValidatorTxSemanticVerify(t *testing.T) {
 		vm.minValidatorStake,
 		uint64(defaultValidateStartTime.Unix())-1,
 		uint64(defaultValidateEndTime.Unix()),
-		nodeID,
+		ids.NodeID(nodeID),
 		nodeID,
 		PercentDenominator,
 		[]*crypto.PrivateKeySECP256K1R{keys[0]},
 		ids.ShortEmpty, // change addr
 	); err != nil {
 		t.Fatal(err)
-	} else if _, _, _, _, err := tx.UnsignedTx.(UnsignedProposalTx).SemanticVerify(vm, vDB, tx); err == nil {
-		t.Fatal("should've errored because start time too early")
+	} else if _, _, _, _, err := tx.UnsignedTx.(UnsignedProposalTx).SemanticVerify(vm, vDB, tx); !errors.Is(err, errStartTimeTooEarly) {
+		t.Fatalf("should've errored because start time too early but got %v", err)
 	}
 	vDB.Abort()
 
@@ -396,15 +397,15 @@ func TestAddValidatorTxSemanticVerify(t *testing.T) {
 		vm.minValidatorStake,
 		uint64(defaultValidateStartTime.Add(maxFutureStartTime).Unix()+1),
 		uint64(defaultValidateStartTime.Add(maxFutureStartTime).Add(defaultMinStakingDuration).Unix()+1),
-		nodeID,
+		ids.NodeID(nodeID),
 		nodeID,
 		PercentDenominator,
 		[]*crypto.PrivateKeySECP256K1R{keys[0]},
 		ids.ShortEmpty, // change addr
 	); err != nil {
 		t.Fatal(err)
-	} else if _, _, _, _, err := tx.UnsignedTx.(UnsignedProposalTx).SemanticVerify(vm, vDB, tx); err == nil {
-		t.Fatal("should've errored because start time too far in the future")
+	} else if _, _, _, _, err := tx.UnsignedTx.(UnsignedProposalTx).SemanticVerify(vm, vDB, tx); !errors.Is(err, errStartTimeTooLate) {
+		t.Fatalf("should've errored because start time too far in the future but got %v", err)
 	}
 	vDB.Abort()
 
@@ -413,8 +414,8 @@ func TestAddValidatorTxSemanticVerify(t *testing.T) {
 		vm.minValidatorStake,
 		uint64(defaultValidateStartTime.Unix()),
 		uint64(defaultValidateEndTime.Unix()),
-		nodeID, // node ID
-		nodeID, // reward address
+		ids.NodeID(nodeID), // node ID
+		nodeID,             // reward address
 		PercentDenominator,
 		[]*crypto.PrivateKeySECP256K1R{keys[0]},
 		ids.ShortEmpty, // change addr
@@ -435,7 +436,7 @@ func TestAddValidatorTxSemanticVerify(t *testing.T) {
 		vm.minValidatorStake,     // stake amount
 		uint64(startTime.Unix()), // start time
 		uint64(startTime.Add(defaultMinStakingDuration).Unix()), // end time
-		nodeID,                     // node ID
+		ids.NodeID(nodeID),         // node ID
 		key2.PublicKey().Address(), // reward address
 		PercentDenominator,         // shares
 		[]*crypto.PrivateKeySECP256K1R{keys[0]},
@@ -458,7 +459,7 @@ func TestAddValidatorTxSemanticVerify(t *testing.T) {
 		vm.minValidatorStake,
 		uint64(defaultValidateStartTime.Unix()),
 		uint64(defaultValidateEndTime.Unix()),
-		nodeID,
+		ids.NodeID(nodeID),
 		nodeID,
 		PercentDenominator,
 		[]*crypto.PrivateKeySECP256K1R{keys[0]},
@@ -4,7 +4,6 @@
 package platformvm
 
 import (
-	"fmt"
 	"time"
 
 	"github.com/ava-labs/avalanchego/database"
@@ -50,8 +49,11 @@ func (tx *UnsignedAdvanceTimeTx) SemanticVerify(
 	case tx == nil:
 		return nil, nil, nil, nil, tempError{errNilTx}
 	case vm.clock.Time().Add(syncBound).Before(tx.Timestamp()):
-		return nil, nil, nil, nil, tempError{fmt.Errorf("proposed time, %s, is too far in the future relative to local time (%s)",
-			tx.Timestamp(), vm.clock.Time())}
+		return nil, nil, nil, nil, tempError{&timestampError{
+			reason:   errTimeTooAdvanced,
+			proposed: tx.Timestamp(),
+			bound:    vm.clock.Time().Add(syncBound),
+		}}
 	case len(stx.Creds) != 0:
 		return nil, nil, nil, nil, permError{errWrongNumberOfCredentials}
 	}
@@ -59,8 +61,11 @@ func (tx *UnsignedAdvanceTimeTx) SemanticVerify(
 	if currentTimestamp, err := vm.getTimestamp(db); err != nil {
 		return nil, nil, nil, nil, tempError{err}
 	} else if tx.Time <= uint64(currentTimestamp.Unix()) {
-		return nil, nil, nil, nil, permError{fmt.Errorf("proposed timestamp (%s), not after current timestamp (%s)",
-			tx.Timestamp(), currentTimestamp)}
+		return nil, nil, nil, nil, permError{&timestampError{
+			reason:   errTimeNotAfterCurrent,
+			proposed: tx.Timestamp(),
+			bound:    currentTimestamp,
+		}}
 	}
 
 	// Only allow timestamp to move forward as far as the time of next staker set change time
@@ -68,8 +73,11 @@ func (tx *UnsignedAdvanceTimeTx) SemanticVerify(
 	if err != nil {
 		return nil, nil, nil, nil, tempError{err}
 	} else if tx.Time > uint64(nextStakerChangeTime.Unix()) {
-		return nil, nil, nil, nil, permError{fmt.Errorf("proposed timestamp (%s) later than next staker change time (%s)",
-			tx.Timestamp(), nextStakerChangeTime)}
+		return nil, nil, nil, nil, permError{&timestampError{
+			reason:   errTimeAfterNextStakerChange,
+			proposed: tx.Timestamp(),
+			bound:    nextStakerChangeTime,
+		}}
 	}
 
 	// Specify what the state of the chain will be if this proposal is committed
@@ -4,6 +4,7 @@
 package platformvm
 
 import (
+	"errors"
 	"testing"
 	"time"
 
@@ -23,10 +24,49 @@ func TestAdvanceTimeTxTimestampTooEarly(t *testing.T) {
 		vm.Ctx.Lock.Unlock()
 	}()
 
-	if tx, err := vm.newAdvanceTimeTx(defaultGenesisTime); err != nil {
+	tx, err := vm.newAdvanceTimeTx(defaultGenesisTime)
+	if err != nil {
 		t.Fatal(err)
-	} else if _, _, _, _, err = tx.UnsignedTx.(UnsignedProposalTx).SemanticVerify(vm, vm.DB, tx); err == nil {
-		t.Fatal("should've failed verification because proposed timestamp same as current timestamp")
+	}
+	_, _, _, _, txErr := tx.UnsignedTx.(UnsignedProposalTx).SemanticVerify(vm, vm.DB, tx)
+	if !errors.Is(txErr, errTimeNotAfterCurrent) {
+		t.Fatalf("should've failed verification with %s because proposed timestamp same as current timestamp but got %v",
+			errTimeNotAfterCurrent, txErr)
+	}
+	tsErr := &timestampError{}
+	if !errors.As(txErr, &tsErr) || !tsErr.proposed.Equal(defaultGenesisTime) || !tsErr.bound.Equal(defaultGenesisTime) {
+		t.Fatalf("expected the error to report the proposed and current timestamps but got %v", txErr)
+	}
+}
+
+// Ensure semantic verification fails when proposed timestamp is too far after
+// local time
+func TestAdvanceTimeTxTimestampTooAdvanced(t *testing.T) {
+	vm, _ := defaultVM()
+	vm.Ctx.Lock.Lock()
+	defer func() {
+		if err := vm.Shutdown(); err != nil {
+			t.Fatal(err)
+		}
+		vm.Ctx.Lock.Unlock()
+	}()
+
+	vm.clock.Set(defaultGenesisTime) // VM's clock reads the genesis time
+
+	proposedTime := defaultGenesisTime.Add(1 * time.Second).Add(syncBound)
+	tx, err := vm.newAdvanceTimeTx(proposedTime)
+	if err != nil {
+		t.Fatal(err)
+	}
+	_, _, _, _, txErr := tx.UnsignedTx.(UnsignedProposalTx).SemanticVerify(vm, vm.DB, tx)
+	if !errors.Is(txErr, errTimeTooAdvanced) {
+		t.Fatalf("expected %s but got %v", errTimeTooAdvanced, txErr)
+	} else if !txErr.Temporary() {
+		t.Fatal("a timestamp too far after local time should be a temporary error")
+	}
+	tsErr := &timestampError{}
+	if !errors.As(txErr, &tsErr) || !tsErr.proposed.Equal(proposedTime) || !tsErr.bound.Equal(defaultGenesisTime.Add(syncBound)) {
+		t.Fatalf("expected the error to report the proposed time and the sync bound but got %v", txErr)
 	}
 }
 
@@ -45,7 +85,7 @@ func TestAdvanceTimeTxTimestampTooLate(t *testing.T) {
 		vm.minValidatorStake,
 		uint64(pendingValidatorStartTime.Unix()),
 		uint64(pendingValidatorEndTime.Unix()),
-		nodeID,
+		ids.NodeID(nodeID),
 		nodeID,
 		PercentDenominator,
 		[]*crypto.PrivateKeySECP256K1R{keys[0]},
@@ -113,7 +153,7 @@ func TestAdvanceTimeTxUpdatePrimaryNetworkStakers(t *testing.T) {
 		vm.minValidatorStake,
 		uint64(pendingValidatorStartTime.Unix()),
 		uint64(pendingValidatorEndTime.Unix()),
-		nodeID,
+		ids.NodeID(nodeID),
 		nodeID,
 		PercentDenominator,
 		[]*crypto.PrivateKeySECP256K1R{keys[0]},
@@ -294,8 +334,8 @@ func TestAdvanceTimeTxUpdatePrimaryNetworkStakers2(t *testing.T) {
 				vm.minValidatorStake,
 				uint64(staker.startTime.Unix()),
 				uint64(staker.endTime.Unix()),
-				staker.nodeID,  // validator ID
-				ids.ShortEmpty, // reward address
+				ids.NodeID(staker.nodeID), // validator ID
+				ids.ShortEmpty,            // reward address
 				PercentDenominator,
 				[]*crypto.PrivateKeySECP256K1R{keys[0]},
 				ids.ShortEmpty, // change addr
@@ -366,7 +406,7 @@ func TestAdvanceTimeTxRemoveSubnetValidator(t *testing.T) {
 		1,                                  // Weight
 		uint64(subnetVdr1StartTime.Unix()), // Start time
 		uint64(subnetVdr1EndTime.Unix()),   // end time
-		subnetValidatorNodeID,              // Node ID
+		ids.NodeID(subnetValidatorNodeID),  // Node ID
 		testSubnet1.ID(),                   // Subnet ID
 		[]*crypto.PrivateKeySECP256K1R{keys[0], keys[1]}, // Keys
 		ids.ShortEmpty, // reward address
@@ -388,9 +428,9 @@ func TestAdvanceTimeTxRemoveSubnetValidator(t *testing.T) {
 		1, // Weight
 		uint64(subnetVdr1EndTime.Add(time.Second).Unix()),                                // Start time
 		uint64(subnetVdr1EndTime.Add(time.Second).Add(defaultMinStakingDuration).Unix()), // end time
-		keys[1].PublicKey().Address(),                                                    // Node ID
-		testSubnet1.ID(),                                                                 // Subnet ID
-		[]*crypto.PrivateKeySECP256K1R{keys[0], keys[1]},                                 // Keys
+		ids.NodeID(keys[1].PublicKey().Address()),                                        // Node ID
+		testSubnet1.ID(), // Subnet ID
+		[]*crypto.PrivateKeySECP256K1R{keys[0], keys[1]}, // Keys
 		ids.ShortEmpty, // reward address
 	)
 	if err != nil {
@@ -112,6 +112,9 @@ func (ab *AtomicBlock) Accept() error {
 	}
 
 	// Update the state of the chain in the database
+	if err := ab.vm.putBlockStateHash(ab.onAcceptDB, ab.ID()); err != nil {
+		return fmt.Errorf("failed to record state hash of block %s: %w", ab.ID(), err)
+	}
 	if err := ab.onAcceptDB.Commit(); err != nil {
 		return fmt.Errorf("failed to commit onAcceptDB for block %s: %w", ab.ID(), err)
 	}
@@ -11,6 +11,7 @@ import (
 	"github.com/ava-labs/avalanchego/utils/formatting"
 	cjson "github.com/ava-labs/avalanchego/utils/json"
 	"github.com/ava-labs/avalanchego/utils/rpc"
+	"github.com/ava-labs/avalanchego/vms/secp256k1fx"
 )
 
 // Client ...
@@ -97,6 +98,29 @@ func (c *Client) GetUTXOs(addresses []string) ([][]byte, api.Index, error) {
 	return utxos, res.EndIndex, nil
 }
 
+// UsedAddresses returns a secp256k1fx.UsedAddresses that reports the addresses
+// that control UTXOs on the P Chain, to scan an HD wallet's addresses with.
+// Addresses are formatted with [hrp], like "avax".
+func (c *Client) UsedAddresses(hrp string) secp256k1fx.UsedAddresses {
+	return func(addrs []ids.ShortID) (ids.ShortSet, error) {
+		used := ids.ShortSet{}
+		for _, addr := range addrs {
+			addrStr, err := formatting.FormatAddress("P", hrp, addr.Bytes())
+			if err != nil {
+				return nil, err
+			}
+			utxos, _, err := c.GetUTXOs([]string{addrStr})
+			if err != nil {
+				return nil, err
+			}
+			if len(utxos) > 0 {
+				used.Add(addr)
+			}
+		}
+		return used, nil
+	}
+}
+
 // GetSubnets returns information about the specified subnets
 func (c *Client) GetSubnets(ids []ids.ID) ([]APISubnet, error) {
 	res := &GetSubnetsResponse{}
@@ -106,6 +130,15 @@ func (c *Client) GetSubnets(ids []ids.ID) ([]APISubnet, error) {
 	return res.Subnets, err
 }
 
+// GetSubnetInfo returns the info recorded for the subnet [subnetID]
+func (c *Client) GetSubnetInfo(subnetID ids.ID) (*SubnetInfo, error) {
+	res := &SubnetInfo{}
+	err := c.requester.SendRequest("getSubnetInfo", &GetSubnetInfoArgs{
+		SubnetID: subnetID,
+	}, res)
+	return res, err
+}
+
 // GetStakingAssetID returns the assetID of the asset used for staking on
 // subnet corresponding to [subnetID]
 func (c *Client) GetStakingAssetID(subnetID ids.ID) (ids.ID, error) {
@@ -141,6 +174,33 @@ func (c *Client) GetCurrentSupply() (uint64, error) {
 	return uint64(res.Supply), err
 }
 
+// GetBurnedFees returns the amount of AVAX burned as fees on the P-Chain
+func (c *Client) GetBurnedFees() (uint64, error) {
+	res := &GetBurnedFeesReply{}
+	err := c.requester.SendRequest("getBurnedFees", struct{}{}, res)
+	return uint64(res.BurnedFees), err
+}
+
+// GetStateHash returns the state hash of the P-Chain after the accepted block
+// [blockID], or after the last accepted block if [blockID] is empty
+func (c *Client) GetStateHash(blockID ids.ID) (*GetStateHashReply, error) {
+	res := &GetStateHashReply{}
+	err := c.requester.SendRequest("getStateHash", &GetStateHashArgs{
+		BlockID: blockID,
+	}, res)
+	return res, err
+}
+
+// GetValidatorSetHash returns the hash of the current validator set of
+// [subnetID]
+func (c *Client) GetValidatorSetHash(subnetID ids.ID) (*GetValidatorSetHashReply, error) {
+	res := &GetValidatorSetHashReply{}
+	err := c.requester.SendRequest("getValidatorSetHash", &GetValidatorSetHashArgs{
+		SubnetID: subnetID,
+	}, res)
+	return res, err
+}
+
 // SampleValidators returns the nodeIDs of a sample of [sampleSize] validators from the current validator set for subnet with ID [subnetID]
 func (c *Client) SampleValidators(subnetID ids.ID, sampleSize uint16) ([]string, error) {
 	res := &SampleValidatorsReply{}
@@ -338,6 +398,27 @@ func (c *Client) CreateBlockchain(
 	return res.TxID, err
 }
 
+// SetSubnetInfo issues a SetSubnetInfo transaction and returns the txID
+func (c *Client) SetSubnetInfo(
+	user api.UserPass,
+	from []string,
+	changeAddr string,
+	subnetID ids.ID,
+	info SubnetInfo,
+) (ids.ID, error) {
+	res := &api.JSONTxID{}
+	err := c.requester.SendRequest("setSubnetInfo", &SetSubnetInfoArgs{
+		JSONSpendHeader: api.JSONSpendHeader{
+			UserPass:       user,
+			JSONFromAddrs:  api.JSONFromAddrs{From: from},
+			JSONChangeAddr: api.JSONChangeAddr{ChangeAddr: changeAddr},
+		},
+		SubnetID:   subnetID,
+		SubnetInfo: info,
+	}, res)
+	return res.TxID, err
+}
+
 // GetBlockchainStatus returns the current status of blockchain with ID: [blockchainID]
 func (c *Client) GetBlockchainStatus(blockchainID string) (Status, error) {
 	res := &GetBlockchainStatusReply{}
@@ -428,6 +509,13 @@ func (c *Client) GetMinStake() (uint64, uint64, error) {
 	return uint64(res.MinValidatorStake), uint64(res.MinDelegatorStake), err
 }
 
+// GetStakingParameters returns the staking parameters of the Primary Network
+func (c *Client) GetStakingParameters() (*GetStakingParametersReply, error) {
+	res := new(GetStakingParametersReply)
+	err := c.requester.SendRequest("getStakingParameters", struct{}{}, res)
+	return res, err
+}
+
 // GetTotalStake returns the total amount (in nAVAX) staked on the network
 func (c *Client) GetTotalStake() (uint64, error) {
 	res := new(GetStakeReply)
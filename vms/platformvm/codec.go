@@ -62,11 +62,15 @@ func init() {
 
 			c.RegisterType(&StakeableLockIn{}),
 			c.RegisterType(&StakeableLockOut{}),
+
+			c.RegisterType(&UnsignedSetSubnetInfoTx{}),
 		)
 	}
 	errs.Add(
 		Codec.RegisterCodec(codecVersion, c),
 		GenesisCodec.RegisterCodec(codecVersion, gc),
+		Codec.Verify(),
+		GenesisCodec.Verify(),
 	)
 	if errs.Errored() {
 		panic(errs.Err)
@@ -223,6 +223,9 @@ func (sdb *SingleDecisionBlock) Accept() error {
 	}
 
 	// Update the state of the chain in the database
+	if err := sdb.vm.putBlockStateHash(sdb.onAcceptDB, sdb.ID()); err != nil {
+		return fmt.Errorf("failed to record state hash: %w", err)
+	}
 	if err := sdb.onAcceptDB.Commit(); err != nil {
 		return fmt.Errorf("failed to commit onAcceptDB: %w", err)
 	}
@@ -267,6 +270,9 @@ func (ddb *DoubleDecisionBlock) Accept() error {
 	}
 
 	// Update the state of the chain in the database
+	if err := ddb.vm.putBlockStateHash(ddb.onAcceptDB, ddb.ID()); err != nil {
+		return fmt.Errorf("failed to record state hash: %w", err)
+	}
 	if err := ddb.onAcceptDB.Commit(); err != nil {
 		return fmt.Errorf("failed to commit onAcceptDB: %w", err)
 	}
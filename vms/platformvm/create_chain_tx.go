@@ -129,6 +129,9 @@ func (tx *UnsignedCreateChainTx) SemanticVerify(
 	if err := vm.produceOutputs(db, txID, tx.Outs); err != nil {
 		return nil, tempError{err}
 	}
+	if err := vm.burnFee(db, vm.creationTxFee); err != nil {
+		return nil, tempError{err}
+	}
 
 	// Verify that this chain is authorized by the subnet
 	subnet, err := vm.getSubnet(db, tx.SubnetID)
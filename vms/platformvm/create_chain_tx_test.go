@@ -315,3 +315,44 @@ func TestCreateChainTxValid(t *testing.T) {
 		t.Fatalf("expected tx to pass verification but got error: %v", err)
 	}
 }
+
+// Ensure the creation fee is recorded as burned once the tx is verified
+func TestCreateChainTxBurnsFee(t *testing.T) {
+	vm, _ := defaultVM()
+	vm.Ctx.Lock.Lock()
+	defer func() {
+		if err := vm.Shutdown(); err != nil {
+			t.Fatal(err)
+		}
+		vm.Ctx.Lock.Unlock()
+	}()
+
+	burnedFees, err := vm.getBurnedFees(vm.DB)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tx, err := vm.newCreateChainTx(
+		testSubnet1.ID(),
+		nil,
+		avm.ID,
+		nil,
+		"chain name",
+		[]*crypto.PrivateKeySECP256K1R{testSubnet1ControlKeys[0], testSubnet1ControlKeys[1]},
+		ids.ShortEmpty, // change addr
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := tx.UnsignedTx.(UnsignedDecisionTx).SemanticVerify(vm, vm.DB, tx); err != nil {
+		t.Fatal(err)
+	}
+
+	newBurnedFees, err := vm.getBurnedFees(vm.DB)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if expected := burnedFees + vm.creationTxFee; newBurnedFees != expected {
+		t.Fatalf("expected %d burned but got %d", expected, newBurnedFees)
+	}
+}
@@ -93,6 +93,9 @@ func (tx *UnsignedCreateSubnetTx) SemanticVerify(
 	if err := vm.produceOutputs(db, txID, tx.Outs); err != nil {
 		return nil, tempError{err}
 	}
+	if err := vm.burnFee(db, vm.creationTxFee); err != nil {
+		return nil, tempError{err}
+	}
 	// Register new subnet in validator manager
 	onAccept := func() error {
 		return vm.vdrMgr.Set(tx.ID(), validators.NewSet())
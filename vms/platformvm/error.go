@@ -3,6 +3,18 @@
 
 package platformvm
 
+import (
+	"errors"
+	"fmt"
+	"time"
+)
+
+var (
+	errTimeTooAdvanced           = errors.New("proposed timestamp is too far in the future relative to local time")
+	errTimeNotAfterCurrent       = errors.New("proposed timestamp isn't after the current chain timestamp")
+	errTimeAfterNextStakerChange = errors.New("proposed timestamp is after the next staker change time")
+)
+
 // TxError provides the ability for errors to be distinguished as permanent or
 // temporary
 type TxError interface {
@@ -14,6 +26,26 @@ type tempError struct{ error }
 
 func (tempError) Temporary() bool { return true }
 
+func (e tempError) Unwrap() error { return e.error }
+
 type permError struct{ error }
 
 func (permError) Temporary() bool { return false }
+
+func (e permError) Unwrap() error { return e.error }
+
+// timestampError reports that [proposed] violates the bound [bound]. [reason]
+// is a sentinel error that describes the bound, like errTimeTooAdvanced or
+// errStartTimeTooEarly, so the kind of violation can be checked with errors.Is
+// and the offending times can be extracted with errors.As.
+type timestampError struct {
+	reason   error
+	proposed time.Time
+	bound    time.Time
+}
+
+func (e *timestampError) Error() string {
+	return fmt.Sprintf("%s (proposed %s, bound %s)", e.reason, e.proposed, e.bound)
+}
+
+func (e *timestampError) Unwrap() error { return e.reason }
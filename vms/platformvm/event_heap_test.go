@@ -26,7 +26,7 @@ func TestTxHeapStart(t *testing.T) {
 		vm.minValidatorStake,                                               // stake amount
 		uint64(defaultGenesisTime.Unix()+1),                                // startTime
 		uint64(defaultGenesisTime.Add(defaultMinStakingDuration).Unix()+1), // endTime
-		ids.NewShortID([20]byte{}),                                         // node ID
+		ids.NodeID(ids.NewShortID([20]byte{})),                             // node ID
 		ids.NewShortID([20]byte{1, 2, 3, 4, 5, 6, 7}),                      // reward address
 		0,                                       // shares
 		[]*crypto.PrivateKeySECP256K1R{keys[0]}, // key
@@ -41,7 +41,7 @@ func TestTxHeapStart(t *testing.T) {
 		vm.minValidatorStake,                                               // stake amount
 		uint64(defaultGenesisTime.Unix()+2),                                // startTime
 		uint64(defaultGenesisTime.Add(defaultMinStakingDuration).Unix()+2), // endTime
-		ids.NewShortID([20]byte{1}),                                        // node ID
+		ids.NodeID(ids.NewShortID([20]byte{1})),                            // node ID
 		ids.NewShortID([20]byte{1, 2, 3, 4, 5, 6, 7}),                      // reward address
 		0,                                       // shares
 		[]*crypto.PrivateKeySECP256K1R{keys[0]}, // key
@@ -56,7 +56,7 @@ func TestTxHeapStart(t *testing.T) {
 		vm.minValidatorStake,                                               // stake amount
 		uint64(defaultGenesisTime.Unix()+3),                                // startTime
 		uint64(defaultGenesisTime.Add(defaultMinStakingDuration).Unix()+3), // endTime
-		ids.NewShortID([20]byte{}),                                         // node ID
+		ids.NodeID(ids.NewShortID([20]byte{})),                             // node ID
 		ids.NewShortID([20]byte{1, 2, 3, 4, 5, 6, 7}),                      // reward address
 		0,                                       // shares
 		[]*crypto.PrivateKeySECP256K1R{keys[0]}, // key
@@ -101,7 +101,7 @@ func TestTxHeapStop(t *testing.T) {
 		vm.minValidatorStake,                                               // stake amount
 		uint64(defaultGenesisTime.Unix()+1),                                // startTime
 		uint64(defaultGenesisTime.Add(defaultMinStakingDuration).Unix()+1), // endTime
-		ids.NewShortID([20]byte{}),                                         // node ID
+		ids.NodeID(ids.NewShortID([20]byte{})),                             // node ID
 		ids.NewShortID([20]byte{1, 2, 3, 4, 5, 6, 7}),                      // reward address
 		0,                                       // shares
 		[]*crypto.PrivateKeySECP256K1R{keys[0]}, // key
@@ -116,7 +116,7 @@ func TestTxHeapStop(t *testing.T) {
 		vm.minValidatorStake,                                               // stake amount
 		uint64(defaultGenesisTime.Unix()+1),                                // startTime
 		uint64(defaultGenesisTime.Add(defaultMinStakingDuration).Unix()+2), // endTime
-		ids.NewShortID([20]byte{1}),                                        // node ID
+		ids.NodeID(ids.NewShortID([20]byte{1})),                            // node ID
 		ids.NewShortID([20]byte{1, 2, 3, 4, 5, 6, 7}),                      // reward address
 		0,                                       // shares
 		[]*crypto.PrivateKeySECP256K1R{keys[0]}, // key
@@ -131,7 +131,7 @@ func TestTxHeapStop(t *testing.T) {
 		vm.minValidatorStake,                                               // stake amount
 		uint64(defaultGenesisTime.Unix()+1),                                // startTime
 		uint64(defaultGenesisTime.Add(defaultMinStakingDuration).Unix()+3), // endTime
-		ids.NewShortID([20]byte{}),                                         // node ID
+		ids.NodeID(ids.NewShortID([20]byte{})),                             // node ID
 		ids.NewShortID([20]byte{1, 2, 3, 4, 5, 6, 7}),                      // reward address
 		0,                                       // shares
 		[]*crypto.PrivateKeySECP256K1R{keys[0]}, // key
@@ -176,7 +176,7 @@ func TestTxHeapStartValidatorVsDelegatorOrdering(t *testing.T) {
 		vm.minValidatorStake,                                               // stake amount
 		uint64(defaultGenesisTime.Unix()+1),                                // startTime
 		uint64(defaultGenesisTime.Add(defaultMinStakingDuration).Unix()+1), // endTime
-		ids.NewShortID([20]byte{}),                                         // node ID
+		ids.NodeID(ids.NewShortID([20]byte{})),                             // node ID
 		ids.NewShortID([20]byte{1, 2, 3, 4, 5, 6, 7}),                      // reward address
 		0,                                       // shares
 		[]*crypto.PrivateKeySECP256K1R{keys[0]}, // key
@@ -190,7 +190,7 @@ func TestTxHeapStartValidatorVsDelegatorOrdering(t *testing.T) {
 		vm.minValidatorStake,                                               // stake amount
 		uint64(defaultGenesisTime.Unix()+1),                                // startTime
 		uint64(defaultGenesisTime.Add(defaultMinStakingDuration).Unix()+1), // endTime
-		ids.NewShortID([20]byte{}),                                         // node ID
+		ids.NodeID(ids.NewShortID([20]byte{})),                             // node ID
 		ids.NewShortID([20]byte{1, 2, 3, 4, 5, 6, 7}),                      // reward address
 		[]*crypto.PrivateKeySECP256K1R{keys[0]},                            // key
 		ids.ShortEmpty,                                                     // change addr
@@ -223,7 +223,7 @@ func TestTxHeapStopValidatorVsDelegatorOrdering(t *testing.T) {
 		vm.minValidatorStake,                                               // stake amount
 		uint64(defaultGenesisTime.Unix()+1),                                // startTime
 		uint64(defaultGenesisTime.Add(defaultMinStakingDuration).Unix()+1), // endTime
-		ids.NewShortID([20]byte{}),                                         // node ID
+		ids.NodeID(ids.NewShortID([20]byte{})),                             // node ID
 		ids.NewShortID([20]byte{1, 2, 3, 4, 5, 6, 7}),                      // reward address
 		0,                                       // shares
 		[]*crypto.PrivateKeySECP256K1R{keys[0]}, // key
@@ -237,7 +237,7 @@ func TestTxHeapStopValidatorVsDelegatorOrdering(t *testing.T) {
 		vm.minValidatorStake,                                               // stake amount
 		uint64(defaultGenesisTime.Unix()+1),                                // startTime
 		uint64(defaultGenesisTime.Add(defaultMinStakingDuration).Unix()+1), // endTime
-		ids.NewShortID([20]byte{}),                                         // node ID
+		ids.NodeID(ids.NewShortID([20]byte{})),                             // node ID
 		ids.NewShortID([20]byte{1, 2, 3, 4, 5, 6, 7}),                      // reward address
 		[]*crypto.PrivateKeySECP256K1R{keys[0]},                            // key
 		ids.ShortEmpty,                                                     // change addr
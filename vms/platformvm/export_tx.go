@@ -124,6 +124,11 @@ func (tx *UnsignedExportTx) SemanticVerify(
 			fmt.Errorf("failed to produce outputs: %w", err),
 		}
 	}
+	if err := vm.burnFee(db, vm.txFee); err != nil {
+		return tempError{
+			fmt.Errorf("failed to burn fee: %w", err),
+		}
+	}
 	return nil
 }
 
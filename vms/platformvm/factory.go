@@ -32,6 +32,9 @@ type Factory struct {
 	MinStakeDuration   time.Duration // Min time allowed for validating
 	MaxStakeDuration   time.Duration // Max time allowed for validating
 	StakeMintingPeriod time.Duration // Staking consumption period
+	// Calculates staking rewards. If nil, the primary network's reward curve
+	// with [StakeMintingPeriod] is used.
+	Rewards RewardCalculator
 }
 
 // New returns a new instance of the Platform Chain
@@ -50,5 +53,6 @@ func (f *Factory) New(*snow.Context) (interface{}, error) {
 		minStakeDuration:   f.MinStakeDuration,
 		maxStakeDuration:   f.MaxStakeDuration,
 		stakeMintingPeriod: f.StakeMintingPeriod,
+		rewards:            f.Rewards,
 	}, nil
 }
@@ -119,6 +119,11 @@ func (tx *UnsignedImportTx) SemanticVerify(
 			fmt.Errorf("failed to produce outputs: %w", err),
 		}
 	}
+	if err := vm.burnFee(db, vm.txFee); err != nil {
+		return tempError{
+			fmt.Errorf("failed to burn fee: %w", err),
+		}
+	}
 
 	if !vm.bootstrapped {
 		return nil
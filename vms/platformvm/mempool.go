@@ -9,6 +9,8 @@ import (
 	"time"
 
 	"github.com/ava-labs/avalanchego/database"
+	"github.com/ava-labs/avalanchego/database/prefixdb"
+	"github.com/ava-labs/avalanchego/database/versiondb"
 	"github.com/ava-labs/avalanchego/ids"
 	"github.com/ava-labs/avalanchego/snow/consensus/snowman"
 	"github.com/ava-labs/avalanchego/utils/constants"
@@ -29,9 +31,17 @@ const (
 	// Time difference between local time and current chain time
 	// at which to attempt to increase the chain timestamp
 	catchUpTime = 2 * time.Hour
+
+	// persistFrequency is how often the mempool's transactions are written to
+	// the database
+	persistFrequency = 5 * time.Second
 )
 
 var (
+	// Prefix of the database that stores transactions that are in the mempool
+	// so that they survive restarts
+	mempoolPrefix = []byte("mempool")
+
 	errEndOfTime       = errors.New("program time is suspiciously far in the future. Either this codebase was way more successful than expected, or a critical error has occurred")
 	errNoPendingBlocks = errors.New("no pending blocks")
 	errUnknownTxType   = errors.New("unknown transaction type")
@@ -78,6 +88,17 @@ type Mempool struct {
 	unissuedDecisionTxs []*Tx
 	unissuedAtomicTxs   []*Tx
 	unissuedTxIDs       ids.Set
+
+	// Transactions that haven't been put into blocks yet, so that they can be
+	// reissued after a restart. Written every [persistFrequency] and on
+	// shutdown.
+	// Key: Tx ID
+	// Value: Tx bytes
+	db database.Database
+	// IDs of the transactions in [db]
+	persistedTxIDs ids.Set
+	// Periodically writes the mempool's transactions to [db]
+	persister *timer.Repeater
 }
 
 // Initialize this mempool.
@@ -89,6 +110,7 @@ func (m *Mempool) Initialize(vm *VM) {
 	// Transactions from clients that have not yet been put into blocks and
 	// added to consensus
 	m.unissuedProposalTxs = &EventHeap{SortByStartTime: true}
+	m.db = prefixdb.New(mempoolPrefix, vm.DB)
 
 	m.timer = timer.NewTimer(func() {
 		m.vm.Ctx.Lock.Lock()
@@ -97,6 +119,20 @@ func (m *Mempool) Initialize(vm *VM) {
 		m.ResetTimer()
 	})
 	go m.vm.Ctx.Log.RecoverAndPanic(m.timer.Dispatch)
+
+	m.persister = timer.NewRepeater(func() {
+		m.vm.Ctx.Lock.Lock()
+		defer m.vm.Ctx.Lock.Unlock()
+
+		if err := m.persist(); err != nil {
+			m.vm.Ctx.Log.Error("failed to persist the mempool: %s", err)
+			return
+		}
+		if err := m.vm.DB.Commit(); err != nil {
+			m.vm.Ctx.Log.Error("failed to commit the mempool: %s", err)
+		}
+	}, persistFrequency)
+	go m.vm.Ctx.Log.RecoverAndPanic(m.persister.Dispatch)
 }
 
 // IssueTx enqueues the [tx] to be put into a block
@@ -109,6 +145,15 @@ func (m *Mempool) IssueTx(tx *Tx) error {
 	if m.unissuedTxIDs.Contains(txID) {
 		return nil
 	}
+	if _, ok := tx.UnsignedTx.(*UnsignedSetSubnetInfoTx); ok {
+		activated, err := m.vm.metadataUpgradeActivated(m.vm.DB)
+		if err != nil {
+			return err
+		}
+		if !activated {
+			return errSubnetInfoNotActive
+		}
+	}
 	switch tx.UnsignedTx.(type) {
 	case TimedTx:
 		m.unissuedProposalTxs.Add(tx)
@@ -124,6 +169,113 @@ func (m *Mempool) IssueTx(tx *Tx) error {
 	return nil
 }
 
+// ReissuePersistedTxs adds the transactions that were in the mempool when it
+// was last persisted back into the mempool. Transactions that have since been
+// decided or that are no longer valid are dropped, and are removed from the
+// database the next time the mempool is persisted.
+func (m *Mempool) ReissuePersistedTxs() error {
+	iter := m.db.NewIterator()
+	var txs []*Tx
+	for iter.Next() {
+		txID, err := ids.ToID(iter.Key())
+		if err != nil {
+			iter.Release()
+			return err
+		}
+		m.persistedTxIDs.Add(txID)
+
+		tx := &Tx{}
+		if _, err := m.vm.codec.Unmarshal(iter.Value(), tx); err != nil {
+			m.vm.Ctx.Log.Debug("dropping unparsable persisted tx: %s", err)
+			continue
+		}
+		txs = append(txs, tx)
+	}
+	err := iter.Error()
+	iter.Release()
+	if err != nil {
+		return err
+	}
+
+	for _, tx := range txs {
+		if err := tx.Sign(m.vm.codec, nil); err != nil {
+			return err
+		}
+		txID := tx.ID()
+		err := m.verifyPersistedTx(tx)
+		if err == nil {
+			err = m.IssueTx(tx)
+		}
+		if err != nil {
+			m.vm.Ctx.Log.Debug("dropping persisted tx %s: %s", txID, err)
+			m.vm.droppedTxCache.Put(txID, err.Error())
+		}
+	}
+	return nil
+}
+
+// persist writes the transactions that haven't been put into blocks to the
+// database, so that they're reissued after a restart, and removes the ones
+// that have been. Must be followed by a commit of the VM's database.
+func (m *Mempool) persist() error {
+	if m.db == nil {
+		// The mempool was never initialized
+		return nil
+	}
+
+	for _, txID := range m.persistedTxIDs.List() {
+		if m.unissuedTxIDs.Contains(txID) {
+			continue
+		}
+		if err := m.db.Delete(txID[:]); err != nil {
+			return err
+		}
+		m.persistedTxIDs.Remove(txID)
+	}
+
+	txs := append([]*Tx(nil), m.unissuedDecisionTxs...)
+	txs = append(txs, m.unissuedAtomicTxs...)
+	txs = append(txs, m.unissuedProposalTxs.Txs...)
+	for _, tx := range txs {
+		txID := tx.ID()
+		if m.persistedTxIDs.Contains(txID) {
+			continue
+		}
+		if err := m.db.Put(txID[:], tx.Bytes()); err != nil {
+			return err
+		}
+		m.persistedTxIDs.Add(txID)
+	}
+	return nil
+}
+
+// verifyPersistedTx returns an error if [tx] was decided or is no longer valid
+// against the last accepted state. Temporary verification errors are ignored,
+// as the transaction may become valid later.
+func (m *Mempool) verifyPersistedTx(tx *Tx) error {
+	if status, err := m.vm.getStatus(m.vm.DB, tx.ID()); err == nil {
+		return fmt.Errorf("tx was already decided with status %s", status)
+	}
+
+	// Verification mustn't modify the last accepted state
+	db := versiondb.New(m.vm.DB)
+	var txErr TxError
+	switch utx := tx.UnsignedTx.(type) {
+	case UnsignedProposalTx:
+		_, _, _, _, txErr = utx.SemanticVerify(m.vm, db, tx)
+	case UnsignedDecisionTx:
+		_, txErr = utx.SemanticVerify(m.vm, db, tx)
+	case UnsignedAtomicTx:
+		txErr = utx.SemanticVerify(m.vm, db, tx)
+	default:
+		return errUnknownTxType
+	}
+	if txErr != nil && !txErr.Temporary() {
+		return txErr
+	}
+	return nil
+}
+
 // BuildBlock builds a block to be added to consensus
 func (m *Mempool) BuildBlock() (snowman.Block, error) {
 	m.vm.Ctx.Log.Debug("in BuildBlock")
@@ -144,7 +296,8 @@ func (m *Mempool) BuildBlock() (snowman.Block, error) {
 		var txs []*Tx
 		txs, m.unissuedDecisionTxs = m.unissuedDecisionTxs[:numTxs], m.unissuedDecisionTxs[numTxs:]
 		for _, tx := range txs {
-			m.unissuedTxIDs.Remove(tx.ID())
+			txID := tx.ID()
+			m.unissuedTxIDs.Remove(txID)
 		}
 		blk, err := m.vm.newStandardBlock(preferredID, preferredHeight+1, txs)
 		if err != nil {
@@ -409,5 +562,6 @@ func (m *Mempool) Shutdown() {
 	// So, the lock must be released before stopping the timer.
 	m.vm.Ctx.Lock.Unlock()
 	m.timer.Stop()
+	m.persister.Stop()
 	m.vm.Ctx.Lock.Lock()
 }
@@ -4,18 +4,17 @@
 package platformvm
 
 import (
+	"errors"
+	"fmt"
 	"math/big"
 	"time"
 )
 
 var (
-	// maxSubMinConsumptionRate is the difference between the maximum
-	// consumption rate of the remaining tokens and the minimum.
-	maxSubMinConsumptionRate = new(big.Int).SetUint64(MaxSubMinConsumptionRate)
-
-	// minConsumptionRate is the consumption rate to use when calculating a
-	// validator period with duration 0.
-	minConsumptionRate = new(big.Int).SetUint64(MinConsumptionRate)
+	errMaxConsumptionRateTooSmall = errors.New("max consumption rate can't be less than min consumption rate")
+	errMaxConsumptionRateTooLarge = fmt.Errorf("max consumption rate can't be greater than %d", PercentDenominator)
+	errNoMintingPeriod            = errors.New("minting period must be positive")
+	errNoSupplyCap                = errors.New("supply cap must be positive")
 
 	// consumptionRateDenominator is the magnitude offset used to emulate
 	// floating point fractions.
@@ -27,36 +26,127 @@ type rewardTx struct {
 	Tx     Tx     `serialize:"true"`
 }
 
-// Reward returns the amount of tokens to reward the staker with.
+// RewardCalculator calculates the amount of tokens to reward stakers with.
+//
+// As long as the current supply is at most the supply cap, implementations
+// must never return a reward that would increase the supply past the supply
+// cap, and the reward must not decrease as the staked duration or the staked
+// amount increases.
+type RewardCalculator interface {
+	// Calculate returns the reward for staking [stakedAmount] for
+	// [stakedDuration] when [currentSupply] tokens exist
+	Calculate(stakedDuration time.Duration, stakedAmount, currentSupply uint64) uint64
+}
+
+// RewardConfig parameterizes the consumption based reward curve
+type RewardConfig struct {
+	// MaxConsumptionRate is the rate, out of [PercentDenominator], at which
+	// the remaining supply is consumed when staking for [MintingPeriod]
+	MaxConsumptionRate uint64
+	// MinConsumptionRate is the rate, out of [PercentDenominator], at which
+	// the remaining supply is consumed when staking for a duration of 0
+	MinConsumptionRate uint64
+	// MintingPeriod is the period over which the consumption rate applies
+	MintingPeriod time.Duration
+	// SupplyCap is the maximum amount of tokens that should ever exist
+	SupplyCap uint64
+}
+
+// DefaultRewardConfig returns the reward curve of the primary network with the
+// provided [mintingPeriod]
+func DefaultRewardConfig(mintingPeriod time.Duration) RewardConfig {
+	return RewardConfig{
+		MaxConsumptionRate: MinConsumptionRate + MaxSubMinConsumptionRate,
+		MinConsumptionRate: MinConsumptionRate,
+		MintingPeriod:      mintingPeriod,
+		SupplyCap:          SupplyCap,
+	}
+}
+
+type consumptionRewardCalculator struct {
+	maxSubMinConsumptionRate *big.Int
+	minConsumptionRate       *big.Int
+	mintingPeriod            *big.Int
+	supplyCap                uint64
+}
+
+// NewRewardCalculator returns a calculator that rewards stakers with a portion
+// of the remaining supply:
 //
 // RemainingSupply = SupplyCap - ExistingSupply
 // PortionOfExistingSupply = StakedAmount / ExistingSupply
-// PortionOfStakingDuration = StakingDuration / MaximumStakingDuration
+// PortionOfStakingDuration = StakingDuration / MintingPeriod
 // MintingRate = MinMintingRate + MaxSubMinMintingRate * PortionOfStakingDuration
 // Reward = RemainingSupply * PortionOfExistingSupply * MintingRate * PortionOfStakingDuration
-func Reward(
+//
+// Returns an error if [config] doesn't describe a valid curve.
+func NewRewardCalculator(config RewardConfig) (RewardCalculator, error) {
+	switch {
+	case config.MaxConsumptionRate < config.MinConsumptionRate:
+		return nil, errMaxConsumptionRateTooSmall
+	case config.MaxConsumptionRate > PercentDenominator:
+		return nil, errMaxConsumptionRateTooLarge
+	case config.MintingPeriod <= 0:
+		return nil, errNoMintingPeriod
+	case config.SupplyCap == 0:
+		return nil, errNoSupplyCap
+	}
+	return newConsumptionRewardCalculator(config), nil
+}
+
+func newConsumptionRewardCalculator(config RewardConfig) *consumptionRewardCalculator {
+	return &consumptionRewardCalculator{
+		maxSubMinConsumptionRate: new(big.Int).SetUint64(config.MaxConsumptionRate - config.MinConsumptionRate),
+		minConsumptionRate:       new(big.Int).SetUint64(config.MinConsumptionRate),
+		mintingPeriod:            new(big.Int).SetUint64(uint64(config.MintingPeriod)),
+		supplyCap:                config.SupplyCap,
+	}
+}
+
+func (c *consumptionRewardCalculator) Calculate(
 	rawDuration time.Duration,
 	rawStakedAmount,
-	rawMaxExistingAmount uint64,
-	rawConsumptionInterval time.Duration,
+	rawCurrentSupply uint64,
 ) uint64 {
+	if rawCurrentSupply >= c.supplyCap || rawCurrentSupply == 0 {
+		return 0
+	}
+
 	duration := new(big.Int).SetUint64(uint64(rawDuration))
 	stakedAmount := new(big.Int).SetUint64(rawStakedAmount)
-	maxExistingAmount := new(big.Int).SetUint64(rawMaxExistingAmount)
-	consumptionInterval := new(big.Int).SetUint64(uint64(rawConsumptionInterval))
+	currentSupply := new(big.Int).SetUint64(rawCurrentSupply)
 
-	adjustedConsumptionRateNumerator := new(big.Int).Mul(maxSubMinConsumptionRate, duration)
-	adjustedMinConsumptionRateNumerator := new(big.Int).Mul(minConsumptionRate, consumptionInterval)
+	adjustedConsumptionRateNumerator := new(big.Int).Mul(c.maxSubMinConsumptionRate, duration)
+	adjustedMinConsumptionRateNumerator := new(big.Int).Mul(c.minConsumptionRate, c.mintingPeriod)
 	adjustedConsumptionRateNumerator.Add(adjustedConsumptionRateNumerator, adjustedMinConsumptionRateNumerator)
-	adjustedConsumptionRateDenominator := new(big.Int).Mul(consumptionInterval, consumptionRateDenominator)
+	adjustedConsumptionRateDenominator := new(big.Int).Mul(c.mintingPeriod, consumptionRateDenominator)
 
-	reward := new(big.Int).SetUint64(SupplyCap - rawMaxExistingAmount)
+	remainingSupply := c.supplyCap - rawCurrentSupply
+	reward := new(big.Int).SetUint64(remainingSupply)
 	reward.Mul(reward, adjustedConsumptionRateNumerator)
 	reward.Mul(reward, stakedAmount)
 	reward.Mul(reward, duration)
 	reward.Div(reward, adjustedConsumptionRateDenominator)
-	reward.Div(reward, maxExistingAmount)
-	reward.Div(reward, consumptionInterval)
+	reward.Div(reward, currentSupply)
+	reward.Div(reward, c.mintingPeriod)
 
+	if !reward.IsUint64() || reward.Uint64() > remainingSupply {
+		return remainingSupply
+	}
 	return reward.Uint64()
 }
+
+// Reward returns the amount of tokens to reward the staker with using the
+// reward curve of the primary network.
+func Reward(
+	rawDuration time.Duration,
+	rawStakedAmount,
+	rawMaxExistingAmount uint64,
+	rawConsumptionInterval time.Duration,
+) uint64 {
+	return newConsumptionRewardCalculator(DefaultRewardConfig(rawConsumptionInterval)).Calculate(
+		rawDuration,
+		rawStakedAmount,
+		rawMaxExistingAmount,
+	)
+}
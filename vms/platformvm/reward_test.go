@@ -5,6 +5,8 @@ package platformvm
 
 import (
 	"fmt"
+	"math"
+	"sort"
 	"testing"
 	"time"
 
@@ -119,3 +121,132 @@ func TestRewards(t *testing.T) {
 		})
 	}
 }
+
+// testRewardCalculatorInvariants checks the properties every reward curve must
+// have: the reward never pushes the supply past [supplyCap], nothing is
+// rewarded if there is no supply or the supply is already at or past
+// [supplyCap], and the reward doesn't decrease as the staked duration or amount
+// increase.
+func testRewardCalculatorInvariants(t *testing.T, calculator RewardCalculator, supplyCap uint64, maxDuration time.Duration) {
+	durations := []time.Duration{
+		0,
+		time.Second,
+		time.Hour,
+		24 * time.Hour,
+		maxDuration / 2,
+		maxDuration,
+		maxDuration + 1,
+		2 * maxDuration,
+		time.Duration(math.MaxInt64),
+	}
+	sort.Slice(durations, func(i, j int) bool { return durations[i] < durations[j] })
+	supplies := []uint64{0, 1, units.Avax, units.MegaAvax, supplyCap / 2, supplyCap - 1, supplyCap, supplyCap + 1, math.MaxUint64}
+	for _, supply := range supplies {
+		amounts := []uint64{0, 1, units.Avax, supply / 2, supply}
+		sort.Slice(amounts, func(i, j int) bool { return amounts[i] < amounts[j] })
+		for _, amount := range amounts {
+			lastReward := uint64(0)
+			for _, duration := range durations {
+				reward := calculator.Calculate(duration, amount, supply)
+				if (supply == 0 || supply >= supplyCap) && reward != 0 {
+					t.Fatalf("reward(%s,%d,%d)=%d should be 0 when the supply is 0 or at least the supply cap", duration, amount, supply, reward)
+				}
+				if supply < supplyCap && reward > supplyCap-supply {
+					t.Fatalf("reward(%s,%d,%d)=%d exceeds the supply cap", duration, amount, supply, reward)
+				}
+				if reward < lastReward {
+					t.Fatalf("reward(%s,%d,%d)=%d decreased as duration increased", duration, amount, supply, reward)
+				}
+				lastReward = reward
+			}
+		}
+		for _, duration := range durations {
+			lastReward := uint64(0)
+			for _, amount := range amounts {
+				reward := calculator.Calculate(duration, amount, supply)
+				if reward < lastReward {
+					t.Fatalf("reward(%s,%d,%d)=%d decreased as amount increased", duration, amount, supply, reward)
+				}
+				lastReward = reward
+			}
+		}
+	}
+}
+
+func TestRewardCalculatorInvariants(t *testing.T) {
+	configs := map[string]RewardConfig{
+		"default": DefaultRewardConfig(defaultMaxStakingDuration),
+		"flat": {
+			MaxConsumptionRate: 50000,
+			MinConsumptionRate: 50000,
+			MintingPeriod:      defaultMaxStakingDuration,
+			SupplyCap:          100 * units.MegaAvax,
+		},
+		"aggressive": {
+			MaxConsumptionRate: PercentDenominator,
+			MinConsumptionRate: 0,
+			MintingPeriod:      24 * time.Hour,
+			SupplyCap:          units.MegaAvax,
+		},
+	}
+	for name, config := range configs {
+		config := config
+		t.Run(name, func(t *testing.T) {
+			calculator, err := NewRewardCalculator(config)
+			if err != nil {
+				t.Fatal(err)
+			}
+			testRewardCalculatorInvariants(t, calculator, config.SupplyCap, config.MintingPeriod)
+		})
+	}
+}
+
+func TestNewRewardCalculatorInvalidConfig(t *testing.T) {
+	tests := map[string]struct {
+		config RewardConfig
+		err    error
+	}{
+		"max less than min": {
+			config: RewardConfig{
+				MaxConsumptionRate: 100000,
+				MinConsumptionRate: 120000,
+				MintingPeriod:      defaultMaxStakingDuration,
+				SupplyCap:          SupplyCap,
+			},
+			err: errMaxConsumptionRateTooSmall,
+		},
+		"max too large": {
+			config: RewardConfig{
+				MaxConsumptionRate: PercentDenominator + 1,
+				MinConsumptionRate: 100000,
+				MintingPeriod:      defaultMaxStakingDuration,
+				SupplyCap:          SupplyCap,
+			},
+			err: errMaxConsumptionRateTooLarge,
+		},
+		"no minting period": {
+			config: RewardConfig{
+				MaxConsumptionRate: 120000,
+				MinConsumptionRate: 100000,
+				SupplyCap:          SupplyCap,
+			},
+			err: errNoMintingPeriod,
+		},
+		"no supply cap": {
+			config: RewardConfig{
+				MaxConsumptionRate: 120000,
+				MinConsumptionRate: 100000,
+				MintingPeriod:      defaultMaxStakingDuration,
+			},
+			err: errNoSupplyCap,
+		},
+	}
+	for name, test := range tests {
+		test := test
+		t.Run(name, func(t *testing.T) {
+			if _, err := NewRewardCalculator(test.config); err != test.err {
+				t.Fatalf("expected %v but got %v", test.err, err)
+			}
+		})
+	}
+}
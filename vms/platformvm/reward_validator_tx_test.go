@@ -128,8 +128,8 @@ func TestRewardDelegatorTxSemanticVerify(t *testing.T) {
 		vm.minValidatorStake, // stakeAmt
 		vdrStartTime,
 		vdrEndTime,
-		vdrNodeID,        // node ID
-		vdrRewardAddress, // reward address
+		ids.NodeID(vdrNodeID), // node ID
+		vdrRewardAddress,      // reward address
 		PercentDenominator/4,
 		[]*crypto.PrivateKeySECP256K1R{keys[0]}, // fee payer
 		ids.ShortEmpty,                          // change addr
@@ -144,7 +144,7 @@ func TestRewardDelegatorTxSemanticVerify(t *testing.T) {
 		vm.minDelegatorStake, // stakeAmt
 		delStartTime,
 		delEndTime,
-		vdrNodeID,                               // node ID
+		ids.NodeID(vdrNodeID),                   // node ID
 		delRewardAddress,                        // reward address
 		[]*crypto.PrivateKeySECP256K1R{keys[0]}, // fee payer
 		ids.ShortEmpty,                          // change addr
@@ -275,9 +275,10 @@ func TestOptimisticUptime(t *testing.T) {
 	firstCtx.Lock.Unlock()
 
 	secondVM := &VM{
-		SnowmanVM:        &core.SnowmanVM{},
-		chainManager:     chains.MockManager{},
-		uptimePercentage: .20,
+		SnowmanVM:          &core.SnowmanVM{},
+		chainManager:       chains.MockManager{},
+		uptimePercentage:   .20,
+		stakeMintingPeriod: defaultMaxStakingDuration,
 	}
 
 	secondVM.vdrMgr = validators.NewManager()
@@ -422,9 +423,10 @@ func TestObservedUptime(t *testing.T) {
 	firstCtx.Lock.Unlock()
 
 	secondVM := &VM{
-		SnowmanVM:        &core.SnowmanVM{},
-		chainManager:     chains.MockManager{},
-		uptimePercentage: .20,
+		SnowmanVM:          &core.SnowmanVM{},
+		chainManager:       chains.MockManager{},
+		uptimePercentage:   .20,
+		stakeMintingPeriod: defaultMaxStakingDuration,
 	}
 
 	secondVM.vdrMgr = validators.NewManager()
@@ -565,9 +567,10 @@ func TestUptimeDisallowed(t *testing.T) {
 	firstCtx.Lock.Unlock()
 
 	secondVM := &VM{
-		SnowmanVM:        &core.SnowmanVM{},
-		chainManager:     chains.MockManager{},
-		uptimePercentage: .21,
+		SnowmanVM:          &core.SnowmanVM{},
+		chainManager:       chains.MockManager{},
+		uptimePercentage:   .21,
+		stakeMintingPeriod: defaultMaxStakingDuration,
 	}
 
 	secondVM.vdrMgr = validators.NewManager()
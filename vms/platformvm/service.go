@@ -11,8 +11,10 @@ import (
 	"time"
 
 	"github.com/ava-labs/avalanchego/api"
+	"github.com/ava-labs/avalanchego/database"
 	"github.com/ava-labs/avalanchego/database/prefixdb"
 	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/snow/validators"
 	"github.com/ava-labs/avalanchego/utils/constants"
 	"github.com/ava-labs/avalanchego/utils/crypto"
 	"github.com/ava-labs/avalanchego/utils/formatting"
@@ -575,6 +577,33 @@ func (service *Service) GetSubnets(_ *http.Request, args *GetSubnetsArgs, respon
 	return nil
 }
 
+// GetSubnetInfoArgs are the arguments to GetSubnetInfo
+type GetSubnetInfoArgs struct {
+	SubnetID ids.ID `json:"subnetID"`
+}
+
+// GetSubnetInfo returns the info recorded for the subnet [args.SubnetID]
+func (service *Service) GetSubnetInfo(_ *http.Request, args *GetSubnetInfoArgs, response *SubnetInfo) error {
+	service.vm.Ctx.Log.Info("Platform: GetSubnetInfo called")
+	if _, err := service.vm.getSubnet(service.vm.DB, args.SubnetID); err != nil {
+		return fmt.Errorf("couldn't get subnet %s: %w", args.SubnetID, err)
+	}
+
+	info, err := service.vm.getSubnetInfo(service.vm.DB, args.SubnetID)
+	switch err {
+	case nil:
+		*response = *info
+	case database.ErrNotFound:
+		// The subnet's info was never set
+	default:
+		return fmt.Errorf("couldn't get info of subnet %s: %w", args.SubnetID, err)
+	}
+	if response.GenesisHashes == nil {
+		response.GenesisHashes = []ids.ID{}
+	}
+	return nil
+}
+
 // GetStakingAssetIDArgs are the arguments to GetStakingAssetID
 type GetStakingAssetIDArgs struct {
 	SubnetID ids.ID `json:"subnetID"`
@@ -672,7 +701,7 @@ func (service *Service) GetCurrentValidators(_ *http.Request, args *GetCurrentVa
 					StartTime:   json.Uint64(staker.StartTime().Unix()),
 					EndTime:     json.Uint64(staker.EndTime().Unix()),
 					StakeAmount: &weight,
-					NodeID:      staker.Validator.ID().PrefixedString(constants.NodeIDPrefix),
+					NodeID:      ids.NodeID(staker.Validator.ID()).String(),
 				},
 				RewardOwner:     rewardOwner,
 				PotentialReward: &potentialReward,
@@ -711,7 +740,7 @@ func (service *Service) GetCurrentValidators(_ *http.Request, args *GetCurrentVa
 			reply.Validators = append(reply.Validators, APIPrimaryValidator{
 				APIStaker: APIStaker{
 					TxID:        tx.Tx.ID(),
-					NodeID:      nodeID.PrefixedString(constants.NodeIDPrefix),
+					NodeID:      ids.NodeID(nodeID).String(),
 					StartTime:   json.Uint64(startTime.Unix()),
 					EndTime:     json.Uint64(staker.EndTime().Unix()),
 					StakeAmount: &weight,
@@ -726,7 +755,7 @@ func (service *Service) GetCurrentValidators(_ *http.Request, args *GetCurrentVa
 			weight := json.Uint64(staker.Validator.Weight())
 			reply.Validators = append(reply.Validators, APIStaker{
 				TxID:      tx.Tx.ID(),
-				NodeID:    staker.Validator.ID().PrefixedString(constants.NodeIDPrefix),
+				NodeID:    ids.NodeID(staker.Validator.ID()).String(),
 				StartTime: json.Uint64(staker.StartTime().Unix()),
 				EndTime:   json.Uint64(staker.EndTime().Unix()),
 				Weight:    &weight,
@@ -797,7 +826,7 @@ func (service *Service) GetPendingValidators(_ *http.Request, args *GetPendingVa
 			weight := json.Uint64(staker.Validator.Weight())
 			reply.Delegators = append(reply.Delegators, APIStaker{
 				TxID:        tx.ID(),
-				NodeID:      staker.Validator.ID().PrefixedString(constants.NodeIDPrefix),
+				NodeID:      ids.NodeID(staker.Validator.ID()).String(),
 				StartTime:   json.Uint64(staker.StartTime().Unix()),
 				EndTime:     json.Uint64(staker.EndTime().Unix()),
 				StakeAmount: &weight,
@@ -811,7 +840,7 @@ func (service *Service) GetPendingValidators(_ *http.Request, args *GetPendingVa
 			reply.Validators = append(reply.Validators, APIPrimaryValidator{
 				APIStaker: APIStaker{
 					TxID:        tx.ID(),
-					NodeID:      staker.Validator.ID().PrefixedString(constants.NodeIDPrefix),
+					NodeID:      ids.NodeID(staker.Validator.ID()).String(),
 					StartTime:   json.Uint64(staker.StartTime().Unix()),
 					EndTime:     json.Uint64(staker.EndTime().Unix()),
 					StakeAmount: &weight,
@@ -823,7 +852,7 @@ func (service *Service) GetPendingValidators(_ *http.Request, args *GetPendingVa
 			weight := json.Uint64(staker.Validator.Weight())
 			reply.Validators = append(reply.Validators, APIStaker{
 				TxID:      tx.ID(),
-				NodeID:    staker.Validator.ID().PrefixedString(constants.NodeIDPrefix),
+				NodeID:    ids.NodeID(staker.Validator.ID()).String(),
 				StartTime: json.Uint64(staker.StartTime().Unix()),
 				EndTime:   json.Uint64(staker.EndTime().Unix()),
 				Weight:    &weight,
@@ -847,6 +876,112 @@ func (service *Service) GetCurrentSupply(_ *http.Request, _ *struct{}, reply *Ge
 	return err
 }
 
+// GetBurnedFeesReply are the results from calling GetBurnedFees
+type GetBurnedFeesReply struct {
+	BurnedFees json.Uint64 `json:"burnedFees"`
+}
+
+// GetBurnedFees returns the total amount of AVAX burned as fees by
+// transactions accepted on the P-Chain
+func (service *Service) GetBurnedFees(_ *http.Request, _ *struct{}, reply *GetBurnedFeesReply) error {
+	burnedFees, err := service.vm.getBurnedFees(service.vm.DB)
+	reply.BurnedFees = json.Uint64(burnedFees)
+	return err
+}
+
+// GetStateHashArgs are the arguments for calling GetStateHash
+type GetStateHashArgs struct {
+	// Block whose resulting state is hashed. If empty, the last accepted block
+	// is used.
+	BlockID ids.ID `json:"blockID"`
+}
+
+// GetStateHashReply are the results from calling GetStateHash
+type GetStateHashReply struct {
+	BlockID   ids.ID      `json:"blockID"`
+	Height    json.Uint64 `json:"height"`
+	StateHash ids.ID      `json:"stateHash"`
+}
+
+// GetStateHash returns a commitment to the stakers, UTXOs, subnets, chains,
+// and supply of the P-Chain after an accepted block. Nodes that accepted the
+// same block should return the same state hash.
+func (service *Service) GetStateHash(_ *http.Request, args *GetStateHashArgs, reply *GetStateHashReply) error {
+	service.vm.SnowmanVM.Ctx.Log.Info("Platform: GetStateHash called")
+
+	blkID := args.BlockID
+	if blkID == ids.Empty {
+		blkID = service.vm.LastAccepted()
+	}
+	blk, err := service.vm.getBlock(blkID)
+	if err != nil {
+		return fmt.Errorf("couldn't get block %s: %w", blkID, err)
+	}
+	stateHash, err := service.vm.getBlockStateHash(service.vm.DB, blkID)
+	if err == errNoBlockStateHash {
+		// Distinguish a database that doesn't track the state hash at all
+		if _, err := service.vm.getStateHash(service.vm.DB); err != nil {
+			return err
+		}
+		return fmt.Errorf("%w: %s", errNoBlockStateHash, blkID)
+	} else if err != nil {
+		return err
+	}
+
+	reply.BlockID = blkID
+	reply.Height = json.Uint64(blk.Height())
+	reply.StateHash = stateHash
+	return nil
+}
+
+// GetValidatorSetHashArgs are the arguments for calling GetValidatorSetHash
+type GetValidatorSetHashArgs struct {
+	// ID of the subnet whose validator set is hashed. If empty, the Primary
+	// Network is used.
+	SubnetID ids.ID `json:"subnetID"`
+}
+
+// GetValidatorSetHashReply are the results from calling GetValidatorSetHash
+type GetValidatorSetHashReply struct {
+	// Height of the last accepted block, at which the validator set was taken
+	Height        json.Uint64 `json:"height"`
+	NumValidators json.Uint32 `json:"numValidators"`
+	TotalWeight   json.Uint64 `json:"totalWeight"`
+	// Hash of the canonical serialization of the validator set
+	Hash ids.ID `json:"hash"`
+}
+
+// GetValidatorSetHash returns the hash of the current validator set of a
+// subnet, so that nodes can cheaply check that they agree on it
+func (service *Service) GetValidatorSetHash(_ *http.Request, args *GetValidatorSetHashArgs, reply *GetValidatorSetHashReply) error {
+	service.vm.SnowmanVM.Ctx.Log.Info("Platform: GetValidatorSetHash called")
+
+	subnetID := args.SubnetID
+	if subnetID == ids.Empty {
+		subnetID = constants.PrimaryNetworkID
+	}
+	vdrs, ok := service.vm.vdrMgr.GetValidators(subnetID)
+	if !ok {
+		return fmt.Errorf("couldn't get the validators of subnet %s", subnetID)
+	}
+	lastAccepted, err := service.vm.getBlock(service.vm.LastAccepted())
+	if err != nil {
+		return fmt.Errorf("couldn't get the last accepted block: %w", err)
+	}
+
+	// Only the validators that are hashed are counted
+	vdrList := validators.Canonical(vdrs.List())
+	hash, err := validators.CanonicalHash(vdrList)
+	if err != nil {
+		return fmt.Errorf("couldn't hash the validator set: %w", err)
+	}
+	reply.Height = json.Uint64(lastAccepted.Height())
+	reply.NumValidators = json.Uint32(len(vdrList))
+	reply.TotalWeight = json.Uint64(vdrs.Weight())
+	reply.Hash = hash
+	return nil
+}
+
 // SampleValidatorsArgs are the arguments for calling SampleValidators
 type SampleValidatorsArgs struct {
 	// Number of validators in the sample
@@ -884,7 +1019,7 @@ func (service *Service) SampleValidators(_ *http.Request, args *SampleValidators
 
 	reply.Validators = make([]string, int(args.Size))
 	for i, vdrID := range validatorIDs {
-		reply.Validators[i] = vdrID.PrefixedString(constants.NodeIDPrefix)
+		reply.Validators[i] = ids.NodeID(vdrID).String()
 	}
 	return nil
 }
@@ -921,11 +1056,11 @@ func (service *Service) AddValidator(_ *http.Request, args *AddValidatorArgs, re
 	}
 
 	// Parse the node ID
-	var nodeID ids.ShortID
+	var nodeID ids.NodeID
 	if args.NodeID == "" {
-		nodeID = service.vm.Ctx.NodeID // If omitted, use this node's ID
+		nodeID = ids.NodeID(service.vm.Ctx.NodeID) // If omitted, use this node's ID
 	} else {
-		nID, err := ids.ShortFromPrefixedString(args.NodeID, constants.NodeIDPrefix)
+		nID, err := ids.NodeIDFromString(args.NodeID)
 		if err != nil {
 			return err
 		}
@@ -1037,11 +1172,11 @@ func (service *Service) AddDelegator(_ *http.Request, args *AddDelegatorArgs, re
 	}
 
 	// Parse the node ID
-	var nodeID ids.ShortID
+	var nodeID ids.NodeID
 	if args.NodeID == "" { // If ID unspecified, use this node's ID
-		nodeID = service.vm.Ctx.NodeID
+		nodeID = ids.NodeID(service.vm.Ctx.NodeID)
 	} else {
-		nID, err := ids.ShortFromPrefixedString(args.NodeID, constants.NodeIDPrefix)
+		nID, err := ids.NodeIDFromString(args.NodeID)
 		if err != nil {
 			return err
 		}
@@ -1154,7 +1289,7 @@ func (service *Service) AddSubnetValidator(_ *http.Request, args *AddSubnetValid
 	}
 
 	// Parse the node ID
-	nodeID, err := ids.ShortFromPrefixedString(args.NodeID, constants.NodeIDPrefix)
+	nodeID, err := ids.NodeIDFromString(args.NodeID)
 	if err != nil {
 		return fmt.Errorf("error parsing nodeID: %q: %w", args.NodeID, err)
 	}
@@ -1682,6 +1817,98 @@ func (service *Service) CreateBlockchain(_ *http.Request, args *CreateBlockchain
 	return errs.Err
 }
 
+// SetSubnetInfoArgs are the arguments for calling SetSubnetInfo
+type SetSubnetInfoArgs struct {
+	// User, password, from addrs, change addr
+	api.JSONSpendHeader
+	// ID of the subnet whose info is set
+	SubnetID ids.ID `json:"subnetID"`
+	// The subnet's new info
+	SubnetInfo
+}
+
+// SetSubnetInfo issues a transaction to set the info of a subnet. The user
+// must control enough of the subnet's control keys to sign the transaction.
+func (service *Service) SetSubnetInfo(_ *http.Request, args *SetSubnetInfoArgs, response *api.JSONTxIDChangeAddr) error {
+	service.vm.Ctx.Log.Info("Platform: SetSubnetInfo called")
+	if args.SubnetID == constants.PrimaryNetworkID {
+		return errPrimaryNetworkSubnetInfo
+	}
+
+	// Get the keys controlled by the user
+	db, err := service.vm.Ctx.Keystore.GetDatabase(args.Username, args.Password)
+	if err != nil {
+		return fmt.Errorf("problem retrieving user %q: %w", args.Username, err)
+	}
+
+	// Drop any potential error closing the database to report the original
+	// error
+	defer db.Close()
+
+	user := user{db: db}
+	keys, err := user.getKeys()
+	if err != nil {
+		return fmt.Errorf("couldn't get addresses controlled by the user: %w", err)
+	}
+
+	// Parse the change address. Assumes that if the user has no keys,
+	// this operation will fail so the change address can be anything.
+	if len(keys) == 0 {
+		return errNoKeys
+	}
+	changeAddr := keys[0].PublicKey().Address() // By default, use a key controlled by the user
+	if args.ChangeAddr != "" {
+		changeAddr, err = service.vm.ParseLocalAddress(args.ChangeAddr)
+		if err != nil {
+			return fmt.Errorf("couldn't parse changeAddr: %w", err)
+		}
+	}
+
+	// Parse the from addresses
+	fromAddrs := ids.ShortSet{}
+	for _, addrStr := range args.From {
+		addr, err := service.vm.ParseLocalAddress(addrStr)
+		if err != nil {
+			return fmt.Errorf("couldn't parse 'from' address %s: %w", addrStr, err)
+		}
+		fromAddrs.Add(addr)
+	}
+
+	// If fromAddrs given, only use those addrs to pay fee
+	filteredPrivKeys := []*crypto.PrivateKeySECP256K1R{}
+	if fromAddrs.Len() == 0 {
+		filteredPrivKeys = keys
+	} else {
+		for _, key := range keys {
+			if fromAddrs.Contains(key.PublicKey().Address()) {
+				filteredPrivKeys = append(filteredPrivKeys, key)
+			}
+		}
+	}
+
+	// Create the transaction
+	tx, err := service.vm.newSetSubnetInfoTx(
+		args.SubnetID,
+		args.SubnetInfo,
+		filteredPrivKeys,
+		changeAddr, // Change address
+	)
+	if err != nil {
+		return fmt.Errorf("couldn't create tx: %w", err)
+	}
+
+	response.TxID = tx.ID()
+	response.ChangeAddr, err = service.vm.FormatLocalAddress(changeAddr)
+
+	errs := wrappers.Errs{}
+	errs.Add(
+		err,
+		service.vm.mempool.IssueTx(tx),
+		db.Close(),
+	)
+	return errs.Err
+}
+
 // GetBlockchainStatusArgs is the arguments for calling GetBlockchainStatus
 // [BlockchainID] is the ID of or an alias of the blockchain to get the status of.
 type GetBlockchainStatusArgs struct {
@@ -2107,6 +2334,40 @@ func (service *Service) GetMinStake(_ *http.Request, _ *struct{}, reply *GetMinS
 	return nil
 }
 
+// GetStakingParametersReply is the response from calling GetStakingParameters.
+type GetStakingParametersReply struct {
+	// The minimum amount of tokens one must bond to be a validator
+	MinValidatorStake json.Uint64 `json:"minValidatorStake"`
+	// The maximum amount of tokens a validator can have bonded, including
+	// delegations
+	MaxValidatorStake json.Uint64 `json:"maxValidatorStake"`
+	// Minimum stake, in nAVAX, that can be delegated
+	MinDelegatorStake json.Uint64 `json:"minDelegatorStake"`
+	// Minimum delegation fee a validator can charge, in parts per
+	// [PercentDenominator]
+	MinDelegationFee json.Uint32 `json:"minDelegationFee"`
+	// Minimum and maximum staking durations, in seconds
+	MinStakeDuration json.Uint64 `json:"minStakeDuration"`
+	MaxStakeDuration json.Uint64 `json:"maxStakeDuration"`
+	// Minimum uptime, in [0, 1], required to be rewarded for staking
+	UptimeRequirement json.Float32 `json:"uptimeRequirement"`
+}
+
+// GetStakingParameters returns the staking parameters of the Primary Network.
+// Subnets are permissioned, so they don't have staking parameters. The
+// parameters are set when the node starts, so they have no history.
+func (service *Service) GetStakingParameters(_ *http.Request, _ *struct{}, reply *GetStakingParametersReply) error {
+	vm := service.vm
+	reply.MinValidatorStake = json.Uint64(vm.minValidatorStake)
+	reply.MaxValidatorStake = json.Uint64(vm.maxValidatorStake)
+	reply.MinDelegatorStake = json.Uint64(vm.minDelegatorStake)
+	reply.MinDelegationFee = json.Uint32(vm.minDelegationFee)
+	reply.MinStakeDuration = json.Uint64(vm.minStakeDuration / time.Second)
+	reply.MaxStakeDuration = json.Uint64(vm.maxStakeDuration / time.Second)
+	reply.UptimeRequirement = json.Float32(vm.uptimePercentage)
+	return nil
+}
+
 // GetTotalStake returns the total amount staked on the Primary Network
 func (service *Service) GetTotalStake(_ *http.Request, _ *struct{}, reply *GetStakeReply) error {
 	stake, err := service.vm.getTotalStake()
@@ -2130,14 +2391,14 @@ type GetMaxStakeAmountReply struct {
 // GetMaxStakeAmount returns the maximum amount of nAVAX staking to the named
 // node during the time period.
 func (service *Service) GetMaxStakeAmount(_ *http.Request, args *GetMaxStakeAmountArgs, reply *GetMaxStakeAmountReply) error {
-	nodeID, err := ids.ShortFromPrefixedString(args.NodeID, constants.NodeIDPrefix)
+	nodeID, err := ids.NodeIDFromString(args.NodeID)
 	if err != nil {
 		return fmt.Errorf("failed to parse nodeID %q due to: %w", args.NodeID, err)
 	}
 	startTime := time.Unix(int64(args.StartTime), 0)
 	endTime := time.Unix(int64(args.EndTime), 0)
 
-	amount, err := service.vm.maxStakeAmount(service.vm.DB, args.SubnetID, nodeID, startTime, endTime)
+	amount, err := service.vm.maxStakeAmount(service.vm.DB, args.SubnetID, nodeID.ShortID(), startTime, endTime)
 	reply.Amount = json.Uint64(amount)
 	return err
 }
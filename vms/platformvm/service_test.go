@@ -10,10 +10,12 @@ import (
 
 	"strings"
 	"testing"
+	"time"
 
 	"github.com/ava-labs/avalanchego/api"
 	"github.com/ava-labs/avalanchego/api/keystore"
 	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/snow/validators"
 	"github.com/ava-labs/avalanchego/utils/constants"
 	"github.com/ava-labs/avalanchego/utils/crypto"
 	"github.com/ava-labs/avalanchego/utils/formatting"
@@ -316,7 +318,7 @@ func TestGetTx(t *testing.T) {
 					service.vm.minValidatorStake,
 					uint64(service.vm.clock.Time().Add(syncBound).Unix()),
 					uint64(service.vm.clock.Time().Add(syncBound).Add(defaultMinStakingDuration).Unix()),
-					ids.GenerateTestShortID(),
+					ids.NodeID(ids.GenerateTestShortID()),
 					ids.GenerateTestShortID(),
 					0,
 					[]*crypto.PrivateKeySECP256K1R{keys[0]},
@@ -476,7 +478,7 @@ func TestGetStake(t *testing.T) {
 		stakeAmt,
 		uint64(defaultGenesisTime.Unix()),
 		uint64(defaultGenesisTime.Add(defaultMinStakingDuration).Unix()),
-		ids.GenerateTestShortID(),
+		ids.NodeID(ids.GenerateTestShortID()),
 		ids.GenerateTestShortID(),
 		[]*crypto.PrivateKeySECP256K1R{keys[0]},
 		keys[0].PublicKey().Address(), // change addr
@@ -507,7 +509,7 @@ func TestGetStake(t *testing.T) {
 		stakeAmt,
 		uint64(defaultGenesisTime.Unix()),
 		uint64(defaultGenesisTime.Add(defaultMinStakingDuration).Unix()),
-		ids.GenerateTestShortID(),
+		ids.NodeID(ids.GenerateTestShortID()),
 		ids.GenerateTestShortID(),
 		0,
 		[]*crypto.PrivateKeySECP256K1R{keys[0]},
@@ -535,7 +537,7 @@ func TestGetStake(t *testing.T) {
 		stakeAmt,
 		uint64(defaultGenesisTime.Unix()),
 		uint64(defaultGenesisTime.Add(defaultMinStakingDuration).Unix()),
-		ids.GenerateTestShortID(),
+		ids.NodeID(ids.GenerateTestShortID()),
 		ids.GenerateTestShortID(),
 		0,
 		[]*crypto.PrivateKeySECP256K1R{keys[0]},
@@ -645,7 +647,7 @@ func TestGetCurrentValidators(t *testing.T) {
 		stakeAmt,
 		delegatorStartTime,
 		delegatorEndTime,
-		validatorNodeID,
+		ids.NodeID(validatorNodeID),
 		ids.GenerateTestShortID(),
 		[]*crypto.PrivateKeySECP256K1R{keys[0]},
 		keys[0].PublicKey().Address(), // change addr
@@ -697,3 +699,70 @@ func TestGetCurrentValidators(t *testing.T) {
 		t.Fatalf("didnt find delegator")
 	}
 }
+
+func TestGetStakingParameters(t *testing.T) {
+	service := defaultService(t)
+	defer func() {
+		service.vm.Ctx.Lock.Lock()
+		if err := service.vm.Shutdown(); err != nil {
+			t.Fatal(err)
+		}
+		service.vm.Ctx.Lock.Unlock()
+	}()
+
+	reply := GetStakingParametersReply{}
+	if err := service.GetStakingParameters(nil, nil, &reply); err != nil {
+		t.Fatal(err)
+	}
+	switch {
+	case uint64(reply.MinValidatorStake) != defaultMinValidatorStake:
+		t.Fatalf("expected min validator stake %d but got %d", defaultMinValidatorStake, reply.MinValidatorStake)
+	case uint64(reply.MaxValidatorStake) != defaultMaxValidatorStake:
+		t.Fatalf("expected max validator stake %d but got %d", defaultMaxValidatorStake, reply.MaxValidatorStake)
+	case uint64(reply.MinDelegatorStake) != defaultMinDelegatorStake:
+		t.Fatalf("expected min delegator stake %d but got %d", defaultMinDelegatorStake, reply.MinDelegatorStake)
+	case uint64(reply.MinStakeDuration) != uint64(defaultMinStakingDuration/time.Second):
+		t.Fatalf("expected min stake duration %s but got %d seconds", defaultMinStakingDuration, reply.MinStakeDuration)
+	case uint64(reply.MaxStakeDuration) != uint64(defaultMaxStakingDuration/time.Second):
+		t.Fatalf("expected max stake duration %s but got %d seconds", defaultMaxStakingDuration, reply.MaxStakeDuration)
+	}
+}
+
+func TestGetValidatorSetHash(t *testing.T) {
+	service := defaultService(t)
+	defer func() {
+		service.vm.Ctx.Lock.Lock()
+		if err := service.vm.Shutdown(); err != nil {
+			t.Fatal(err)
+		}
+		service.vm.Ctx.Lock.Unlock()
+	}()
+
+	reply := GetValidatorSetHashReply{}
+	if err := service.GetValidatorSetHash(nil, &GetValidatorSetHashArgs{}, &reply); err != nil {
+		t.Fatal(err)
+	}
+
+	vdrs, ok := service.vm.vdrMgr.GetValidators(constants.PrimaryNetworkID)
+	if !ok {
+		t.Fatalf("expected the primary network to have validators")
+	}
+	expectedHash, err := validators.CanonicalHash(vdrs.List())
+	if err != nil {
+		t.Fatal(err)
+	}
+	switch {
+	case reply.Hash != expectedHash:
+		t.Fatalf("expected hash %s but got %s", expectedHash, reply.Hash)
+	case int(reply.NumValidators) != len(keys):
+		t.Fatalf("expected %d validators but got %d", len(keys), reply.NumValidators)
+	case uint64(reply.TotalWeight) != vdrs.Weight():
+		t.Fatalf("expected total weight %d but got %d", vdrs.Weight(), reply.TotalWeight)
+	case reply.Height != 1: // The test subnet was created in the block after genesis
+		t.Fatalf("expected height 1 but got %d", reply.Height)
+	}
+
+	if err := service.GetValidatorSetHash(nil, &GetValidatorSetHashArgs{SubnetID: ids.GenerateTestID()}, &reply); err == nil {
+		t.Fatalf("should have errored due to an unknown subnet")
+	}
+}
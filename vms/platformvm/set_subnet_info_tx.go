@@ -0,0 +1,231 @@
+// (c) 2019-2020, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package platformvm
+
+import (
+	"errors"
+	"fmt"
+	"unicode"
+	"unicode/utf8"
+
+	"github.com/ava-labs/avalanchego/database"
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/snow"
+	"github.com/ava-labs/avalanchego/utils/codec"
+	"github.com/ava-labs/avalanchego/utils/constants"
+	"github.com/ava-labs/avalanchego/utils/crypto"
+	"github.com/ava-labs/avalanchego/vms/components/avax"
+	"github.com/ava-labs/avalanchego/vms/components/verify"
+)
+
+var (
+	errHomepageTooLong          = errors.New("homepage too long")
+	errContactTooLong           = errors.New("contact too long")
+	errIllegalInfoCharacter     = errors.New("homepage and contact must be printable UTF-8")
+	errTooManyGenesisHashes     = errors.New("too many genesis hashes")
+	errGenesisHashesNotSorted   = errors.New("genesis hashes must be sorted and unique")
+	errPrimaryNetworkSubnetInfo = errors.New("the primary network's info can't be set")
+	errSubnetInfoNotActive      = errors.New("subnet info can't be set before the metadata upgrade activates")
+
+	_ UnsignedDecisionTx = &UnsignedSetSubnetInfoTx{}
+)
+
+const (
+	maxHomepageLen      = 1 << 8
+	maxContactLen       = 1 << 8
+	maxGenesisHashesLen = 1 << 5
+)
+
+// SubnetInfo is a human readable description of a subnet, recorded on chain
+// by the subnet's owners
+type SubnetInfo struct {
+	// Name of the subnet; need not be unique
+	Name string `serialize:"true" json:"name"`
+	// URL of the subnet's homepage
+	Homepage string `serialize:"true" json:"homepage"`
+	// How to contact the subnet's operators
+	Contact string `serialize:"true" json:"contact"`
+	// Hashes of the genesis data of the chains the subnet's owners intend to
+	// create, so that anyone can check a chain's genesis against them
+	GenesisHashes []ids.ID `serialize:"true" json:"genesisHashes"`
+}
+
+// Verify returns nil iff [info] is well-formed
+func (info *SubnetInfo) Verify() error {
+	switch {
+	case len(info.Name) > maxNameLen:
+		return errNameTooLong
+	case len(info.Homepage) > maxHomepageLen:
+		return errHomepageTooLong
+	case len(info.Contact) > maxContactLen:
+		return errContactTooLong
+	case len(info.GenesisHashes) > maxGenesisHashesLen:
+		return errTooManyGenesisHashes
+	case !ids.IsSortedAndUniqueIDs(info.GenesisHashes):
+		return errGenesisHashesNotSorted
+	}
+
+	for _, r := range info.Name {
+		if r > unicode.MaxASCII || !(unicode.IsLetter(r) || unicode.IsNumber(r) || r == ' ') {
+			return errIllegalNameCharacter
+		}
+	}
+	for _, s := range []string{info.Homepage, info.Contact} {
+		if !utf8.ValidString(s) {
+			return errIllegalInfoCharacter
+		}
+		for _, r := range s {
+			if !unicode.IsPrint(r) {
+				return errIllegalInfoCharacter
+			}
+		}
+	}
+	return nil
+}
+
+// UnsignedSetSubnetInfoTx is an unsigned transaction that sets the info of a
+// subnet, replacing any info it had
+type UnsignedSetSubnetInfoTx struct {
+	// Metadata, inputs and outputs
+	BaseTx `serialize:"true"`
+	// ID of the subnet whose info is set
+	SubnetID ids.ID `serialize:"true" json:"subnetID"`
+	// The subnet's new info
+	Info SubnetInfo `serialize:"true" json:"info"`
+	// Auth that will be allowing the subnet's info to be set
+	SubnetAuth verify.Verifiable `serialize:"true" json:"subnetAuthorization"`
+}
+
+// Verify this transaction is well-formed
+func (tx *UnsignedSetSubnetInfoTx) Verify(
+	ctx *snow.Context,
+	c codec.Manager,
+	feeAmount uint64,
+	feeAssetID ids.ID,
+) error {
+	switch {
+	case tx == nil:
+		return errNilTx
+	case tx.syntacticallyVerified: // already passed syntactic verification
+		return nil
+	case tx.SubnetID == constants.PrimaryNetworkID:
+		return errPrimaryNetworkSubnetInfo
+	}
+
+	if err := tx.Info.Verify(); err != nil {
+		return err
+	}
+	if err := tx.BaseTx.Verify(ctx, c); err != nil {
+		return err
+	}
+	if err := tx.SubnetAuth.Verify(); err != nil {
+		return err
+	}
+
+	tx.syntacticallyVerified = true
+	return nil
+}
+
+// SemanticVerify this transaction is valid.
+func (tx *UnsignedSetSubnetInfoTx) SemanticVerify(
+	vm *VM,
+	db database.Database,
+	stx *Tx,
+) (
+	func() error,
+	TxError,
+) {
+	// Make sure this transaction is well formed.
+	if len(stx.Creds) == 0 {
+		return nil, permError{errWrongNumberOfCredentials}
+	}
+	if err := tx.Verify(vm.Ctx, vm.codec, vm.txFee, vm.Ctx.AVAXAssetID); err != nil {
+		return nil, permError{err}
+	}
+
+	// Nodes that predate the metadata upgrade can't parse this transaction
+	if activated, err := vm.metadataUpgradeActivated(db); err != nil {
+		return nil, tempError{err}
+	} else if !activated {
+		return nil, tempError{errSubnetInfoNotActive}
+	}
+
+	// Select the credentials for each purpose
+	baseTxCredsLen := len(stx.Creds) - 1
+	baseTxCreds := stx.Creds[:baseTxCredsLen]
+	subnetCred := stx.Creds[baseTxCredsLen]
+
+	// Verify that the info is set by the subnet's owners
+	subnet, err := vm.getSubnet(db, tx.SubnetID)
+	if err != nil {
+		return nil, err
+	}
+	unsignedSubnet := subnet.UnsignedTx.(*UnsignedCreateSubnetTx)
+	if err := vm.fx.VerifyPermission(tx, tx.SubnetAuth, subnetCred, unsignedSubnet.Owner); err != nil {
+		return nil, permError{err}
+	}
+
+	// Verify the flowcheck
+	if err := vm.semanticVerifySpend(db, tx, tx.Ins, tx.Outs, baseTxCreds, vm.txFee, vm.Ctx.AVAXAssetID); err != nil {
+		return nil, err
+	}
+
+	txID := tx.ID()
+
+	// Consume the UTXOS
+	if err := vm.consumeInputs(db, tx.Ins); err != nil {
+		return nil, tempError{err}
+	}
+	// Produce the UTXOS
+	if err := vm.produceOutputs(db, txID, tx.Outs); err != nil {
+		return nil, tempError{err}
+	}
+	if err := vm.burnFee(db, vm.txFee); err != nil {
+		return nil, tempError{err}
+	}
+	if err := vm.putSubnetInfo(db, tx.SubnetID, &tx.Info); err != nil {
+		return nil, tempError{err}
+	}
+	return nil, nil
+}
+
+// Create a new transaction
+func (vm *VM) newSetSubnetInfoTx(
+	subnetID ids.ID, // ID of the subnet whose info is set
+	info SubnetInfo, // The subnet's new info
+	keys []*crypto.PrivateKeySECP256K1R, // Keys to sign the tx
+	changeAddr ids.ShortID, // Address to send change to, if there is any
+) (*Tx, error) {
+	ins, outs, _, signers, err := vm.stake(vm.DB, keys, 0, vm.txFee, changeAddr)
+	if err != nil {
+		return nil, fmt.Errorf("couldn't generate tx inputs/outputs: %w", err)
+	}
+
+	subnetAuth, subnetSigners, err := vm.authorize(vm.DB, subnetID, keys)
+	if err != nil {
+		return nil, fmt.Errorf("couldn't authorize tx's subnet restrictions: %w", err)
+	}
+	signers = append(signers, subnetSigners)
+
+	// Sort the provided genesis hashes
+	ids.SortIDs(info.GenesisHashes)
+
+	// Create the tx
+	utx := &UnsignedSetSubnetInfoTx{
+		BaseTx: BaseTx{BaseTx: avax.BaseTx{
+			NetworkID:    vm.Ctx.NetworkID,
+			BlockchainID: vm.Ctx.ChainID,
+			Ins:          ins,
+			Outs:         outs,
+		}},
+		SubnetID:   subnetID,
+		Info:       info,
+		SubnetAuth: subnetAuth,
+	}
+	tx := &Tx{UnsignedTx: utx}
+	if err := tx.Sign(vm.codec, signers); err != nil {
+		return nil, err
+	}
+	return tx, utx.Verify(vm.Ctx, vm.codec, vm.txFee, vm.Ctx.AVAXAssetID)
+}
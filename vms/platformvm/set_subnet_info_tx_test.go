@@ -0,0 +1,232 @@
+// (c) 2019-2020, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package platformvm
+
+import (
+	"testing"
+	"time"
+
+	"github.com/ava-labs/avalanchego/database"
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/utils/constants"
+	"github.com/ava-labs/avalanchego/utils/crypto"
+	"github.com/ava-labs/avalanchego/version"
+	"github.com/ava-labs/avalanchego/vms/secp256k1fx"
+)
+
+func TestSubnetInfoVerify(t *testing.T) {
+	type test struct {
+		description string
+		shouldErr   bool
+		info        SubnetInfo
+	}
+
+	hashes := []ids.ID{{1}, {2}}
+	tests := []test{
+		{
+			description: "empty",
+			info:        SubnetInfo{},
+		},
+		{
+			description: "valid",
+			info: SubnetInfo{
+				Name:          "my subnet",
+				Homepage:      "https://example.com/subnet?x=1",
+				Contact:       "ops@example.com",
+				GenesisHashes: hashes,
+			},
+		},
+		{
+			description: "name too long",
+			shouldErr:   true,
+			info:        SubnetInfo{Name: string(make([]byte, maxNameLen+1))},
+		},
+		{
+			description: "name has invalid character",
+			shouldErr:   true,
+			info:        SubnetInfo{Name: "my-subnet"},
+		},
+		{
+			description: "homepage too long",
+			shouldErr:   true,
+			info:        SubnetInfo{Homepage: string(make([]byte, maxHomepageLen+1))},
+		},
+		{
+			description: "contact isn't printable",
+			shouldErr:   true,
+			info:        SubnetInfo{Contact: "ops\n"},
+		},
+		{
+			description: "contact isn't UTF-8",
+			shouldErr:   true,
+			info:        SubnetInfo{Contact: string([]byte{0xff})},
+		},
+		{
+			description: "too many genesis hashes",
+			shouldErr:   true,
+			info:        SubnetInfo{GenesisHashes: make([]ids.ID, maxGenesisHashesLen+1)},
+		},
+		{
+			description: "genesis hashes not sorted",
+			shouldErr:   true,
+			info:        SubnetInfo{GenesisHashes: []ids.ID{hashes[1], hashes[0]}},
+		},
+		{
+			description: "genesis hashes not unique",
+			shouldErr:   true,
+			info:        SubnetInfo{GenesisHashes: []ids.ID{hashes[0], hashes[0]}},
+		},
+	}
+
+	for _, test := range tests {
+		if err := test.info.Verify(); err != nil && !test.shouldErr {
+			t.Fatalf("test '%s' shouldn't have errored but got: %s", test.description, err)
+		} else if err == nil && test.shouldErr {
+			t.Fatalf("test '%s' didn't error but should have", test.description)
+		}
+	}
+}
+
+func TestSetSubnetInfoTx(t *testing.T) {
+	vm, _ := defaultVM()
+	vm.Ctx.Lock.Lock()
+	defer func() {
+		if err := vm.Shutdown(); err != nil {
+			t.Fatal(err)
+		}
+		vm.Ctx.Lock.Unlock()
+	}()
+
+	subnetID := testSubnet1.ID()
+	if _, err := vm.getSubnetInfo(vm.DB, subnetID); err != database.ErrNotFound {
+		t.Fatalf("expected subnet to have no info but got: %v", err)
+	}
+	oldStateHash, err := vm.getStateHash(vm.DB)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	info := SubnetInfo{
+		Name:          "my subnet",
+		Homepage:      "https://example.com",
+		Contact:       "ops@example.com",
+		GenesisHashes: []ids.ID{{2}, {1}},
+	}
+	tx, err := vm.newSetSubnetInfoTx(
+		subnetID,
+		info,
+		[]*crypto.PrivateKeySECP256K1R{testSubnet1ControlKeys[0], testSubnet1ControlKeys[1]},
+		ids.ShortEmpty, // change addr
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := tx.UnsignedTx.(UnsignedDecisionTx).SemanticVerify(vm, vm.DB, tx); err != nil {
+		t.Fatal(err)
+	}
+
+	storedInfo, err := vm.getSubnetInfo(vm.DB, subnetID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	switch {
+	case storedInfo.Name != info.Name:
+		t.Fatalf("expected name %q but got %q", info.Name, storedInfo.Name)
+	case storedInfo.Homepage != info.Homepage:
+		t.Fatalf("expected homepage %q but got %q", info.Homepage, storedInfo.Homepage)
+	case storedInfo.Contact != info.Contact:
+		t.Fatalf("expected contact %q but got %q", info.Contact, storedInfo.Contact)
+	case len(storedInfo.GenesisHashes) != 2 || storedInfo.GenesisHashes[0] != (ids.ID{1}):
+		t.Fatalf("expected sorted genesis hashes but got %v", storedInfo.GenesisHashes)
+	}
+
+	newStateHash, err := vm.getStateHash(vm.DB)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if newStateHash == oldStateHash {
+		t.Fatal("setting the subnet's info should have changed the state hash")
+	}
+}
+
+// Ensure SemanticVerify fails when the subnet's owners didn't sign
+func TestSetSubnetInfoTxWrongControlSig(t *testing.T) {
+	vm, _ := defaultVM()
+	vm.Ctx.Lock.Lock()
+	defer func() {
+		if err := vm.Shutdown(); err != nil {
+			t.Fatal(err)
+		}
+		vm.Ctx.Lock.Unlock()
+	}()
+
+	tx, err := vm.newSetSubnetInfoTx(
+		testSubnet1.ID(),
+		SubnetInfo{Name: "my subnet"},
+		[]*crypto.PrivateKeySECP256K1R{testSubnet1ControlKeys[0], testSubnet1ControlKeys[1]},
+		ids.ShortEmpty, // change addr
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// Remove a signature from the subnet auth
+	subnetCred := tx.Creds[len(tx.Creds)-1].(*secp256k1fx.Credential)
+	subnetCred.Sigs = subnetCred.Sigs[1:]
+	if _, err := tx.UnsignedTx.(UnsignedDecisionTx).SemanticVerify(vm, vm.DB, tx); err == nil {
+		t.Fatal("should have errored because a sig is missing")
+	}
+}
+
+// Ensure SetSubnetInfo txs are rejected before the metadata upgrade activates
+func TestSetSubnetInfoTxBeforeUpgrade(t *testing.T) {
+	vm, _ := defaultVM()
+	vm.Ctx.Lock.Lock()
+	defer func() {
+		if err := vm.Shutdown(); err != nil {
+			t.Fatal(err)
+		}
+		vm.Ctx.Lock.Unlock()
+	}()
+
+	currentTime, err := vm.getTimestamp(vm.DB)
+	if err != nil {
+		t.Fatal(err)
+	}
+	upgradeTime := currentTime.Add(time.Second)
+	vm.Ctx.NetworkUpgrades = version.NetworkUpgrades{
+		version.MetadataUpgrade: upgradeTime,
+	}
+
+	tx, err := vm.newSetSubnetInfoTx(
+		testSubnet1.ID(),
+		SubnetInfo{Name: "my subnet"},
+		[]*crypto.PrivateKeySECP256K1R{testSubnet1ControlKeys[0], testSubnet1ControlKeys[1]},
+		ids.ShortEmpty, // change addr
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := tx.UnsignedTx.(UnsignedDecisionTx).SemanticVerify(vm, vm.DB, tx); err == nil || err.Error() != errSubnetInfoNotActive.Error() {
+		t.Fatalf("expected %s but got %v", errSubnetInfoNotActive, err)
+	}
+	if err := vm.mempool.IssueTx(tx); err != errSubnetInfoNotActive {
+		t.Fatalf("expected %s but got %v", errSubnetInfoNotActive, err)
+	}
+
+	// Once the chain time reaches the activation time, the tx is valid
+	if err := vm.putTimestamp(vm.DB, upgradeTime); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := tx.UnsignedTx.(UnsignedDecisionTx).SemanticVerify(vm, vm.DB, tx); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestSetSubnetInfoTxPrimaryNetwork(t *testing.T) {
+	tx := &UnsignedSetSubnetInfoTx{SubnetID: constants.PrimaryNetworkID}
+	if err := tx.Verify(nil, nil, 0, ids.ID{}); err != errPrimaryNetworkSubnetInfo {
+		t.Fatalf("expected %s but got %v", errPrimaryNetworkSubnetInfo, err)
+	}
+}
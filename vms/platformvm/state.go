@@ -107,7 +107,10 @@ func (vm *VM) enqueueStaker(db database.Database, subnetID ids.ID, stakerTx *Tx)
 	}
 	startKey := p.Bytes
 
-	return prefixStartDB.Put(startKey, txBytes)
+	if err := prefixStartDB.Put(startKey, txBytes); err != nil {
+		return err
+	}
+	return vm.updateStateHash(db, pendingStakerStateElement, stakerStateKey(prefixStart, startKey), nil, txBytes)
 }
 
 // Remove a staker from subnet [subnetID]'s pending validator queue. A staker
@@ -146,7 +149,10 @@ func (vm *VM) dequeueStaker(db database.Database, subnetID ids.ID, stakerTx *Tx)
 	}
 	startKey := p.Bytes
 
-	return prefixStartDB.Delete(startKey)
+	if err := prefixStartDB.Delete(startKey); err != nil {
+		return err
+	}
+	return vm.updateStateHash(db, pendingStakerStateElement, stakerStateKey(prefixStart, startKey), stakerTx.Bytes(), nil)
 }
 
 // Add a staker to subnet [subnetID]
@@ -191,7 +197,10 @@ func (vm *VM) addStaker(db database.Database, subnetID ids.ID, tx *rewardTx) err
 	}
 	stopKey := p.Bytes
 
-	return prefixStopDB.Put(stopKey, txBytes)
+	if err := prefixStopDB.Put(stopKey, txBytes); err != nil {
+		return err
+	}
+	return vm.updateStateHash(db, currentStakerStateElement, stakerStateKey(prefixStop, stopKey), nil, txBytes)
 }
 
 // Remove a staker from subnet [subnetID]
@@ -215,6 +224,11 @@ func (vm *VM) removeStaker(db database.Database, subnetID ids.ID, tx *rewardTx)
 		return fmt.Errorf("staker is unexpected type %T", tx.Tx.UnsignedTx)
 	}
 
+	txBytes, err := vm.codec.Marshal(codecVersion, tx)
+	if err != nil {
+		return err
+	}
+
 	txID := tx.Tx.ID() // Tx ID of this tx
 
 	// Sorted by subnet ID then stop time
@@ -231,7 +245,10 @@ func (vm *VM) removeStaker(db database.Database, subnetID ids.ID, tx *rewardTx)
 	}
 	stopKey := p.Bytes
 
-	return prefixStopDB.Delete(stopKey)
+	if err := prefixStopDB.Delete(stopKey); err != nil {
+		return err
+	}
+	return vm.updateStateHash(db, currentStakerStateElement, stakerStateKey(prefixStop, stopKey), txBytes, nil)
 }
 
 // Returns the pending staker that will start staking next
@@ -350,6 +367,13 @@ func (vm *VM) putUTXO(db database.Database, utxo *avax.UTXO) error {
 	if err := vm.State.Put(db, utxoTypeID, utxoID, utxo); err != nil {
 		return err
 	}
+	utxoBytes, err := Codec.Marshal(codecVersion, utxo)
+	if err != nil {
+		return err
+	}
+	if err := vm.updateStateHash(db, utxoStateElement, utxoID[:], nil, utxoBytes); err != nil {
+		return err
+	}
 
 	// If this output lists addresses that it references index it
 	if addressable, ok := utxo.Out.(avax.Addressable); ok {
@@ -377,6 +401,13 @@ func (vm *VM) removeUTXO(db database.Database, utxoID ids.ID) error {
 	if err := vm.State.Put(db, utxoTypeID, utxoID, nil); err != nil { // remove the UTXO
 		return err
 	}
+	utxoBytes, err := Codec.Marshal(codecVersion, utxo)
+	if err != nil {
+		return err
+	}
+	if err := vm.updateStateHash(db, utxoStateElement, utxoID[:], utxoBytes, nil); err != nil {
+		return err
+	}
 	// If this output lists addresses that it references remove the indices
 	if addressable, ok := utxo.Out.(avax.Addressable); ok {
 		// For each owner of this UTXO, remove from their list of UTXOs
@@ -546,7 +577,18 @@ func (vm *VM) getChain(db database.Database, id ids.ID) (*Tx, error) {
 
 // put the list of blockchains that exist to database
 func (vm *VM) putChains(db database.Database, chains []*Tx) error {
-	return vm.State.Put(db, chainsTypeID, chainsKey, chains)
+	var oldValue []byte
+	switch oldChains, err := vm.getChains(db); err {
+	case nil:
+		oldValue = txsStateValue(oldChains)
+	case database.ErrNotFound:
+	default:
+		return err
+	}
+	if err := vm.State.Put(db, chainsTypeID, chainsKey, chains); err != nil {
+		return err
+	}
+	return vm.updateStateHash(db, chainsStateElement, chainsKey[:], oldValue, txsStateValue(chains))
 }
 
 // get the platform chain's timestamp from [db]
@@ -556,12 +598,34 @@ func (vm *VM) getTimestamp(db database.Database) (time.Time, error) {
 
 // put the platform chain's timestamp in [db]
 func (vm *VM) putTimestamp(db database.Database, timestamp time.Time) error {
-	return vm.State.PutTime(db, timestampKey, timestamp)
+	var oldValue []byte
+	switch oldTimestamp, err := vm.getTimestamp(db); err {
+	case nil:
+		oldValue = uint64StateValue(uint64(oldTimestamp.Unix()))
+	case database.ErrNotFound:
+	default:
+		return err
+	}
+	if err := vm.State.PutTime(db, timestampKey, timestamp); err != nil {
+		return err
+	}
+	return vm.updateStateHash(db, timestampStateElement, timestampKey[:], oldValue, uint64StateValue(uint64(timestamp.Unix())))
 }
 
 // put the subnets that exist to [db]
 func (vm *VM) putSubnets(db database.Database, subnets []*Tx) error {
-	return vm.State.Put(db, subnetsTypeID, subnetsKey, subnets)
+	var oldValue []byte
+	switch oldSubnets, err := vm.getSubnets(db); err {
+	case nil:
+		oldValue = txsStateValue(oldSubnets)
+	case database.ErrNotFound:
+	default:
+		return err
+	}
+	if err := vm.State.Put(db, subnetsTypeID, subnetsKey, subnets); err != nil {
+		return err
+	}
+	return vm.updateStateHash(db, subnetsStateElement, subnetsKey[:], oldValue, txsStateValue(subnets))
 }
 
 // get the subnets that exist in [db]
@@ -742,6 +806,62 @@ func (vm *VM) registerDBTypes() {
 	if err := vm.State.RegisterType(currentSupplyTypeID, marshalCurrentSupplyFunc, unmarshalCurrentSupplyFunc); err != nil {
 		vm.Ctx.Log.Warn(errRegisteringType.Error())
 	}
+	// Burned fees are stored the same way as the current supply
+	if err := vm.State.RegisterType(burnedFeesTypeID, marshalCurrentSupplyFunc, unmarshalCurrentSupplyFunc); err != nil {
+		vm.Ctx.Log.Warn(errRegisteringType.Error())
+	}
+
+	marshalSubnetInfoFunc := func(infoIntf interface{}) ([]byte, error) {
+		if info, ok := infoIntf.(*SubnetInfo); ok {
+			return vm.codec.Marshal(codecVersion, info)
+		}
+		return nil, fmt.Errorf("expected *SubnetInfo but got type %T", infoIntf)
+	}
+	unmarshalSubnetInfoFunc := func(bytes []byte) (interface{}, error) {
+		info := &SubnetInfo{}
+		if _, err := Codec.Unmarshal(bytes, info); err != nil {
+			return nil, err
+		}
+		return info, nil
+	}
+	if err := vm.State.RegisterType(subnetInfoTypeID, marshalSubnetInfoFunc, unmarshalSubnetInfoFunc); err != nil {
+		vm.Ctx.Log.Warn(errRegisteringType.Error())
+	}
+}
+
+// getSubnetInfo returns the info of the subnet with ID [subnetID]. Returns
+// database.ErrNotFound if the subnet's info was never set.
+func (vm *VM) getSubnetInfo(db database.Database, subnetID ids.ID) (*SubnetInfo, error) {
+	infoIntf, err := vm.State.Get(db, subnetInfoTypeID, subnetID)
+	if err != nil {
+		return nil, err
+	}
+	if info, ok := infoIntf.(*SubnetInfo); ok {
+		return info, nil
+	}
+	return nil, fmt.Errorf("expected subnet info to be *SubnetInfo but is type %T", infoIntf)
+}
+
+// putSubnetInfo sets the info of the subnet with ID [subnetID] to [info]
+func (vm *VM) putSubnetInfo(db database.Database, subnetID ids.ID, info *SubnetInfo) error {
+	var oldValue []byte
+	switch oldInfo, err := vm.getSubnetInfo(db, subnetID); err {
+	case nil:
+		if oldValue, err = vm.codec.Marshal(codecVersion, oldInfo); err != nil {
+			return err
+		}
+	case database.ErrNotFound:
+	default:
+		return err
+	}
+	newValue, err := vm.codec.Marshal(codecVersion, info)
+	if err != nil {
+		return err
+	}
+	if err := vm.State.Put(db, subnetInfoTypeID, subnetID, info); err != nil {
+		return err
+	}
+	return vm.updateStateHash(db, subnetInfoStateElement, subnetID[:], oldValue, newValue)
 }
 
 func (vm *VM) getCurrentSupply(db database.Database) (uint64, error) {
@@ -756,7 +876,57 @@ func (vm *VM) getCurrentSupply(db database.Database) (uint64, error) {
 }
 
 func (vm *VM) putCurrentSupply(db database.Database, currentSupply uint64) error {
-	return vm.State.Put(db, currentSupplyTypeID, currentSupplyKey, currentSupply)
+	var oldValue []byte
+	switch oldSupply, err := vm.getCurrentSupply(db); err {
+	case nil:
+		oldValue = uint64StateValue(oldSupply)
+	case database.ErrNotFound:
+	default:
+		return err
+	}
+	if err := vm.State.Put(db, currentSupplyTypeID, currentSupplyKey, currentSupply); err != nil {
+		return err
+	}
+	return vm.updateStateHash(db, currentSupplyStateElement, currentSupplyKey[:], oldValue, uint64StateValue(currentSupply))
+}
+
+// getBurnedFees returns the total amount of fees burned by transactions
+// accepted on this chain. Fees burned before this was tracked aren't included.
+func (vm *VM) getBurnedFees(db database.Database) (uint64, error) {
+	burnedFeesIntf, err := vm.State.Get(db, burnedFeesTypeID, burnedFeesKey)
+	if err == database.ErrNotFound {
+		return 0, nil
+	} else if err != nil {
+		return 0, err
+	}
+	if burnedFees, ok := burnedFeesIntf.(uint64); ok {
+		return burnedFees, nil
+	}
+	return 0, fmt.Errorf("expected burned fees to be uint64 but is type %T", burnedFeesIntf)
+}
+
+// burnFee adds [fee] to the total amount of fees burned in [db]
+func (vm *VM) burnFee(db database.Database, fee uint64) error {
+	if fee == 0 {
+		return nil
+	}
+	burnedFees, err := vm.getBurnedFees(db)
+	if err != nil {
+		return err
+	}
+	newBurnedFees, err := safemath.Add64(burnedFees, fee)
+	if err != nil {
+		return err
+	}
+	if err := vm.State.Put(db, burnedFeesTypeID, burnedFeesKey, newBurnedFees); err != nil {
+		return err
+	}
+	// Burned fees are only stored once they are non-zero
+	var oldValue []byte
+	if burnedFees != 0 {
+		oldValue = uint64StateValue(burnedFees)
+	}
+	return vm.updateStateHash(db, burnedFeesStateElement, burnedFeesKey[:], oldValue, uint64StateValue(newBurnedFees))
 }
 
 type validatorUptime struct {
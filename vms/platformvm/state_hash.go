@@ -0,0 +1,283 @@
+// (c) 2019-2020, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package platformvm
+
+import (
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"fmt"
+
+	"github.com/ava-labs/avalanchego/database"
+	"github.com/ava-labs/avalanchego/database/prefixdb"
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/utils/constants"
+	"github.com/ava-labs/avalanchego/utils/hashing"
+	"github.com/ava-labs/avalanchego/utils/wrappers"
+	"github.com/ava-labs/avalanchego/vms/components/avax"
+)
+
+// The state hash commits to the UTXOs, the pending and current stakers, the
+// subnets, the chains, the timestamp, the current supply, the burned fees, and
+// the subnets' info.
+// Transactions, their statuses, indices, and uptimes are local to a node and
+// aren't included.
+//
+// The state hash is the sum, modulo 2^256, of the hashes of each element of
+// the state. This allows it to be updated as elements are added and removed,
+// rather than re-hashing the whole state on every block.
+
+// Kinds of state elements
+const (
+	utxoStateElement byte = iota
+	pendingStakerStateElement
+	currentStakerStateElement
+	subnetsStateElement
+	chainsStateElement
+	timestampStateElement
+	currentSupplyStateElement
+	burnedFeesStateElement
+	subnetInfoStateElement
+)
+
+var (
+	stateHashKey = ids.ID{'s', 't', 'a', 't', 'e', ' ', 'h', 'a', 's', 'h'}
+
+	errStateHashNotTracked = errors.New("this node's database predates state hashing, so it doesn't track the state hash")
+	errNoBlockStateHash    = errors.New("no state hash was recorded for the block")
+)
+
+// stateElementHash returns the hash of the state element of kind [kind] with
+// key [key] and value [value]
+func stateElementHash(kind byte, key, value []byte) [hashing.HashLen]byte {
+	p := wrappers.Packer{MaxSize: wrappers.ByteLen + 2*wrappers.IntLen + len(key) + len(value)}
+	p.PackByte(kind)
+	p.PackBytes(key)
+	p.PackBytes(value)
+	return hashing.ComputeHash256Array(p.Bytes)
+}
+
+// addStateElementHash sets [sum] to [sum] + [h] modulo 2^256
+func addStateElementHash(sum *ids.ID, h [hashing.HashLen]byte) {
+	carry := uint16(0)
+	for i := hashing.HashLen - 1; i >= 0; i-- {
+		total := uint16(sum[i]) + uint16(h[i]) + carry
+		sum[i] = byte(total)
+		carry = total >> 8
+	}
+}
+
+// subStateElementHash sets [sum] to [sum] - [h] modulo 2^256
+func subStateElementHash(sum *ids.ID, h [hashing.HashLen]byte) {
+	borrow := int16(0)
+	for i := hashing.HashLen - 1; i >= 0; i-- {
+		diff := int16(sum[i]) - int16(h[i]) - borrow
+		borrow = 0
+		if diff < 0 {
+			diff += 256
+			borrow = 1
+		}
+		sum[i] = byte(diff)
+	}
+}
+
+// uint64StateValue returns the state element value of [value]
+func uint64StateValue(value uint64) []byte {
+	valueBytes := make([]byte, wrappers.LongLen)
+	binary.BigEndian.PutUint64(valueBytes, value)
+	return valueBytes
+}
+
+// txsStateValue returns the state element value of a list of transactions,
+// which is the concatenation of their IDs
+func txsStateValue(txs []*Tx) []byte {
+	value := make([]byte, 0, len(txs)*hashing.HashLen)
+	for _, tx := range txs {
+		txID := tx.ID()
+		value = append(value, txID[:]...)
+	}
+	return value
+}
+
+// stakerStateKey returns the state element key of the staker stored at [key]
+// in the staker queue with prefix [prefix]
+func stakerStateKey(prefix, key []byte) []byte {
+	stateKey := make([]byte, 0, len(prefix)+len(key))
+	stateKey = append(stateKey, prefix...)
+	return append(stateKey, key...)
+}
+
+// getStateHash returns the state hash of [db].
+// Returns errStateHashNotTracked if [db] doesn't track the state hash.
+func (vm *VM) getStateHash(db database.Database) (ids.ID, error) {
+	stateHash, err := vm.State.GetID(db, stateHashKey)
+	if err == database.ErrNotFound {
+		return ids.ID{}, errStateHashNotTracked
+	}
+	return stateHash, err
+}
+
+// updateStateHash replaces the state element of kind [kind] with key [key]
+// and value [oldValue] with one with value [newValue]. A nil value means that
+// the element doesn't exist. This is a no-op if [db] doesn't track the state
+// hash.
+func (vm *VM) updateStateHash(db database.Database, kind byte, key, oldValue, newValue []byte) error {
+	stateHash, err := vm.getStateHash(db)
+	if err == errStateHashNotTracked {
+		return nil
+	} else if err != nil {
+		return err
+	}
+	if oldValue != nil {
+		subStateElementHash(&stateHash, stateElementHash(kind, key, oldValue))
+	}
+	if newValue != nil {
+		addStateElementHash(&stateHash, stateElementHash(kind, key, newValue))
+	}
+	return vm.State.PutID(db, stateHashKey, stateHash)
+}
+
+// putBlockStateHash records the state hash of [db] as the state hash of the
+// block with ID [blkID]. This is a no-op if [db] doesn't track the state hash.
+func (vm *VM) putBlockStateHash(db database.Database, blkID ids.ID) error {
+	stateHash, err := vm.getStateHash(db)
+	if err == errStateHashNotTracked {
+		return nil
+	} else if err != nil {
+		return err
+	}
+	return vm.State.PutID(db, blkID.Prefix(stateHashTypeID), stateHash)
+}
+
+// getBlockStateHash returns the state hash of the state after the accepted
+// block with ID [blkID]
+func (vm *VM) getBlockStateHash(db database.Database, blkID ids.ID) (ids.ID, error) {
+	stateHash, err := vm.State.GetID(db, blkID.Prefix(stateHashTypeID))
+	if err == database.ErrNotFound {
+		return ids.ID{}, errNoBlockStateHash
+	}
+	return stateHash, err
+}
+
+// migrateStateHash starts tracking the state hash of [db], which was created
+// before the state hash was tracked. The state hash is computed from every
+// element of the state and recorded as the state hash of the last accepted
+// block. This is a no-op if [db] already tracks the state hash.
+func (vm *VM) migrateStateHash(db database.Database) error {
+	if _, err := vm.getStateHash(db); err != errStateHashNotTracked {
+		return err
+	}
+
+	stateHash := ids.Empty
+
+	// UTXOs aren't indexed by ID, so find them by scanning the database for
+	// values that are UTXOs stored under their own ID
+	utxoIter := db.NewIterator()
+	for utxoIter.Next() {
+		key := utxoIter.Key()
+		if len(key) != hashing.HashLen {
+			continue
+		}
+		utxo := avax.UTXO{}
+		if _, err := Codec.Unmarshal(utxoIter.Value(), &utxo); err != nil {
+			continue
+		}
+		utxoID := utxo.InputID()
+		if uniqueID := utxoID.Prefix(utxoTypeID); !bytes.Equal(uniqueID[:], key) {
+			continue
+		}
+		utxoBytes, err := Codec.Marshal(codecVersion, &utxo)
+		if err != nil {
+			utxoIter.Release()
+			return err
+		}
+		addStateElementHash(&stateHash, stateElementHash(utxoStateElement, utxoID[:], utxoBytes))
+	}
+	err := utxoIter.Error()
+	utxoIter.Release()
+	if err != nil {
+		return err
+	}
+
+	timestamp, err := vm.getTimestamp(db)
+	if err != nil {
+		return err
+	}
+	addStateElementHash(&stateHash, stateElementHash(timestampStateElement, timestampKey[:], uint64StateValue(uint64(timestamp.Unix()))))
+
+	currentSupply, err := vm.getCurrentSupply(db)
+	if err != nil {
+		return err
+	}
+	addStateElementHash(&stateHash, stateElementHash(currentSupplyStateElement, currentSupplyKey[:], uint64StateValue(currentSupply)))
+
+	// Burned fees are only stored once they are non-zero
+	burnedFees, err := vm.getBurnedFees(db)
+	if err != nil {
+		return err
+	}
+	if burnedFees != 0 {
+		addStateElementHash(&stateHash, stateElementHash(burnedFeesStateElement, burnedFeesKey[:], uint64StateValue(burnedFees)))
+	}
+
+	chains, err := vm.getChains(db)
+	if err != nil {
+		return err
+	}
+	addStateElementHash(&stateHash, stateElementHash(chainsStateElement, chainsKey[:], txsStateValue(chains)))
+
+	subnets, err := vm.getSubnets(db)
+	if err != nil {
+		return err
+	}
+	addStateElementHash(&stateHash, stateElementHash(subnetsStateElement, subnetsKey[:], txsStateValue(subnets)))
+
+	subnetIDs := []ids.ID{constants.PrimaryNetworkID}
+	for _, subnet := range subnets {
+		subnetID := subnet.ID()
+		subnetIDs = append(subnetIDs, subnetID)
+
+		switch info, err := vm.getSubnetInfo(db, subnetID); err {
+		case nil:
+			infoBytes, err := vm.codec.Marshal(codecVersion, info)
+			if err != nil {
+				return err
+			}
+			addStateElementHash(&stateHash, stateElementHash(subnetInfoStateElement, subnetID[:], infoBytes))
+		case database.ErrNotFound:
+		default:
+			return err
+		}
+	}
+
+	// The stakers are stored as the state element values
+	for _, subnetID := range subnetIDs {
+		if err := addStakersStateHash(db, &stateHash, pendingStakerStateElement, []byte(fmt.Sprintf("%s%s", subnetID, startDBPrefix))); err != nil {
+			return err
+		}
+		if err := addStakersStateHash(db, &stateHash, currentStakerStateElement, []byte(fmt.Sprintf("%s%s", subnetID, stopDBPrefix))); err != nil {
+			return err
+		}
+	}
+
+	if err := vm.State.PutID(db, stateHashKey, stateHash); err != nil {
+		return err
+	}
+	return vm.putBlockStateHash(db, vm.LastAccepted())
+}
+
+// addStakersStateHash adds the state elements of kind [kind] of the stakers in
+// the staker queue with prefix [prefix] to [stateHash]
+func addStakersStateHash(db database.Database, stateHash *ids.ID, kind byte, prefix []byte) error {
+	stakersDB := prefixdb.NewNested(prefix, db)
+	defer stakersDB.Close()
+
+	iter := stakersDB.NewIterator()
+	defer iter.Release()
+
+	for iter.Next() {
+		addStateElementHash(stateHash, stateElementHash(kind, stakerStateKey(prefix, iter.Key()), iter.Value()))
+	}
+	return iter.Error()
+}
@@ -0,0 +1,244 @@
+// (c) 2019-2020, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package platformvm
+
+import (
+	"testing"
+	"time"
+
+	"github.com/ava-labs/avalanchego/chains"
+	"github.com/ava-labs/avalanchego/database/prefixdb"
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/snow/engine/common"
+	"github.com/ava-labs/avalanchego/snow/validators"
+	"github.com/ava-labs/avalanchego/utils/crypto"
+	"github.com/ava-labs/avalanchego/vms/components/core"
+	"github.com/ava-labs/avalanchego/vms/components/state"
+)
+
+func TestStateElementHashSum(t *testing.T) {
+	a := stateElementHash(utxoStateElement, []byte{1}, []byte{2})
+	b := stateElementHash(utxoStateElement, []byte{1}, []byte{3})
+	c := stateElementHash(timestampStateElement, []byte{1}, []byte{2})
+
+	sum1 := ids.ID{}
+	addStateElementHash(&sum1, a)
+	addStateElementHash(&sum1, b)
+	addStateElementHash(&sum1, c)
+
+	// The order elements are added in doesn't matter
+	sum2 := ids.ID{}
+	addStateElementHash(&sum2, c)
+	addStateElementHash(&sum2, a)
+	addStateElementHash(&sum2, b)
+	if sum1 != sum2 {
+		t.Fatalf("expected %s but got %s", sum1, sum2)
+	}
+
+	// Removing an element undoes adding it
+	subStateElementHash(&sum1, b)
+	sum3 := ids.ID{}
+	addStateElementHash(&sum3, a)
+	addStateElementHash(&sum3, c)
+	if sum1 != sum3 {
+		t.Fatalf("expected %s but got %s", sum3, sum1)
+	}
+
+	subStateElementHash(&sum1, a)
+	subStateElementHash(&sum1, c)
+	if sum1 != ids.Empty {
+		t.Fatalf("expected the empty sum but got %s", sum1)
+	}
+}
+
+func TestGetStateHash(t *testing.T) {
+	vm, _ := defaultVM()
+	vm.Ctx.Lock.Lock()
+	defer func() {
+		if err := vm.Shutdown(); err != nil {
+			t.Fatal(err)
+		}
+		vm.Ctx.Lock.Unlock()
+	}()
+	service := &Service{vm: vm}
+
+	genesisReply := GetStateHashReply{}
+	if err := service.GetStateHash(nil, &GetStateHashArgs{}, &genesisReply); err != nil {
+		t.Fatal(err)
+	}
+	if genesisReply.StateHash == ids.Empty {
+		t.Fatalf("genesis state hash shouldn't be empty")
+	}
+	if genesisReply.BlockID != vm.LastAccepted() {
+		t.Fatalf("expected block %s but got %s", vm.LastAccepted(), genesisReply.BlockID)
+	}
+
+	// Another node with the same genesis has the same state hash
+	otherVM, _ := defaultVM()
+	otherVM.Ctx.Lock.Lock()
+	defer func() {
+		if err := otherVM.Shutdown(); err != nil {
+			t.Fatal(err)
+		}
+		otherVM.Ctx.Lock.Unlock()
+	}()
+	if stateHash, err := otherVM.getStateHash(otherVM.DB); err != nil {
+		t.Fatal(err)
+	} else if stateHash != genesisReply.StateHash {
+		t.Fatalf("expected state hash %s but got %s", genesisReply.StateHash, stateHash)
+	}
+
+	createSubnetTx, err := vm.newCreateSubnetTx(
+		1, // threshold
+		[]ids.ShortID{keys[0].PublicKey().Address()}, // control keys
+		[]*crypto.PrivateKeySECP256K1R{keys[0]},      // payer
+		keys[0].PublicKey().Address(),                // change addr
+	)
+	if err != nil {
+		t.Fatal(err)
+	} else if err := vm.mempool.IssueTx(createSubnetTx); err != nil {
+		t.Fatal(err)
+	}
+	blk, err := vm.BuildBlock()
+	if err != nil {
+		t.Fatal(err)
+	} else if err := blk.Verify(); err != nil {
+		t.Fatal(err)
+	} else if err := blk.Accept(); err != nil {
+		t.Fatal(err)
+	}
+
+	reply := GetStateHashReply{}
+	if err := service.GetStateHash(nil, &GetStateHashArgs{}, &reply); err != nil {
+		t.Fatal(err)
+	}
+	switch {
+	case reply.BlockID != blk.ID():
+		t.Fatalf("expected block %s but got %s", blk.ID(), reply.BlockID)
+	case uint64(reply.Height) != 1:
+		t.Fatalf("expected height 1 but got %d", reply.Height)
+	case reply.StateHash == genesisReply.StateHash:
+		t.Fatalf("state hash should have changed after creating a subnet")
+	}
+
+	// The state hash of the genesis block is still available
+	oldReply := GetStateHashReply{}
+	if err := service.GetStateHash(nil, &GetStateHashArgs{BlockID: genesisReply.BlockID}, &oldReply); err != nil {
+		t.Fatal(err)
+	}
+	if oldReply.StateHash != genesisReply.StateHash {
+		t.Fatalf("expected state hash %s but got %s", genesisReply.StateHash, oldReply.StateHash)
+	}
+}
+
+func TestGetStateHashNotTracked(t *testing.T) {
+	vm, _ := defaultVM()
+	vm.Ctx.Lock.Lock()
+	defer func() {
+		if err := vm.Shutdown(); err != nil {
+			t.Fatal(err)
+		}
+		vm.Ctx.Lock.Unlock()
+	}()
+	service := &Service{vm: vm}
+
+	// Simulate a database created before the state hash was tracked
+	if err := vm.State.Put(vm.DB, state.IDTypeID, stateHashKey, nil); err != nil {
+		t.Fatal(err)
+	}
+	if err := vm.State.Put(vm.DB, state.IDTypeID, vm.LastAccepted().Prefix(stateHashTypeID), nil); err != nil {
+		t.Fatal(err)
+	}
+
+	reply := GetStateHashReply{}
+	if err := service.GetStateHash(nil, &GetStateHashArgs{}, &reply); err != errStateHashNotTracked {
+		t.Fatalf("expected %v but got %v", errStateHashNotTracked, err)
+	}
+
+	// Updating the state doesn't start tracking the state hash
+	if err := vm.putTimestamp(vm.DB, defaultGenesisTime); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := vm.getStateHash(vm.DB); err != errStateHashNotTracked {
+		t.Fatalf("expected %v but got %v", errStateHashNotTracked, err)
+	}
+}
+
+func TestMigrateStateHash(t *testing.T) {
+	firstVM, baseDB := defaultVM()
+	firstVM.Ctx.Lock.Lock()
+
+	subnetID := testSubnet1.ID()
+	if err := firstVM.putSubnetInfo(firstVM.DB, subnetID, &SubnetInfo{Name: "my subnet"}); err != nil {
+		t.Fatal(err)
+	}
+	if err := firstVM.burnFee(firstVM.DB, defaultTxFee); err != nil {
+		t.Fatal(err)
+	}
+	expectedStateHash, err := firstVM.getStateHash(firstVM.DB)
+	if err != nil {
+		t.Fatal(err)
+	}
+	lastAcceptedID := firstVM.LastAccepted()
+
+	// Simulate a database created before the state hash was tracked
+	if err := firstVM.State.Put(firstVM.DB, state.IDTypeID, stateHashKey, nil); err != nil {
+		t.Fatal(err)
+	}
+	if err := firstVM.State.Put(firstVM.DB, state.IDTypeID, lastAcceptedID.Prefix(stateHashTypeID), nil); err != nil {
+		t.Fatal(err)
+	}
+	if err := firstVM.DB.Commit(); err != nil {
+		t.Fatal(err)
+	}
+	if err := firstVM.Shutdown(); err != nil {
+		t.Fatal(err)
+	}
+	firstVM.Ctx.Lock.Unlock()
+
+	secondVM := &VM{
+		SnowmanVM:          &core.SnowmanVM{},
+		chainManager:       chains.MockManager{},
+		minStakeDuration:   defaultMinStakingDuration,
+		maxStakeDuration:   defaultMaxStakingDuration,
+		stakeMintingPeriod: defaultMaxStakingDuration,
+	}
+	secondVM.vdrMgr = validators.NewManager()
+	secondVM.clock.Set(defaultGenesisTime)
+	secondCtx := defaultContext()
+	secondCtx.Lock.Lock()
+	defer func() {
+		if err := secondVM.Shutdown(); err != nil {
+			t.Fatal(err)
+		}
+		secondCtx.Lock.Unlock()
+	}()
+
+	_, genesisBytes := defaultGenesis()
+	if err := secondVM.Initialize(secondCtx, prefixdb.New([]byte{0}, baseDB), genesisBytes, make(chan common.Message, 1), nil); err != nil {
+		t.Fatal(err)
+	}
+
+	// The migrated state hash matches the one tracked since genesis
+	if stateHash, err := secondVM.getStateHash(secondVM.DB); err != nil {
+		t.Fatal(err)
+	} else if stateHash != expectedStateHash {
+		t.Fatalf("expected state hash %s but got %s", expectedStateHash, stateHash)
+	}
+	if stateHash, err := secondVM.getBlockStateHash(secondVM.DB, lastAcceptedID); err != nil {
+		t.Fatal(err)
+	} else if stateHash != expectedStateHash {
+		t.Fatalf("expected block state hash %s but got %s", expectedStateHash, stateHash)
+	}
+
+	// The migrated state hash is kept up to date
+	if err := secondVM.putTimestamp(secondVM.DB, defaultGenesisTime.Add(time.Second)); err != nil {
+		t.Fatal(err)
+	}
+	if stateHash, err := secondVM.getStateHash(secondVM.DB); err != nil {
+		t.Fatal(err)
+	} else if stateHash == expectedStateHash {
+		t.Fatal("updating the timestamp should have changed the state hash")
+	}
+}
@@ -11,7 +11,6 @@ import (
 	"sort"
 
 	"github.com/ava-labs/avalanchego/ids"
-	"github.com/ava-labs/avalanchego/utils/constants"
 	"github.com/ava-labs/avalanchego/utils/formatting"
 	"github.com/ava-labs/avalanchego/utils/json"
 	"github.com/ava-labs/avalanchego/vms/components/avax"
@@ -277,7 +276,7 @@ func (ss *StaticService) BuildGenesis(_ *http.Request, args *BuildGenesisArgs, r
 		if uint64(validator.EndTime) <= uint64(args.Time) {
 			return errValidatorAddsNoValue
 		}
-		nodeID, err := ids.ShortFromPrefixedString(validator.NodeID, constants.NodeIDPrefix)
+		nodeID, err := ids.NodeIDFromString(validator.NodeID)
 		if err != nil {
 			return err
 		}
@@ -306,7 +305,7 @@ func (ss *StaticService) BuildGenesis(_ *http.Request, args *BuildGenesisArgs, r
 				BlockchainID: ids.Empty,
 			}},
 			Validator: Validator{
-				NodeID: nodeID,
+				NodeID: nodeID.ShortID(),
 				Start:  uint64(args.Time),
 				End:    uint64(validator.EndTime),
 				Wght:   weight,
@@ -29,6 +29,7 @@ import (
 	"github.com/ava-labs/avalanchego/utils/timer"
 	"github.com/ava-labs/avalanchego/utils/units"
 	"github.com/ava-labs/avalanchego/utils/wrappers"
+	"github.com/ava-labs/avalanchego/version"
 	"github.com/ava-labs/avalanchego/vms/components/avax"
 	"github.com/ava-labs/avalanchego/vms/components/core"
 	"github.com/ava-labs/avalanchego/vms/components/state"
@@ -49,6 +50,9 @@ const (
 	txTypeID
 	statusTypeID
 	currentSupplyTypeID
+	burnedFeesTypeID
+	stateHashTypeID
+	subnetInfoTypeID
 
 	// PercentDenominator is the denominator used to calculate percentages
 	PercentDenominator = 1000000
@@ -78,6 +82,7 @@ var (
 	chainsKey        = ids.ID{'c', 'h', 'a', 'i', 'n', 's'}
 	subnetsKey       = ids.ID{'s', 'u', 'b', 'n', 'e', 't', 's'}
 	currentSupplyKey = ids.ID{'c', 'u', 'r', 'r', 'e', 't', ' ', 's', 'u', 'p', 'p', 'l', 'y'}
+	burnedFeesKey    = ids.ID{'b', 'u', 'r', 'n', 'e', 'd', ' ', 'f', 'e', 'e', 's'}
 
 	errRegisteringType          = errors.New("error registering type with database")
 	errInvalidLastAcceptedBlock = errors.New("last accepted block must be a decision block")
@@ -150,6 +155,10 @@ type VM struct {
 	// Consumption period for the minting function
 	stakeMintingPeriod time.Duration
 
+	// Calculates the rewards of stakers. If nil, the primary network's reward
+	// curve with [stakeMintingPeriod] is used.
+	rewards RewardCalculator
+
 	// Contains the IDs of transactions recently dropped because they failed verification.
 	// These txs may be re-issued and put into accepted blocks, so check the database
 	// to see if it was later committed/aborted before reporting that it's dropped.
@@ -181,6 +190,13 @@ func (vm *VM) Initialize(
 		return err
 	}
 	vm.fx = &secp256k1fx.Fx{}
+	if vm.rewards == nil {
+		rewards, err := NewRewardCalculator(DefaultRewardConfig(vm.stakeMintingPeriod))
+		if err != nil {
+			return err
+		}
+		vm.rewards = rewards
+	}
 
 	vm.codec = Codec
 	vm.codecRegistry = codec.NewDefault()
@@ -207,6 +223,11 @@ func (vm *VM) Initialize(
 			return err
 		}
 
+		// Start tracking the state hash before any state is written
+		if err := vm.State.PutID(vm.DB, stateHashKey, ids.Empty); err != nil {
+			return err
+		}
+
 		// Persist UTXOs that exist at genesis
 		for _, utxo := range genesis.UTXOs {
 			if err := vm.putUTXO(vm.DB, &utxo.UTXO); err != nil {
@@ -216,7 +237,7 @@ func (vm *VM) Initialize(
 
 		// Persist the platform chain's timestamp at genesis
 		genesisTime := time.Unix(int64(genesis.Timestamp), 0)
-		if err := vm.State.PutTime(vm.DB, timestampKey, genesisTime); err != nil {
+		if err := vm.putTimestamp(vm.DB, genesisTime); err != nil {
 			return err
 		}
 
@@ -287,6 +308,9 @@ func (vm *VM) Initialize(
 		if err := vm.State.PutBlock(vm.DB, genesisBlock); err != nil {
 			return err
 		}
+		if err := vm.putBlockStateHash(vm.DB, genesisBlock.ID()); err != nil {
+			return err
+		}
 		genesisBlock.onAcceptDB = versiondb.New(vm.DB)
 		if err := genesisBlock.CommonBlock.Accept(); err != nil {
 			return fmt.Errorf("error accepting genesis block: %w", err)
@@ -301,6 +325,14 @@ func (vm *VM) Initialize(
 		}
 	}
 
+	// Databases created before the state hash was tracked start tracking it
+	if err := vm.migrateStateHash(vm.DB); err != nil {
+		return fmt.Errorf("couldn't migrate the state hash: %w", err)
+	}
+	if err := vm.DB.Commit(); err != nil {
+		return err
+	}
+
 	vm.currentBlocks = make(map[ids.ID]Block)
 
 	if err := vm.initSubnets(); err != nil {
@@ -458,7 +490,10 @@ func (vm *VM) Bootstrapped() error {
 	if err := stopIter.Error(); err != nil {
 		return err
 	}
-	return vm.DB.Commit()
+	if err := vm.DB.Commit(); err != nil {
+		return err
+	}
+	return vm.mempool.ReissuePersistedTxs()
 }
 
 // Shutdown this blockchain
@@ -468,6 +503,9 @@ func (vm *VM) Shutdown() error {
 	}
 
 	vm.mempool.Shutdown()
+	if err := vm.mempool.persist(); err != nil {
+		return err
+	}
 
 	stopPrefix := []byte(fmt.Sprintf("%s%s", constants.PrimaryNetworkID, stopDBPrefix))
 	stopDB := prefixdb.NewNested(stopPrefix, vm.DB)
@@ -610,6 +648,13 @@ func (vm *VM) CreateStaticHandlers() map[string]*common.HTTPHandler {
 	}
 }
 
+// BurnedFees implements the common.FeeTracker interface
+func (vm *VM) BurnedFees() (uint64, error) { return vm.getBurnedFees(vm.DB) }
+
+// CurrentSupply returns an upper bound on the supply of AVAX, including the
+// genesis supply and the rewards minted or promised to current stakers
+func (vm *VM) CurrentSupply() (uint64, error) { return vm.getCurrentSupply(vm.DB) }
+
 // Connected implements validators.Connector
 func (vm *VM) Connected(vdrID ids.ShortID) {
 	vm.connections[vdrID.Key()] = time.Unix(vm.clock.Time().Unix(), 0)
@@ -743,7 +788,7 @@ func (vm *VM) calculateReward(db database.Database, duration time.Duration, stak
 	if err != nil {
 		return 0, err
 	}
-	reward := Reward(duration, stakeAmount, currentSupply, vm.stakeMintingPeriod)
+	reward := vm.rewards.Calculate(duration, stakeAmount, currentSupply)
 	newSupply, err := safemath.Add64(currentSupply, reward)
 	if err != nil {
 		return 0, err
@@ -1094,6 +1139,16 @@ func (vm *VM) FormatAddress(chainID ids.ID, addr ids.ShortID) (string, error) {
 	return formatting.FormatAddress(chainIDAlias, hrp, addr.Bytes())
 }
 
+// metadataUpgradeActivated returns true if the chain time in [db] is at or
+// after the metadata upgrade's activation time
+func (vm *VM) metadataUpgradeActivated(db database.Database) (bool, error) {
+	currentTime, err := vm.getTimestamp(db)
+	if err != nil {
+		return false, err
+	}
+	return vm.Ctx.NetworkUpgrades.IsActivated(version.MetadataUpgrade, currentTime), nil
+}
+
 func (vm *VM) calculateUptime(db database.Database, nodeID ids.ShortID, startTime time.Time) (float64, error) {
 	uptime, err := vm.uptime(db, nodeID)
 	switch {
@@ -606,7 +606,7 @@ func TestGetTotalStake(t *testing.T) {
 		vdrStakeAmt,
 		uint64(defaultValidateStartTime.Unix()),
 		uint64(defaultValidateEndTime.Unix()),
-		vdrID,
+		ids.NodeID(vdrID),
 		ids.GenerateTestShortID(),
 		0,
 		keys,
@@ -641,7 +641,7 @@ func TestGetTotalStake(t *testing.T) {
 		delegatorStakeAmt,
 		uint64(defaultValidateStartTime.Unix()),
 		uint64(defaultValidateEndTime.Unix()),
-		vdrID,
+		ids.NodeID(vdrID),
 		ids.GenerateTestShortID(),
 		keys,
 		keys[0].PublicKey().Address(),
@@ -727,7 +727,7 @@ func TestAddValidatorCommit(t *testing.T) {
 		vm.minValidatorStake,
 		uint64(startTime.Unix()),
 		uint64(endTime.Unix()),
-		ID,
+		ids.NodeID(ID),
 		ID,
 		PercentDenominator,
 		[]*crypto.PrivateKeySECP256K1R{keys[0]},
@@ -806,7 +806,7 @@ func TestInvalidAddValidatorCommit(t *testing.T) {
 		vm.minValidatorStake,
 		uint64(startTime.Unix()),
 		uint64(endTime.Unix()),
-		ID,
+		ids.NodeID(ID),
 		ID,
 		PercentDenominator,
 		[]*crypto.PrivateKeySECP256K1R{keys[0]},
@@ -855,7 +855,7 @@ func TestAddValidatorReject(t *testing.T) {
 		vm.minValidatorStake,
 		uint64(startTime.Unix()),
 		uint64(endTime.Unix()),
-		ID,
+		ids.NodeID(ID),
 		ID,
 		PercentDenominator,
 		[]*crypto.PrivateKeySECP256K1R{keys[0]},
@@ -936,7 +936,7 @@ func TestAddSubnetValidatorAccept(t *testing.T) {
 		defaultWeight,
 		uint64(startTime.Unix()),
 		uint64(endTime.Unix()),
-		keys[0].PublicKey().Address(),
+		ids.NodeID(keys[0].PublicKey().Address()),
 		testSubnet1.ID(),
 		[]*crypto.PrivateKeySECP256K1R{testSubnet1ControlKeys[0], testSubnet1ControlKeys[1]},
 		ids.ShortEmpty, // change addr
@@ -1019,7 +1019,7 @@ func TestAddSubnetValidatorReject(t *testing.T) {
 		defaultWeight,
 		uint64(startTime.Unix()),
 		uint64(endTime.Unix()),
-		nodeID,
+		ids.NodeID(nodeID),
 		testSubnet1.ID(),
 		[]*crypto.PrivateKeySECP256K1R{testSubnet1ControlKeys[1], testSubnet1ControlKeys[2]},
 		ids.ShortEmpty, // change addr
@@ -1475,7 +1475,7 @@ func TestCreateSubnet(t *testing.T) {
 		defaultWeight,
 		uint64(startTime.Unix()),
 		uint64(endTime.Unix()),
-		nodeID,
+		ids.NodeID(nodeID),
 		createSubnetTx.ID(),
 		[]*crypto.PrivateKeySECP256K1R{keys[0]},
 		ids.ShortEmpty, // change addr
@@ -2366,7 +2366,7 @@ func TestNextValidatorStartTime(t *testing.T) {
 		vm.minValidatorStake,      // stake amount
 		uint64(startTime.Unix()),  // start time
 		uint64(endTime.Unix()),    // end time
-		vm.Ctx.NodeID,             // node ID
+		ids.NodeID(vm.Ctx.NodeID), // node ID
 		ids.GenerateTestShortID(), // reward address
 		PercentDenominator,        // shares
 		[]*crypto.PrivateKeySECP256K1R{keys[0]},
@@ -2463,3 +2463,208 @@ func TestMaxStakeAmount(t *testing.T) {
 		})
 	}
 }
+
+// test that transactions in the mempool are reissued after a restart
+func TestRestartReissuesMempoolTxs(t *testing.T) {
+	_, genesisBytes := defaultGenesis()
+
+	db := memdb.New()
+
+	firstVM := &VM{
+		SnowmanVM:          &core.SnowmanVM{},
+		chainManager:       chains.MockManager{},
+		txFee:              defaultTxFee,
+		minStakeDuration:   defaultMinStakingDuration,
+		maxStakeDuration:   defaultMaxStakingDuration,
+		stakeMintingPeriod: defaultMaxStakingDuration,
+	}
+
+	firstVM.vdrMgr = validators.NewManager()
+
+	firstVM.clock.Set(defaultGenesisTime)
+	firstCtx := defaultContext()
+	firstCtx.Lock.Lock()
+
+	firstMsgChan := make(chan common.Message, 1)
+	if err := firstVM.Initialize(firstCtx, db, genesisBytes, firstMsgChan, nil); err != nil {
+		t.Fatal(err)
+	} else if err := firstVM.Bootstrapped(); err != nil {
+		t.Fatal(err)
+	}
+
+	tx, err := firstVM.newCreateSubnetTx(
+		1,
+		[]ids.ShortID{keys[0].PublicKey().Address()},
+		[]*crypto.PrivateKeySECP256K1R{keys[0]},
+		keys[0].PublicKey().Address(),
+	)
+	if err != nil {
+		t.Fatal(err)
+	} else if err := firstVM.mempool.IssueTx(tx); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := firstVM.Shutdown(); err != nil {
+		t.Fatal(err)
+	}
+	firstCtx.Lock.Unlock()
+
+	secondVM := &VM{
+		SnowmanVM:          &core.SnowmanVM{},
+		chainManager:       chains.MockManager{},
+		txFee:              defaultTxFee,
+		minStakeDuration:   defaultMinStakingDuration,
+		maxStakeDuration:   defaultMaxStakingDuration,
+		stakeMintingPeriod: defaultMaxStakingDuration,
+	}
+
+	secondVM.vdrMgr = validators.NewManager()
+
+	secondVM.clock.Set(defaultGenesisTime)
+	secondCtx := defaultContext()
+	secondCtx.Lock.Lock()
+	defer func() {
+		if err := secondVM.Shutdown(); err != nil {
+			t.Fatal(err)
+		}
+		secondCtx.Lock.Unlock()
+	}()
+
+	secondMsgChan := make(chan common.Message, 1)
+	if err := secondVM.Initialize(secondCtx, db, genesisBytes, secondMsgChan, nil); err != nil {
+		t.Fatal(err)
+	} else if err := secondVM.Bootstrapped(); err != nil {
+		t.Fatal(err)
+	}
+
+	txID := tx.ID()
+	if !secondVM.mempool.unissuedTxIDs.Contains(txID) {
+		t.Fatalf("Should have reissued the persisted tx")
+	}
+
+	blk, err := secondVM.BuildBlock()
+	if err != nil {
+		t.Fatal(err)
+	} else if err := blk.Verify(); err != nil {
+		t.Fatal(err)
+	} else if err := blk.Accept(); err != nil {
+		t.Fatal(err)
+	}
+
+	// The next time the mempool is persisted, the tx is removed
+	if err := secondVM.mempool.persist(); err != nil {
+		t.Fatal(err)
+	}
+	if has, err := secondVM.mempool.db.Has(txID[:]); err != nil {
+		t.Fatal(err)
+	} else if has {
+		t.Fatalf("Should have removed the accepted tx from the persisted mempool")
+	}
+}
+
+// test that transactions in the mempool are reissued after the node stops
+// without shutting down, once the mempool has been persisted
+func TestCrashReissuesPersistedMempoolTxs(t *testing.T) {
+	_, genesisBytes := defaultGenesis()
+
+	db := memdb.New()
+
+	firstVM := &VM{
+		SnowmanVM:          &core.SnowmanVM{},
+		chainManager:       chains.MockManager{},
+		txFee:              defaultTxFee,
+		minStakeDuration:   defaultMinStakingDuration,
+		maxStakeDuration:   defaultMaxStakingDuration,
+		stakeMintingPeriod: defaultMaxStakingDuration,
+	}
+
+	firstVM.vdrMgr = validators.NewManager()
+
+	firstVM.clock.Set(defaultGenesisTime)
+	firstCtx := defaultContext()
+	firstCtx.Lock.Lock()
+
+	firstMsgChan := make(chan common.Message, 1)
+	if err := firstVM.Initialize(firstCtx, db, genesisBytes, firstMsgChan, nil); err != nil {
+		t.Fatal(err)
+	} else if err := firstVM.Bootstrapped(); err != nil {
+		t.Fatal(err)
+	}
+
+	tx, err := firstVM.newCreateSubnetTx(
+		1,
+		[]ids.ShortID{keys[0].PublicKey().Address()},
+		[]*crypto.PrivateKeySECP256K1R{keys[0]},
+		keys[0].PublicKey().Address(),
+	)
+	if err != nil {
+		t.Fatal(err)
+	} else if err := firstVM.mempool.IssueTx(tx); err != nil {
+		t.Fatal(err)
+	}
+
+	// Persist the mempool the way its timer does, then stop the node without
+	// shutting down the VM
+	if err := firstVM.mempool.persist(); err != nil {
+		t.Fatal(err)
+	} else if err := firstVM.DB.Commit(); err != nil {
+		t.Fatal(err)
+	}
+	firstVM.mempool.Shutdown()
+	firstCtx.Lock.Unlock()
+
+	secondVM := &VM{
+		SnowmanVM:          &core.SnowmanVM{},
+		chainManager:       chains.MockManager{},
+		txFee:              defaultTxFee,
+		minStakeDuration:   defaultMinStakingDuration,
+		maxStakeDuration:   defaultMaxStakingDuration,
+		stakeMintingPeriod: defaultMaxStakingDuration,
+	}
+
+	secondVM.vdrMgr = validators.NewManager()
+
+	secondVM.clock.Set(defaultGenesisTime)
+	secondCtx := defaultContext()
+	secondCtx.Lock.Lock()
+	defer func() {
+		if err := secondVM.Shutdown(); err != nil {
+			t.Fatal(err)
+		}
+		secondCtx.Lock.Unlock()
+	}()
+
+	secondMsgChan := make(chan common.Message, 1)
+	if err := secondVM.Initialize(secondCtx, db, genesisBytes, secondMsgChan, nil); err != nil {
+		t.Fatal(err)
+	} else if err := secondVM.Bootstrapped(); err != nil {
+		t.Fatal(err)
+	}
+
+	if !secondVM.mempool.unissuedTxIDs.Contains(tx.ID()) {
+		t.Fatalf("Should have reissued the persisted tx")
+	}
+}
+
+// A VM that failed to initialize can still be shut down
+func TestShutdownAfterFailedInitialize(t *testing.T) {
+	_, genesisBytes := defaultGenesis()
+
+	vm := &VM{
+		SnowmanVM:    &core.SnowmanVM{},
+		chainManager: chains.MockManager{},
+		// No minting period, so the reward config is rejected
+	}
+	vm.vdrMgr = validators.NewManager()
+
+	ctx := defaultContext()
+	ctx.Lock.Lock()
+	defer ctx.Lock.Unlock()
+
+	if err := vm.Initialize(ctx, memdb.New(), genesisBytes, make(chan common.Message, 1), nil); err == nil {
+		t.Fatal("should have failed to initialize without a minting period")
+	}
+	if err := vm.Shutdown(); err != nil {
+		t.Fatal(err)
+	}
+}
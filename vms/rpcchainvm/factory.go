@@ -8,9 +8,13 @@ import (
 	"fmt"
 	"io/ioutil"
 	"log"
+	"os"
 	"os/exec"
+	"path/filepath"
 
+	"github.com/ava-labs/avalanchego/ids"
 	"github.com/ava-labs/avalanchego/snow"
+	"github.com/ava-labs/avalanchego/vms/rpcchainvm/manifest"
 	"github.com/hashicorp/go-hclog"
 	"github.com/hashicorp/go-plugin"
 )
@@ -23,17 +27,34 @@ var (
 type Factory struct {
 	Path   string
 	Config string
+
+	// If non-nil, the plugin is only run if [Verifier] allows the binary at
+	// [Path] to be run as the VM [VMID]. A verified copy of the binary is run,
+	// rather than the binary at [Path].
+	VMID     ids.ID
+	Verifier *manifest.Verifier
 }
 
 // New ...
 func (f *Factory) New(ctx *snow.Context) (interface{}, error) {
+	path := f.Path
+	if f.Verifier != nil {
+		copyPath, err := f.Verifier.VerifiedCopy(f.VMID, f.Path)
+		if err != nil {
+			return nil, fmt.Errorf("refusing to run plugin: %w", err)
+		}
+		// The copy is only needed until the plugin has started
+		defer os.RemoveAll(filepath.Dir(copyPath))
+		path = copyPath
+	}
+
 	// Ignore warning from launching an executable with a variable command
 	// because the command is a controlled and required input
 	// #nosec G204
 	config := &plugin.ClientConfig{
 		HandshakeConfig: Handshake,
 		Plugins:         PluginMap,
-		Cmd:             exec.Command(f.Path, fmt.Sprintf("--config=%s", f.Config)),
+		Cmd:             exec.Command(path, fmt.Sprintf("--config=%s", f.Config)),
 		AllowedProtocols: []plugin.Protocol{
 			plugin.ProtocolNetRPC,
 			plugin.ProtocolGRPC,
@@ -0,0 +1,268 @@
+// (c) 2019-2020, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package manifest
+
+import (
+	"crypto/sha256"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sync"
+
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/utils/crypto"
+	"github.com/ava-labs/avalanchego/utils/formatting"
+	"github.com/ava-labs/avalanchego/utils/hashing"
+)
+
+// signaturePrefix is prepended to a manifest before it's hashed and signed, so
+// that a signature of a manifest can't be passed off as a signature of
+// anything else, or vice versa
+const signaturePrefix = "avalanchego plugin manifest\n"
+
+var (
+	errUntrustedSigner = errors.New("plugin manifest isn't signed by a trusted signer")
+	errStaleManifest   = errors.New("plugin manifest isn't newer than the current one")
+	errDuplicateVM     = errors.New("plugin manifest lists a VM more than once")
+	errNotInManifest   = errors.New("plugin isn't listed in the plugin manifest")
+	errHashMismatch    = errors.New("plugin binary doesn't match the plugin manifest")
+)
+
+// Entry allows the plugin binary with hash [Hash] to be run as the VM [VMID]
+type Entry struct {
+	VMID ids.ID `json:"vmID"`
+	// SHA256 hash of the plugin binary
+	Hash ids.ID `json:"hash"`
+}
+
+// Manifest lists the plugin binaries a node may run
+type Manifest struct {
+	// Must be increased each time a new manifest is signed, so that an old
+	// manifest can't replace a newer one
+	Version uint64  `json:"version"`
+	Plugins []Entry `json:"plugins"`
+}
+
+// Signed is a JSON encoded manifest along with a signature of it
+type Signed struct {
+	// The JSON encoded manifest. This is kept as the exact text that was
+	// signed, rather than decoded, so that the signature can be verified.
+	Manifest string `json:"manifest"`
+	// Hex encoded secp256k1 signature of the SHA256 hash of [Manifest],
+	// prefixed with [signaturePrefix]
+	Signature string `json:"signature"`
+}
+
+// Sign returns [manifest] signed by [key]
+func Sign(manifest Manifest, key *crypto.PrivateKeySECP256K1R) (Signed, error) {
+	manifestBytes, err := json.Marshal(manifest)
+	if err != nil {
+		return Signed{}, err
+	}
+	sig, err := key.SignHash(signatureHash(string(manifestBytes)))
+	if err != nil {
+		return Signed{}, err
+	}
+	sigStr, err := formatting.Encode(formatting.Hex, sig)
+	if err != nil {
+		return Signed{}, err
+	}
+	return Signed{
+		Manifest:  string(manifestBytes),
+		Signature: sigStr,
+	}, nil
+}
+
+// Verifier only allows the plugin binaries listed in a manifest signed by a
+// trusted signer to be run. Until a manifest is set, no plugin may be run.
+type Verifier struct {
+	// Addresses of the keys that may sign manifests
+	signers ids.ShortSet
+	// File the manifest is persisted to. If empty, the manifest isn't
+	// persisted.
+	file string
+	// Directory plugin binaries are copied into before they're verified
+	copyDir string
+	factory crypto.FactorySECP256K1R
+
+	lock     sync.RWMutex
+	manifest *Manifest
+	// VM ID --> Hash of the plugin binary that may be run as that VM
+	hashes map[ids.ID]ids.ID
+}
+
+// NewVerifier returns a verifier that trusts the manifests signed by
+// [signers]. If [file] exists, the manifest in it is loaded. Manifests set
+// later are written to [file]. Plugin binaries are copied into [copyDir],
+// which is created if it doesn't exist, before they're verified.
+func NewVerifier(signers ids.ShortSet, file, copyDir string) (*Verifier, error) {
+	v := &Verifier{
+		signers: signers,
+		file:    file,
+		copyDir: copyDir,
+	}
+	if file == "" {
+		return v, nil
+	}
+
+	signedBytes, err := ioutil.ReadFile(file)
+	if os.IsNotExist(err) {
+		return v, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("couldn't read plugin manifest %s: %w", file, err)
+	}
+	signed := Signed{}
+	if err := json.Unmarshal(signedBytes, &signed); err != nil {
+		return nil, fmt.Errorf("couldn't parse plugin manifest %s: %w", file, err)
+	}
+	manifest, hashes, err := v.verify(signed)
+	if err != nil {
+		return nil, fmt.Errorf("invalid plugin manifest %s: %w", file, err)
+	}
+	v.manifest = manifest
+	v.hashes = hashes
+	return v, nil
+}
+
+// SetManifest replaces the current manifest with [signed], if [signed] is
+// signed by a trusted signer and is newer than the current manifest
+func (v *Verifier) SetManifest(signed Signed) error {
+	manifest, hashes, err := v.verify(signed)
+	if err != nil {
+		return err
+	}
+
+	v.lock.Lock()
+	defer v.lock.Unlock()
+
+	if v.manifest != nil && manifest.Version <= v.manifest.Version {
+		return fmt.Errorf("%w: version %d isn't greater than %d", errStaleManifest, manifest.Version, v.manifest.Version)
+	}
+	if v.file != "" {
+		signedBytes, err := json.Marshal(signed)
+		if err != nil {
+			return err
+		}
+		if err := ioutil.WriteFile(v.file, signedBytes, 0600); err != nil {
+			return fmt.Errorf("couldn't persist plugin manifest to %s: %w", v.file, err)
+		}
+	}
+	v.manifest = manifest
+	v.hashes = hashes
+	return nil
+}
+
+// Manifest returns the current manifest. Returns false if no manifest has been
+// set.
+func (v *Verifier) Manifest() (Manifest, bool) {
+	v.lock.RLock()
+	defer v.lock.RUnlock()
+
+	if v.manifest == nil {
+		return Manifest{}, false
+	}
+	return *v.manifest, true
+}
+
+// VerifiedCopy copies the plugin binary at [path] into a new directory in the
+// verifier's copy directory that only this process's user can access, and
+// returns the path of the copy if it
+// may be run as the VM [vmID]. The copy is what's hashed, so replacing the
+// binary at [path] after it's verified has no effect on what is run. The
+// caller should remove the directory the copy is in once the plugin has been
+// started.
+func (v *Verifier) VerifiedCopy(vmID ids.ID, path string) (string, error) {
+	v.lock.RLock()
+	expectedHash, ok := v.hashes[vmID]
+	v.lock.RUnlock()
+	if !ok {
+		return "", fmt.Errorf("%w: %s", errNotInManifest, vmID)
+	}
+
+	src, err := os.Open(path)
+	if err != nil {
+		return "", err
+	}
+	defer src.Close()
+
+	if err := os.MkdirAll(v.copyDir, 0700); err != nil {
+		return "", fmt.Errorf("couldn't create plugin copy directory %s: %w", v.copyDir, err)
+	}
+	// TempDir creates the directory with permissions 0700
+	dir, err := ioutil.TempDir(v.copyDir, "plugin")
+	if err != nil {
+		return "", err
+	}
+	copyPath := filepath.Join(dir, filepath.Base(path))
+	if err := copyAndCheck(src, copyPath, expectedHash); err != nil {
+		_ = os.RemoveAll(dir)
+		return "", fmt.Errorf("%w: plugin %s may not be run as VM %s", err, path, vmID)
+	}
+	return copyPath, nil
+}
+
+// copyAndCheck writes the contents of [src] to a new executable file at
+// [dst], and returns an error if they don't have hash [expectedHash]
+func copyAndCheck(src io.Reader, dst string, expectedHash ids.ID) error {
+	file, err := os.OpenFile(dst, os.O_WRONLY|os.O_CREATE|os.O_EXCL, 0700)
+	if err != nil {
+		return err
+	}
+	hasher := sha256.New()
+	_, err = io.Copy(io.MultiWriter(file, hasher), src)
+	if closeErr := file.Close(); err == nil {
+		err = closeErr
+	}
+	if err != nil {
+		return fmt.Errorf("couldn't copy plugin: %w", err)
+	}
+
+	hash, err := ids.ToID(hasher.Sum(nil))
+	if err != nil {
+		return err
+	}
+	if hash != expectedHash {
+		return fmt.Errorf("%w: hash is %s but must be %s", errHashMismatch, hash, expectedHash)
+	}
+	return nil
+}
+
+// signatureHash returns the hash that is signed to sign [manifest]
+func signatureHash(manifest string) []byte {
+	return hashing.ComputeHash256([]byte(signaturePrefix + manifest))
+}
+
+// verify returns the manifest in [signed] along with the plugin hashes it
+// allows, if it is signed by a trusted signer and is well formed
+func (v *Verifier) verify(signed Signed) (*Manifest, map[ids.ID]ids.ID, error) {
+	sig, err := formatting.Decode(formatting.Hex, signed.Signature)
+	if err != nil {
+		return nil, nil, fmt.Errorf("couldn't decode signature: %w", err)
+	}
+	pk, err := v.factory.RecoverHashPublicKey(signatureHash(signed.Manifest), sig)
+	if err != nil {
+		return nil, nil, fmt.Errorf("couldn't recover signer: %w", err)
+	}
+	if signer := pk.Address(); !v.signers.Contains(signer) {
+		return nil, nil, fmt.Errorf("%w: signed by %s", errUntrustedSigner, signer)
+	}
+
+	manifest := &Manifest{}
+	if err := json.Unmarshal([]byte(signed.Manifest), manifest); err != nil {
+		return nil, nil, fmt.Errorf("couldn't parse manifest: %w", err)
+	}
+	hashes := make(map[ids.ID]ids.ID, len(manifest.Plugins))
+	for _, entry := range manifest.Plugins {
+		if _, exists := hashes[entry.VMID]; exists {
+			return nil, nil, fmt.Errorf("%w: %s", errDuplicateVM, entry.VMID)
+		}
+		hashes[entry.VMID] = entry.Hash
+	}
+	return manifest, hashes, nil
+}
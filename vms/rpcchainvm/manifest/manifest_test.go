@@ -0,0 +1,195 @@
+// (c) 2019-2020, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package manifest
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/utils/crypto"
+	"github.com/ava-labs/avalanchego/utils/formatting"
+	"github.com/ava-labs/avalanchego/utils/hashing"
+)
+
+func newKey(t *testing.T) *crypto.PrivateKeySECP256K1R {
+	factory := crypto.FactorySECP256K1R{}
+	key, err := factory.NewPrivateKey()
+	if err != nil {
+		t.Fatal(err)
+	}
+	return key.(*crypto.PrivateKeySECP256K1R)
+}
+
+func TestVerifier(t *testing.T) {
+	dir, err := ioutil.TempDir("", "manifest")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	pluginPath := filepath.Join(dir, "plugin")
+	pluginBytes := []byte("plugin binary")
+	if err := ioutil.WriteFile(pluginPath, pluginBytes, 0600); err != nil {
+		t.Fatal(err)
+	}
+	vmID := ids.GenerateTestID()
+
+	key := newKey(t)
+	signers := ids.ShortSet{}
+	signers.Add(key.PublicKey().Address())
+	manifestFile := filepath.Join(dir, "manifest.json")
+	copyDir := filepath.Join(dir, "verified")
+	v, err := NewVerifier(signers, manifestFile, copyDir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, ok := v.Manifest(); ok {
+		t.Fatal("shouldn't have a manifest before one is set")
+	}
+	if _, err := v.VerifiedCopy(vmID, pluginPath); !errors.Is(err, errNotInManifest) {
+		t.Fatalf("expected %s but got %v", errNotInManifest, err)
+	}
+
+	manifest := Manifest{
+		Version: 1,
+		Plugins: []Entry{{
+			VMID: vmID,
+			Hash: hashing.ComputeHash256Array(pluginBytes),
+		}},
+	}
+	signed, err := Sign(manifest, newKey(t))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := v.SetManifest(signed); !errors.Is(err, errUntrustedSigner) {
+		t.Fatalf("expected %s but got %v", errUntrustedSigner, err)
+	}
+
+	signed, err = Sign(manifest, key)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := v.SetManifest(signed); err != nil {
+		t.Fatal(err)
+	}
+	copyPath, err := v.VerifiedCopy(vmID, pluginPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(filepath.Dir(copyPath))
+	if copyPath == pluginPath {
+		t.Fatal("expected the plugin to be copied")
+	}
+	if filepath.Dir(filepath.Dir(copyPath)) != copyDir {
+		t.Fatalf("expected the plugin to be copied into %s but got %s", copyDir, copyPath)
+	}
+	dirInfo, err := os.Stat(filepath.Dir(copyPath))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if perm := dirInfo.Mode().Perm(); perm != 0700 {
+		t.Fatalf("expected the copy's directory to have permissions 0700 but got %o", perm)
+	}
+	if _, err := v.VerifiedCopy(ids.GenerateTestID(), pluginPath); !errors.Is(err, errNotInManifest) {
+		t.Fatalf("expected %s but got %v", errNotInManifest, err)
+	}
+
+	// A tampered manifest doesn't verify
+	tampered := signed
+	tampered.Manifest = tampered.Manifest[:len(tampered.Manifest)-1] + " }"
+	if err := v.SetManifest(tampered); !errors.Is(err, errUntrustedSigner) {
+		t.Fatalf("expected %s but got %v", errUntrustedSigner, err)
+	}
+
+	// A manifest can't be replaced by one that isn't newer
+	if err := v.SetManifest(signed); !errors.Is(err, errStaleManifest) {
+		t.Fatalf("expected %s but got %v", errStaleManifest, err)
+	}
+
+	// Replacing the binary after it was verified doesn't change the copy
+	if err := ioutil.WriteFile(pluginPath, []byte("tampered binary"), 0600); err != nil {
+		t.Fatal(err)
+	}
+	copyBytes, err := ioutil.ReadFile(copyPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !bytes.Equal(copyBytes, pluginBytes) {
+		t.Fatalf("expected the copy to be %q but got %q", pluginBytes, copyBytes)
+	}
+
+	// A tampered binary doesn't verify
+	if _, err := v.VerifiedCopy(vmID, pluginPath); !errors.Is(err, errHashMismatch) {
+		t.Fatalf("expected %s but got %v", errHashMismatch, err)
+	}
+
+	// The manifest is loaded from where it was persisted
+	v, err = NewVerifier(signers, manifestFile, copyDir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if loaded, ok := v.Manifest(); !ok || loaded.Version != 1 {
+		t.Fatalf("expected the persisted manifest to be loaded but got %v", loaded)
+	}
+}
+
+// A signature of the manifest's hash without the signature prefix isn't a
+// valid signature of the manifest
+func TestVerifierRequiresSignaturePrefix(t *testing.T) {
+	key := newKey(t)
+	signers := ids.ShortSet{}
+	signers.Add(key.PublicKey().Address())
+	v, err := NewVerifier(signers, "", "")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	manifestBytes, err := json.Marshal(Manifest{Version: 1})
+	if err != nil {
+		t.Fatal(err)
+	}
+	sig, err := key.SignHash(hashing.ComputeHash256(manifestBytes))
+	if err != nil {
+		t.Fatal(err)
+	}
+	sigStr, err := formatting.Encode(formatting.Hex, sig)
+	if err != nil {
+		t.Fatal(err)
+	}
+	signed := Signed{
+		Manifest:  string(manifestBytes),
+		Signature: sigStr,
+	}
+	if err := v.SetManifest(signed); !errors.Is(err, errUntrustedSigner) {
+		t.Fatalf("expected %s but got %v", errUntrustedSigner, err)
+	}
+}
+
+func TestVerifierDuplicateVM(t *testing.T) {
+	key := newKey(t)
+	signers := ids.ShortSet{}
+	signers.Add(key.PublicKey().Address())
+	v, err := NewVerifier(signers, "", "")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	vmID := ids.GenerateTestID()
+	signed, err := Sign(Manifest{
+		Version: 1,
+		Plugins: []Entry{{VMID: vmID}, {VMID: vmID}},
+	}, key)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := v.SetManifest(signed); !errors.Is(err, errDuplicateVM) {
+		t.Fatalf("expected %s but got %v", errDuplicateVM, err)
+	}
+}
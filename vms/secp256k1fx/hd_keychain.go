@@ -0,0 +1,113 @@
+// (c) 2019-2020, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package secp256k1fx
+
+import (
+	"errors"
+
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/utils/crypto"
+)
+
+const (
+	// DefaultGapLimit is the number of consecutive unused addresses after
+	// which an HD wallet stops looking for used addresses, as recommended by
+	// BIP44
+	DefaultGapLimit = 20
+
+	// ExternalChain is the index of the chain of addresses that receive funds
+	// from others, under a BIP44 account key
+	ExternalChain uint32 = 0
+	// ChangeChain is the index of the chain of addresses that receive change,
+	// under a BIP44 account key
+	ChangeChain uint32 = 1
+)
+
+var errInvalidGapLimit = errors.New("gap limit must be positive")
+
+// UsedAddresses returns the subset of [addrs] that have been used, for example
+// because they control UTXOs
+type UsedAddresses func(addrs []ids.ShortID) (ids.ShortSet, error)
+
+// AddDerived adds to this keychain the keys at indices [start, start+count)
+// of [chain] under the BIP44 account key [account]. Returns the added keys.
+func (kc *Keychain) AddDerived(account *crypto.ExtendedPrivateKeySECP256K1R, chain, start, count uint32) ([]*crypto.PrivateKeySECP256K1R, error) {
+	chainKey, err := account.Child(chain)
+	if err != nil {
+		return nil, err
+	}
+
+	keys := make([]*crypto.PrivateKeySECP256K1R, 0, count)
+	for index := start; index < start+count; index++ {
+		child, err := chainKey.Child(index)
+		if err != nil {
+			return nil, err
+		}
+		key := child.Key()
+		kc.Add(key)
+		keys = append(keys, key)
+	}
+	return keys, nil
+}
+
+// ScanHD adds to this keychain the keys of the BIP44 account key [account]
+// whose addresses have been used, according to [used]. Each of the account's
+// external and change chains is scanned until [gapLimit] consecutive addresses
+// are unused. Returns the index of the first unused address on the external
+// chain after the last used one, which should be used to receive funds next.
+func (kc *Keychain) ScanHD(account *crypto.ExtendedPrivateKeySECP256K1R, gapLimit int, used UsedAddresses) (uint32, error) {
+	if gapLimit <= 0 {
+		return 0, errInvalidGapLimit
+	}
+
+	nextExternal, err := kc.scanChain(account, ExternalChain, gapLimit, used)
+	if err != nil {
+		return 0, err
+	}
+	if _, err := kc.scanChain(account, ChangeChain, gapLimit, used); err != nil {
+		return 0, err
+	}
+	return nextExternal, nil
+}
+
+// scanChain adds the keys of [chain] under [account] whose addresses have been
+// used, and returns the index after the last used one
+func (kc *Keychain) scanChain(account *crypto.ExtendedPrivateKeySECP256K1R, chain uint32, gapLimit int, used UsedAddresses) (uint32, error) {
+	chainKey, err := account.Child(chain)
+	if err != nil {
+		return 0, err
+	}
+
+	// [next] is the index after the last used address, and [index] is the
+	// index of the next address to check
+	next, index := uint32(0), uint32(0)
+	for index-next < uint32(gapLimit) {
+		// Check just enough addresses to reach the gap limit if none of them
+		// are used
+		count := next + uint32(gapLimit) - index
+		keys := make([]*crypto.PrivateKeySECP256K1R, count)
+		addrs := make([]ids.ShortID, count)
+		for i := range keys {
+			child, err := chainKey.Child(index + uint32(i))
+			if err != nil {
+				return 0, err
+			}
+			keys[i] = child.Key()
+			addrs[i] = keys[i].PublicKey().Address()
+		}
+
+		usedAddrs, err := used(addrs)
+		if err != nil {
+			return 0, err
+		}
+		for i, addr := range addrs {
+			if usedAddrs.Contains(addr) {
+				kc.Add(keys[i])
+				next = index + uint32(i) + 1
+			}
+		}
+		index += count
+	}
+	return next, nil
+}
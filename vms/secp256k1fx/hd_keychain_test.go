@@ -0,0 +1,106 @@
+// (c) 2019-2020, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package secp256k1fx
+
+import (
+	"testing"
+
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/utils/crypto"
+)
+
+func testAccount(t *testing.T) *crypto.ExtendedPrivateKeySECP256K1R {
+	master, err := crypto.NewMasterKey([]byte("keychain test seed"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	account, err := master.DerivePath(crypto.AVAXAccountPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return account
+}
+
+func TestKeychainAddDerived(t *testing.T) {
+	account := testAccount(t)
+	kc := NewKeychain()
+
+	keys, err := kc.AddDerived(account, ExternalChain, 5, 3)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(keys) != 3 || kc.Addrs.Len() != 3 {
+		t.Fatalf("Should have added 3 keys")
+	}
+
+	expected, err := account.Derive([]uint32{ExternalChain, 6})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, exists := kc.Get(expected.Key().PublicKey().Address()); !exists {
+		t.Fatalf("Should have added the key at index 6")
+	}
+}
+
+func TestKeychainScanHD(t *testing.T) {
+	account := testAccount(t)
+
+	address := func(chain, index uint32) ids.ShortID {
+		key, err := account.Derive([]uint32{chain, index})
+		if err != nil {
+			t.Fatal(err)
+		}
+		return key.Key().PublicKey().Address()
+	}
+	usedAddrs := ids.ShortSet{}
+	usedAddrs.Add(
+		address(ExternalChain, 3),
+		// 19 unused addresses separate these addresses from the previous ones,
+		// so they're within the gap limit
+		address(ExternalChain, 23),
+		address(ExternalChain, 43),
+		// 20 unused addresses separate this address from the previous one, so
+		// it's beyond the gap limit
+		address(ExternalChain, 64),
+		address(ChangeChain, 0),
+	)
+	used := func(addrs []ids.ShortID) (ids.ShortSet, error) {
+		result := ids.ShortSet{}
+		for _, addr := range addrs {
+			if usedAddrs.Contains(addr) {
+				result.Add(addr)
+			}
+		}
+		return result, nil
+	}
+
+	kc := NewKeychain()
+	next, err := kc.ScanHD(account, DefaultGapLimit, used)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if next != 44 {
+		t.Fatalf("Expected the next external address to be at index 44 but got %d", next)
+	}
+	if kc.Addrs.Len() != 4 {
+		t.Fatalf("Expected to add 4 used keys but added %d", kc.Addrs.Len())
+	}
+	for _, addr := range []ids.ShortID{
+		address(ExternalChain, 3),
+		address(ExternalChain, 23),
+		address(ExternalChain, 43),
+		address(ChangeChain, 0),
+	} {
+		if !kc.Addrs.Contains(addr) {
+			t.Fatalf("Should have added the key of used address %s", addr)
+		}
+	}
+	if kc.Addrs.Contains(address(ExternalChain, 64)) {
+		t.Fatalf("Shouldn't have scanned beyond the gap limit")
+	}
+
+	if _, err := kc.ScanHD(account, 0, used); err == nil {
+		t.Fatalf("Should have rejected a gap limit of 0")
+	}
+}
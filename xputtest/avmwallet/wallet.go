@@ -132,11 +132,16 @@ func (w *Wallet) RemoveUTXO(utxoID ids.ID) {
 // Balance returns the amount of the assets in this wallet
 func (w *Wallet) Balance(assetID ids.ID) uint64 { return w.balance[assetID] }
 
-// CreateTx returns a tx that sends [amount] of [assetID] to [destAddr]
+// CreateTx returns a tx that sends [amount] of [assetID] to [destAddr]. The
+// transaction fee is also paid in [assetID].
 func (w *Wallet) CreateTx(assetID ids.ID, amount uint64, destAddr ids.ShortID) (*avm.Tx, error) {
 	if amount == 0 {
 		return nil, errors.New("invalid amount")
 	}
+	amountWithFee, err := math.Add64(amount, w.txFee)
+	if err != nil {
+		return nil, err
+	}
 
 	amountSpent := uint64(0)
 	time := w.clock.Unix()
@@ -170,12 +175,12 @@ func (w *Wallet) CreateTx(assetID ids.ID, amount uint64, destAddr ids.ShortID) (
 		ins = append(ins, in)
 		keys = append(keys, signers)
 
-		if amountSpent >= amount {
+		if amountSpent >= amountWithFee {
 			break
 		}
 	}
 
-	if amountSpent < amount {
+	if amountSpent < amountWithFee {
 		return nil, errors.New("insufficient funds")
 	}
 
@@ -193,7 +198,7 @@ func (w *Wallet) CreateTx(assetID ids.ID, amount uint64, destAddr ids.ShortID) (
 		},
 	}}
 
-	if amountSpent > amount {
+	if amountSpent > amountWithFee {
 		changeAddr, err := w.GetAddress()
 		if err != nil {
 			return nil, err
@@ -201,7 +206,7 @@ func (w *Wallet) CreateTx(assetID ids.ID, amount uint64, destAddr ids.ShortID) (
 		outs = append(outs, &avax.TransferableOutput{
 			Asset: avax.Asset{ID: assetID},
 			Out: &secp256k1fx.TransferOutput{
-				Amt: amountSpent - amount,
+				Amt: amountSpent - amountWithFee,
 				OutputOwners: secp256k1fx.OutputOwners{
 					Locktime:  0,
 					Threshold: 1,
@@ -9,6 +9,7 @@ import (
 	"github.com/ava-labs/avalanchego/ids"
 	"github.com/ava-labs/avalanchego/utils/crypto"
 	"github.com/ava-labs/avalanchego/utils/logging"
+	"github.com/ava-labs/avalanchego/vms/avm"
 	"github.com/ava-labs/avalanchego/vms/components/avax"
 	"github.com/ava-labs/avalanchego/vms/secp256k1fx"
 )
@@ -163,6 +164,50 @@ func TestWalletCreateTx(t *testing.T) {
 	}
 }
 
+func TestWalletCreateTxPaysFee(t *testing.T) {
+	chainID := ids.ID{1, 2, 3, 4, 5, 6, 7, 8, 9, 10}
+	w, err := NewWallet(logging.NoLog{}, 12345, chainID, 10)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	assetID := ids.Empty.Prefix(0)
+
+	addr, err := w.GetAddress()
+	if err != nil {
+		t.Fatal(err)
+	}
+	w.AddUTXO(&avax.UTXO{
+		UTXOID: avax.UTXOID{TxID: ids.Empty.Prefix(1)},
+		Asset:  avax.Asset{ID: assetID},
+		Out: &secp256k1fx.TransferOutput{
+			Amt: 1000,
+			OutputOwners: secp256k1fx.OutputOwners{
+				Threshold: 1,
+				Addrs:     []ids.ShortID{addr},
+			},
+		},
+	})
+
+	if _, err := w.CreateTx(assetID, 991, ids.ShortEmpty); err == nil {
+		t.Fatalf("should have errored due to the fee exceeding the remaining funds")
+	}
+
+	tx, err := w.CreateTx(assetID, 500, ids.ShortEmpty)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	outs := tx.UnsignedTx.(*avm.BaseTx).Outs
+	if len(outs) != 2 {
+		t.Fatalf("expected 2 outputs but got %d", len(outs))
+	}
+	amountOut := outs[0].Out.Amount() + outs[1].Out.Amount()
+	if amountOut != 990 {
+		t.Fatalf("expected 990 to be sent but got %d", amountOut)
+	}
+}
+
 func TestWalletImportKey(t *testing.T) {
 	chainID := ids.ID{1, 2, 3, 4, 5, 6, 7, 8, 9, 10}
 	w, err := NewWallet(logging.NoLog{}, 12345, chainID, 0)
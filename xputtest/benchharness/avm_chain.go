@@ -0,0 +1,153 @@
+// (c) 2019-2020, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package benchharness
+
+import (
+	"fmt"
+
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/snow/choices"
+	"github.com/ava-labs/avalanchego/utils/constants"
+	"github.com/ava-labs/avalanchego/utils/crypto"
+	"github.com/ava-labs/avalanchego/utils/formatting"
+	"github.com/ava-labs/avalanchego/utils/logging"
+	"github.com/ava-labs/avalanchego/vms/avm"
+	"github.com/ava-labs/avalanchego/vms/components/avax"
+	"github.com/ava-labs/avalanchego/xputtest/avmwallet"
+)
+
+const (
+	// Amount sent by each transaction. Funds are sent to addresses that nobody
+	// controls so the wallet's UTXO set doesn't fill up with dust.
+	avmTxAmount = 1
+
+	// Number of UTXOs to fetch per request when funding the wallet
+	avmUTXOPageSize = 1024
+)
+
+var (
+	// Destination of transactions, and of the transactions that conflict with
+	// them
+	avmDestAddr         = ids.NewShortID([20]byte{1})
+	avmConflictDestAddr = ids.NewShortID([20]byte{2})
+)
+
+// avmChain issues transfers of an asset on an AVM chain. Each transaction
+// spends the change of the previous one.
+type avmChain struct {
+	client  *avm.Client
+	wallet  *avmwallet.Wallet
+	assetID ids.ID
+}
+
+// NewAVMChain returns a Chain that issues transfers of [assetID] on the AVM
+// chain [chainID], funded by the UTXOs of [key].
+// [chainAlias] is used to format addresses, and to reach the chain's API.
+func NewAVMChain(
+	log logging.Logger,
+	client *avm.Client,
+	networkID uint32,
+	chainID ids.ID,
+	chainAlias string,
+	assetID ids.ID,
+	txFee uint64,
+	key *crypto.PrivateKeySECP256K1R,
+) (Chain, error) {
+	wallet, err := avmwallet.NewWallet(log, networkID, chainID, txFee)
+	if err != nil {
+		return nil, err
+	}
+	wallet.ImportKey(key)
+
+	addr, err := formatting.FormatAddress(chainAlias, constants.GetHRP(networkID), key.PublicKey().Address().Bytes())
+	if err != nil {
+		return nil, err
+	}
+
+	startAddr, startUTXOID := "", ""
+	for {
+		utxos, endIndex, err := client.GetUTXOs([]string{addr}, avmUTXOPageSize, startAddr, startUTXOID)
+		if err != nil {
+			return nil, fmt.Errorf("couldn't fetch the UTXOs of %s: %w", addr, err)
+		}
+		for _, utxoBytes := range utxos {
+			utxo := &avax.UTXO{}
+			if _, err := wallet.Codec().Unmarshal(utxoBytes, utxo); err != nil {
+				return nil, fmt.Errorf("couldn't parse UTXO: %w", err)
+			}
+			wallet.AddUTXO(utxo)
+		}
+		if len(utxos) < avmUTXOPageSize {
+			break
+		}
+		startAddr, startUTXOID = endIndex.Address, endIndex.UTXO
+	}
+
+	if balance := wallet.Balance(assetID); balance == 0 {
+		return nil, fmt.Errorf("%s has no spendable funds of asset %s", addr, assetID)
+	}
+	return &avmChain{
+		client:  client,
+		wallet:  wallet,
+		assetID: assetID,
+	}, nil
+}
+
+// Issue implements the Chain interface
+func (c *avmChain) Issue() (ids.ID, error) {
+	tx, err := c.wallet.CreateTx(c.assetID, avmTxAmount, avmDestAddr)
+	if err != nil {
+		return ids.ID{}, err
+	}
+	c.spend(tx)
+	return c.client.IssueTx(tx.Bytes())
+}
+
+// IssueConflicting implements the Chain interface
+func (c *avmChain) IssueConflicting() (ids.ID, ids.ID, error) {
+	// Both transactions are created from the same UTXO set, so they spend the
+	// same inputs
+	tx, err := c.wallet.CreateTx(c.assetID, avmTxAmount, avmDestAddr)
+	if err != nil {
+		return ids.ID{}, ids.ID{}, err
+	}
+	conflictingTx, err := c.wallet.CreateTx(c.assetID, avmTxAmount, avmConflictDestAddr)
+	if err != nil {
+		return ids.ID{}, ids.ID{}, err
+	}
+	c.spend(tx)
+
+	txID, err := c.client.IssueTx(tx.Bytes())
+	if err != nil {
+		return ids.ID{}, ids.ID{}, err
+	}
+	conflictingTxID, err := c.client.IssueTx(conflictingTx.Bytes())
+	return txID, conflictingTxID, err
+}
+
+// Status implements the Chain interface
+func (c *avmChain) Status(txID ids.ID) (Status, error) {
+	status, err := c.client.GetTxStatus(txID)
+	if err != nil {
+		return Processing, err
+	}
+	switch status {
+	case choices.Accepted:
+		return Accepted, nil
+	case choices.Rejected:
+		return Rejected, nil
+	default:
+		return Processing, nil
+	}
+}
+
+// spend removes the inputs of [tx] from the wallet and adds its change
+func (c *avmChain) spend(tx *avm.Tx) {
+	for _, utxoID := range tx.InputUTXOs() {
+		c.wallet.RemoveUTXO(utxoID.InputID())
+	}
+	for _, utxo := range tx.UTXOs() {
+		c.wallet.AddUTXO(utxo)
+	}
+}
@@ -0,0 +1,42 @@
+// (c) 2019-2020, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package benchharness
+
+import (
+	"errors"
+
+	"github.com/ava-labs/avalanchego/ids"
+)
+
+var errConflictsNotSupported = errors.New("chain doesn't support issuing conflicting transactions")
+
+// Status is the status of a transaction issued by the harness
+type Status uint32
+
+// List of possible status values
+// [Processing] The transaction hasn't been decided yet
+// [Accepted] The transaction was accepted
+// [Rejected] The transaction was rejected or dropped
+const (
+	Processing Status = iota
+	Accepted
+	Rejected
+)
+
+// Decided returns true if the status is Accepted or Rejected
+func (s Status) Decided() bool { return s == Accepted || s == Rejected }
+
+// Chain issues transactions to a chain of a running node
+type Chain interface {
+	// Issue the next transaction and return its ID
+	Issue() (ids.ID, error)
+
+	// IssueConflicting issues the next transaction along with a transaction
+	// that spends the same funds. The ID of the transaction issued first is
+	// returned first.
+	IssueConflicting() (ids.ID, ids.ID, error)
+
+	// Status returns the current status of the transaction with ID [txID]
+	Status(txID ids.ID) (Status, error)
+}
@@ -0,0 +1,54 @@
+// (c) 2019-2020, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package benchharness
+
+import (
+	"errors"
+	"time"
+)
+
+var (
+	errInvalidRate          = errors.New("rate must be positive")
+	errInvalidDuration      = errors.New("duration must be positive")
+	errInvalidConflictRatio = errors.New("conflict ratio must be in [0, 1]")
+	errInvalidPollFrequency = errors.New("poll frequency must be positive")
+)
+
+// Config describes the load generated by a run
+type Config struct {
+	// Number of transactions issued per second, not counting conflicting
+	// transactions
+	Rate float64
+
+	// How long transactions are issued for
+	Duration time.Duration
+
+	// Fraction of transactions, in [0, 1], that are issued along with a
+	// transaction that conflicts with them
+	ConflictRatio float64
+
+	// How often the status of undecided transactions is checked. Latencies
+	// are measured with this granularity.
+	PollFrequency time.Duration
+
+	// How long to wait, once issuance stops, for the issued transactions to
+	// be decided
+	DrainTimeout time.Duration
+}
+
+// Verify that the config is valid
+func (c *Config) Verify() error {
+	switch {
+	case c.Rate <= 0:
+		return errInvalidRate
+	case c.Duration <= 0:
+		return errInvalidDuration
+	case c.ConflictRatio < 0 || c.ConflictRatio > 1:
+		return errInvalidConflictRatio
+	case c.PollFrequency <= 0:
+		return errInvalidPollFrequency
+	default:
+		return nil
+	}
+}
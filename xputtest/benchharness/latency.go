@@ -0,0 +1,46 @@
+// (c) 2019-2020, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package benchharness
+
+import (
+	"math"
+	"sort"
+	"time"
+)
+
+// latencies records the time it took transactions to be accepted
+type latencies struct {
+	samples []time.Duration
+	sorted  bool
+}
+
+// Add a sample
+func (l *latencies) Add(latency time.Duration) {
+	l.samples = append(l.samples, latency)
+	l.sorted = false
+}
+
+// Len returns the number of samples
+func (l *latencies) Len() int { return len(l.samples) }
+
+// Percentile returns the smallest sample that is at least as large as [p] of
+// the samples, where [p] is in [0, 1]. Returns 0 if there are no samples.
+func (l *latencies) Percentile(p float64) time.Duration {
+	if len(l.samples) == 0 {
+		return 0
+	}
+	if !l.sorted {
+		sort.Slice(l.samples, func(i, j int) bool { return l.samples[i] < l.samples[j] })
+		l.sorted = true
+	}
+
+	index := int(math.Ceil(p*float64(len(l.samples)))) - 1
+	switch {
+	case index < 0:
+		index = 0
+	case index >= len(l.samples):
+		index = len(l.samples) - 1
+	}
+	return l.samples[index]
+}
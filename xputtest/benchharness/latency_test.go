@@ -0,0 +1,45 @@
+// (c) 2019-2020, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package benchharness
+
+import (
+	"testing"
+	"time"
+)
+
+func TestLatenciesPercentile(t *testing.T) {
+	l := latencies{}
+	if p := l.Percentile(.5); p != 0 {
+		t.Fatalf("expected 0 with no samples but got %s", p)
+	}
+
+	// Add the samples out of order
+	for i := 100; i > 0; i-- {
+		l.Add(time.Duration(i) * time.Millisecond)
+	}
+	if l.Len() != 100 {
+		t.Fatalf("expected 100 samples but got %d", l.Len())
+	}
+
+	tests := []struct {
+		p        float64
+		expected time.Duration
+	}{
+		{p: 0, expected: time.Millisecond},
+		{p: .5, expected: 50 * time.Millisecond},
+		{p: .9, expected: 90 * time.Millisecond},
+		{p: .99, expected: 99 * time.Millisecond},
+		{p: 1, expected: 100 * time.Millisecond},
+	}
+	for _, test := range tests {
+		if p := l.Percentile(test.p); p != test.expected {
+			t.Fatalf("expected percentile %v to be %s but got %s", test.p, test.expected, p)
+		}
+	}
+
+	l.Add(time.Microsecond)
+	if p := l.Percentile(0); p != time.Microsecond {
+		t.Fatalf("expected the new sample to be the smallest but got %s", p)
+	}
+}
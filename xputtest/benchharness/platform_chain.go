@@ -0,0 +1,56 @@
+// (c) 2019-2020, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package benchharness
+
+import (
+	"github.com/ava-labs/avalanchego/api"
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/vms/platformvm"
+)
+
+// platformChain issues CreateSubnetTxs on the P-Chain. The transactions are
+// built and signed by the node, using the keys of a keystore user, since the
+// P-Chain has no plain transfer transaction.
+type platformChain struct {
+	client     *platformvm.Client
+	user       api.UserPass
+	controlKey string
+}
+
+// NewPlatformChain returns a Chain that issues CreateSubnetTxs paid for by
+// the funds of [user]. [controlKey] is the address that controls the subnets.
+func NewPlatformChain(client *platformvm.Client, user api.UserPass, controlKey string) Chain {
+	return &platformChain{
+		client:     client,
+		user:       user,
+		controlKey: controlKey,
+	}
+}
+
+// Issue implements the Chain interface
+func (c *platformChain) Issue() (ids.ID, error) {
+	return c.client.CreateSubnet(c.user, nil, "", []string{c.controlKey}, 1)
+}
+
+// IssueConflicting implements the Chain interface. The node chooses the UTXOs
+// that are spent, so conflicts can't be issued through the API.
+func (c *platformChain) IssueConflicting() (ids.ID, ids.ID, error) {
+	return ids.ID{}, ids.ID{}, errConflictsNotSupported
+}
+
+// Status implements the Chain interface
+func (c *platformChain) Status(txID ids.ID) (Status, error) {
+	reply, err := c.client.GetTxStatus(txID, false)
+	if err != nil {
+		return Processing, err
+	}
+	switch reply.Status {
+	case platformvm.Committed:
+		return Accepted, nil
+	case platformvm.Aborted, platformvm.Dropped:
+		return Rejected, nil
+	default:
+		return Processing, nil
+	}
+}
@@ -0,0 +1,176 @@
+// (c) 2019-2020, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package benchharness
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/utils/logging"
+)
+
+// Report summarizes a run
+type Report struct {
+	// Time spent issuing transactions
+	Duration time.Duration
+
+	// Number of transactions issued, including conflicting transactions
+	Issued int
+	// Number of conflicting transactions issued
+	Conflicting int
+	// Number of transactions that couldn't be issued
+	IssueFailures int
+
+	// Number of issued transactions that were accepted, rejected, or still
+	// undecided when the run ended
+	Accepted, Rejected, Undecided int
+
+	// Time between a transaction being issued and it being seen as accepted
+	P50, P90, P99, Max time.Duration
+}
+
+// IssueRate returns the number of transactions issued per second
+func (r *Report) IssueRate() float64 {
+	if r.Duration <= 0 {
+		return 0
+	}
+	return float64(r.Issued) / r.Duration.Seconds()
+}
+
+func (r *Report) String() string {
+	return fmt.Sprintf(
+		"Issued %d transactions (%d conflicting, %d failed to issue) in %s (%.2f tx/s)\n"+
+			"Accepted: %d, Rejected: %d, Undecided: %d\n"+
+			"Acceptance latency: p50=%s p90=%s p99=%s max=%s",
+		r.Issued, r.Conflicting, r.IssueFailures, r.Duration, r.IssueRate(),
+		r.Accepted, r.Rejected, r.Undecided,
+		r.P50, r.P90, r.P99, r.Max,
+	)
+}
+
+type issuedTx struct {
+	txID     ids.ID
+	issuedAt time.Time
+}
+
+// Run issues transactions to [chain] as described by [config] and waits for
+// them to be decided
+func Run(log logging.Logger, chain Chain, config Config) (*Report, error) {
+	if err := config.Verify(); err != nil {
+		return nil, err
+	}
+
+	issued := make(chan issuedTx, 1024)
+	reports := make(chan *Report, 1)
+	go func() {
+		reports <- track(log, chain, config, issued)
+	}()
+
+	interval := time.Duration(float64(time.Second) / config.Rate)
+	numAttempts := 0
+	numConflicting := 0
+	numIssued := 0
+	numFailed := 0
+
+	start := time.Now()
+	nextIssue := start
+	for time.Since(start) < config.Duration {
+		if wait := time.Until(nextIssue); wait > 0 {
+			time.Sleep(wait)
+		}
+		nextIssue = nextIssue.Add(interval)
+		numAttempts++
+
+		// Spread the conflicting transactions evenly over the run
+		issuedAt := time.Now()
+		if float64(numConflicting+1) <= config.ConflictRatio*float64(numAttempts) {
+			txID, conflictingTxID, err := chain.IssueConflicting()
+			if err != nil {
+				log.Debug("failed to issue conflicting transactions: %s", err)
+				numFailed++
+				continue
+			}
+			numConflicting++
+			numIssued += 2
+			issued <- issuedTx{txID: txID, issuedAt: issuedAt}
+			issued <- issuedTx{txID: conflictingTxID, issuedAt: issuedAt}
+			continue
+		}
+
+		txID, err := chain.Issue()
+		if err != nil {
+			log.Debug("failed to issue transaction: %s", err)
+			numFailed++
+			continue
+		}
+		numIssued++
+		issued <- issuedTx{txID: txID, issuedAt: issuedAt}
+	}
+	duration := time.Since(start)
+	close(issued)
+
+	report := <-reports
+	report.Duration = duration
+	report.Issued = numIssued
+	report.Conflicting = numConflicting
+	report.IssueFailures = numFailed
+	return report, nil
+}
+
+// track polls the status of the transactions sent on [issued] until they are
+// decided. Once [issued] is closed, transactions that aren't decided within
+// the drain timeout are reported as undecided.
+func track(log logging.Logger, chain Chain, config Config, issued <-chan issuedTx) *Report {
+	ticker := time.NewTicker(config.PollFrequency)
+	defer ticker.Stop()
+
+	report := &Report{}
+	acceptLatencies := latencies{}
+	pending := []issuedTx(nil)
+	drainDeadline := time.Time{}
+	for issued != nil || len(pending) > 0 {
+		select {
+		case tx, ok := <-issued:
+			if !ok {
+				issued = nil
+				drainDeadline = time.Now().Add(config.DrainTimeout)
+				continue
+			}
+			pending = append(pending, tx)
+			continue
+		case <-ticker.C:
+		}
+
+		now := time.Now()
+		stillPending := pending[:0]
+		for _, tx := range pending {
+			status, err := chain.Status(tx.txID)
+			if err != nil {
+				log.Debug("failed to fetch the status of %s: %s", tx.txID, err)
+			}
+			switch status {
+			case Accepted:
+				report.Accepted++
+				acceptLatencies.Add(now.Sub(tx.issuedAt))
+			case Rejected:
+				report.Rejected++
+			default:
+				stillPending = append(stillPending, tx)
+			}
+		}
+		pending = stillPending
+
+		if issued == nil && !now.Before(drainDeadline) {
+			break
+		}
+	}
+	report.Undecided = len(pending)
+
+	report.P50 = acceptLatencies.Percentile(.5)
+	report.P90 = acceptLatencies.Percentile(.9)
+	report.P99 = acceptLatencies.Percentile(.99)
+	report.Max = acceptLatencies.Percentile(1)
+	return report
+}
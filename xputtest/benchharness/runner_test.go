@@ -0,0 +1,125 @@
+// (c) 2019-2020, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package benchharness
+
+import (
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/utils/logging"
+)
+
+// testChain accepts every transaction except the second transaction of each
+// conflicting pair, which it rejects
+type testChain struct {
+	lock     sync.Mutex
+	nextTxID uint64
+	statuses map[ids.ID]Status
+	failNext bool
+}
+
+func (c *testChain) newTxID(status Status) ids.ID {
+	c.nextTxID++
+	txID := ids.Empty.Prefix(c.nextTxID)
+	c.statuses[txID] = status
+	return txID
+}
+
+func (c *testChain) Issue() (ids.ID, error) {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	if c.failNext {
+		c.failNext = false
+		return ids.ID{}, errors.New("failed to issue")
+	}
+	return c.newTxID(Accepted), nil
+}
+
+func (c *testChain) IssueConflicting() (ids.ID, ids.ID, error) {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	return c.newTxID(Accepted), c.newTxID(Rejected), nil
+}
+
+func (c *testChain) Status(txID ids.ID) (Status, error) {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	return c.statuses[txID], nil
+}
+
+func TestRunInvalidConfig(t *testing.T) {
+	chain := &testChain{statuses: make(map[ids.ID]Status)}
+	if _, err := Run(logging.NoLog{}, chain, Config{}); err == nil {
+		t.Fatalf("should have errored due to an invalid config")
+	}
+}
+
+func TestRun(t *testing.T) {
+	chain := &testChain{
+		statuses: make(map[ids.ID]Status),
+		failNext: true,
+	}
+	report, err := Run(logging.NoLog{}, chain, Config{
+		Rate:          1000,
+		Duration:      100 * time.Millisecond,
+		ConflictRatio: .5,
+		PollFrequency: time.Millisecond,
+		DrainTimeout:  time.Second,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if report.IssueFailures != 1 {
+		t.Fatalf("expected 1 issue failure but got %d", report.IssueFailures)
+	}
+	if report.Conflicting == 0 {
+		t.Fatalf("should have issued conflicting transactions")
+	}
+	if report.Issued != int(chain.nextTxID) {
+		t.Fatalf("expected %d issued transactions but got %d", chain.nextTxID, report.Issued)
+	}
+	if report.Rejected != report.Conflicting {
+		t.Fatalf("expected %d rejected transactions but got %d", report.Conflicting, report.Rejected)
+	}
+	if report.Accepted != report.Issued-report.Conflicting {
+		t.Fatalf("expected %d accepted transactions but got %d", report.Issued-report.Conflicting, report.Accepted)
+	}
+	if report.Undecided != 0 {
+		t.Fatalf("expected no undecided transactions but got %d", report.Undecided)
+	}
+	if report.P50 > report.P99 || report.P99 > report.Max {
+		t.Fatalf("latency percentiles should be ordered but got p50=%s p99=%s max=%s", report.P50, report.P99, report.Max)
+	}
+}
+
+func TestRunReportsUndecided(t *testing.T) {
+	chain := &testChain{statuses: make(map[ids.ID]Status)}
+	undecidedChain := &undecidedTestChain{testChain: chain}
+	report, err := Run(logging.NoLog{}, undecidedChain, Config{
+		Rate:          100,
+		Duration:      20 * time.Millisecond,
+		PollFrequency: time.Millisecond,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if report.Issued == 0 {
+		t.Fatalf("should have issued transactions")
+	}
+	if report.Undecided != report.Issued {
+		t.Fatalf("expected %d undecided transactions but got %d", report.Issued, report.Undecided)
+	}
+}
+
+// undecidedTestChain never decides transactions
+type undecidedTestChain struct{ *testChain }
+
+func (*undecidedTestChain) Status(ids.ID) (Status, error) { return Processing, nil }
@@ -0,0 +1,161 @@
+// (c) 2019-2020, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package main
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/ava-labs/avalanchego/api"
+	"github.com/ava-labs/avalanchego/api/info"
+	"github.com/ava-labs/avalanchego/api/keystore"
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/utils/constants"
+	"github.com/ava-labs/avalanchego/utils/crypto"
+	"github.com/ava-labs/avalanchego/utils/formatting"
+	"github.com/ava-labs/avalanchego/utils/logging"
+	"github.com/ava-labs/avalanchego/vms/avm"
+	"github.com/ava-labs/avalanchego/vms/platformvm"
+	"github.com/ava-labs/avalanchego/xputtest/benchharness"
+)
+
+const (
+	xChainAlias = "X"
+	pChainAlias = "P"
+)
+
+var errMissingKey = errors.New("a funded private key must be provided")
+
+// main generates sustained transaction load against a chain of a running node
+// and reports how quickly the transactions were accepted.
+func main() {
+	fs := flag.NewFlagSet("xputtest", flag.ExitOnError)
+	uri := fs.String("uri", "http://127.0.0.1:9650", "URI of the node's API")
+	chain := fs.String("chain", xChainAlias, "Chain to issue transactions to. One of X or P")
+	privateKey := fs.String("private-key", "", "Private key, prefixed with "+constants.SecretKeyPrefix+", holding the funds that pay for the transactions")
+	username := fs.String("username", "xputtest", "Keystore user that signs P-Chain transactions. Created if it doesn't exist")
+	password := fs.String("password", "", "Password of the keystore user")
+	rate := fs.Float64("rate", 10, "Number of transactions issued per second")
+	duration := fs.Duration("duration", time.Minute, "How long to issue transactions for")
+	conflictRatio := fs.Float64("conflict-ratio", 0, "Fraction of transactions, in [0, 1], issued along with a conflicting transaction. X-Chain only")
+	pollFrequency := fs.Duration("poll-frequency", 100*time.Millisecond, "How often to check the status of undecided transactions")
+	drainTimeout := fs.Duration("drain-timeout", 30*time.Second, "How long to wait for issued transactions to be decided once issuance stops")
+	requestTimeout := fs.Duration("request-timeout", 10*time.Second, "Timeout of each API request")
+	if err := fs.Parse(os.Args[1:]); err != nil {
+		fmt.Printf("parsing parameters returned with error %s\n", err)
+		os.Exit(1)
+	}
+
+	logConfig, err := logging.DefaultConfig()
+	if err != nil {
+		fmt.Printf("couldn't create the logging config: %s\n", err)
+		os.Exit(1)
+	}
+	logConfig.MsgPrefix = "xputtest"
+	log, err := logging.New(logConfig)
+	if err != nil {
+		fmt.Printf("starting logger failed with: %s\n", err)
+		os.Exit(1)
+	}
+
+	var target benchharness.Chain
+	switch *chain {
+	case xChainAlias:
+		target, err = newAVMChain(log, *uri, *privateKey, *requestTimeout)
+	case pChainAlias:
+		target, err = newPlatformChain(*uri, *privateKey, api.UserPass{Username: *username, Password: *password}, *requestTimeout)
+	default:
+		err = fmt.Errorf("unknown chain %q", *chain)
+	}
+	if err != nil {
+		fmt.Printf("couldn't set up the load generator: %s\n", err)
+		os.Exit(1)
+	}
+
+	report, err := benchharness.Run(log, target, benchharness.Config{
+		Rate:          *rate,
+		Duration:      *duration,
+		ConflictRatio: *conflictRatio,
+		PollFrequency: *pollFrequency,
+		DrainTimeout:  *drainTimeout,
+	})
+	if err != nil {
+		fmt.Printf("load generation failed with: %s\n", err)
+		os.Exit(1)
+	}
+	fmt.Println(report)
+}
+
+// parsePrivateKey parses a private key formatted like the keystore exports it
+func parsePrivateKey(keyStr string) (*crypto.PrivateKeySECP256K1R, error) {
+	if keyStr == "" {
+		return nil, errMissingKey
+	}
+	if !strings.HasPrefix(keyStr, constants.SecretKeyPrefix) {
+		return nil, fmt.Errorf("private key missing %s prefix", constants.SecretKeyPrefix)
+	}
+	keyBytes, err := formatting.Decode(formatting.CB58, strings.TrimPrefix(keyStr, constants.SecretKeyPrefix))
+	if err != nil {
+		return nil, fmt.Errorf("problem parsing private key: %w", err)
+	}
+	factory := crypto.FactorySECP256K1R{}
+	key, err := factory.ToPrivateKey(keyBytes)
+	if err != nil {
+		return nil, fmt.Errorf("problem parsing private key: %w", err)
+	}
+	return key.(*crypto.PrivateKeySECP256K1R), nil
+}
+
+func newAVMChain(log logging.Logger, uri, keyStr string, requestTimeout time.Duration) (benchharness.Chain, error) {
+	key, err := parsePrivateKey(keyStr)
+	if err != nil {
+		return nil, err
+	}
+
+	infoClient := info.NewClient(uri, requestTimeout)
+	networkID, err := infoClient.GetNetworkID()
+	if err != nil {
+		return nil, fmt.Errorf("couldn't get the network ID: %w", err)
+	}
+	chainIDStr, err := infoClient.GetBlockchainID(xChainAlias)
+	if err != nil {
+		return nil, fmt.Errorf("couldn't get the ID of the X-Chain: %w", err)
+	}
+	chainID, err := ids.FromString(chainIDStr)
+	if err != nil {
+		return nil, err
+	}
+	fees, err := infoClient.GetTxFee()
+	if err != nil {
+		return nil, fmt.Errorf("couldn't get the transaction fee: %w", err)
+	}
+
+	client := avm.NewClient(uri, xChainAlias, requestTimeout)
+	avax, err := client.GetAssetDescription("AVAX")
+	if err != nil {
+		return nil, fmt.Errorf("couldn't get the ID of AVAX: %w", err)
+	}
+	return benchharness.NewAVMChain(log, client, networkID, chainID, xChainAlias, avax.AssetID, uint64(fees.TxFee), key)
+}
+
+func newPlatformChain(uri, keyStr string, user api.UserPass, requestTimeout time.Duration) (benchharness.Chain, error) {
+	if keyStr == "" {
+		return nil, errMissingKey
+	}
+
+	// The user may already exist from a previous run
+	keystoreClient := keystore.NewClient(uri, requestTimeout)
+	_, _ = keystoreClient.CreateUser(user)
+
+	client := platformvm.NewClient(uri, requestTimeout)
+	addr, err := client.ImportKey(user, keyStr)
+	if err != nil {
+		return nil, fmt.Errorf("couldn't import the private key: %w", err)
+	}
+	return benchharness.NewPlatformChain(client, user, addr), nil
+}